@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/url"
+)
+
+var archiveCommands = map[string]command{
+	"lookup": {
+		usage: "[-format md|txt] <url>",
+		help:  "print the archived content of a source URL",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("lookup", flag.ContinueOnError)
+			format := fs.String("format", "md", "md or txt")
+			args, err := parseFlags(fs, args, 1)
+			if err != nil {
+				return nil, err
+			}
+			if *format != "md" && *format != "txt" {
+				return nil, errUsage
+			}
+			// The archive route renders a page rather than JSON, so wrap its body.
+			body, err := c.request(http.MethodGet, "/archive/"+url.PathEscape(args[0]), url.Values{"format": {*format}}, nil)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]string{"url": args[0], "format": *format, "content": string(body)}, nil
+		},
+	},
+	"stats": {
+		usage: "[-author ID] [-type TYPE] [-from DATE] [-to DATE] [-interval day|week|month] [-top N] <platform>",
+		help:  "print publishing statistics of a zhihu author, zsxq group, xiaobot paper or tombkeeper",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+			query := url.Values{}
+			for _, name := range []string{"author", "type", "from", "to", "interval", "top"} {
+				fs.Func(name, "", func(v string) error { query.Set(name, v); return nil })
+			}
+			args, err := parseFlags(fs, args, 1)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("platform", args[0])
+			return c.call(http.MethodGet, "/archive/statistics", query, nil)
+		},
+	},
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type clientOptions struct {
+	server string
+	user   string
+	groups string
+	token  string
+}
+
+// client calls the /api/v1 routes of a server. With a token it authenticates
+// with Authorization: Bearer and works through the auth proxy. Without one it
+// sets the Remote-User/Remote-Groups headers the proxy would set itself, so
+// -server must then be an address that is not behind the proxy.
+type client struct {
+	opts clientOptions
+	http *http.Client
+}
+
+func newClient(opts clientOptions) *client {
+	opts.server = strings.TrimRight(opts.server, "/")
+	return &client{opts: opts, http: &http.Client{Timeout: time.Minute}}
+}
+
+// apiError is a response outside 2xx; Message is the server's message when the
+// body is the usual {"message": ...}.
+type apiError struct {
+	Status  int
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("server returned %d: %s", e.Status, e.Message)
+}
+
+// request sends body as JSON to /api/v1 + path and returns the raw response body.
+func (c *client) request(method, path string, query url.Values, body any) ([]byte, error) {
+	u := c.opts.server + "/api/v1" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.opts.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.opts.token)
+	} else {
+		req.Header.Set("Remote-User", c.opts.user)
+		req.Header.Set("Remote-Groups", c.opts.groups)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newAPIError(resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
+
+func newAPIError(status int, body []byte) *apiError {
+	var msg struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	e := &apiError{Status: status, Message: strings.TrimSpace(string(body))}
+	if json.Unmarshal(body, &msg) == nil {
+		if msg.Message != "" {
+			e.Message = msg.Message
+		} else if msg.Error != "" {
+			e.Message = msg.Error
+		}
+	}
+	return e
+}
+
+// call sends the request and returns the data field of the usual
+// {"message", "data"} response, or the message when there is no data.
+func (c *client) call(method, path string, query url.Values, body any) (any, error) {
+	respBody, err := c.request(method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err = json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return map[string]string{"message": resp.Message}, nil
+	}
+	return resp.Data, nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	cookieController "github.com/eli-yip/rss-zero/internal/controller/cookie"
+)
+
+// stdin is read by the commands that accept "-" for a value or a file.
+var stdin io.Reader = os.Stdin
+
+var credentialCommands = map[string]command{
+	"status": {
+		help: "show every credential with its expiry and health",
+		run: func(c *client, args []string) (any, error) {
+			if _, err := parseFlags(flag.NewFlagSet("status", flag.ContinueOnError), args, 0); err != nil {
+				return nil, err
+			}
+			return c.call(http.MethodGet, "/credentials", nil, nil)
+		},
+	},
+	"update": {
+		usage: "-value V|- [-expires RFC3339] <platform> <name>",
+		help:  "store a manually managed credential; -value - reads it from stdin",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("update", flag.ContinueOnError)
+			value := fs.String("value", "", "credential value, - for stdin")
+			expires := fs.String("expires", "", "expiry in RFC3339, e.g. 2026-12-31T00:00:00+08:00")
+			args, err := parseFlags(fs, args, 2)
+			if err != nil {
+				return nil, err
+			}
+			req := cookieController.CredentialUpdateRequest{Value: *value}
+			if req.Value == "-" {
+				raw, err := io.ReadAll(stdin)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read value from stdin: %w", err)
+				}
+				req.Value = strings.TrimSpace(string(raw))
+			}
+			if req.Value == "" {
+				return nil, errUsage
+			}
+			if *expires != "" {
+				req.ExpiresAt = expires
+			}
+			return c.call(http.MethodPut, "/credentials/"+url.PathEscape(args[0])+"/"+url.PathEscape(args[1]), nil, req)
+		},
+	},
+	"import": {
+		usage: "<cookies.json|->",
+		help:  "import cookies exported from a browser; the file is a JSON array or {\"cookies\": [...]}",
+		run: func(c *client, args []string) (any, error) {
+			args, err := parseFlags(flag.NewFlagSet("import", flag.ContinueOnError), args, 1)
+			if err != nil {
+				return nil, err
+			}
+			raw, err := readInput(args[0])
+			if err != nil {
+				return nil, err
+			}
+			cookies, err := parseBrowserCookies(raw)
+			if err != nil {
+				return nil, err
+			}
+			return c.call(http.MethodPost, "/browser-cookies/import", nil, map[string]any{"cookies": cookies})
+		},
+	},
+}
+
+// readInput reads the named file, or stdin for "-".
+func readInput(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(name)
+}
+
+// parseBrowserCookies accepts what cookie export extensions write, a bare array,
+// as well as the {"cookies": [...]} body of the import route.
+func parseBrowserCookies(raw []byte) ([]cookieController.InCookie, error) {
+	raw = bytes.TrimSpace(raw)
+	var cookies []cookieController.InCookie
+	if bytes.HasPrefix(raw, []byte("[")) {
+		if err := json.Unmarshal(raw, &cookies); err != nil {
+			return nil, fmt.Errorf("invalid cookie file: %w", err)
+		}
+		return cookies, nil
+	}
+	var body struct {
+		Cookies []cookieController.InCookie `json:"cookies"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, fmt.Errorf("invalid cookie file: %w", err)
+	}
+	return body.Cookies, nil
+}
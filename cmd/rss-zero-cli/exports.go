@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	xiaobotController "github.com/eli-yip/rss-zero/internal/controller/xiaobot"
+	zhihuController "github.com/eli-yip/rss-zero/internal/controller/zhihu"
+	zsxqController "github.com/eli-yip/rss-zero/internal/controller/zsxq"
+)
+
+// Exports run in the background on the server and land in object storage; start
+// returns the file URL, poll waits until it exists and download fetches it.
+var exportCommands = map[string]command{
+	"start": {
+		usage: "<zsxq|zhihu|xiaobot> [-group ID] [-author A] [-paper ID] [-type T] [-start YYYY-MM-DD] [-end YYYY-MM-DD] [-digest] [-single]",
+		help:  "start an export and print its file name and URL",
+		run:   startExport,
+	},
+	"poll": {
+		usage: "[-interval D] [-timeout D] <url>",
+		help:  "wait until an export file is available",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("poll", flag.ContinueOnError)
+			interval := fs.Duration("interval", 5*time.Second, "time between checks")
+			timeout := fs.Duration("timeout", 10*time.Minute, "give up after this long")
+			args, err := parseFlags(fs, args, 1)
+			if err != nil {
+				return nil, err
+			}
+			return pollExport(c.http, args[0], *interval, *timeout)
+		},
+	},
+	"download": {
+		usage: "[-o FILE] <url>",
+		help:  "download an export file, named after the URL unless -o is given",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("download", flag.ContinueOnError)
+			output := fs.String("o", "", "output file")
+			args, err := parseFlags(fs, args, 1)
+			if err != nil {
+				return nil, err
+			}
+			// no client timeout: a large export may take longer than an API call
+			return downloadExport(http.DefaultClient, args[0], *output)
+		},
+	},
+}
+
+func startExport(c *client, args []string) (any, error) {
+	if len(args) == 0 {
+		return nil, errUsage
+	}
+	platform := args[0]
+	fs := flag.NewFlagSet("start", flag.ContinueOnError)
+	group := fs.Int("group", 0, "zsxq group id")
+	author := fs.String("author", "", "zsxq or zhihu author")
+	paper := fs.String("paper", "", "xiaobot paper id")
+	typ := fs.String("type", "", "content type, e.g. talk/q&a for zsxq, answer/article/pin for zhihu")
+	start := fs.String("start", "", "first day included")
+	end := fs.String("end", "", "last day included")
+	digest := fs.Bool("digest", false, "zsxq digest topics only")
+	single := fs.Bool("single", false, "zhihu: one file per item")
+	if _, err := parseFlags(fs, args[1:], 0); err != nil {
+		return nil, err
+	}
+
+	var req any
+	switch platform {
+	case "zsxq":
+		if *group == 0 {
+			return nil, errUsage
+		}
+		r := zsxqController.ZxsqExportReq{GroupID: *group, Type: optional(*typ), StartTime: optional(*start), EndTime: optional(*end), Author: optional(*author)}
+		if *digest {
+			r.Digest = digest
+		}
+		req = r
+	case "zhihu":
+		if *author == "" {
+			return nil, errUsage
+		}
+		r := zhihuController.ZhihuExportReq{Author: author, Type: optional(*typ), StartTime: optional(*start), EndTime: optional(*end)}
+		if *single {
+			r.Single = single
+		}
+		req = r
+	case "xiaobot":
+		if *paper == "" {
+			return nil, errUsage
+		}
+		req = xiaobotController.XiaobotExportReq{PaperID: paper, StartTime: optional(*start), EndTime: optional(*end)}
+	default:
+		return nil, errUsage
+	}
+	return c.call(http.MethodPost, "/export/"+platform, nil, req)
+}
+
+// optional leaves unset flags out of the request.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+var errExportTimeout = errors.New("export file is not available yet")
+
+func pollExport(httpClient *http.Client, u string, interval, timeout time.Duration) (any, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := httpClient.Head(u)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check export file: %w", err)
+		}
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return map[string]any{"url": u, "ready": true, "size": resp.ContentLength}, nil
+		case resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusForbidden:
+			// object storage answers 403 for missing objects when listing is denied
+			return nil, &apiError{Status: resp.StatusCode, Message: resp.Status}
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return nil, errExportTimeout
+		}
+		time.Sleep(interval)
+	}
+}
+
+func downloadExport(httpClient *http.Client, u, output string) (any, error) {
+	resp, err := httpClient.Get(u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download export file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &apiError{Status: resp.StatusCode, Message: resp.Status}
+	}
+
+	if output == "" {
+		output = path.Base(resp.Request.URL.Path)
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return nil, err
+	}
+	n, err := io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	return map[string]any{"url": u, "file": output, "bytes": n}, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/url"
+
+	subscriptionController "github.com/eli-yip/rss-zero/internal/controller/subscription"
+)
+
+var grantCommands = map[string]command{
+	"list": {
+		usage: "[-user NAME]",
+		help:  "list grants to paid zsxq groups and xiaobot papers",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("list", flag.ContinueOnError)
+			user := fs.String("user", "", "only grants of this user")
+			if _, err := parseFlags(fs, args, 0); err != nil {
+				return nil, err
+			}
+			query := url.Values{}
+			if *user != "" {
+				query.Set("username", *user)
+			}
+			return c.call(http.MethodGet, "/grants", query, nil)
+		},
+	},
+	"add": {
+		usage: "<platform> <target> <user>",
+		help:  "grant a user access to a zsxq group or xiaobot paper",
+		run: func(c *client, args []string) (any, error) {
+			args, err := parseFlags(flag.NewFlagSet("add", flag.ContinueOnError), args, 3)
+			if err != nil {
+				return nil, err
+			}
+			req := subscriptionController.GrantRequest{Platform: args[0], TargetID: args[1], Username: args[2]}
+			return c.call(http.MethodPost, "/grants", nil, req)
+		},
+	},
+	"revoke": {
+		usage: "<platform> <target> <user>",
+		help:  "revoke a grant; the user's paid feed links stop working",
+		run: func(c *client, args []string) (any, error) {
+			args, err := parseFlags(flag.NewFlagSet("revoke", flag.ContinueOnError), args, 3)
+			if err != nil {
+				return nil, err
+			}
+			return c.call(http.MethodDelete, "/grants/"+url.PathEscape(args[0])+"/"+url.PathEscape(args[1])+"/"+url.PathEscape(args[2]), nil, nil)
+		},
+	},
+}
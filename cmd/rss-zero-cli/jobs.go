@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var jobCommands = map[string]command{
+	"list": {
+		usage: "[-errors]",
+		help:  "list running jobs, or failed ones with -errors",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("list", flag.ContinueOnError)
+			failed := fs.Bool("errors", false, "list failed jobs instead")
+			if _, err := parseFlags(fs, args, 0); err != nil {
+				return nil, err
+			}
+			if *failed {
+				return c.call(http.MethodGet, "/job/list/error", nil, nil)
+			}
+			return c.call(http.MethodGet, "/job/list", nil, nil)
+		},
+	},
+	"history": {
+		usage: "[-task ID] [-limit N]",
+		help:  "list the latest runs, newest first",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("history", flag.ContinueOnError)
+			task := fs.String("task", "", "only runs of this task")
+			limit := fs.Int("limit", 0, "number of runs, server default when 0")
+			if _, err := parseFlags(fs, args, 0); err != nil {
+				return nil, err
+			}
+			query := url.Values{}
+			if *task != "" {
+				query.Set("task", *task)
+			}
+			if *limit > 0 {
+				query.Set("limit", strconv.Itoa(*limit))
+			}
+			return c.call(http.MethodGet, "/job/history", query, nil)
+		},
+	},
+	"tasks": {
+		help: "list task definitions, static jobs included",
+		run: func(c *client, args []string) (any, error) {
+			if _, err := parseFlags(flag.NewFlagSet("tasks", flag.ContinueOnError), args, 0); err != nil {
+				return nil, err
+			}
+			return c.call(http.MethodGet, "/job/task/list", nil, nil)
+		},
+	},
+	"start":  jobAction("<task-id>", "/job/start/%s", "start a dynamic task now"),
+	"run":    jobAction("<job-name>", "/job/run/%s", "run a scheduled job now by name, e.g. macked_crawl"),
+	"cancel": jobAction("<job-id>", "/job/%s/cancel", "cancel a running or paused job"),
+	"pause":  jobAction("<job-id>", "/job/%s/pause", "pause a running zsxq/zhihu job"),
+	"resume": jobAction("<job-id>", "/job/%s/resume", "resume a paused job"),
+	"logs": {
+		usage: "[-level L]... <job-id>",
+		help:  "print the captured logs of a run",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("logs", flag.ContinueOnError)
+			var levels stringList
+			fs.Var(&levels, "level", "only this level, repeatable")
+			args, err := parseFlags(fs, args, 1)
+			if err != nil {
+				return nil, err
+			}
+			return c.call(http.MethodGet, "/job/"+url.PathEscape(args[0])+"/logs", url.Values{"level": levels}, nil)
+		},
+	},
+}
+
+// jobAction is a POST to pathFormat filled with the single argument.
+func jobAction(usage, pathFormat, help string) command {
+	return command{
+		usage: usage,
+		help:  help,
+		run: func(c *client, args []string) (any, error) {
+			args, err := parseFlags(flag.NewFlagSet("job", flag.ContinueOnError), args, 1)
+			if err != nil {
+				return nil, err
+			}
+			return c.call(http.MethodPost, fmt.Sprintf(pathFormat, url.PathEscape(args[0])), nil, nil)
+		},
+	}
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string     { return strings.Join(*l, ",") }
+func (l *stringList) Set(v string) error { *l = append(*l, v); return nil }
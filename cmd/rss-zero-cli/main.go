@@ -0,0 +1,122 @@
+// Command rss-zero-cli is the non-interactive admin client of an rss-zero
+// server. Every subcommand calls an /api/v1 route and prints the response data
+// as JSON on stdout; errors go to stderr as {"error": ...} with exit code 1.
+//
+//	rss-zero-cli [global flags] <command> <subcommand> [flags] [args]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// command is one "<group> <name>" entry, e.g. "jobs cancel".
+type command struct {
+	usage string // arguments and flags after the command name
+	help  string
+	run   func(c *client, args []string) (any, error)
+}
+
+var commands = map[string]map[string]command{
+	"subscriptions": subscriptionCommands,
+	"jobs":          jobCommands,
+	"credentials":   credentialCommands,
+	"exports":       exportCommands,
+	"migrations":    migrationCommands,
+	"archive":       archiveCommands,
+	"tokens":        tokenCommands,
+	"grants":        grantCommands,
+}
+
+// errUsage makes main print the usage of the failing command.
+var errUsage = errors.New("usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	global := flag.NewFlagSet("rss-zero-cli", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	opts := clientOptions{}
+	global.StringVar(&opts.server, "server", envOr("RSS_ZERO_SERVER", "http://localhost:8080"), "server base URL, env RSS_ZERO_SERVER")
+	global.StringVar(&opts.user, "user", envOr("RSS_ZERO_USER", "admin"), "Remote-User sent to the server, env RSS_ZERO_USER")
+	global.StringVar(&opts.groups, "groups", envOr("RSS_ZERO_GROUPS", "lldap_admin"), "Remote-Groups sent to the server, env RSS_ZERO_GROUPS")
+	global.StringVar(&opts.token, "token", os.Getenv("RSS_ZERO_TOKEN"), "API token sent as Authorization: Bearer instead of the Remote-* headers, env RSS_ZERO_TOKEN")
+	global.Usage = func() { printUsage(stderr) }
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+
+	rest := global.Args()
+	if len(rest) < 2 {
+		printUsage(stderr)
+		return 2
+	}
+	cmd, ok := commands[rest[0]][rest[1]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", strings.Join(rest[:2], " "))
+		printUsage(stderr)
+		return 2
+	}
+
+	data, err := cmd.run(newClient(opts), rest[2:])
+	if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(stderr, "usage: rss-zero-cli %s %s %s\n", rest[0], rest[1], cmd.usage)
+		return 2
+	}
+	if err != nil {
+		writeJSON(stderr, map[string]any{"error": err.Error()})
+		return 1
+	}
+	if err = writeJSON(stdout, data); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: rss-zero-cli [-server URL] [-user NAME] [-groups GROUPS] [-token TOKEN] <command> <subcommand> [flags] [args]")
+	fmt.Fprintln(w)
+	groups := make([]string, 0, len(commands))
+	for group := range commands {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		names := make([]string, 0, len(commands[group]))
+		for name := range commands[group] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			cmd := commands[group][name]
+			fmt.Fprintf(w, "  %s %s %s\n      %s\n", group, name, cmd.usage, cmd.help)
+		}
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// parseFlags parses args with fs and checks the number of positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string, nArgs int) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	if fs.NArg() != nArgs {
+		return nil, errUsage
+	}
+	return fs.Args(), nil
+}
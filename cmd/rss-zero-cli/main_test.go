@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// request 记录 fake server 收到的一次请求。
+type request struct {
+	method, path, query, groups, authorization string
+	body                                       map[string]any
+}
+
+func newServer(t *testing.T, status int, resp string) (*httptest.Server, *request) {
+	got := &request{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.method, got.path, got.query = r.Method, r.URL.EscapedPath(), r.URL.RawQuery
+		got.groups = r.Header.Get("Remote-Groups")
+		got.authorization = r.Header.Get("Authorization")
+		if raw, _ := io.ReadAll(r.Body); len(raw) > 0 {
+			require.NoError(t, json.Unmarshal(raw, &got.body))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+func runCLI(t *testing.T, server string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	code = run(append([]string{"-server", server}, args...), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRunCallsAPI(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantMethod string
+		wantPath   string
+		wantQuery  string
+		wantBody   map[string]any
+	}{
+		{name: "subscriptions list", args: []string{"subscriptions", "list", "-platform", "zhihu", "-active", "false"},
+			wantMethod: http.MethodGet, wantPath: "/api/v1/subscriptions", wantQuery: "active=false&platform=zhihu"},
+		{name: "subscriptions create", args: []string{"subscriptions", "create", "-platform", "github", "-target", "a/b"},
+			wantMethod: http.MethodPost, wantPath: "/api/v1/subscriptions",
+			wantBody: map[string]any{"platform": "github", "target_id": "a/b", "kind": "", "name": ""}},
+		{name: "subscriptions pause", args: []string{"subscriptions", "pause", "zhihu", "canglimo"},
+			wantMethod: http.MethodPost, wantPath: "/api/v1/subscriptions/zhihu/canglimo/pause"},
+		{name: "jobs history", args: []string{"jobs", "history", "-task", "t1", "-limit", "5"},
+			wantMethod: http.MethodGet, wantPath: "/api/v1/job/history", wantQuery: "limit=5&task=t1"},
+		{name: "jobs cancel", args: []string{"jobs", "cancel", "job-1"},
+			wantMethod: http.MethodPost, wantPath: "/api/v1/job/job-1/cancel"},
+		{name: "jobs logs", args: []string{"jobs", "logs", "-level", "warn", "-level", "error", "job-1"},
+			wantMethod: http.MethodGet, wantPath: "/api/v1/job/job-1/logs", wantQuery: "level=warn&level=error"},
+		{name: "credentials update", args: []string{"credentials", "update", "-value", "v1", "zsxq", "access_token"},
+			wantMethod: http.MethodPut, wantPath: "/api/v1/credentials/zsxq/access_token",
+			wantBody: map[string]any{"value": "v1", "expires_at": nil}},
+		{name: "exports start", args: []string{"exports", "start", "zsxq", "-group", "42", "-start", "2026-01-01"},
+			wantMethod: http.MethodPost, wantPath: "/api/v1/export/zsxq",
+			wantBody: map[string]any{"group_id": float64(42), "start_time": "2026-01-01", "type": nil, "end_time": nil, "digest": nil, "author": nil}},
+		{name: "migrations run pending", args: []string{"migrations", "run", "pending"},
+			wantMethod: http.MethodPost, wantPath: "/api/v1/migrate/run-pending"},
+		{name: "archive lookup escapes the url", args: []string{"archive", "lookup", "https://www.zhihu.com/pin/1"},
+			wantMethod: http.MethodGet, wantPath: "/api/v1/archive/https:%2F%2Fwww.zhihu.com%2Fpin%2F1", wantQuery: "format=md"},
+		{name: "archive stats", args: []string{"archive", "stats", "-author", "42", "-interval", "week", "zsxq"},
+			wantMethod: http.MethodGet, wantPath: "/api/v1/archive/statistics", wantQuery: "author=42&interval=week&platform=zsxq"},
+		{name: "tokens create", args: []string{"tokens", "create", "-scopes", "jobs:run,subs:manage", "ci"},
+			wantMethod: http.MethodPost, wantPath: "/api/v1/token",
+			wantBody: map[string]any{"name": "ci", "scopes": []any{"jobs:run", "subs:manage"}, "expires_at": nil}},
+		{name: "grants add", args: []string{"grants", "add", "xiaobot", "paper1", "alice"},
+			wantMethod: http.MethodPost, wantPath: "/api/v1/grants",
+			wantBody: map[string]any{"platform": "xiaobot", "target_id": "paper1", "username": "alice"}},
+		{name: "tokens revoke", args: []string{"tokens", "revoke", "t1"},
+			wantMethod: http.MethodDelete, wantPath: "/api/v1/token/t1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, got := newServer(t, http.StatusOK, `{"message":"success","data":{"id":"x"}}`)
+			code, stdout, stderr := runCLI(t, srv.URL, tt.args...)
+			require.Equal(t, 0, code, stderr)
+			assert.Equal(t, tt.wantMethod, got.method)
+			assert.Equal(t, tt.wantPath, got.path)
+			assert.Equal(t, tt.wantQuery, got.query)
+			assert.Equal(t, tt.wantBody, got.body)
+			assert.Equal(t, "lldap_admin", got.groups)
+			assert.True(t, json.Valid([]byte(stdout)), stdout)
+		})
+	}
+}
+
+func TestRunSendsToken(t *testing.T) {
+	srv, got := newServer(t, http.StatusOK, `{"message":"success","data":[]}`)
+	code, _, stderr := runCLI(t, srv.URL, "-token", "rzt_abc", "jobs", "list")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "Bearer rzt_abc", got.authorization)
+	assert.Empty(t, got.groups)
+}
+
+func TestRunPrintsData(t *testing.T) {
+	srv, _ := newServer(t, http.StatusOK, `{"message":"success","data":[{"id":"job-1"}]}`)
+	code, stdout, _ := runCLI(t, srv.URL, "jobs", "list")
+	require.Equal(t, 0, code)
+	assert.JSONEq(t, `[{"id":"job-1"}]`, stdout)
+
+	srv, _ = newServer(t, http.StatusAccepted, `{"message":"job canceling"}`)
+	code, stdout, _ = runCLI(t, srv.URL, "jobs", "cancel", "job-1")
+	require.Equal(t, 0, code)
+	assert.JSONEq(t, `{"message":"job canceling"}`, stdout)
+}
+
+func TestRunReportsErrors(t *testing.T) {
+	srv, _ := newServer(t, http.StatusConflict, `{"message":"job is not running or paused"}`)
+	code, stdout, stderr := runCLI(t, srv.URL, "jobs", "cancel", "job-1")
+	assert.Equal(t, 1, code)
+	assert.Empty(t, stdout)
+	assert.JSONEq(t, `{"error":"server returned 409: job is not running or paused"}`, stderr)
+
+	code, _, stderr = runCLI(t, srv.URL, "jobs", "cancel")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "usage: rss-zero-cli jobs cancel <job-id>")
+
+	code, _, stderr = runCLI(t, srv.URL, "jobs", "nope")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `unknown command "jobs nope"`)
+}
+
+func TestCredentialsImport(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cookies.json")
+	require.NoError(t, os.WriteFile(file, []byte(`[{"name":"zsxq_access_token","value":"v","domain":".zsxq.com","expirationDate":1.8e9}]`), 0o600))
+
+	srv, got := newServer(t, http.StatusOK, `{"message":"success","data":{"results":[]}}`)
+	code, _, stderr := runCLI(t, srv.URL, "credentials", "import", file)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "/api/v1/browser-cookies/import", got.path)
+	assert.Equal(t, map[string]any{"cookies": []any{map[string]any{
+		"name": "zsxq_access_token", "value": "v", "domain": ".zsxq.com", "expirationDate": 1.8e9,
+	}}}, got.body)
+
+	cookies, err := parseBrowserCookies([]byte(`{"cookies":[{"name":"a","value":"b"}]}`))
+	require.NoError(t, err)
+	assert.Len(t, cookies, 1)
+}
+
+func TestExportPollAndDownload(t *testing.T) {
+	ready := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !ready {
+			ready = true
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("exported"))
+	}))
+	t.Cleanup(srv.Close)
+	u := srv.URL + "/export/zsxq/file.md"
+
+	code, stdout, stderr := runCLI(t, srv.URL, "exports", "poll", "-interval", "1ms", u)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, `"ready": true`)
+
+	output := filepath.Join(t.TempDir(), "out.md")
+	code, _, stderr = runCLI(t, srv.URL, "exports", "download", "-o", output, u)
+	require.Equal(t, 0, code, stderr)
+	content, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, "exported", string(content))
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/url"
+)
+
+var migrationCommands = map[string]command{
+	"status": {
+		help: "list registered migrations with their applied state",
+		run: func(c *client, args []string) (any, error) {
+			if _, err := parseFlags(flag.NewFlagSet("status", flag.ContinueOnError), args, 0); err != nil {
+				return nil, err
+			}
+			return c.call(http.MethodGet, "/migrate/registry", nil, nil)
+		},
+	},
+	"run": {
+		usage: "<version>|pending",
+		help:  "run one migration by version, or every pending one",
+		run: func(c *client, args []string) (any, error) {
+			args, err := parseFlags(flag.NewFlagSet("run", flag.ContinueOnError), args, 1)
+			if err != nil {
+				return nil, err
+			}
+			if args[0] == "pending" {
+				return c.call(http.MethodPost, "/migrate/run-pending", nil, nil)
+			}
+			return c.call(http.MethodPost, "/migrate/run/"+url.PathEscape(args[0]), nil, nil)
+		},
+	},
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/url"
+
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+)
+
+var subscriptionCommands = map[string]command{
+	"list": {
+		usage: "[-platform P] [-active true|false]",
+		help:  "list subscriptions, paused ones included unless -active is set",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("list", flag.ContinueOnError)
+			platform := fs.String("platform", "", "only this platform")
+			active := fs.String("active", "", "only active (true) or paused (false) subscriptions")
+			if _, err := parseFlags(fs, args, 0); err != nil {
+				return nil, err
+			}
+			query := url.Values{}
+			if *platform != "" {
+				query.Set("platform", *platform)
+			}
+			if *active != "" {
+				query.Set("active", *active)
+			}
+			return c.call(http.MethodGet, "/subscriptions", query, nil)
+		},
+	},
+	"create": {
+		usage: "-platform P -target ID [-kind K] [-name N]",
+		help:  "subscribe to a target; an existing subscription is returned as is",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("create", flag.ContinueOnError)
+			var req subscription.CreateRequest
+			fs.StringVar(&req.Platform, "platform", "", "zhihu/zsxq/xiaobot/github")
+			fs.StringVar(&req.TargetID, "target", "", "author, question, column, collection, group, paper or owner/repo")
+			fs.StringVar(&req.Kind, "kind", "", "content kind, e.g. answer/article/pin/question/column/collection for zhihu")
+			fs.StringVar(&req.Name, "name", "", "display name")
+			if _, err := parseFlags(fs, args, 0); err != nil {
+				return nil, err
+			}
+			if req.Platform == "" || req.TargetID == "" {
+				return nil, errUsage
+			}
+			return c.call(http.MethodPost, "/subscriptions", nil, req)
+		},
+	},
+	"pause":  subscriptionAction(http.MethodPost, "/pause", "stop crawling a subscription, its feed stays available"),
+	"resume": subscriptionAction(http.MethodPost, "/resume", "resume a paused subscription"),
+	"delete": subscriptionAction(http.MethodDelete, "", "delete a subscription"),
+}
+
+func subscriptionAction(method, suffix, help string) command {
+	return command{
+		usage: "<platform> <id>",
+		help:  help,
+		run: func(c *client, args []string) (any, error) {
+			args, err := parseFlags(flag.NewFlagSet("subscription", flag.ContinueOnError), args, 2)
+			if err != nil {
+				return nil, err
+			}
+			return c.call(method, "/subscriptions/"+url.PathEscape(args[0])+"/"+url.PathEscape(args[1])+suffix, nil, nil)
+		},
+	}
+}
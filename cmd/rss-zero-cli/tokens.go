@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/url"
+	"strings"
+
+	tokenController "github.com/eli-yip/rss-zero/internal/controller/token"
+)
+
+var tokenCommands = map[string]command{
+	"list": {
+		help: "list the API tokens of the current user",
+		run: func(c *client, args []string) (any, error) {
+			if _, err := parseFlags(flag.NewFlagSet("list", flag.ContinueOnError), args, 0); err != nil {
+				return nil, err
+			}
+			return c.call(http.MethodGet, "/token", nil, nil)
+		},
+	},
+	"create": {
+		usage: "-scopes S[,S...] [-expires RFC3339] <name>",
+		help:  "create an API token; the plain token is printed only this once",
+		run: func(c *client, args []string) (any, error) {
+			fs := flag.NewFlagSet("create", flag.ContinueOnError)
+			scopes := fs.String("scopes", "", "comma separated: archive:read, subs:manage, jobs:run, admin")
+			expires := fs.String("expires", "", "expiry time, never expires when empty")
+			args, err := parseFlags(fs, args, 1)
+			if err != nil {
+				return nil, err
+			}
+			if *scopes == "" {
+				return nil, errUsage
+			}
+			req := tokenController.CreateRequest{Name: args[0], Scopes: strings.Split(*scopes, ",")}
+			if *expires != "" {
+				req.ExpiresAt = expires
+			}
+			return c.call(http.MethodPost, "/token", nil, req)
+		},
+	},
+	"revoke": {
+		usage: "<token-id>",
+		help:  "revoke an API token",
+		run: func(c *client, args []string) (any, error) {
+			args, err := parseFlags(flag.NewFlagSet("revoke", flag.ContinueOnError), args, 1)
+			if err != nil {
+				return nil, err
+			}
+			return c.call(http.MethodDelete, "/token/"+url.PathEscape(args[0]), nil, nil)
+		},
+	},
+}
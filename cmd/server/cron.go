@@ -1,9 +1,7 @@
 package main
 
 import (
-	"errors"
 	"fmt"
-	"math/rand/v2"
 	"time"
 
 	"go.uber.org/zap"
@@ -12,70 +10,57 @@ import (
 	"github.com/eli-yip/rss-zero/config"
 	"github.com/eli-yip/rss-zero/internal/ai"
 	jobController "github.com/eli-yip/rss-zero/internal/controller/job"
+	"github.com/eli-yip/rss-zero/internal/digest"
 	"github.com/eli-yip/rss-zero/internal/file"
 	"github.com/eli-yip/rss-zero/internal/notify"
 	"github.com/eli-yip/rss-zero/internal/redis"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	"github.com/eli-yip/rss-zero/pkg/cron"
 	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
-	"github.com/eli-yip/rss-zero/pkg/routers/douyu"
 	"github.com/eli-yip/rss-zero/pkg/routers/macked"
-	"github.com/eli-yip/rss-zero/pkg/routers/tombkeeper"
-	zhihuCron "github.com/eli-yip/rss-zero/pkg/routers/zhihu/cron"
-	zsxqCron "github.com/eli-yip/rss-zero/pkg/routers/zsxq/cron"
 )
 
+// jobDefinition is a job configured only through TOML, e.g. ai_digest. The
+// built-in static jobs live in cron_tasks, see jobController.StaticSpec.
 type jobDefinition struct {
-	name        string
-	schedule    string
-	fn          func()
-	randomDelay bool
-}
-
-const staticCrawlMaxDelay = 10 * time.Minute
-
-type randomDelayRunner struct {
-	maxDelay time.Duration
-	random   func(time.Duration) time.Duration
-	sleep    func(time.Duration)
-}
-
-var staticCrawlDelayRunner = randomDelayRunner{
-	maxDelay: staticCrawlMaxDelay,
-	random:   rand.N[time.Duration],
-	sleep:    time.Sleep,
-}
-
-func (r randomDelayRunner) wrap(jobName string, logger *zap.Logger, fn func()) func() {
-	return func() {
-		delay := r.random(r.maxDelay)
-		logger.Info("Delay static crawl job", zap.String("job_name", jobName), zap.Duration("delay", delay))
-		r.sleep(delay)
-		fn()
-	}
+	name     string
+	schedule string
+	fn       func()
 }
 
 // setupCronCrawlJob sets up cron jobs
 func setupCronCrawlJob(logger *zap.Logger, redisService redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, ai ai.AI, notifier notify.Notifier, fileService file.File,
 ) (cronService *cron.CronService, jobIndex *jobController.JobIndex, err error) {
-	cronService, err = cron.NewCronService(logger)
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get sql db: %w", err)
+	}
+	cronService, err = cron.NewCronService(logger, cron.WithElector(cron.NewPGElector(sqlDB, logger)))
 	if err != nil {
 		return nil, nil, fmt.Errorf("cron service init failed: %w", err)
 	}
 	jobIndex = jobController.NewJobIndex()
 
 	cronDBService := cronDB.NewDBService(db)
-	deps := jobController.BuildDeps{Redis: redisService, Cookie: cookieService, DB: db, AI: ai, Notifier: notifier}
+	deps := jobController.BuildDeps{Redis: redisService, Cookie: cookieService, DB: db, AI: ai, Notifier: notifier, File: fileService, Logger: logger}
+	cron.SetRetrier(jobController.NewRetrier(cronDBService, deps))
 	err = resumeRunningJobs(cronDBService, deps, logger)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to resume running jobs: %w", err)
 	}
+	if !config.C.Settings.Debug {
+		go reclaimExpiredJobs(cronDBService, deps, reclaimInterval, logger)
+	}
 
-	if err = addJobToCronService(cronService, cronDBService, jobIndex, deps, logger); err != nil {
+	if err = seedStaticTasks(cronDBService, config.C.StaticJobs, config.C.Settings.DisableDouyu, logger); err != nil {
+		return nil, nil, fmt.Errorf("failed to seed static tasks: %w", err)
+	}
+
+	if err = addJobToCronService(cronService, cronDBService, jobIndex, deps, config.C.Settings.Debug, logger); err != nil {
 		return nil, nil, fmt.Errorf("failed to add job to cron service: %w", err)
 	}
 
-	jobs := buildStaticJobDefinitions(redisService, cookieService, db, notifier, fileService, logger, config.C.Settings.DisableDouyu)
+	jobs := buildStaticJobDefinitions(redisService, db, ai, notifier, logger, config.C.Digest)
 
 	// If debug is true, add no jobs
 	if config.C.Settings.Debug {
@@ -83,11 +68,7 @@ func setupCronCrawlJob(logger *zap.Logger, redisService redis.Redis, cookieServi
 	}
 
 	for _, job := range jobs {
-		fn := job.fn
-		if job.randomDelay {
-			fn = staticCrawlDelayRunner.wrap(job.name, logger, fn)
-		}
-		jobID, err := cronService.AddJob(job.name, job.schedule, fn)
+		jobID, err := cronService.AddJob(job.name, job.schedule, jobController.TraceStaticJob(job.name, job.fn))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to add %s job: %w", job.name, err)
 		}
@@ -99,61 +80,111 @@ func setupCronCrawlJob(logger *zap.Logger, redisService redis.Redis, cookieServi
 	// once at startup to close that window.
 	if !config.C.Settings.Debug {
 		fn := macked.CrawlFunc(redisService, macked.NewDBService(db), logger)
-		go staticCrawlDelayRunner.wrap("macked_startup_prewarm", logger, fn)()
+		go jobController.RandomDelayRunner(jobController.StaticMaxDelay).Wrap("macked_startup_prewarm", logger, fn)()
 	}
 
 	return cronService, jobIndex, nil
 }
 
-func buildStaticJobDefinitions(redisService redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, notifier notify.Notifier, fileService file.File, logger *zap.Logger, disableDouyu bool) []jobDefinition {
-	jobs := []jobDefinition{
-		{
-			name:     "check_cookies",
-			schedule: "0 0 * * *",
-			fn:       checkCookies(cookieService, notifier, logger),
-		},
-		{
-			name:        "macked_crawl",
-			schedule:    "0 * * * *",
-			fn:          macked.CrawlFunc(redisService, macked.NewDBService(db), logger),
-			randomDelay: true,
-		},
-		{
-			name:        "tombkeeper_crawl",
-			schedule:    "0 * * * *",
-			fn:          tombkeeper.CrawlFunc(redisService, tombkeeper.NewDBService(db), fileService, notifier, logger),
-			randomDelay: true,
-		},
-		{
-			name:     "canglimo_random_select",
-			schedule: "0 0 * * *",
-			fn:       zhihuCron.BuildRandomSelectCanglimoAnswerCronFunc(db, redisService),
-		},
-		{
-			name:     "canglimo_digest_random_select",
-			schedule: "0 0 * * *",
-			fn:       zsxqCron.BuildRandomSelectCanglimoDigestTopicFunc(db, redisService),
-		},
-		{
-			name:        "zvideo_crawl",
-			schedule:    "0 0,3,6,9,12,15,18,21 * * *",
-			fn:          zhihuCron.BuildZvideoCrawlFunc("canglimo", db, notifier, cookieService),
-			randomDelay: true,
-		},
-	}
-
-	if !disableDouyu {
+// buildStaticJobDefinitions returns the jobs configured only through TOML.
+func buildStaticJobDefinitions(redisService redis.Redis, db *gorm.DB, aiService ai.AI, notifier notify.Notifier, logger *zap.Logger, digestConfig config.DigestConfig) []jobDefinition {
+	var jobs []jobDefinition
+
+	if digestConfig.Enabled {
+		schedule := digestConfig.Schedule
+		if schedule == "" {
+			schedule = config.DefaultDigestSchedule
+		}
 		jobs = append(jobs, jobDefinition{
-			name:        "douyu_crawl",
-			schedule:    "0 19 * * *",
-			fn:          douyu.BuildCrawlFunc(notifier, redisService),
-			randomDelay: true,
+			name:     "ai_digest",
+			schedule: schedule,
+			fn:       digest.CrawlFunc(digest.NewDeps(db, aiService, redisService, notifier), digestConfig, logger),
 		})
 	}
 
 	return jobs
 }
 
+// seedStaticTasks makes sure every built-in static job has its cron_tasks row and
+// writes the TOML overrides onto it. disable_douyu is kept as a shortcut for
+// static_jobs.douyu_crawl.enabled = false.
+func seedStaticTasks(cronDBService cronDB.DB, overrides map[string]config.StaticJobConfig, disableDouyu bool, logger *zap.Logger) error {
+	definitions, err := cronDBService.GetDefinitions()
+	if err != nil {
+		return fmt.Errorf("failed to get cron task definitions: %w", err)
+	}
+	byKind := make(map[string]*cronDB.CronTask, len(definitions))
+	for _, def := range definitions {
+		byKind[def.Kind] = def
+	}
+
+	for kind := range overrides {
+		if _, ok := jobController.StaticSpecByKind(kind); !ok {
+			logger.Warn("Unknown static job in config, ignore it", zap.String("kind", kind))
+		}
+	}
+
+	for _, spec := range jobController.StaticSpecs() {
+		def, exists := byKind[spec.Kind]
+		if !exists {
+			def = spec.Definition()
+		}
+		override := overrides[spec.Kind]
+		if spec.Kind == "douyu_crawl" && disableDouyu {
+			disabled := false
+			override.Enabled = &disabled
+		}
+		if !applyStaticJobConfig(def, override) && exists {
+			continue
+		}
+		if _, err = cronDBService.SaveDefinition(def); err != nil {
+			return fmt.Errorf("failed to save %s definition: %w", spec.Kind, err)
+		}
+		logger.Info("Save static task definition", zap.String("kind", spec.Kind), zap.String("task_id", def.ID))
+	}
+	return nil
+}
+
+// applyStaticJobConfig writes the fields set in cfg onto def and reports whether def changed.
+func applyStaticJobConfig(def *cronDB.CronTask, cfg config.StaticJobConfig) (changed bool) {
+	if cfg.Schedule != "" && cfg.Schedule != def.CronExpr {
+		def.CronExpr, changed = cfg.Schedule, true
+	}
+	if cfg.Enabled != nil && *cfg.Enabled == def.Disabled {
+		def.Disabled, changed = !*cfg.Enabled, true
+	}
+	if cfg.MaxDelaySeconds != nil && *cfg.MaxDelaySeconds != def.MaxDelay {
+		def.MaxDelay, changed = *cfg.MaxDelaySeconds, true
+	}
+	for k, v := range cfg.Params {
+		if old, ok := def.Params[k]; ok && old == v {
+			continue
+		}
+		if def.Params == nil {
+			def.Params = make(map[string]string, len(cfg.Params))
+		}
+		def.Params[k], changed = v, true
+	}
+	return changed
+}
+
+// reclaimInterval is how often running jobs are checked for a lapsed lease.
+// At startup the previous process's leases are usually still valid, so the
+// jobs it left behind are only taken over by a later pass.
+const reclaimInterval = cronDB.LeaseTTL / 2
+
+// reclaimExpiredJobs periodically resumes running jobs whose owner stopped
+// renewing the lease, e.g. a crashed replica or this host before a restart.
+func reclaimExpiredJobs(cronDBService cronDB.DB, deps jobController.BuildDeps, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := resumeRunningJobs(cronDBService, deps, logger); err != nil {
+			logger.Error("Failed to reclaim running jobs", zap.Error(err))
+		}
+	}
+}
+
 func resumeRunningJobs(cronDBService cronDB.DB, deps jobController.BuildDeps, logger *zap.Logger) (err error) {
 	if config.C.Settings.Debug {
 		return nil
@@ -165,6 +196,21 @@ func resumeRunningJobs(cronDBService cronDB.DB, deps jobController.BuildDeps, lo
 	}
 
 	for _, job := range runningJobs {
+		// This process is running it and renews the lease itself.
+		if job.Owner == cronDB.InstanceID {
+			continue
+		}
+		// Another replica may still be running it; only take over jobs whose
+		// owner stopped renewing the lease.
+		claimed, err := cronDBService.ClaimJob(job.ID, cronDB.InstanceID)
+		if err != nil {
+			return fmt.Errorf("failed to claim running cron job: %w", err)
+		}
+		if !claimed {
+			logger.Info("Running job is owned by another instance, skip it", zap.String("job_id", job.ID), zap.String("owner", job.Owner))
+			continue
+		}
+
 		definition, err := cronDBService.GetDefinition(job.TaskType)
 		if err != nil {
 			return fmt.Errorf("failed to get cron task definition: %w", err)
@@ -177,7 +223,7 @@ func resumeRunningJobs(cronDBService cronDB.DB, deps jobController.BuildDeps, lo
 
 		if spec.Resumable {
 			fn := spec.Build(deps, definition, &jobController.ResumeInfo{JobID: job.ID, LastCrawled: job.Detail})
-			go cron.GenerateRealCrawlFunc(fn)()
+			go cron.GenerateRealCrawlFunc(fn, cronDB.TriggerResume)()
 			logger.Info("Start running job", zap.String("source", spec.Kind), zap.String("job_id", job.ID))
 		} else if err = cronDBService.UpdateStatus(job.ID, cronDB.StatusStopped); err != nil {
 			return fmt.Errorf("failed to stop %s running job: %w", spec.Kind, err)
@@ -186,13 +232,29 @@ func resumeRunningJobs(cronDBService cronDB.DB, deps jobController.BuildDeps, lo
 	return nil
 }
 
-func addJobToCronService(cronService *cron.CronService, cronDBService cronDB.DB, jobIndex *jobController.JobIndex, deps jobController.BuildDeps, logger *zap.Logger) error {
+func addJobToCronService(cronService *cron.CronService, cronDBService cronDB.DB, jobIndex *jobController.JobIndex, deps jobController.BuildDeps, debug bool, logger *zap.Logger) error {
 	definitions, err := cronDBService.GetDefinitions()
 	if err != nil {
 		return fmt.Errorf("failed to get cron task definitions: %w", err)
 	}
 
 	for _, def := range definitions {
+		if def.Disabled {
+			logger.Info("Skip disabled cron task", zap.String("kind", def.Kind), zap.String("task_id", def.ID))
+			continue
+		}
+		if staticSpec, ok := jobController.StaticSpecByKind(def.Kind); ok {
+			// If debug is true, add no static jobs
+			if debug {
+				continue
+			}
+			jobID, err := jobController.AddStaticToScheduler(cronService, jobIndex, staticSpec, deps, def)
+			if err != nil {
+				return fmt.Errorf("failed to add %s job: %w", staticSpec.Kind, err)
+			}
+			logger.Info(fmt.Sprintf("Add %s job successfully", staticSpec.Kind), zap.String("job_id", jobID))
+			continue
+		}
 		spec, ok := jobController.SpecByKind(def.Kind)
 		if !ok {
 			return fmt.Errorf("unknown cron job type %s", def.Kind)
@@ -205,20 +267,3 @@ func addJobToCronService(cronService *cron.CronService, cronDBService cronDB.DB,
 	}
 	return nil
 }
-
-func checkCookies(cookieService cookie.CookieIface, notifier notify.Notifier, logger *zap.Logger) func() {
-	return func() {
-		// Iterate the registry (not GetCookieTypes) so a never-set cookie is flagged too.
-		for _, spec := range cookie.AllSpecs() {
-			label := spec.Label()
-			err := cookieService.CheckTTL(spec.Type, 48*time.Hour)
-			if errors.Is(err, cookie.ErrKeyNotExist) {
-				logger.Error("Need to update cookies", zap.String("cookie_type", label))
-				notify.NoticeWithLogger(notifier, "Need to update cookies", fmt.Sprintf("Cookie type: %s", label), logger)
-			} else if err != nil {
-				logger.Error("Failed to check cookie", zap.String("cookie_type", label), zap.Error(err))
-				notify.NoticeWithLogger(notifier, "Failed to check cookie", fmt.Sprintf("Cookie type: %s", label), logger)
-			}
-		}
-	}
-}
@@ -6,96 +6,159 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/config"
+	jobController "github.com/eli-yip/rss-zero/internal/controller/job"
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
 )
 
-func TestRandomDelayRunnerWrap(t *testing.T) {
-	t.Parallel()
+// seedCronDB 只实现 seedStaticTasks 用到的方法。
+type seedCronDB struct {
+	cronDB.DB
+	tasks []*cronDB.CronTask
+	saved []string
+}
 
-	const (
-		maxDelay = 10 * time.Minute
-		delay    = 3 * time.Minute
-	)
-	events := make([]string, 0, 3)
-	runner := randomDelayRunner{
-		maxDelay: maxDelay,
-		random: func(gotMax time.Duration) time.Duration {
-			if gotMax != maxDelay {
-				t.Fatalf("max delay = %s, want %s", gotMax, maxDelay)
-			}
-			events = append(events, "random")
-			return delay
-		},
-		sleep: func(gotDelay time.Duration) {
-			if gotDelay != delay {
-				t.Fatalf("sleep delay = %s, want %s", gotDelay, delay)
-			}
-			events = append(events, "sleep")
-		},
-	}
+func (f *seedCronDB) GetDefinitions() ([]*cronDB.CronTask, error) { return f.tasks, nil }
 
-	runner.wrap("test_crawl", zap.NewNop(), func() { events = append(events, "run") })()
+func (f *seedCronDB) SaveDefinition(task *cronDB.CronTask) (string, error) {
+	if task.ID == "" {
+		task.ID = "new-" + task.Kind
+		f.tasks = append(f.tasks, task)
+	}
+	f.saved = append(f.saved, task.Kind)
+	return task.ID, nil
+}
 
-	want := []string{"random", "sleep", "run"}
-	if !slices.Equal(events, want) {
-		t.Fatalf("events = %v, want %v", events, want)
+func (f *seedCronDB) byKind(kind string) *cronDB.CronTask {
+	for _, task := range f.tasks {
+		if task.Kind == kind {
+			return task
+		}
 	}
+	return nil
 }
 
-func TestBuildStaticJobDefinitionsRandomDelay(t *testing.T) {
+func TestSeedStaticTasks(t *testing.T) {
 	t.Parallel()
 
-	wantDelayed := []string{"douyu_crawl", "macked_crawl", "tombkeeper_crawl", "zvideo_crawl"}
-	wantSchedules := map[string]string{
-		"douyu_crawl":      "0 19 * * *",
-		"macked_crawl":     "0 * * * *",
-		"tombkeeper_crawl": "0 * * * *",
-		"zvideo_crawl":     "0 0,3,6,9,12,15,18,21 * * *",
-	}
-	jobs := buildStaticJobDefinitions(nil, nil, nil, nil, nil, nil, false)
-	gotDelayed := make([]string, 0, len(wantDelayed))
-	for _, job := range jobs {
-		if job.randomDelay {
-			gotDelayed = append(gotDelayed, job.name)
-			if job.schedule != wantSchedules[job.name] {
-				t.Errorf("%s schedule = %q, want %q", job.name, job.schedule, wantSchedules[job.name])
-			}
-		}
+	edited := &cronDB.CronTask{ID: "zvideo", Kind: "zvideo_crawl", CronExpr: "0 2 * * *", MaxDelay: 60, Params: map[string]string{"author": "canglimo"}}
+	fake := &seedCronDB{tasks: []*cronDB.CronTask{edited, {ID: "macked", Kind: "macked_crawl", CronExpr: "0 * * * *", MaxDelay: 600}}}
+	enabled := true
+	overrides := map[string]config.StaticJobConfig{
+		"zvideo_crawl": {Enabled: &enabled, Params: map[string]string{"author": "someone"}},
+		"unknown":      {Schedule: "0 0 * * *"},
+	}
+
+	if err := seedStaticTasks(fake, overrides, true, zap.NewNop()); err != nil {
+		t.Fatalf("seedStaticTasks: %v", err)
 	}
-	slices.Sort(gotDelayed)
 
-	if !slices.Equal(gotDelayed, wantDelayed) {
-		t.Fatalf("randomly delayed jobs = %v, want %v", gotDelayed, wantDelayed)
+	if slices.Contains(fake.saved, "macked_crawl") {
+		t.Errorf("unchanged macked_crawl row was saved again")
+	}
+	if edited.CronExpr != "0 2 * * *" || edited.MaxDelay != 60 {
+		t.Errorf("fields absent from config were overwritten: %+v", edited)
+	}
+	if edited.Params["author"] != "someone" {
+		t.Errorf("zvideo author = %q, want someone", edited.Params["author"])
+	}
+	douyu := fake.byKind("douyu_crawl")
+	if douyu == nil || !douyu.Disabled {
+		t.Fatalf("douyu_crawl = %+v, want a disabled row", douyu)
+	}
+	if douyu.CronExpr != "0 19 * * *" || douyu.MaxDelay != 600 {
+		t.Errorf("seeded douyu_crawl = %+v, want default schedule and delay", douyu)
+	}
+	if fake.byKind("check_cookies") == nil || fake.byKind("unknown") != nil {
+		t.Errorf("seeded kinds = %v", fake.saved)
 	}
 }
 
-func TestBuildStaticJobDefinitionsDisableDouyu(t *testing.T) {
+func TestBuildStaticJobDefinitionsDigest(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name         string
-		disableDouyu bool
-		wantDouyu    bool
+		cfg          config.DigestConfig
+		wantSchedule string // empty means the job must be absent
 	}{
-		{name: "enabled by default", wantDouyu: true},
-		{name: "disabled", disableDouyu: true, wantDouyu: false},
+		{name: "disabled by default"},
+		{name: "default schedule", cfg: config.DigestConfig{Enabled: true}, wantSchedule: config.DefaultDigestSchedule},
+		{name: "custom schedule", cfg: config.DigestConfig{Enabled: true, Schedule: "30 7 * * *"}, wantSchedule: "30 7 * * *"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			jobs := buildStaticJobDefinitions(nil, nil, nil, nil, nil, nil, tt.disableDouyu)
-			names := make([]string, 0, len(jobs))
-			for _, job := range jobs {
-				names = append(names, job.name)
+			jobs := buildStaticJobDefinitions(nil, nil, nil, nil, nil, tt.cfg)
+			idx := slices.IndexFunc(jobs, func(job jobDefinition) bool { return job.name == "ai_digest" })
+			if tt.wantSchedule == "" {
+				if idx >= 0 {
+					t.Fatalf("ai_digest registered while disabled")
+				}
+				return
 			}
-
-			if got := slices.Contains(names, "douyu_crawl"); got != tt.wantDouyu {
-				t.Fatalf("douyu_crawl present = %t, want %t; jobs = %v", got, tt.wantDouyu, names)
+			if idx < 0 {
+				t.Fatalf("ai_digest not registered")
 			}
-			if !slices.Contains(names, "check_cookies") {
-				t.Fatalf("unrelated static job missing; jobs = %v", names)
+			if jobs[idx].schedule != tt.wantSchedule {
+				t.Fatalf("ai_digest schedule = %q, want %q", jobs[idx].schedule, tt.wantSchedule)
 			}
 		})
 	}
 }
+
+// resumeCronDB 只实现 resumeRunningJobs 用到的方法，按租约判定能否接管。
+type resumeCronDB struct {
+	cronDB.DB
+	jobs    []*cronDB.CronJob
+	claimed []string
+	stopped []string
+}
+
+func (f *resumeCronDB) FindRunningJob() ([]*cronDB.CronJob, error) { return f.jobs, nil }
+
+func (f *resumeCronDB) ClaimJob(jobID, owner string) (bool, error) {
+	for _, job := range f.jobs {
+		if job.ID == jobID && (job.Owner == owner || !job.LeaseHeld(time.Now())) {
+			f.claimed = append(f.claimed, jobID)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *resumeCronDB) GetDefinition(taskID string) (*cronDB.CronTask, error) {
+	return &cronDB.CronTask{ID: taskID, Kind: "xiaobot"}, nil
+}
+
+func (f *resumeCronDB) UpdateStatus(jobID string, status int) error {
+	if status == cronDB.StatusStopped {
+		f.stopped = append(f.stopped, jobID)
+	}
+	return nil
+}
+
+func TestResumeRunningJobs(t *testing.T) {
+	t.Parallel()
+
+	held, lapsed := time.Now().Add(time.Minute), time.Now().Add(-time.Minute)
+	fake := &resumeCronDB{jobs: []*cronDB.CronJob{
+		{ID: "own", TaskType: "t", Status: cronDB.StatusRunning, Owner: cronDB.InstanceID, LeaseUntil: &held},
+		{ID: "held", TaskType: "t", Status: cronDB.StatusRunning, Owner: "other", LeaseUntil: &held},
+		{ID: "lapsed", TaskType: "t", Status: cronDB.StatusRunning, Owner: "restarted", LeaseUntil: &lapsed},
+	}}
+
+	if err := resumeRunningJobs(fake, jobController.BuildDeps{}, zap.NewNop()); err != nil {
+		t.Fatalf("resumeRunningJobs: %v", err)
+	}
+	// 本进程自己的运行不重复接管，仍被持有的租约不动，过期的被接管（xiaobot 不可续跑，直接停止）
+	if !slices.Equal(fake.claimed, []string{"lapsed"}) {
+		t.Errorf("claimed = %v, want [lapsed]", fake.claimed)
+	}
+	if !slices.Equal(fake.stopped, []string{"lapsed"}) {
+		t.Errorf("stopped = %v, want [lapsed]", fake.stopped)
+	}
+}
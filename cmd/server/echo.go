@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"slices"
 
 	"github.com/labstack/echo/v5"
 	"github.com/labstack/echo/v5/middleware"
@@ -17,24 +16,31 @@ import (
 	"github.com/eli-yip/rss-zero/internal/ai"
 	archiveController "github.com/eli-yip/rss-zero/internal/controller/archive"
 	cookieController "github.com/eli-yip/rss-zero/internal/controller/cookie"
+	digestController "github.com/eli-yip/rss-zero/internal/controller/digest"
 	endoflifeController "github.com/eli-yip/rss-zero/internal/controller/endoflife"
 	githubController "github.com/eli-yip/rss-zero/internal/controller/github"
 	jobController "github.com/eli-yip/rss-zero/internal/controller/job"
 	mackedHandler "github.com/eli-yip/rss-zero/internal/controller/macked"
 	migrateController "github.com/eli-yip/rss-zero/internal/controller/migrate"
+	opmlController "github.com/eli-yip/rss-zero/internal/controller/opml"
 	parseHandler "github.com/eli-yip/rss-zero/internal/controller/parse"
 	rsshubController "github.com/eli-yip/rss-zero/internal/controller/rsshub"
+	subscriptionController "github.com/eli-yip/rss-zero/internal/controller/subscription"
 	tkblogHandler "github.com/eli-yip/rss-zero/internal/controller/tkblog"
+	tokenController "github.com/eli-yip/rss-zero/internal/controller/token"
 	tombkeeperHandler "github.com/eli-yip/rss-zero/internal/controller/tombkeeper"
 	userController "github.com/eli-yip/rss-zero/internal/controller/user"
 	xiaobotController "github.com/eli-yip/rss-zero/internal/controller/xiaobot"
 	zhihuController "github.com/eli-yip/rss-zero/internal/controller/zhihu"
 	zsxqController "github.com/eli-yip/rss-zero/internal/controller/zsxq"
+	"github.com/eli-yip/rss-zero/internal/digest"
 	"github.com/eli-yip/rss-zero/internal/file"
 	myMiddleware "github.com/eli-yip/rss-zero/internal/middleware"
 	"github.com/eli-yip/rss-zero/internal/notify"
 	"github.com/eli-yip/rss-zero/internal/redis"
 	"github.com/eli-yip/rss-zero/internal/version"
+	"github.com/eli-yip/rss-zero/pkg/apitoken"
+	apitokenDB "github.com/eli-yip/rss-zero/pkg/apitoken/db"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	"github.com/eli-yip/rss-zero/pkg/cron"
 	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
@@ -47,7 +53,10 @@ import (
 	xiaobotDB "github.com/eli-yip/rss-zero/pkg/routers/xiaobot/db"
 	xiaobotRequest "github.com/eli-yip/rss-zero/pkg/routers/xiaobot/request"
 	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
 	zsxqRequest "github.com/eli-yip/rss-zero/pkg/routers/zsxq/request"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
 )
 
 func setupEcho(redisService redis.Redis,
@@ -86,6 +95,7 @@ func setupEcho(redisService redis.Redis,
 				"Sec-Fetch-Site",
 				"Sec-Fetch-Mode",
 				"Sec-Fetch-Dest",
+				"authorization",
 			},
 			AllowMethods: []string{
 				http.MethodGet,
@@ -99,13 +109,14 @@ func setupEcho(redisService redis.Redis,
 			AllowCredentials: true,
 			MaxAge:           60 * 60 * 24,
 		}),
+		myMiddleware.Tracing(),            // opentelemetry server span
+		myMiddleware.Metrics(),            // prometheus http metrics
 		myMiddleware.LogRequest(logger),   // log request
 		myMiddleware.InjectLogger(logger), // inject logger to context
 	)
 	// Render all errors as the unified {message} envelope.
 	e.HTTPErrorHandler = httputil.NewHTTPErrorHandler(logger)
 
-	zsxqHandler := zsxqController.NewZsxqController(redisService, cookieService, db, notifier, logger)
 	zhihuDBService := zhihuDB.NewDBService(db)
 	zhihuHandler := zhihuController.NewController(redisService, cookieService, zhihuDBService, notifier)
 	xiaobotDBService := xiaobotDB.NewDBService(db)
@@ -113,9 +124,8 @@ func setupEcho(redisService redis.Redis,
 	endOfLifeHandler := endoflifeController.NewController(redisService, logger)
 	cronDBService := cronDB.NewDBService(db)
 	jobHandler := jobController.NewController(cronService, jobIndex,
-		redisService, cookieService, db, ai, notifier,
+		redisService, cookieService, db, ai, notifier, fileService,
 		cronDBService, logger)
-	archiveHandler := archiveController.NewController(db)
 	githubDBService := githubDB.NewDBService(db)
 	githubController := githubController.NewController(redisService, cookieService, githubDBService, notifier)
 	cookieHandler := cookieController.NewController(cookieService)
@@ -125,10 +135,29 @@ func setupEcho(redisService redis.Redis,
 	tkblogH := tkblogHandler.NewController(tkblogRouter.NewDBService(db), notifier, logger)
 	parseHandler := parseHandler.NewHandler(db, ai, cookieService, fileService, notifier)
 	migrateHandler := migrateController.NewController(logger, db, notifier)
-
-	registerRSS(e, zsxqHandler, zhihuHandler, xiaobotHandler, endOfLifeHandler, githubController, mHandler, tombkeeperH)
+	digestHandler := digestController.NewController(redisService, digest.NewDBService(db))
+	subscriptionRegistry := subscription.NewRegistry(subscriptionDB.NewSubscriptionDBImpl(db),
+		subscription.NewZhihuSource(zhihuDBService, zhihuHandler.CheckSub),
+		subscription.NewZsxqSource(zsxqDB.NewDBService(db)),
+		subscription.NewXiaobotSource(xiaobotDBService, xiaobotHandler.CheckPaper),
+		subscription.NewGitHubSource(githubDBService, githubController.CheckRepo),
+	)
+	ownership := subscription.NewOwnership(subscriptionRegistry, subscriptionDB.NewOwnerDBImpl(db),
+		config.C.Settings.SubscriptionQuota, config.C.Settings.FeedSecret)
+	subscriptionHandler := subscriptionController.NewController(subscriptionRegistry, ownership)
+	zsxqHandler := zsxqController.NewZsxqController(redisService, cookieService, db, notifier, ownership, logger)
+	opmlHandler := opmlController.NewController(subscriptionRegistry, ownership)
+	archiveHandler := archiveController.NewController(db, ownership)
+	tokenService := apitoken.NewService(apitokenDB.NewDBService(db))
+	tokenHandler := tokenController.NewController(tokenService)
+
+	registerRSS(e, zsxqHandler, zhihuHandler, xiaobotHandler, endOfLifeHandler, githubController, mHandler, tombkeeperH, digestHandler, archiveHandler, ownership)
 	// /api/v1
-	apiGroup := e.Group("/api/v1")
+	// Middleware is bound when a route is added, so every group gets its auth
+	// middleware at creation, before its routes are registered.
+	apiGroup := e.Group("/api/v1", myMiddleware.Authenticate(tokenService))
+	adminGroup := func(prefix string) *echo.Group { return apiGroup.Group(prefix, myMiddleware.AllowAdmin()) }
+
 	registerArchive(apiGroup, archiveHandler)
 	apiGroup.GET("/user", userController.GetUserInfo, myMiddleware.InjectUser())
 	bookmarkGroup := apiGroup.Group("/bookmark")
@@ -137,67 +166,53 @@ func setupEcho(redisService redis.Redis,
 	tagGroup := apiGroup.Group("/tag")
 	tagGroup.Use(myMiddleware.InjectUser())
 	registerTag(tagGroup, archiveHandler)
+	tokenGroup := apiGroup.Group("/token")
+	tokenGroup.Use(myMiddleware.InjectUser())
+	registerToken(tokenGroup, tokenHandler)
+	myGroup := apiGroup.Group("/my")
+	myGroup.Use(myMiddleware.InjectUser())
+	registerMy(myGroup, subscriptionHandler, opmlHandler)
+
+	registerAuthor(adminGroup("/author"), zhihuHandler)
 
-	var groupNeedAuth []*echo.Group
+	registerFeed(adminGroup("/feed"), zhihuHandler, githubController)
 
-	authorApi := apiGroup.Group("/author")
-	groupNeedAuth = append(groupNeedAuth, authorApi)
-	registerAuthor(authorApi, zhihuHandler)
+	registerJob(apiGroup.Group("/job", myMiddleware.AllowScope(apitoken.ScopeJobsRun)), jobHandler)
 
-	feedApi := apiGroup.Group("/feed")
-	groupNeedAuth = append(groupNeedAuth, feedApi)
-	registerFeed(feedApi, zhihuHandler, githubController)
+	registerCredentials(adminGroup("/credentials"), cookieHandler)
 
-	jobApi := apiGroup.Group("/job")
-	groupNeedAuth = append(groupNeedAuth, jobApi)
-	registerJob(jobApi, jobHandler)
+	registerBrowserCookies(adminGroup("/browser-cookies"), cookieHandler)
 
-	credentialsAPI := apiGroup.Group("/credentials")
-	groupNeedAuth = append(groupNeedAuth, credentialsAPI)
-	registerCredentials(credentialsAPI, cookieHandler)
+	registerDEncryptionService(adminGroup("/es"), zhihuHandler)
 
-	browserCookiesAPI := apiGroup.Group("/browser-cookies")
-	groupNeedAuth = append(groupNeedAuth, browserCookiesAPI)
-	registerBrowserCookies(browserCookiesAPI, cookieHandler)
+	registerReformat(adminGroup("/refmt"), xiaobotHandler)
 
-	encryptionServiceApi := apiGroup.Group("/es")
-	groupNeedAuth = append(groupNeedAuth, encryptionServiceApi)
-	registerDEncryptionService(encryptionServiceApi, zhihuHandler)
+	registerExport(adminGroup("/export"), zsxqHandler, zhihuHandler, xiaobotHandler)
 
-	refmtGroup := apiGroup.Group("/refmt")
-	groupNeedAuth = append(groupNeedAuth, refmtGroup)
-	registerReformat(refmtGroup, xiaobotHandler)
+	registerNamedRoute(apiGroup.Group("/zsxq", myMiddleware.InjectUser()), http.MethodGet, "/:group/files", "Zsxq group files route", zsxqHandler.Files)
 
-	exportGroup := apiGroup.Group("/export")
-	groupNeedAuth = append(groupNeedAuth, exportGroup)
-	registerExport(exportGroup, zsxqHandler, zhihuHandler, xiaobotHandler)
+	subsScope := myMiddleware.AllowScope(apitoken.ScopeSubsManage)
+	registerSub(apiGroup.Group("/sub", subsScope), zhihuHandler, githubController, xiaobotHandler, subscriptionHandler)
 
-	subGroup := apiGroup.Group("/sub")
-	groupNeedAuth = append(groupNeedAuth, subGroup)
-	registerSub(subGroup, zhihuHandler, githubController, xiaobotHandler)
+	registerSubscription(apiGroup.Group("/subscriptions", subsScope), subscriptionHandler)
 
-	migrateGroup := apiGroup.Group("/migrate")
-	groupNeedAuth = append(groupNeedAuth, migrateGroup)
-	registerMigrate(migrateGroup, migrateHandler)
+	registerGrants(adminGroup("/grants"), subscriptionHandler)
 
-	parseGroup := apiGroup.Group("/parse")
-	groupNeedAuth = append(groupNeedAuth, parseGroup)
-	registerParse(parseGroup, parseHandler)
+	// /api/v1/health 本身公开，其下的订阅健康明细仅管理员可见
+	registerNamedRoute(adminGroup("/health"), http.MethodGet, "/subscriptions", "Subscription health route", subscriptionHandler.Health)
+
+	registerMigrate(adminGroup("/migrate"), migrateHandler)
+
+	registerParse(adminGroup("/parse"), parseHandler)
 
 	mackedGroup := apiGroup.Group("/macked")
 	registerMacked(mackedGroup, mHandler)
 
-	tombkeeperGroup := apiGroup.Group("/tombkeeper")
-	groupNeedAuth = append(groupNeedAuth, tombkeeperGroup)
-	registerNamedRoute(tombkeeperGroup, http.MethodPost, "/history", "Tombkeeper history backfill route", tombkeeperH.History)
+	registerNamedRoute(adminGroup("/tombkeeper"), http.MethodPost, "/history", "Tombkeeper history backfill route", tombkeeperH.History)
 
-	tkblogGroup := apiGroup.Group("/tkblog")
-	groupNeedAuth = append(groupNeedAuth, tkblogGroup)
-	registerNamedRoute(tkblogGroup, http.MethodPost, "/:category/crawl", "Tkblog crawl route", tkblogH.Crawl)
+	registerOPML(adminGroup("/opml"), opmlHandler)
 
-	for g := range slices.Values(groupNeedAuth) {
-		g.Use(myMiddleware.AllowAdmin())
-	}
+	registerNamedRoute(adminGroup("/tkblog"), http.MethodPost, "/:category/crawl", "Tkblog crawl route", tkblogH.Crawl)
 
 	registerNamedRoute(apiGroup, http.MethodGet, "/health", "Health check route", func(c *echo.Context) error {
 		return c.JSON(http.StatusOK, httputil.NewResp("ok", map[string]string{
@@ -214,6 +229,7 @@ func setupEcho(redisService redis.Redis,
 	}
 
 	registerPprof(e)
+	registerMetrics(e, cookieService, tokenService, logger)
 
 	return e
 }
@@ -241,10 +257,17 @@ func registerBookmark(bookmarkGroup *echo.Group, archiveHandler *archiveControll
 	registerNamedRoute(bookmarkGroup, http.MethodPut, "", "Bookmark add route", archiveHandler.PutBookmark)
 	registerNamedRoute(bookmarkGroup, http.MethodDelete, "/:id", "Bookmark delete route", archiveHandler.DeleteBookmark)
 	registerNamedRoute(bookmarkGroup, http.MethodPatch, "/:id", "Bookmark update route", archiveHandler.PatchBookmark)
+	registerNamedRoute(bookmarkGroup, http.MethodGet, "/export", "Bookmark export route", archiveHandler.ExportBookmarks)
+	registerNamedRoute(bookmarkGroup, http.MethodPost, "/import", "Bookmark import route", archiveHandler.ImportBookmarks)
+	registerNamedRoute(bookmarkGroup, http.MethodGet, "/feed/*", "Bookmark tag feed url route", archiveHandler.GetBookmarkFeedURL)
 }
 
 func registerTag(tagGroup *echo.Group, archiveHandler *archiveController.Controller) {
 	registerNamedRoute(tagGroup, http.MethodGet, "", "Tag list route", archiveHandler.GetAllTags)
+	registerNamedRoute(tagGroup, http.MethodPatch, "", "Tag update route", archiveHandler.PatchTag)
+	registerNamedRoute(tagGroup, http.MethodPost, "/rename", "Tag rename route", archiveHandler.RenameTag)
+	registerNamedRoute(tagGroup, http.MethodPost, "/merge", "Tag merge route", archiveHandler.MergeTags)
+	registerNamedRoute(tagGroup, http.MethodPost, "/delete", "Tag delete route", archiveHandler.DeleteTags)
 }
 
 func registerAuthor(apiGroup *echo.Group, zhihuHandler *zhihuController.Controller) {
@@ -267,6 +290,11 @@ func registerJob(apiGroup *echo.Group, jobHandler *jobController.Controller) {
 	registerNamedRoute(apiGroup, http.MethodPost, "/start/:task", "Start job route", jobHandler.StartJob)
 	registerNamedRoute(apiGroup, http.MethodGet, "/list", "Get jobs route", jobHandler.GetJobs)
 	registerNamedRoute(apiGroup, http.MethodGet, "/list/error", "Get error jobs route", jobHandler.GetErrorJobs)
+	registerNamedRoute(apiGroup, http.MethodGet, "/history", "Get job history route", jobHandler.GetJobHistory)
+	registerNamedRoute(apiGroup, http.MethodGet, "/:id/logs", "Get job logs route", jobHandler.GetJobLogs)
+	registerNamedRoute(apiGroup, http.MethodPost, "/:id/cancel", "Cancel job route", jobHandler.CancelJob)
+	registerNamedRoute(apiGroup, http.MethodPost, "/:id/pause", "Pause job route", jobHandler.PauseJob)
+	registerNamedRoute(apiGroup, http.MethodPost, "/:id/resume", "Resume job route", jobHandler.ResumeJob)
 	registerNamedRoute(apiGroup, http.MethodPost, "/task", "Add task route", jobHandler.AddTask)
 	registerNamedRoute(apiGroup, http.MethodPost, "/task/patch", "Patch task route", jobHandler.PatchTask)
 	registerNamedRoute(apiGroup, http.MethodDelete, "/task/:id", "Delete task route", jobHandler.DeleteTask)
@@ -274,6 +302,32 @@ func registerJob(apiGroup *echo.Group, jobHandler *jobController.Controller) {
 	registerNamedRoute(apiGroup, http.MethodPost, "/run/:job", "Run job now route", jobHandler.RunJobByName)
 }
 
+// /api/v1/token
+func registerToken(tokenGroup *echo.Group, tokenHandler *tokenController.Controller) {
+	registerNamedRoute(tokenGroup, http.MethodGet, "", "API token list route", tokenHandler.List)
+	registerNamedRoute(tokenGroup, http.MethodPost, "", "API token create route", tokenHandler.Create)
+	registerNamedRoute(tokenGroup, http.MethodDelete, "/:id", "API token delete route", tokenHandler.Delete)
+}
+
+// /api/v1/my
+//
+// 改动订阅的路由还要求 API token 具备 subs:manage，只读 token 只能查看。
+func registerMy(myGroup *echo.Group, subscriptionHandler *subscriptionController.Controller, opmlHandler *opmlController.Controller) {
+	manage := myMiddleware.TokenScope(apitoken.ScopeSubsManage)
+	registerNamedRoute(myGroup, http.MethodGet, "/subscriptions", "My subscription list route", subscriptionHandler.MyList)
+	registerNamedRoute(myGroup, http.MethodPost, "/subscriptions", "My subscription create route", subscriptionHandler.MySubscribe, manage)
+	registerNamedRoute(myGroup, http.MethodDelete, "/subscriptions/:platform/:id", "My subscription delete route", subscriptionHandler.MyUnsubscribe, manage)
+	registerNamedRoute(myGroup, http.MethodGet, "/opml", "My OPML export route", opmlHandler.ExportMine)
+	registerNamedRoute(myGroup, http.MethodPost, "/opml", "My OPML import route", opmlHandler.ImportMine, manage)
+}
+
+// /api/v1/grants
+func registerGrants(grantGroup *echo.Group, subscriptionHandler *subscriptionController.Controller) {
+	registerNamedRoute(grantGroup, http.MethodGet, "", "Grant list route", subscriptionHandler.ListGrants)
+	registerNamedRoute(grantGroup, http.MethodPost, "", "Grant create route", subscriptionHandler.CreateGrant)
+	registerNamedRoute(grantGroup, http.MethodDelete, "/:platform/:target/:username", "Grant delete route", subscriptionHandler.DeleteGrant)
+}
+
 // /api/v1/archive
 func registerArchive(apiGroup *echo.Group, archiveHandler *archiveController.Controller) {
 	archiveGroup := apiGroup.Group("/archive")
@@ -283,8 +337,12 @@ func registerArchive(apiGroup *echo.Group, archiveHandler *archiveController.Con
 	registerNamedRoute(archiveGroup, http.MethodGet, "/statistics", "Statistics route", archiveHandler.GetStatistics)
 	registerNamedRoute(archiveGroup, http.MethodGet, "/:url", "Archive route", archiveHandler.History)
 	registerNamedRoute(archiveGroup, http.MethodPost, "/random", "Random pick route", archiveHandler.Random)
+	registerNamedRoute(archiveGroup, http.MethodPut, "/reading", "Reading state update route", archiveHandler.PutReading)
 	registerNamedRoute(archiveGroup, http.MethodGet, "/zvideo", "Zvideo list route", archiveHandler.ZvideoList)
 	registerNamedRoute(archiveGroup, http.MethodGet, "/similarity/:id", "Similarity route", archiveHandler.Similarity)
+	registerNamedRoute(archiveGroup, http.MethodGet, "/zhihu/:type/:id/revisions", "Zhihu revision list route", archiveHandler.ZhihuRevisions)
+	registerNamedRoute(archiveGroup, http.MethodGet, "/zhihu/:type/:id/diff", "Zhihu revision diff route", archiveHandler.ZhihuRevisionDiff)
+	registerNamedRoute(archiveGroup, http.MethodGet, "/zhihu/:type/:id/items", "Zhihu column and collection item list route", archiveHandler.ZhihuListItems)
 	// registerNamedRoute(archiveGroup, http.MethodPost, "/select", "Select pick route", archiveHandler.Select)
 }
 
@@ -309,6 +367,7 @@ func registerDEncryptionService(apiGroup *echo.Group, zhihuHandler *zhihuControl
 	registerNamedRoute(zhihuEncryptionServiceApi, http.MethodDelete, "/:id", "Delete route for zhihu db api", zhihuHandler.Delete)
 	registerNamedRoute(zhihuEncryptionServiceApi, http.MethodGet, "", "List route for zhihu db api", zhihuHandler.List)
 	registerNamedRoute(zhihuEncryptionServiceApi, http.MethodPost, "/activate/:id", "Activate route for zhihu db api", zhihuHandler.Activate)
+	registerNamedRoute(zhihuEncryptionServiceApi, http.MethodPost, "/deactivate/:id", "Deactivate route for zhihu db api", zhihuHandler.Deactivate)
 }
 
 // /api/v1/refmt
@@ -336,7 +395,7 @@ func registerCookieProbes(cs cookie.CookieIface) {
 		return err
 	})
 	cookie.RegisterProbe(cookie.CookieTypeXiaobotAccessToken, func(value string, l *zap.Logger) error {
-		_, err := xiaobotRequest.NewRequestService(cs, value, l).Limit(config.C.TestURL.Xiaobot)
+		_, err := xiaobotRequest.NewRequestService(cs, value, l).Limit(context.Background(), config.C.TestURL.Xiaobot)
 		return err
 	})
 }
@@ -351,16 +410,18 @@ func registerBrowserCookies(apiGroup *echo.Group, cookieHandler *cookieControlle
 }
 
 // /rss
-func registerRSS(e *echo.Echo, zsxqHandler *zsxqController.Controller, zhihuHandler *zhihuController.Controller, xiaobotHandler *xiaobotController.Controller, endOfLifeHandler *endoflifeController.Controller, githubController *githubController.Controller, mackedController *mackedHandler.Handler, tombkeeperController *tombkeeperHandler.Controller) {
+func registerRSS(e *echo.Echo, zsxqHandler *zsxqController.Controller, zhihuHandler *zhihuController.Controller, xiaobotHandler *xiaobotController.Controller, endOfLifeHandler *endoflifeController.Controller, githubController *githubController.Controller, mackedController *mackedHandler.Handler, tombkeeperController *tombkeeperHandler.Controller, digestController *digestController.Controller, archiveHandler *archiveController.Controller, feedChecker myMiddleware.FeedChecker) {
 	rssGroup := e.Group("/rss")
 	rssGroup.Use(
 		myMiddleware.SetRSSContentType(), // set content type to application/atom+xml
 		myMiddleware.ExtractFeedID(),     // extract feed id from url and set it to context
 	)
 
-	registerNamedRoute(rssGroup, http.MethodGet, "/zsxq/:feed", "RSS route for zsxq group", zsxqHandler.RSS)
+	zsxqAccess := myMiddleware.RequireFeedAccess(feedChecker, subscriptionDB.PlatformZsxq)
+	registerNamedRoute(rssGroup, http.MethodGet, "/zsxq/:feed", "RSS route for zsxq group", zsxqHandler.RSS, zsxqAccess)
+	registerNamedRoute(rssGroup, http.MethodGet, "/zsxq/files/:feed", "RSS route for zsxq group files", zsxqHandler.FilesRSS, zsxqAccess)
 
-	registerNamedRoute(rssGroup, http.MethodGet, "/zsxq/random", "RSS route for zsxq random canglimo digest", zsxqHandler.RandomCanglimoDigest)
+	registerNamedRoute(rssGroup, http.MethodGet, "/zsxq/random", "RSS route for zsxq random canglimo digest", zsxqHandler.RandomCanglimoDigest, zsxqAccess)
 
 	rssZhihu := rssGroup.Group("/zhihu")
 
@@ -370,9 +431,18 @@ func registerRSS(e *echo.Echo, zsxqHandler *zsxqController.Controller, zhihuHand
 
 	registerNamedRoute(rssZhihu, http.MethodGet, "/pin/:feed", "RSS route for zhihu pin", zhihuHandler.PinRSS)
 
+	registerNamedRoute(rssZhihu, http.MethodGet, "/question/:feed", "RSS route for zhihu question", zhihuHandler.QuestionRSS)
+
+	registerNamedRoute(rssZhihu, http.MethodGet, "/column/:feed", "RSS route for zhihu column", zhihuHandler.ColumnRSS)
+
+	registerNamedRoute(rssZhihu, http.MethodGet, "/collection/:feed", "RSS route for zhihu collection", zhihuHandler.CollectionRSS)
+
 	registerNamedRoute(rssZhihu, http.MethodGet, "/random", "RSS route for zhihu random canglimo answers", zhihuHandler.RandomCanglimoAnswers)
 
-	registerNamedRoute(rssGroup, http.MethodGet, "/xiaobot/:feed", "RSS route for xiaobot", xiaobotHandler.RSS)
+	registerNamedRoute(rssZhihu, http.MethodGet, "/changes/:feed", "RSS route for zhihu edits and deletions", zhihuHandler.ChangesRSS)
+
+	registerNamedRoute(rssGroup, http.MethodGet, "/xiaobot/:feed", "RSS route for xiaobot", xiaobotHandler.RSS,
+		myMiddleware.RequireFeedAccess(feedChecker, subscriptionDB.PlatformXiaobot))
 
 	registerNamedRoute(rssGroup, http.MethodGet, "/endoflife/:feed", "RSS route for endoflife.date", endOfLifeHandler.RSS)
 
@@ -389,23 +459,42 @@ func registerRSS(e *echo.Echo, zsxqHandler *zsxqController.Controller, zhihuHand
 	registerNamedRoute(rssGroup, http.MethodGet, "/github/:feed", "RSS route for github", githubController.RSS)
 
 	registerNamedRoute(rssGroup, http.MethodGet, "/github/pre/:feed", "RSS route for github pre", githubController.RSS)
+
+	registerNamedRoute(rssGroup, http.MethodGet, "/digest", "RSS bare route for ai daily digest", digestController.RSS)
+
+	// Add :feed here to fit the ExtractFeedID middleware
+	registerNamedRoute(rssGroup, http.MethodGet, "/digest/:feed", "RSS route for ai daily digest", digestController.RSS)
+
+	registerNamedRoute(rssGroup, http.MethodGet, "/bookmark/:user/*", "RSS route for private bookmark tag", archiveHandler.BookmarkRSS)
 }
 
-func registerSub(subApi *echo.Group, zhihuHandler *zhihuController.Controller, github *githubController.Controller, xiaobotHandler *xiaobotController.Controller) {
+// The per-platform routes below predate /api/v1/subscriptions; their delete and
+// activate are adapters onto the registry (delete pauses, activate resumes).
+func registerSub(subApi *echo.Group, zhihuHandler *zhihuController.Controller, github *githubController.Controller, xiaobotHandler *xiaobotController.Controller, subscriptionHandler *subscriptionController.Controller) {
 	// /api/v1/sub/zhihu
 	registerNamedRoute(subApi, http.MethodGet, "/zhihu", "Sub list route for zhihu", zhihuHandler.GetSubs)
-	registerNamedRoute(subApi, http.MethodDelete, "/sub/zhihu/:id", "Delete sub route for zhihu", zhihuHandler.DeleteSub)
-	registerNamedRoute(subApi, http.MethodPost, "/sub/zhihu/activate/:id", "Activate sub route for zhihu", zhihuHandler.ActivateSub)
+	registerNamedRoute(subApi, http.MethodPost, "/zhihu", "Add sub route for zhihu", zhihuHandler.AddSub)
+	registerNamedRoute(subApi, http.MethodDelete, "/sub/zhihu/:id", "Delete sub route for zhihu", subscriptionHandler.Legacy(subscriptionDB.PlatformZhihu, "pause"))
+	registerNamedRoute(subApi, http.MethodPost, "/sub/zhihu/activate/:id", "Activate sub route for zhihu", subscriptionHandler.Legacy(subscriptionDB.PlatformZhihu, "resume"))
 
 	// /api/v1/sub/github
 	registerNamedRoute(subApi, http.MethodGet, "/github", "Sub list route for github", github.GetSubs)
-	registerNamedRoute(subApi, http.MethodDelete, "/github/:id", "Delete sub route for github", github.DeleteSub)
-	registerNamedRoute(subApi, http.MethodPost, "/github/activate/:id", "Activate sub route for github", github.ActivateSub)
+	registerNamedRoute(subApi, http.MethodDelete, "/github/:id", "Delete sub route for github", subscriptionHandler.Legacy(subscriptionDB.PlatformGitHub, "pause"))
+	registerNamedRoute(subApi, http.MethodPost, "/github/activate/:id", "Activate sub route for github", subscriptionHandler.Legacy(subscriptionDB.PlatformGitHub, "resume"))
 
 	// /api/v1/sub/xiaobot
 	registerNamedRoute(subApi, http.MethodGet, "/xiaobot", "Sub list route for xiaobot", xiaobotHandler.GetSubs)
-	registerNamedRoute(subApi, http.MethodDelete, "/xiaobot/:id", "Delete sub route for xiaobot", xiaobotHandler.DeleteSub)
-	registerNamedRoute(subApi, http.MethodPost, "/xiaobot/activate/:id", "Activate sub route for xiaobot", xiaobotHandler.ActivateSub)
+	registerNamedRoute(subApi, http.MethodDelete, "/xiaobot/:id", "Delete sub route for xiaobot", subscriptionHandler.Legacy(subscriptionDB.PlatformXiaobot, "pause"))
+	registerNamedRoute(subApi, http.MethodPost, "/xiaobot/activate/:id", "Activate sub route for xiaobot", subscriptionHandler.Legacy(subscriptionDB.PlatformXiaobot, "resume"))
+}
+
+// /api/v1/subscriptions
+func registerSubscription(subscriptionApi *echo.Group, subscriptionHandler *subscriptionController.Controller) {
+	registerNamedRoute(subscriptionApi, http.MethodGet, "", "Subscription list route", subscriptionHandler.List)
+	registerNamedRoute(subscriptionApi, http.MethodPost, "", "Subscription create route", subscriptionHandler.Create)
+	registerNamedRoute(subscriptionApi, http.MethodPost, "/:platform/:id/pause", "Subscription pause route", subscriptionHandler.Pause)
+	registerNamedRoute(subscriptionApi, http.MethodPost, "/:platform/:id/resume", "Subscription resume route", subscriptionHandler.Resume)
+	registerNamedRoute(subscriptionApi, http.MethodDelete, "/:platform/:id", "Subscription delete route", subscriptionHandler.Delete)
 }
 
 func registerMigrate(migrateApi *echo.Group, migrateHandler *migrateController.Controller) {
@@ -423,6 +512,12 @@ func registerParse(parseApi *echo.Group, parseHandler *parseHandler.Handler) {
 	registerNamedRoute(parseApi, http.MethodPost, "/xiaobot", "Parse xiaobot paper route", parseHandler.ParseXiaobotPaper)
 }
 
+// /api/v1/opml
+func registerOPML(opmlApi *echo.Group, opmlHandler *opmlController.Controller) {
+	registerNamedRoute(opmlApi, http.MethodGet, "", "OPML export route", opmlHandler.Export)
+	registerNamedRoute(opmlApi, http.MethodPost, "", "OPML import route", opmlHandler.Import)
+}
+
 func registerMacked(mackedApi *echo.Group, mackedHandler *mackedHandler.Handler) {
 	registerNamedRoute(mackedApi, http.MethodPost, "/appinfo", "Add app info route for macked", mackedHandler.AddAppInfo)
 }
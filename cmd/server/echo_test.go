@@ -6,13 +6,18 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v5"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	cookieController "github.com/eli-yip/rss-zero/internal/controller/cookie"
+	myMiddleware "github.com/eli-yip/rss-zero/internal/middleware"
+	"github.com/eli-yip/rss-zero/pkg/apitoken"
+	apitokenDB "github.com/eli-yip/rss-zero/pkg/apitoken/db"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
 )
 
 type roundTripperFunc func(*http.Request) (*http.Response, error)
@@ -42,6 +47,56 @@ func TestRegisterPprof(t *testing.T) {
 	require.Contains(t, recorder.Body.String(), "profile")
 }
 
+type fakeCookieService struct {
+	cookie.CookieIface
+}
+
+func (fakeCookieService) GetTTL(int) (time.Duration, error) { return time.Hour, nil }
+
+type fakeVerifier map[string]*apitokenDB.Token
+
+func (f fakeVerifier) Verify(plain string) (*apitokenDB.Token, error) {
+	if token, ok := f[plain]; ok {
+		return token, nil
+	}
+	return nil, apitoken.ErrInvalidToken
+}
+
+func TestRegisterMetricsRequiresAdmin(t *testing.T) {
+	e := echo.New()
+	e.Use(myMiddleware.Metrics())
+	registerMetrics(e, fakeCookieService{}, fakeVerifier{}, zap.NewNop())
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	request.Header.Set("Remote-Groups", "lldap_admin")
+	e.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `rss_zero_http_requests_total{method="GET",route="Prometheus metrics route",status="403"} 1`)
+	require.Contains(t, recorder.Body.String(), "rss_zero_cookie_expiry_seconds")
+}
+
+func TestRegisterMetricsAcceptsAdminToken(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = httputil.NewHTTPErrorHandler(zap.NewNop())
+	registerMetrics(e, fakeCookieService{}, fakeVerifier{
+		"rzt_admin": {Scopes: []string{apitoken.ScopeAdmin}},
+		"rzt_jobs":  {Scopes: []string{apitoken.ScopeJobsRun}},
+	}, zap.NewNop())
+
+	for plain, want := range map[string]int{"rzt_admin": http.StatusOK, "rzt_jobs": http.StatusForbidden, "rzt_nope": http.StatusUnauthorized} {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		request.Header.Set("Authorization", "Bearer "+plain)
+		e.ServeHTTP(recorder, request)
+		require.Equal(t, want, recorder.Code, plain)
+	}
+}
+
 func TestRegisterCookieProbesIncludesGitHubTokenValidation(t *testing.T) {
 	originalClient := http.DefaultClient
 	var gotAuthorization string
@@ -78,3 +133,14 @@ func TestCredentialRoutesReplaceLegacyCookieRoute(t *testing.T) {
 	_, err = e.Router().Routes().FindByMethodPath(http.MethodPost, "/api/v1/cookie")
 	require.Error(t, err)
 }
+
+// TestAdminRoutesRequireAdmin 防止管理路由组在注册后才挂守卫：echo v5 在注册路由时固定中间件，事后 Use 不生效。
+func TestAdminRoutesRequireAdmin(t *testing.T) {
+	e := setupEcho(nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	for _, path := range []string{"/api/v1/migrate/registry", "/api/v1/credentials", "/api/v1/job/list"} {
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusForbidden, recorder.Code, path)
+	}
+}
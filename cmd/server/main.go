@@ -22,6 +22,7 @@ import (
 	"github.com/eli-yip/rss-zero/internal/migrate"
 	"github.com/eli-yip/rss-zero/internal/notify"
 	"github.com/eli-yip/rss-zero/internal/redis"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 	"github.com/eli-yip/rss-zero/internal/version"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	"github.com/eli-yip/rss-zero/pkg/cron"
@@ -59,6 +60,16 @@ func main() {
 		}()
 	}()
 
+	shutdownTracing, err := tracing.Init(config.C.Tracing)
+	if err != nil {
+		logger.Fatal("Failed to init tracing", zap.Error(err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("Failed to shutdown tracing", zap.Error(err))
+		}
+	}()
+
 	redisService, cookieService, db, bark, err := initService(logger)
 	if err != nil {
 		logger.Fatal("Failed to init service", zap.Error(err))
@@ -108,6 +119,10 @@ func main() {
 		logger.Fatal("Shutdown server", zap.Error(err))
 	}
 
+	if err = cronService.Shutdown(); err != nil {
+		logger.Error("Failed to shutdown cron service", zap.Error(err))
+	}
+
 	logger.Info("Shutdown server successfully")
 }
 
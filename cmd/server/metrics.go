@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/internal/metrics"
+	myMiddleware "github.com/eli-yip/rss-zero/internal/middleware"
+	"github.com/eli-yip/rss-zero/pkg/apitoken"
+	"github.com/eli-yip/rss-zero/pkg/cookie"
+)
+
+// registerMetrics 暴露 Prometheus 指标，与其他管理接口一样需要管理员身份。
+func registerMetrics(e *echo.Echo, cookieService cookie.CookieIface, tokens apitoken.Verifier, logger *zap.Logger) {
+	if err := metrics.RegisterCookieCollector(func() []metrics.CookieExpiry {
+		return cookieExpiries(cookieService, logger)
+	}); err != nil {
+		logger.Error("Failed to register cookie metrics collector", zap.Error(err))
+	}
+	registerNamedRoute(e, http.MethodGet, "/metrics", "Prometheus metrics route", wrapHTTPHandler(metrics.Handler()),
+		myMiddleware.Authenticate(tokens), myMiddleware.AllowAdmin())
+}
+
+// cookieExpiries 读取 cookie.Spec 注册表中每个凭据的剩余有效期。读库失败的凭据不输出，避免误报为已过期。
+func cookieExpiries(cookieService cookie.CookieIface, logger *zap.Logger) []metrics.CookieExpiry {
+	specs := cookie.AllSpecs()
+	result := make([]metrics.CookieExpiry, 0, len(specs))
+	for _, spec := range specs {
+		ttl, err := cookieService.GetTTL(spec.Type)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			ttl = 0
+		case err != nil:
+			logger.Error("Failed to get cookie ttl", zap.String("cookie", spec.Label()), zap.Error(err))
+			continue
+		}
+		result = append(result, metrics.CookieExpiry{Platform: spec.Platform, Name: spec.Name, TTL: ttl})
+	}
+	return result
+}
@@ -28,6 +28,13 @@ type TomlConfig struct {
 		Debug             bool   `toml:"debug"`
 		DisableZhihu      bool   `toml:"disable_zhihu"`
 		DisableDouyu      bool   `toml:"disable_douyu"`
+		// FeedSecret 用于签发私有 feed 的访问令牌：收藏 feed 与付费 feed（星球、小报童）。
+		// 为空时关闭收藏 feed，付费 feed 不做限制
+		FeedSecret string `toml:"feed_secret"`
+		// SubscriptionQuota 是每个非管理员用户可持有的订阅数，不大于 0 时取 subscription.DefaultQuota
+		SubscriptionQuota int `toml:"subscription_quota"`
+		// StatisticsAuthor 是 /archive/statistics 不带 platform 时统计的知乎作者，为空时取 canglimo
+		StatisticsAuthor string `toml:"statistics_author"`
 	} `toml:"settings"`
 	Minio    MinioConfig    `toml:"minio"`
 	Openai   OpenAIConfig   `toml:"openai"`
@@ -51,11 +58,50 @@ type TomlConfig struct {
 	Utils struct {
 		RsshubURL string `toml:"rsshub_url"`
 	} `toml:"utils"`
-	Zsxq ZsxqConfig `toml:"zsxq"`
+	Zsxq    ZsxqConfig    `toml:"zsxq"`
+	Digest  DigestConfig  `toml:"digest"`
+	Tracing TracingConfig `toml:"tracing"`
+	// StaticJobs 以任务名（如 zvideo_crawl）为键覆盖内置静态任务，见 StaticJobConfig
+	StaticJobs map[string]StaticJobConfig `toml:"static_jobs"`
 
 	BJT *time.Location
 }
 
+// DigestConfig 控制 AI 每日摘要任务：是否启用、调度时间与纳入摘要的来源范围。
+// 缺省整段时不注册摘要任务；各范围列表为空表示该来源下全部订阅。
+type DigestConfig struct {
+	Enabled  bool   `toml:"enabled"`
+	Schedule string `toml:"schedule"` // cron 表达式，为空时使用 DefaultDigestSchedule
+	// Sources 取 zhihu/zsxq/xiaobot/tombkeeper，为空表示全部来源。
+	Sources       []string `toml:"sources"`
+	ZhihuAuthors  []string `toml:"zhihu_authors"`
+	ZsxqGroups    []int    `toml:"zsxq_groups"`
+	XiaobotPapers []string `toml:"xiaobot_papers"`
+}
+
+// DefaultDigestSchedule 是未配置 schedule 时摘要任务的调度时间：每天 08:00（BJT）。
+const DefaultDigestSchedule = "0 8 * * *"
+
+// TracingConfig 控制 OpenTelemetry 链路追踪的导出方式。缺省整段时不导出，span 只在进程内创建后丢弃。
+type TracingConfig struct {
+	// Exporter 取 otlp/stdout，为空表示关闭；stdout 便于本地调试
+	Exporter string `toml:"exporter"`
+	// Endpoint 是 OTLP/HTTP 地址（如 http://localhost:4318），为空时沿用 OTEL_EXPORTER_OTLP_* 环境变量
+	Endpoint string `toml:"endpoint"`
+	// SampleRatio 是根 span 的采样比例，0 视为全部采样
+	SampleRatio float64 `toml:"sample_ratio"`
+}
+
+// StaticJobConfig 覆盖一个内置静态任务的 cron_tasks 行。写了的字段每次启动都会写回库里，
+// 未写的字段保留库里的值（首次启动时为内置默认值），之后仍可通过 /api/v1/job/task/patch 修改。
+type StaticJobConfig struct {
+	Schedule string `toml:"schedule"` // cron 表达式
+	Enabled  *bool  `toml:"enabled"`
+	// MaxDelaySeconds 是运行前随机延迟的上限，0 表示准点运行
+	MaxDelaySeconds *int              `toml:"max_delay_seconds"`
+	Params          map[string]string `toml:"params"` // 与库里的参数合并，如 author = 'canglimo'
+}
+
 // ZsxqConfig holds operational rules for the zsxq router that change by
 // business decision rather than code. Absent section -> empty lists (no-op).
 type ZsxqConfig struct {
@@ -63,6 +109,9 @@ type ZsxqConfig struct {
 	// skipped during parsing. Matched by OR (either id or name).
 	BlockedAuthorIDs   []int    `toml:"blocked_author_ids"`
 	BlockedAuthorNames []string `toml:"blocked_author_names"`
+	// SkipComments: do not fetch comments while crawling topics. Each topic
+	// with comments costs at least one more rate-limited request.
+	SkipComments bool `toml:"skip_comments"`
 }
 
 type OpenAIConfig struct {
@@ -22,3 +22,28 @@ func TestInitFromTomlDisableDouyu(t *testing.T) {
 		t.Fatal("DisableDouyu = false, want true")
 	}
 }
+
+func TestInitFromTomlStaticJobs(t *testing.T) {
+	original := C
+	t.Cleanup(func() { C = original })
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "[static_jobs.zvideo_crawl]\nschedule = '0 1 * * *'\nenabled = false\nmax_delay_seconds = 0\nparams = { author = 'someone' }\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	if err := InitFromToml(path); err != nil {
+		t.Fatalf("InitFromToml: %v", err)
+	}
+	job, ok := C.StaticJobs["zvideo_crawl"]
+	if !ok {
+		t.Fatal("static_jobs.zvideo_crawl not parsed")
+	}
+	if job.Schedule != "0 1 * * *" || job.Enabled == nil || *job.Enabled || job.MaxDelaySeconds == nil || *job.MaxDelaySeconds != 0 {
+		t.Fatalf("static job = %+v", job)
+	}
+	if job.Params["author"] != "someone" {
+		t.Fatalf("params = %v, want author someone", job.Params)
+	}
+}
@@ -1,8 +1,10 @@
 package ai
 
 import (
+	"fmt"
 	"io"
 	"net/url"
+	"strings"
 
 	openai "github.com/sashabaranov/go-openai"
 )
@@ -27,6 +29,17 @@ type AI interface {
 	// Classify sends a single prompt to the chat model and returns the raw reply.
 	// Callers own the prompt construction and reply parsing.
 	Classify(prompt string) (reply string, err error)
+	// SummarizeMany 把多条来自不同来源的内容汇总成一篇按来源分组、带链接的 Markdown 摘要。
+	SummarizeMany(items []DigestItem) (result string, err error)
+}
+
+// DigestItem 是 SummarizeMany 的一条输入：来源、作者、标题、链接与正文摘录。
+type DigestItem struct {
+	Source  string
+	Author  string
+	Title   string
+	Link    string
+	Excerpt string
 }
 
 // AIService implements AI interface.
@@ -44,7 +57,7 @@ func NewAIService(apiKey string, baseURL string) AI {
 	clientConfig := openai.DefaultConfig(apiKey)
 	url, _ := url.Parse(baseURL)
 	clientConfig.BaseURL = url.String()
-	return &AIService{client: openai.NewClientWithConfig(clientConfig)}
+	return withMetrics(&AIService{client: openai.NewClientWithConfig(clientConfig)})
 }
 
 // AIServiceWithoutAPI is a mock service for testing purposes.
@@ -66,3 +79,20 @@ func (s *AIServiceWithoutAPI) Embed(text string) (result []float32, err error) {
 func (s *AIServiceWithoutAPI) Classify(prompt string) (reply string, err error) {
 	return `{"skip": false}`, nil
 }
+
+// SummarizeMany 在没有 API Key 时退化为按来源分组的链接列表，不做任何总结。
+func (s *AIServiceWithoutAPI) SummarizeMany(items []DigestItem) (result string, err error) {
+	var sb strings.Builder
+	lastSource := ""
+	for _, item := range items {
+		if item.Source != lastSource {
+			if lastSource != "" {
+				sb.WriteString("\n")
+			}
+			fmt.Fprintf(&sb, "## %s\n\n", item.Source)
+			lastSource = item.Source
+		}
+		fmt.Fprintf(&sb, "- [%s](%s)（%s）\n", item.Title, item.Link, item.Author)
+	}
+	return sb.String(), nil
+}
@@ -3,6 +3,7 @@ package ai
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	openai "github.com/sashabaranov/go-openai"
 
@@ -31,6 +32,21 @@ func (a *AIService) Classify(prompt string) (reply string, err error) {
 	return a.askGPT(prompt)
 }
 
+func (a *AIService) SummarizeMany(items []DigestItem) (result string, err error) {
+	const summarizeManyPrompt = "下面是过去一天从多个订阅来源抓取到的新内容，每条包含来源、作者、标题、链接和正文摘录。" +
+		"请为我写一份中文每日摘要：按来源分组（使用二级标题），每组内把主题相近的内容合并概括，" +
+		"每条要点后用 Markdown 链接附上对应原文链接，不要编造链接，也不要遗漏任何一条内容。" +
+		"只需要回答 Markdown 格式的摘要正文，不需要其他内容。\n\"\"\"%s\"\"\""
+
+	var sb strings.Builder
+	for i, item := range items {
+		fmt.Fprintf(&sb, "%d. 来源：%s\n作者：%s\n标题：%s\n链接：%s\n摘录：%s\n\n",
+			i+1, item.Source, item.Author, item.Title, item.Link, item.Excerpt)
+	}
+
+	return a.askGPT(fmt.Sprintf(summarizeManyPrompt, sb.String()))
+}
+
 // askGPT will ask model to generate a reply based on the prompt.
 func (a *AIService) askGPT(prompt string) (reply string, err error) {
 	req := openai.ChatCompletionRequest{
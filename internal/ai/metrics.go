@@ -0,0 +1,48 @@
+package ai
+
+import (
+	"io"
+	"time"
+
+	"github.com/eli-yip/rss-zero/internal/metrics"
+)
+
+// instrumentedAI 按能力（方法）记录 AI 调用次数、结果与耗时。
+type instrumentedAI struct{ AI }
+
+func withMetrics(a AI) AI { return &instrumentedAI{a} }
+
+func observe[T any](capability string, call func() (T, error)) (T, error) {
+	start := time.Now()
+	result, err := call()
+	metrics.ObserveAI(capability, err, time.Since(start))
+	return result, err
+}
+
+func (a *instrumentedAI) Polish(text string) (string, error) {
+	return observe("polish", func() (string, error) { return a.AI.Polish(text) })
+}
+
+func (a *instrumentedAI) Text(stream io.Reader) (string, error) {
+	return observe("text", func() (string, error) { return a.AI.Text(stream) })
+}
+
+func (a *instrumentedAI) Conclude(text string) (string, error) {
+	return observe("conclude", func() (string, error) { return a.AI.Conclude(text) })
+}
+
+func (a *instrumentedAI) TranslateToZh(text string) (string, error) {
+	return observe("translate", func() (string, error) { return a.AI.TranslateToZh(text) })
+}
+
+func (a *instrumentedAI) Embed(text string) ([]float32, error) {
+	return observe("embed", func() ([]float32, error) { return a.AI.Embed(text) })
+}
+
+func (a *instrumentedAI) Classify(prompt string) (string, error) {
+	return observe("classify", func() (string, error) { return a.AI.Classify(prompt) })
+}
+
+func (a *instrumentedAI) SummarizeMany(items []DigestItem) (string, error) {
+	return observe("summarize", func() (string, error) { return a.AI.SummarizeMany(items) })
+}
@@ -0,0 +1,92 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/middleware"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+)
+
+// AccessChecker 校验用户能否读取付费来源（星球 group、小报童 paper），由 subscription.Ownership 实现。
+type AccessChecker interface {
+	CheckAccess(user subscription.User, platform, targetID string) error
+}
+
+// contextUser 返回发起请求的用户。
+func contextUser(c *echo.Context) (subscription.User, error) {
+	username, err := contextUsername(c)
+	if err != nil {
+		return subscription.User{}, err
+	}
+	return subscription.User{Name: username, Admin: middleware.IsAdmin(c)}, nil
+}
+
+// accessFilter 在一次请求内缓存用户对各付费来源的授权结果，非付费平台总是可读。
+type accessFilter struct {
+	checker AccessChecker
+	user    subscription.User
+	allowed map[string]bool
+}
+
+func (h *Controller) accessFilter(user subscription.User) *accessFilter {
+	return &accessFilter{checker: h.access, user: user, allowed: make(map[string]bool)}
+}
+
+// readable 报告用户能否读取该来源；查询授权失败时返回错误。
+func (f *accessFilter) readable(platform, targetID string) (bool, error) {
+	if !subscription.IsPaid(platform) {
+		return true, nil
+	}
+	key := platform + "/" + targetID
+	if ok, cached := f.allowed[key]; cached {
+		return ok, nil
+	}
+	err := f.checker.CheckAccess(f.user, platform, targetID)
+	if err != nil && !errors.Is(err, subscription.ErrForbidden) {
+		return false, err
+	}
+	f.allowed[key] = err == nil
+	return err == nil, nil
+}
+
+// check 与 readable 相同，但未获授权时返回 subscription.ErrForbidden。
+func (f *accessFilter) check(platform, targetID string) error {
+	ok, err := f.readable(platform, targetID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s/%s is not granted to %s", subscription.ErrForbidden, platform, targetID, f.user.Name)
+	}
+	return nil
+}
+
+// filterReadable 只保留用户可读的付费内容，target 取出内容所属的 group 或 paper。
+func filterReadable[T any](f *accessFilter, platform string, items []T, target func(T) string) ([]T, error) {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		ok, err := f.readable(platform, target(item))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// accessHTTPError 把授权检查的错误映射为 403 或 500。
+func accessHTTPError(logger *zap.Logger, err error) error {
+	if errors.Is(err, subscription.ErrForbidden) {
+		return httputil.NewHTTPError(http.StatusForbidden, "no access to this paid content")
+	}
+	logger.Error("Failed to check paid content access", zap.Error(err))
+	return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to check access")
+}
@@ -0,0 +1,58 @@
+package archive
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+)
+
+type fakeAccessChecker struct {
+	granted map[string]bool
+	err     error
+	calls   int
+}
+
+func (f *fakeAccessChecker) CheckAccess(user subscription.User, platform, targetID string) error {
+	f.calls++
+	if f.err != nil {
+		return f.err
+	}
+	if user.Admin || f.granted[platform+"/"+targetID] {
+		return nil
+	}
+	return subscription.ErrForbidden
+}
+
+func TestAccessFilter(t *testing.T) {
+	checker := &fakeAccessChecker{granted: map[string]bool{"zsxq/1": true}}
+	h := &Controller{access: checker}
+	f := h.accessFilter(subscription.User{Name: "jason"})
+
+	ok, err := f.readable("zhihu", "canglimo")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Zero(t, checker.calls, "free platforms skip the grant lookup")
+
+	require.NoError(t, f.check("zsxq", "1"))
+	assert.ErrorIs(t, f.check("zsxq", "2"), subscription.ErrForbidden)
+	assert.ErrorIs(t, f.check("xiaobot", "paper"), subscription.ErrForbidden)
+
+	ok, err = f.readable("zsxq", "2")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 3, checker.calls, "results are cached per source")
+
+	topics, err := filterReadable(f, "zsxq", []int{1, 2, 1}, func(gid int) string { return map[int]string{1: "1", 2: "2"}[gid] })
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 1}, topics)
+
+	dbErr := errors.New("connection refused")
+	f = h.accessFilter(subscription.User{Name: "jason"})
+	checker.err = dbErr
+	_, err = f.readable("zsxq", "1")
+	assert.ErrorIs(t, err, dbErr)
+}
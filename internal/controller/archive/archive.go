@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,10 +17,13 @@ import (
 	"github.com/eli-yip/rss-zero/config"
 	"github.com/eli-yip/rss-zero/internal/controller/common"
 	utils "github.com/eli-yip/rss-zero/internal/utils"
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+	pkgCommon "github.com/eli-yip/rss-zero/pkg/common"
 	"github.com/eli-yip/rss-zero/pkg/httputil"
 	"github.com/eli-yip/rss-zero/pkg/render"
 	tkblog "github.com/eli-yip/rss-zero/pkg/routers/tkblog"
 	tk "github.com/eli-yip/rss-zero/pkg/routers/tombkeeper"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
 )
 
 // POST /api/v1/archive
@@ -34,8 +38,25 @@ func (h *Controller) Archive(c *echo.Context) (err error) {
 	logger.Info("Retrieved archive request successfully")
 
 	supportedAuthors := []string{"canglimo", "zi-e-79-23", "fu-lan-ke-yang", "ffancage"}
-	if req.Platform != PlatformZhihu ||
-		!slices.Contains(supportedAuthors, req.Author) {
+	var zsxqGroupID int
+	switch req.Platform {
+	case PlatformZhihu:
+		if !slices.Contains(supportedAuthors, req.Author) {
+			logger.Error("Invalid request parameters", zap.Any("request", req))
+			return httputil.NewHTTPError(http.StatusBadRequest, "invalid request")
+		}
+	case bookmarkDB.PlatformZsxq:
+		if zsxqGroupID, err = strconv.Atoi(req.Author); err != nil {
+			logger.Error("Invalid zsxq group id", zap.Any("request", req))
+			return httputil.NewHTTPError(http.StatusBadRequest, "invalid request")
+		}
+	case bookmarkDB.PlatformTkblog:
+		if req.Author != "" && !tkblog.ValidCategory(req.Author) {
+			logger.Error("Invalid tkblog category", zap.Any("request", req))
+			return httputil.NewHTTPError(http.StatusBadRequest, "invalid request")
+		}
+	case bookmarkDB.PlatformTombkeeper:
+	default:
 		logger.Error("Invalid request parameters", zap.Any("request", req))
 		return httputil.NewHTTPError(http.StatusBadRequest, "invalid request")
 	}
@@ -58,49 +79,83 @@ func (h *Controller) Archive(c *echo.Context) (err error) {
 		topics []Topic
 	)
 
-	username, err := contextUsername(c)
+	user, err := contextUser(c)
 	if err != nil {
 		return err
 	}
-	switch req.Type {
-	case ContentTypeAnswer:
-		answers, err := h.zhihuDBService.FetchAnswerWithDateRange(req.Author, req.Count, offset, req.Order, startDate, endDate)
-		if err != nil {
-			logger.Error("Failed to fetch answer", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to fetch answer")
-		}
-
-		topics, err = buildTopicsFromAnswer(answers, username, h.zhihuDBService, h.bookmarkDBService)
-		if err != nil {
-			logger.Error("Failed to build topics", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to build topics")
-		}
-
-		count, err = h.zhihuDBService.CountAnswerWithDateRange(req.Author, startDate, endDate)
-		if err != nil {
-			logger.Error("Failed to count answer", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to count answer")
+	username := user.Name
+	if req.Platform == bookmarkDB.PlatformZsxq {
+		if err = h.accessFilter(user).check(req.Platform, req.Author); err != nil {
+			return accessHTTPError(logger, err)
 		}
-	case ContentTypePin:
-		pins, err := h.zhihuDBService.FetchPinWithDateRange(req.Author, req.Count, offset, req.Order, startDate, endDate)
-		if err != nil {
-			logger.Error("Failed to fetch pin", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to fetch pin")
+	}
+	if req.Platform != PlatformZhihu {
+		q := listContentQuery{req: &req, user: username, offset: offset, startAt: startDate, endAt: endDate}
+		switch req.Platform {
+		case bookmarkDB.PlatformZsxq:
+			topics, count, err = h.listZsxqTopics(q, zsxqGroupID)
+		case bookmarkDB.PlatformTombkeeper:
+			topics, count, err = h.listTombkeeperPosts(q)
+		case bookmarkDB.PlatformTkblog:
+			topics, count, err = h.listTkblogPosts(q, req.Author)
 		}
-
-		topics, err = buildTopicsFromPin(pins, username, h.zhihuDBService, h.bookmarkDBService)
 		if err != nil {
-			logger.Error("Failed to build topics", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to build topics")
+			logger.Error("Failed to list archive content", zap.String("platform", req.Platform), zap.Error(err))
+			return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to list archive content")
 		}
-
-		count, err = h.zhihuDBService.CountPinWithDateRange(req.Author, startDate, endDate)
-		if err != nil {
-			logger.Error("Failed to count pin", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to count pin")
+	} else {
+		switch req.Type {
+		case ContentTypeAnswer:
+			kind := pkgCommon.ZhihuAnswer.Slug()
+			fetchScopes, countScopes := readingScopes(&req, username, PlatformZhihu, kind, "zhihu_answer.id", "create_at")
+			answers, err := h.zhihuDBService.FetchAnswerWithDateRange(req.Author, req.Count, offset, req.Order, startDate, endDate, fetchScopes...)
+			if err != nil {
+				logger.Error("Failed to fetch answer", zap.Error(err))
+				return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to fetch answer")
+			}
+
+			topics, err = buildTopicsFromAnswer(answers, username, h.zhihuDBService, h.bookmarkDBService)
+			if err != nil {
+				logger.Error("Failed to build topics", zap.Error(err))
+				return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to build topics")
+			}
+			if err = attachReading(h.readingDBService, username, PlatformZhihu, kind, topics); err != nil {
+				logger.Error("Failed to attach reading state", zap.Error(err))
+				return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to attach reading state")
+			}
+
+			count, err = h.zhihuDBService.CountAnswerWithDateRange(req.Author, startDate, endDate, countScopes...)
+			if err != nil {
+				logger.Error("Failed to count answer", zap.Error(err))
+				return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to count answer")
+			}
+		case ContentTypePin:
+			kind := pkgCommon.ZhihuPin.Slug()
+			fetchScopes, countScopes := readingScopes(&req, username, PlatformZhihu, kind, "zhihu_pin.id", "create_at")
+			pins, err := h.zhihuDBService.FetchPinWithDateRange(req.Author, req.Count, offset, req.Order, startDate, endDate, fetchScopes...)
+			if err != nil {
+				logger.Error("Failed to fetch pin", zap.Error(err))
+				return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to fetch pin")
+			}
+
+			topics, err = buildTopicsFromPin(pins, username, h.zhihuDBService, h.bookmarkDBService)
+			if err != nil {
+				logger.Error("Failed to build topics", zap.Error(err))
+				return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to build topics")
+			}
+			if err = attachReading(h.readingDBService, username, PlatformZhihu, kind, topics); err != nil {
+				logger.Error("Failed to attach reading state", zap.Error(err))
+				return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to attach reading state")
+			}
+
+			count, err = h.zhihuDBService.CountPinWithDateRange(req.Author, startDate, endDate, countScopes...)
+			if err != nil {
+				logger.Error("Failed to count pin", zap.Error(err))
+				return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to count pin")
+			}
+		case ContentTypeArticle:
+		default:
 		}
-	case ContentTypeArticle:
-	default:
 	}
 
 	// calculate page counts (ceil)
@@ -116,6 +171,9 @@ type archiveResult struct {
 	// title is the page title (used for the HTML <title>); markdown is the
 	// full-text markdown that every output format is derived from.
 	title, markdown, redirectTo string
+	// platform and targetID name the paid source (zsxq group) the content
+	// belongs to; History checks the reader's grant before serving it.
+	platform, targetID string
 }
 
 // archive output formats, selected via the `format` query parameter.
@@ -229,9 +287,26 @@ func (h *Controller) History(c *echo.Context) (err error) {
 		}
 		return c.HTML(status, renderErrorPage(err, requestID))
 	}
+	if result.platform != "" {
+		user, err := contextUser(c)
+		if err != nil {
+			return err
+		}
+		if err = h.accessFilter(user).check(result.platform, result.targetID); err != nil {
+			logger.Error("Failed to check archive access", zap.Error(err))
+			status := http.StatusInternalServerError
+			if errors.Is(err, subscription.ErrForbidden) {
+				status = http.StatusForbidden
+			}
+			return c.HTML(status, renderErrorPage(err, requestID))
+		}
+	}
 	if result.redirectTo != "" {
 		return c.Redirect(http.StatusFound, result.redirectTo)
 	}
+	if username, err := contextUsername(c); err == nil {
+		h.touchReading(logger, username, u)
+	}
 
 	htmlLink, mdLink, txtLink := buildFormatLinks(u)
 
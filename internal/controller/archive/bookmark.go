@@ -2,18 +2,16 @@ package archive
 
 import (
 	"errors"
-	"fmt"
 	"math"
 	"net/http"
 	"slices"
-	"strconv"
 	"time"
 
 	"github.com/eli-yip/rss-zero/internal/controller/common"
 	utils "github.com/eli-yip/rss-zero/internal/utils"
 	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
-	pkgCommon "github.com/eli-yip/rss-zero/pkg/common"
 	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
 
 	"github.com/labstack/echo/v5"
 	"github.com/samber/lo"
@@ -30,10 +28,11 @@ func (h *Controller) GetBookmarkList(c *echo.Context) (err error) {
 	}
 	logger.Info("bind request successfully")
 
-	username, err := contextUsername(c)
+	user, err := contextUser(c)
 	if err != nil {
 		return err
 	}
+	username := user.Name
 	var startDate, endDate time.Time
 	startDate, err = utils.ParseStartTime(req.StartDate)
 	if err != nil {
@@ -76,83 +75,9 @@ func (h *Controller) GetBookmarkList(c *echo.Context) (err error) {
 		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	answerBookmarks := lo.Filter(bookmarks, func(b bookmarkDB.Bookmark, _ int) bool { return b.ContentType == pkgCommon.ZhihuAnswer })
-	answerIDs := lo.Map(answerBookmarks, func(b bookmarkDB.Bookmark, _ int) string { return b.ContentID })
-	err = nil
-	answerIDsInt := lo.Map(answerIDs, func(id string, _ int) int {
-		var idInt int
-		idInt, err = strconv.Atoi(id)
-		if err != nil {
-			logger.Error("failed to convert answer ID to int", zap.Error(err), zap.String("answer_id", id))
-			err = fmt.Errorf("failed to convert answer ID to int: %w", err)
-			return 0
-		}
-		return idInt
-	})
-	if err != nil {
-		logger.Error("failed to convert answer ID to int", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	answers, err := h.zhihuDBService.FetchAnswerByIDs(answerIDsInt)
-	if err != nil {
-		logger.Error("failed to fetch answers by IDs", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	answerIDToBookmark := lo.SliceToMap(answerBookmarks, func(b bookmarkDB.Bookmark) (int, bookmarkDB.Bookmark) {
-		var answerID int
-		answerID, err = strconv.Atoi(b.ContentID)
-		if err != nil {
-			err = fmt.Errorf("failed to convert content ID %s to int: %w", b.ContentID, err)
-			return 0, bookmarkDB.Bookmark{}
-		}
-		return answerID, b
-	})
-	if err != nil {
-		logger.Error("failed to convert bookmark ID to int", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	answerTopics, err := buildTopicMapFromAnswer(answers, answerIDToBookmark, bookmarkIDToTags, h.zhihuDBService)
-	if err != nil {
-		logger.Error("failed to build topic map from answers", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-
-	pinBookmarks := lo.Filter(bookmarks, func(b bookmarkDB.Bookmark, _ int) bool { return b.ContentType == pkgCommon.ZhihuPin })
-	pinIDs := lo.Map(pinBookmarks, func(b bookmarkDB.Bookmark, _ int) string { return b.ContentID })
-	pinIDsInt := lo.Map(pinIDs, func(id string, _ int) int {
-		var idInt int
-		idInt, err = strconv.Atoi(id)
-		if err != nil {
-			err = fmt.Errorf("failed to convert pin ID %s to int: %w", id, err)
-			return 0
-		}
-		return idInt
-	})
-	if err != nil {
-		logger.Error("failed to convert pin ID to int", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	pins, err := h.zhihuDBService.FetchPinByIDs(pinIDsInt)
-	if err != nil {
-		logger.Error("failed to fetch pins by IDs", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	pinIDToBookmarkMap := lo.SliceToMap(pinBookmarks, func(b bookmarkDB.Bookmark) (int, bookmarkDB.Bookmark) {
-		var pinID int
-		pinID, err = strconv.Atoi(b.ContentID)
-		if err != nil {
-			err = fmt.Errorf("failed to convert content ID %s to int: %w", b.ContentID, err)
-			return 0, bookmarkDB.Bookmark{}
-		}
-		return pinID, b
-	})
+	bookmarkTopics, err := h.buildBookmarkTopics(h.accessFilter(user), bookmarks, bookmarkIDToTags)
 	if err != nil {
-		logger.Error("failed to convert bookmark ID to int", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	pinTopics, err := buildTopicMapFromPin(pins, pinIDToBookmarkMap, bookmarkIDToTags, h.zhihuDBService)
-	if err != nil {
-		logger.Error("failed to build topic map from pins", zap.Error(err))
+		logger.Error("failed to build bookmark topics", zap.Error(err))
 		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
@@ -174,11 +99,9 @@ func (h *Controller) GetBookmarkList(c *echo.Context) (err error) {
 	}
 
 	for b := range slices.Values(bookmarks) {
-		switch b.ContentType {
-		case pkgCommon.ZhihuAnswer:
-			response.Topics = append(response.Topics, answerTopics[b.ContentID])
-		case pkgCommon.ZhihuPin:
-			response.Topics = append(response.Topics, pinTopics[b.ContentID])
+		// 被收藏内容已不在库中时跳过，不让一条失效收藏拖垮整页
+		if topic, ok := bookmarkTopics[b.ID]; ok {
+			response.Topics = append(response.Topics, topic)
 		}
 	}
 
@@ -190,10 +113,11 @@ func (h *Controller) GetBookmarkList(c *echo.Context) (err error) {
 // PutBookmark handles the request to create a new bookmark
 func (h *Controller) PutBookmark(c *echo.Context) (err error) {
 	logger := common.ExtractLogger(c)
-	user, err := contextUsername(c)
+	reqUser, err := contextUser(c)
 	if err != nil {
 		return err
 	}
+	user := reqUser.Name
 
 	var req NewBookmarkRequest
 	if err = c.Bind(&req); err != nil {
@@ -202,13 +126,13 @@ func (h *Controller) PutBookmark(c *echo.Context) (err error) {
 	}
 	logger.Info("bind request successfully")
 
-	contentType, err := pkgCommon.ParseZhihuLegacyID(req.ContentType)
+	ref, err := resolveBookmarkRef(req.Platform, req.Kind, req.ContentType, req.ContentID)
 	if err != nil {
-		logger.Error("invalid content type", zap.Int("content_type", req.ContentType), zap.Error(err))
-		return httputil.NewHTTPError(http.StatusBadRequest, "invalid content type")
+		logger.Error("invalid bookmark target", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	_, err = h.bookmarkDBService.GetBookmarkByContent(user, contentType, req.ContentID)
+	_, err = h.bookmarkDBService.GetBookmarkByContent(user, ref)
 	if err == nil {
 		logger.Info("bookmark already exists, return now", zap.String("content_id", req.ContentID))
 		return httputil.NewHTTPError(http.StatusBadRequest, "bookmark already exists")
@@ -217,43 +141,23 @@ func (h *Controller) PutBookmark(c *echo.Context) (err error) {
 		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	var b *bookmarkDB.Bookmark
-	switch contentType {
-	case pkgCommon.ZhihuAnswer:
-		answerIDInt, err := strconv.Atoi(req.ContentID)
-		if err != nil {
-			logger.Error("failed to convert answer ID to int", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
-		}
-		_, err = h.zhihuDBService.GetAnswer(answerIDInt)
-		if err != nil {
-			logger.Error("failed to get answer", zap.Error(err))
+	if err = bookmarkSources[ref.Platform].check(h, h.accessFilter(reqUser), ref); err != nil {
+		logger.Error("failed to check bookmark target", zap.Error(err))
+		switch {
+		case errors.Is(err, errBookmarkTarget):
 			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, errBookmarkNotFound):
+			return httputil.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, subscription.ErrForbidden):
+			return httputil.NewHTTPError(http.StatusForbidden, err.Error())
 		}
-		b, err = h.bookmarkDBService.NewBookmark(user, pkgCommon.ZhihuAnswer, req.ContentID)
-		if err != nil {
-			logger.Error("failed to create bookmark", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-		}
-	case pkgCommon.ZhihuPin:
-		pinIDInt, err := strconv.Atoi(req.ContentID)
-		if err != nil {
-			logger.Error("failed to convert pin ID to int", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
-		}
-		_, err = h.zhihuDBService.GetPin(pinIDInt)
-		if err != nil {
-			logger.Error("failed to get pin", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
-		}
-		b, err = h.bookmarkDBService.NewBookmark(user, pkgCommon.ZhihuPin, req.ContentID)
-		if err != nil {
-			logger.Error("failed to create bookmark", zap.Error(err))
-			return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-		}
-	default:
-		logger.Error("invalid content type", zap.Int("content_type", req.ContentType))
-		return httputil.NewHTTPError(http.StatusBadRequest, "invalid content type")
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	b, err := h.bookmarkDBService.NewBookmark(user, ref)
+	if err != nil {
+		logger.Error("failed to create bookmark", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
 	logger.Info("Create bookmark successfully", zap.String("bookmark_id", b.ID))
@@ -281,6 +185,12 @@ func (h *Controller) PatchBookmark(c *echo.Context) (err error) {
 		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 	logger.Info("bind request successfully")
+	if req.Tags != nil {
+		if req.Tags, err = normalizeTagNames(req.Tags); err != nil {
+			logger.Error("invalid tags", zap.Error(err))
+			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
 
 	b, err := h.bookmarkDBService.GetBookmark(user, bookmarkID)
 	if err != nil {
@@ -361,26 +271,3 @@ func (h *Controller) DeleteBookmark(c *echo.Context) (err error) {
 
 	return c.JSON(http.StatusOK, httputil.NewMessage("success"))
 }
-
-func (h *Controller) GetAllTags(c *echo.Context) (err error) {
-	logger := common.ExtractLogger(c)
-	user, err := contextUsername(c)
-	if err != nil {
-		return err
-	}
-
-	tagCounts, err := h.bookmarkDBService.GetTagCountByUser(user)
-	if err != nil {
-		logger.Error("failed to get tag counts", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	logger.Info("Get tag counts successfully", zap.Int("count", len(tagCounts)))
-
-	response := struct {
-		Tags []bookmarkDB.TagCount `json:"tags"`
-	}{
-		Tags: tagCounts,
-	}
-
-	return c.JSON(http.StatusOK, httputil.NewResp("success", response))
-}
@@ -0,0 +1,530 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/config"
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+	pkgCommon "github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/render"
+	tkblog "github.com/eli-yip/rss-zero/pkg/routers/tkblog"
+	tk "github.com/eli-yip/rss-zero/pkg/routers/tombkeeper"
+	xiaobotDB "github.com/eli-yip/rss-zero/pkg/routers/xiaobot/db"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	zsxqRender "github.com/eli-yip/rss-zero/pkg/routers/zsxq/render"
+)
+
+var (
+	// errBookmarkTarget 表示收藏目标不合法：平台/类型未知或 id 格式错误。PutBookmark 映射为 400。
+	errBookmarkTarget = errors.New("invalid bookmark target")
+	// errBookmarkNotFound 表示收藏目标不在库中。PutBookmark 映射为 404，其余查询错误为 500。
+	errBookmarkNotFound = errors.New("bookmark target not found")
+)
+
+// targetLookupErr 把内容查询错误区分为不存在与其他数据库错误。
+func targetLookupErr(err error, what string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %s", errBookmarkNotFound, what)
+	}
+	return fmt.Errorf("failed to get %s: %w", what, err)
+}
+
+// bookmarkSource 是一个可收藏平台：kinds 为该平台允许的内容类型（首个为缺省），check 校验内容存在且用户可读，
+// topics 把该平台的一批收藏批量解析为 Topic（key 为 bookmark id，已挂好 Custom，跳过用户无权读取的付费内容），
+// parseURL 把该平台的原文链接反解为 kind 与 content id（导入收藏时使用）。
+// 新增一个可收藏平台 = 在 bookmarkSources 加一行。
+type bookmarkSource struct {
+	kinds    []string
+	check    func(h *Controller, access *accessFilter, ref bookmarkDB.ContentRef) error
+	topics   func(h *Controller, access *accessFilter, bookmarks []bookmarkDB.Bookmark, tags map[string][]string) (map[string]Topic, error)
+	parseURL func(link string) (kind, id string, ok bool)
+}
+
+var bookmarkSources = map[string]bookmarkSource{
+	bookmarkDB.PlatformZhihu: {
+		kinds:    []string{pkgCommon.ZhihuAnswer.Slug(), pkgCommon.ZhihuPin.Slug()},
+		check:    checkZhihuBookmark,
+		topics:   zhihuBookmarkTopics,
+		parseURL: parseZhihuURL,
+	},
+	bookmarkDB.PlatformZsxq: {
+		kinds:    []string{bookmarkDB.KindZsxqTopic},
+		check:    checkZsxqBookmark,
+		topics:   zsxqBookmarkTopics,
+		parseURL: parseZsxqURL,
+	},
+	bookmarkDB.PlatformXiaobot: {
+		kinds:    []string{bookmarkDB.KindXiaobotPost},
+		check:    checkXiaobotBookmark,
+		topics:   xiaobotBookmarkTopics,
+		parseURL: parseXiaobotURL,
+	},
+	bookmarkDB.PlatformTombkeeper: {
+		kinds:    []string{bookmarkDB.KindTombkeeperPost},
+		check:    checkTombkeeperBookmark,
+		topics:   tombkeeperBookmarkTopics,
+		parseURL: parseTombkeeperURL,
+	},
+	bookmarkDB.PlatformTkblog: {
+		kinds:    []string{bookmarkDB.KindTkblogPost},
+		check:    checkTkblogBookmark,
+		topics:   tkblogBookmarkTopics,
+		parseURL: parseTkblogURL,
+	},
+}
+
+// resolveBookmarkRef 把请求里的平台、类型与 id 规整为 ContentRef。platform 为空时按旧协议视为知乎，
+// 此时 kind 为空则由 legacyType（旧 int content_type）推出；其他平台 kind 为空取该平台缺省类型。
+func resolveBookmarkRef(platform, kind string, legacyType int, contentID string) (bookmarkDB.ContentRef, error) {
+	if platform == "" {
+		platform = bookmarkDB.PlatformZhihu
+	}
+	source, ok := bookmarkSources[platform]
+	if !ok {
+		return bookmarkDB.ContentRef{}, fmt.Errorf("%w: unknown platform %q", errBookmarkTarget, platform)
+	}
+	if kind == "" {
+		if platform == bookmarkDB.PlatformZhihu {
+			t, err := pkgCommon.ParseZhihuLegacyID(legacyType)
+			if err != nil {
+				return bookmarkDB.ContentRef{}, fmt.Errorf("%w: %w", errBookmarkTarget, err)
+			}
+			kind = t.Slug()
+		} else {
+			kind = source.kinds[0]
+		}
+	}
+	if !lo.Contains(source.kinds, kind) {
+		return bookmarkDB.ContentRef{}, fmt.Errorf("%w: unsupported %s content type %q", errBookmarkTarget, platform, kind)
+	}
+	if contentID == "" {
+		return bookmarkDB.ContentRef{}, fmt.Errorf("%w: content id is empty", errBookmarkTarget)
+	}
+	return bookmarkDB.ContentRef{Platform: platform, Kind: kind, ID: contentID}, nil
+}
+
+// buildBookmarkTopics 按平台分组批量解析收藏，返回 bookmark id → Topic。
+func (h *Controller) buildBookmarkTopics(access *accessFilter, bookmarks []bookmarkDB.Bookmark, tags map[string][]string) (map[string]Topic, error) {
+	topics := make(map[string]Topic, len(bookmarks))
+	for platform, group := range lo.GroupBy(bookmarks, func(b bookmarkDB.Bookmark) string { return b.Platform }) {
+		source, ok := bookmarkSources[platform]
+		if !ok {
+			return nil, fmt.Errorf("unknown bookmark platform %q", platform)
+		}
+		platformTopics, err := source.topics(h, access, group, tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s bookmark topics: %w", platform, err)
+		}
+		for id, topic := range platformTopics {
+			topics[id] = topic
+		}
+	}
+	return topics, nil
+}
+
+func bookmarkCustom(b bookmarkDB.Bookmark, tags map[string][]string) *Custom {
+	custom := &Custom{Bookmark: true, BookmarkID: b.ID, Comment: b.Comment, Note: b.Note, Tags: tags[b.ID]}
+	if custom.Tags == nil {
+		custom.Tags = []string{}
+	}
+	return custom
+}
+
+// bookmarkTopicsByContent 把按内容 id 构建的 Topic 挂上收藏信息，返回 bookmark id → Topic；
+// 内容已不在库中的收藏被跳过。
+func bookmarkTopicsByContent(bookmarks []bookmarkDB.Bookmark, tags map[string][]string, topics []Topic) map[string]Topic {
+	byID := lo.KeyBy(topics, func(t Topic) string { return t.ID })
+	result := make(map[string]Topic, len(bookmarks))
+	for _, b := range bookmarks {
+		if topic, ok := byID[b.ContentID]; ok {
+			topic.Custom = bookmarkCustom(b, tags)
+			result[b.ID] = topic
+		}
+	}
+	return result
+}
+
+// attachBookmarks 为同一平台、同一类型的一组 Topic 填入当前用户的收藏与标签，未收藏的保持 nil。
+func attachBookmarks(bd bookmarkDB.DB, user, platform, kind string, topics []Topic) error {
+	ids := lo.Map(topics, func(t Topic, _ int) string { return t.ID })
+	bookmarks, err := bd.GetBookmarksByContents(user, platform, kind, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get bookmarks: %w", err)
+	}
+	if len(bookmarks) == 0 {
+		return nil
+	}
+	tags, err := bd.GetTags(lo.Map(bookmarks, func(b bookmarkDB.Bookmark, _ int) string { return b.ID }))
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+	byContent := lo.KeyBy(bookmarks, func(b bookmarkDB.Bookmark) string { return b.ContentID })
+	for i := range topics {
+		if b, ok := byContent[topics[i].ID]; ok {
+			topics[i].Custom = bookmarkCustom(b, tags)
+		}
+	}
+	return nil
+}
+
+func parseIntID(id string) (int, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("%w: content id %q is not a number", errBookmarkTarget, id)
+	}
+	return n, nil
+}
+
+func checkZhihuBookmark(h *Controller, access *accessFilter, ref bookmarkDB.ContentRef) (err error) {
+	id, err := parseIntID(ref.ID)
+	if err != nil {
+		return err
+	}
+	switch pkgCommon.ZhihuContentType(ref.Kind) {
+	case pkgCommon.ZhihuAnswer:
+		if _, err = h.zhihuDBService.GetAnswer(id); err != nil {
+			return targetLookupErr(err, "zhihu answer "+ref.ID)
+		}
+	case pkgCommon.ZhihuPin:
+		if _, err = h.zhihuDBService.GetPin(id); err != nil {
+			return targetLookupErr(err, "zhihu pin "+ref.ID)
+		}
+	}
+	return nil
+}
+
+// zhihuBookmarkTopics 复用知乎回答/想法的批量 topic 构建，再把 content id 键换成 bookmark id 键。
+func zhihuBookmarkTopics(h *Controller, access *accessFilter, bookmarks []bookmarkDB.Bookmark, tags map[string][]string) (map[string]Topic, error) {
+	result := make(map[string]Topic, len(bookmarks))
+	for kind, group := range lo.GroupBy(bookmarks, func(b bookmarkDB.Bookmark) string { return b.Kind }) {
+		byContentID := make(map[int]bookmarkDB.Bookmark, len(group))
+		for _, b := range group {
+			id, err := strconv.Atoi(b.ContentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert content ID %s to int: %w", b.ContentID, err)
+			}
+			byContentID[id] = b
+		}
+		ids := lo.Keys(byContentID)
+
+		var topicMap map[string]Topic
+		var err error
+		switch pkgCommon.ZhihuContentType(kind) {
+		case pkgCommon.ZhihuAnswer:
+			var answers map[int]zhihuDB.Answer
+			if answers, err = h.zhihuDBService.FetchAnswerByIDs(ids); err != nil {
+				return nil, fmt.Errorf("failed to fetch answers by IDs: %w", err)
+			}
+			topicMap, err = buildTopicMapFromAnswer(answers, byContentID, tags, h.zhihuDBService)
+		case pkgCommon.ZhihuPin:
+			var pins map[int]zhihuDB.Pin
+			if pins, err = h.zhihuDBService.FetchPinByIDs(ids); err != nil {
+				return nil, fmt.Errorf("failed to fetch pins by IDs: %w", err)
+			}
+			topicMap, err = buildTopicMapFromPin(pins, byContentID, tags, h.zhihuDBService)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range group {
+			if topic, ok := topicMap[b.ContentID]; ok {
+				result[b.ID] = topic
+			}
+		}
+	}
+	return result, nil
+}
+
+func checkZsxqBookmark(h *Controller, access *accessFilter, ref bookmarkDB.ContentRef) error {
+	id, err := parseIntID(ref.ID)
+	if err != nil {
+		return err
+	}
+	topic, err := h.zsxqDBService.GetTopicByID(id)
+	if err != nil {
+		return targetLookupErr(err, "zsxq topic "+ref.ID)
+	}
+	return access.check(bookmarkDB.PlatformZsxq, strconv.Itoa(topic.GroupID))
+}
+
+func zsxqBookmarkTopics(h *Controller, access *accessFilter, bookmarks []bookmarkDB.Bookmark, tags map[string][]string) (map[string]Topic, error) {
+	ids := make([]int, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		id, err := strconv.Atoi(b.ContentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert content ID %s to int: %w", b.ContentID, err)
+		}
+		ids = append(ids, id)
+	}
+	rows, err := h.zsxqDBService.GetTopicsByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch zsxq topics by IDs: %w", err)
+	}
+	if rows, err = filterReadable(access, bookmarkDB.PlatformZsxq, rows, func(t zsxqDB.Topic) string { return strconv.Itoa(t.GroupID) }); err != nil {
+		return nil, err
+	}
+	topics, err := zsxqContentTopics(h, rows)
+	if err != nil {
+		return nil, err
+	}
+	return bookmarkTopicsByContent(bookmarks, tags, topics), nil
+}
+
+// zsxqContentTopics 按原顺序把星球主题渲染为 Topic，不含书签信息。
+func zsxqContentTopics(h *Controller, rows []zsxqDB.Topic) ([]Topic, error) {
+	snapshot, err := zsxqRender.NewContentLoader(h.zsxqDBService).Load(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load zsxq content snapshot: %w", err)
+	}
+	topics := make([]Topic, 0, len(rows))
+	for _, topic := range rows {
+		body, err := zsxqRender.RenderMarkdown(topic.ID, snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render zsxq topic %d: %w", topic.ID, err)
+		}
+		link := zsxqRender.BuildLink(topic.GroupID, topic.ID)
+		topics = append(topics, Topic{
+			ID:          strconv.Itoa(topic.ID),
+			OriginalURL: link,
+			ArchiveURL:  render.BuildArchiveLink(config.C.Settings.ServerURL, link),
+			Platform:    bookmarkDB.PlatformZsxq,
+			Title:       zsxqRender.BuildTitle(topic),
+			CreatedAt:   topic.Time.Format(time.RFC3339),
+			Body:        body,
+			Author:      Author{ID: strconv.Itoa(topic.AuthorID), Nickname: snapshot.Authors[topic.AuthorID].Name},
+		})
+	}
+	return topics, nil
+}
+
+func checkXiaobotBookmark(h *Controller, access *accessFilter, ref bookmarkDB.ContentRef) error {
+	posts, err := h.xiaobotDBService.GetPostsByIDs([]string{ref.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get xiaobot post: %w", err)
+	}
+	if len(posts) == 0 {
+		return fmt.Errorf("%w: xiaobot post %s", errBookmarkNotFound, ref.ID)
+	}
+	return access.check(bookmarkDB.PlatformXiaobot, posts[0].PaperID)
+}
+
+// xiaobotBookmarkTopics 的 ArchiveURL 留空：小报童没有归档页。
+func xiaobotBookmarkTopics(h *Controller, access *accessFilter, bookmarks []bookmarkDB.Bookmark, tags map[string][]string) (map[string]Topic, error) {
+	posts, err := h.xiaobotDBService.GetPostsByIDs(lo.Map(bookmarks, func(b bookmarkDB.Bookmark, _ int) string { return b.ContentID }))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch xiaobot posts by IDs: %w", err)
+	}
+	if posts, err = filterReadable(access, bookmarkDB.PlatformXiaobot, posts, func(p xiaobotDB.Post) string { return p.PaperID }); err != nil {
+		return nil, err
+	}
+	byID := lo.KeyBy(posts, func(p xiaobotDB.Post) string { return p.ID })
+
+	authorByPaper := make(map[string]Author)
+	result := make(map[string]Topic, len(bookmarks))
+	for _, b := range bookmarks {
+		post, ok := byID[b.ContentID]
+		if !ok {
+			continue
+		}
+		author, ok := authorByPaper[post.PaperID]
+		if !ok {
+			paper, err := h.xiaobotDBService.GetPaper(post.PaperID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get xiaobot paper %s: %w", post.PaperID, err)
+			}
+			name, err := h.xiaobotDBService.GetCreatorName(paper.CreatorID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get xiaobot creator %s: %w", paper.CreatorID, err)
+			}
+			author = Author{ID: paper.CreatorID, Nickname: name}
+			authorByPaper[post.PaperID] = author
+		}
+		result[b.ID] = Topic{
+			ID:          post.ID,
+			OriginalURL: fmt.Sprintf("https://xiaobot.net/post/%s", post.ID),
+			Platform:    bookmarkDB.PlatformXiaobot,
+			Title:       post.Title,
+			CreatedAt:   post.CreateAt.Format(time.RFC3339),
+			Body:        post.Text,
+			Author:      author,
+			Custom:      bookmarkCustom(b, tags),
+		}
+	}
+	return result, nil
+}
+
+func checkTombkeeperBookmark(h *Controller, access *accessFilter, ref bookmarkDB.ContentRef) error {
+	id, err := strconv.ParseInt(ref.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: content id %q is not a number", errBookmarkTarget, ref.ID)
+	}
+	if _, err = h.tombkeeperDBService.GetPost(id); err != nil {
+		return targetLookupErr(err, "tombkeeper post "+ref.ID)
+	}
+	return nil
+}
+
+func tombkeeperBookmarkTopics(h *Controller, access *accessFilter, bookmarks []bookmarkDB.Bookmark, tags map[string][]string) (map[string]Topic, error) {
+	ids := make([]int64, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		id, err := strconv.ParseInt(b.ContentID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert content ID %s to int64: %w", b.ContentID, err)
+		}
+		ids = append(ids, id)
+	}
+	posts, err := h.tombkeeperDBService.GetPosts(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tombkeeper posts by IDs: %w", err)
+	}
+	topics, err := tombkeeperContentTopics(h, posts)
+	if err != nil {
+		return nil, err
+	}
+	return bookmarkTopicsByContent(bookmarks, tags, topics), nil
+}
+
+// tombkeeperContentTopics 按原顺序把微博渲染为 Topic，不含书签信息。
+func tombkeeperContentTopics(h *Controller, posts []tk.Post) ([]Topic, error) {
+	content, err := tk.NewContentLoader(h.tombkeeperDBService).Load(posts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tombkeeper content: %w", err)
+	}
+	topics := make([]Topic, 0, len(posts))
+	for _, post := range posts {
+		body, err := tk.RenderMarkdown(post.ID, content, config.C.Settings.ServerURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render tombkeeper post %d: %w", post.ID, err)
+		}
+		id := strconv.FormatInt(post.ID, 10)
+		topics = append(topics, Topic{
+			ID:          id,
+			OriginalURL: tk.WeiboPostURL(post.AuthorID, post.Bid, id),
+			ArchiveURL:  render.BuildArchiveLink(config.C.Settings.ServerURL, tk.FanSiteURL(id)),
+			Platform:    bookmarkDB.PlatformTombkeeper,
+			Title:       tk.PostTitle(post),
+			CreatedAt:   post.PublishedAt.Format(time.RFC3339),
+			Body:        body,
+			Author:      Author{ID: post.AuthorID, Nickname: post.ScreenName},
+		})
+	}
+	return topics, nil
+}
+
+// splitTkblogID 拆出 tkblog 收藏 id "{category}/{id}"。
+func splitTkblogID(contentID string) (category, id string, ok bool) {
+	category, id, ok = strings.Cut(contentID, "/")
+	return category, id, ok && category != "" && id != ""
+}
+
+func checkTkblogBookmark(h *Controller, access *accessFilter, ref bookmarkDB.ContentRef) error {
+	category, id, ok := splitTkblogID(ref.ID)
+	if !ok {
+		return fmt.Errorf("%w: tkblog content id must be {category}/{id}", errBookmarkTarget)
+	}
+	if _, err := h.tkblogDBService.GetPost(category, id); err != nil {
+		return targetLookupErr(err, "tkblog post "+ref.ID)
+	}
+	return nil
+}
+
+func tkblogBookmarkTopics(h *Controller, access *accessFilter, bookmarks []bookmarkDB.Bookmark, tags map[string][]string) (map[string]Topic, error) {
+	keys := make([]tkblog.PostKey, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		category, id, ok := splitTkblogID(b.ContentID)
+		if !ok {
+			return nil, fmt.Errorf("invalid tkblog content id %q", b.ContentID)
+		}
+		keys = append(keys, tkblog.PostKey{Category: category, ID: id})
+	}
+	posts, err := h.tkblogDBService.GetPosts(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tkblog posts: %w", err)
+	}
+	topics, err := tkblogContentTopics(h, posts)
+	if err != nil {
+		return nil, err
+	}
+	return bookmarkTopicsByContent(bookmarks, tags, topics), nil
+}
+
+// tkblogContentTopics 按原顺序把博文转为 Topic，不含书签信息。
+// 博文本身不带作者，作者取自同一博主的微博时间线。
+func tkblogContentTopics(h *Controller, posts []tkblog.Post) ([]Topic, error) {
+	var author Author
+	latest, err := h.tombkeeperDBService.LatestTimelineEntries(1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tombkeeper author: %w", err)
+	}
+	if len(latest) > 0 {
+		author = Author{ID: latest[0].AuthorID, Nickname: latest[0].ScreenName}
+	}
+
+	topics := make([]Topic, 0, len(posts))
+	for _, post := range posts {
+		link := tkblog.FanSiteURL(post.Category, post.ID)
+		topics = append(topics, Topic{
+			ID:          post.Category + "/" + post.ID,
+			OriginalURL: link,
+			ArchiveURL:  render.BuildArchiveLink(config.C.Settings.ServerURL, link),
+			Platform:    bookmarkDB.PlatformTkblog,
+			Title:       post.Title,
+			CreatedAt:   post.CreatedAt.Format(time.RFC3339),
+			Body:        post.TextMarkdown,
+			Author:      author,
+		})
+	}
+	return topics, nil
+}
+
+var (
+	reZhihuAnswerURL = regexp.MustCompile(`zhihu\.com/(?:question/\d+/)?answer/(\d+)`)
+	reZhihuPinURL    = regexp.MustCompile(`zhihu\.com/pin/(\d+)`)
+	reZsxqTopicURL   = regexp.MustCompile(`zsxq\.com/(?:[^?#]*/)?(?:group/\d+/topic|topic_detail)/(\d+)`)
+	reXiaobotPostURL = regexp.MustCompile(`xiaobot\.net/post/([0-9A-Za-z-]+)`)
+)
+
+func parseZhihuURL(link string) (kind, id string, ok bool) {
+	if m := reZhihuAnswerURL.FindStringSubmatch(link); m != nil {
+		return pkgCommon.ZhihuAnswer.Slug(), m[1], true
+	}
+	if m := reZhihuPinURL.FindStringSubmatch(link); m != nil {
+		return pkgCommon.ZhihuPin.Slug(), m[1], true
+	}
+	return "", "", false
+}
+
+func parseZsxqURL(link string) (kind, id string, ok bool) {
+	if m := reZsxqTopicURL.FindStringSubmatch(link); m != nil {
+		return bookmarkDB.KindZsxqTopic, m[1], true
+	}
+	return "", "", false
+}
+
+func parseXiaobotURL(link string) (kind, id string, ok bool) {
+	if m := reXiaobotPostURL.FindStringSubmatch(link); m != nil {
+		return bookmarkDB.KindXiaobotPost, m[1], true
+	}
+	return "", "", false
+}
+
+func parseTombkeeperURL(link string) (kind, id string, ok bool) {
+	mid, ok := tk.WeiboArchiveMid(link)
+	return bookmarkDB.KindTombkeeperPost, mid, ok
+}
+
+func parseTkblogURL(link string) (kind, id string, ok bool) {
+	category, postID, ok := tkblog.BlogArchiveKey(link)
+	return bookmarkDB.KindTkblogPost, category + "/" + postID, ok
+}
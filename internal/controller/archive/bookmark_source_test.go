@@ -0,0 +1,121 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+)
+
+func TestResolveBookmarkRef(t *testing.T) {
+	cases := []struct {
+		name       string
+		platform   string
+		kind       string
+		legacyType int
+		contentID  string
+		want       bookmarkDB.ContentRef
+	}{
+		{"legacy zhihu answer", "", "", 0, "1", bookmarkDB.ContentRef{Platform: "zhihu", Kind: "answer", ID: "1"}},
+		{"legacy zhihu pin", "", "", 2, "2", bookmarkDB.ContentRef{Platform: "zhihu", Kind: "pin", ID: "2"}},
+		{"explicit zhihu kind wins over legacy type", "zhihu", "pin", 0, "3", bookmarkDB.ContentRef{Platform: "zhihu", Kind: "pin", ID: "3"}},
+		{"zsxq default kind", "zsxq", "", 0, "4", bookmarkDB.ContentRef{Platform: "zsxq", Kind: "topic", ID: "4"}},
+		{"xiaobot", "xiaobot", "post", 0, "abc", bookmarkDB.ContentRef{Platform: "xiaobot", Kind: "post", ID: "abc"}},
+		{"tombkeeper", "tombkeeper", "", 0, "5", bookmarkDB.ContentRef{Platform: "tombkeeper", Kind: "weibo", ID: "5"}},
+		{"tkblog", "tkblog", "", 0, "xfocus/26ho", bookmarkDB.ContentRef{Platform: "tkblog", Kind: "blog", ID: "xfocus/26ho"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := resolveBookmarkRef(c.platform, c.kind, c.legacyType, c.contentID)
+			require.NoError(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestResolveBookmarkRefRejectsInvalidTarget(t *testing.T) {
+	cases := []struct {
+		name       string
+		platform   string
+		kind       string
+		legacyType int
+		contentID  string
+	}{
+		{"unknown platform", "weibo", "", 0, "1"},
+		{"unknown legacy type", "", "", 9, "1"},
+		{"zhihu article is not bookmarkable", "zhihu", "article", 0, "1"},
+		{"kind of another platform", "zsxq", "post", 0, "1"},
+		{"empty content id", "zsxq", "", 0, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := resolveBookmarkRef(c.platform, c.kind, c.legacyType, c.contentID)
+			assert.ErrorIs(t, err, errBookmarkTarget)
+		})
+	}
+}
+
+func TestTargetLookupErr(t *testing.T) {
+	err := targetLookupErr(fmt.Errorf("query: %w", gorm.ErrRecordNotFound), "zhihu answer 1")
+	assert.ErrorIs(t, err, errBookmarkNotFound)
+	assert.NotErrorIs(t, err, errBookmarkTarget)
+
+	dbErr := errors.New("connection refused")
+	err = targetLookupErr(dbErr, "zhihu answer 1")
+	assert.ErrorIs(t, err, dbErr)
+	assert.NotErrorIs(t, err, errBookmarkNotFound)
+	assert.NotErrorIs(t, err, errBookmarkTarget)
+}
+
+func TestSplitTkblogID(t *testing.T) {
+	category, id, ok := splitTkblogID("baidu/26ho1i8FcjS")
+	assert.True(t, ok)
+	assert.Equal(t, "baidu", category)
+	assert.Equal(t, "26ho1i8FcjS", id)
+
+	for _, bad := range []string{"", "baidu", "baidu/", "/26ho"} {
+		_, _, ok = splitTkblogID(bad)
+		assert.False(t, ok, bad)
+	}
+}
+
+type fakeBookmarkDB struct {
+	bookmarkDB.DB
+	bookmarks []bookmarkDB.Bookmark
+	tags      map[string][]string
+}
+
+func (f *fakeBookmarkDB) GetBookmarksByContents(user, platform, kind string, ids []string) ([]bookmarkDB.Bookmark, error) {
+	var result []bookmarkDB.Bookmark
+	for _, b := range f.bookmarks {
+		if b.UserID == user && b.Platform == platform && b.Kind == kind && slices.Contains(ids, b.ContentID) {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeBookmarkDB) GetTags(ids []string) (map[string][]string, error) { return f.tags, nil }
+
+func TestAttachBookmarks(t *testing.T) {
+	bd := &fakeBookmarkDB{
+		bookmarks: []bookmarkDB.Bookmark{
+			{ID: "b1", UserID: "jason", Platform: bookmarkDB.PlatformZsxq, Kind: bookmarkDB.KindZsxqTopic, ContentID: "1", Note: "n"},
+			{ID: "b2", UserID: "jason", Platform: bookmarkDB.PlatformZsxq, Kind: bookmarkDB.KindZsxqTopic, ContentID: "2"},
+			{ID: "b3", UserID: "other", Platform: bookmarkDB.PlatformZsxq, Kind: bookmarkDB.KindZsxqTopic, ContentID: "3"},
+		},
+		tags: map[string][]string{"b1": {"x", "y"}},
+	}
+	topics := []Topic{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	require.NoError(t, attachBookmarks(bd, "jason", bookmarkDB.PlatformZsxq, bookmarkDB.KindZsxqTopic, topics))
+	assert.Equal(t, &Custom{Bookmark: true, BookmarkID: "b1", Note: "n", Tags: []string{"x", "y"}}, topics[0].Custom)
+	assert.Equal(t, &Custom{Bookmark: true, BookmarkID: "b2", Tags: []string{}}, topics[1].Custom)
+	assert.Nil(t, topics[2].Custom)
+}
@@ -0,0 +1,361 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/internal/rss"
+	"github.com/eli-yip/rss-zero/pkg/bookmark"
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+	"github.com/eli-yip/rss-zero/pkg/feedtoken"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/render"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+)
+
+// maxImportSize 限制导入文件大小，浏览器导出的书签文件通常只有几 MB。
+const maxImportSize = 16 << 20
+
+// archivePathMarker 是 render.BuildArchiveLink 生成的存档链接中原文链接之前的路径。
+const archivePathMarker = "/api/v1/archive/"
+
+// resolveBookmarkURL 把原文链接或本站存档链接反解为 ContentRef。
+func resolveBookmarkURL(link string) (bookmarkDB.ContentRef, bool) {
+	if _, inner, ok := strings.Cut(link, archivePathMarker); ok {
+		link = inner
+		if unescaped, err := url.PathUnescape(link); err == nil {
+			link = unescaped
+		}
+	}
+	if link == "" {
+		return bookmarkDB.ContentRef{}, false
+	}
+	// 固定顺序遍历，避免 map 随机顺序让同一链接在不同次导入中归属不同平台
+	platforms := lo.Keys(bookmarkSources)
+	slices.Sort(platforms)
+	for _, platform := range platforms {
+		if kind, id, ok := bookmarkSources[platform].parseURL(link); ok {
+			return bookmarkDB.ContentRef{Platform: platform, Kind: kind, ID: id}, true
+		}
+	}
+	return bookmarkDB.ContentRef{}, false
+}
+
+// resolveEntryRef 优先使用条目自带的 platform/kind/content_id（本站 JSON 导出），
+// 否则依次尝试原文链接与存档链接。
+func resolveEntryRef(e bookmark.Entry) (bookmarkDB.ContentRef, bool) {
+	if e.Platform != "" && e.Kind != "" && e.ContentID != "" {
+		if ref, err := resolveBookmarkRef(e.Platform, e.Kind, 0, e.ContentID); err == nil {
+			return ref, true
+		}
+	}
+	for _, link := range []string{e.URL, e.ArchiveURL} {
+		if ref, ok := resolveBookmarkURL(link); ok {
+			return ref, true
+		}
+	}
+	return bookmarkDB.ContentRef{}, false
+}
+
+// loadBookmarkTopics 批量读取一组收藏的标签与 Topic，用户无权读取的付费内容没有 Topic。
+func (h *Controller) loadBookmarkTopics(access *accessFilter, bookmarks []bookmarkDB.Bookmark) (map[string]Topic, map[string][]string, error) {
+	tags, err := h.bookmarkDBService.GetTags(lo.Map(bookmarks, func(b bookmarkDB.Bookmark, _ int) string { return b.ID }))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	topics, err := h.buildBookmarkTopics(access, bookmarks, tags)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build bookmark topics: %w", err)
+	}
+	return topics, tags, nil
+}
+
+func bookmarkEntries(bookmarks []bookmarkDB.Bookmark, topics map[string]Topic, tags map[string][]string) []bookmark.Entry {
+	entries := make([]bookmark.Entry, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		e := bookmark.Entry{
+			Platform:  b.Platform,
+			Kind:      b.Kind,
+			ContentID: b.ContentID,
+			Tags:      tags[b.ID],
+			Comment:   b.Comment,
+			Note:      b.Note,
+			CreatedAt: b.CreatedAt,
+			UpdatedAt: b.UpdatedAt,
+		}
+		if e.Tags == nil {
+			e.Tags = []string{}
+		}
+		// 被收藏内容已不在库中时仍导出收藏本身，只是没有链接与标题
+		if topic, ok := topics[b.ID]; ok {
+			e.URL, e.ArchiveURL, e.Title = topic.OriginalURL, topic.ArchiveURL, topic.Title
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// ExportBookmarks 导出当前用户的全部收藏，?format=html 为 Netscape 书签格式，缺省为 JSON。
+func (h *Controller) ExportBookmarks(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	reqUser, err := contextUser(c)
+	if err != nil {
+		return err
+	}
+	user := reqUser.Name
+
+	format, err := echo.QueryParamOr[string](c, "format", bookmark.FormatJSON)
+	if err != nil || (format != bookmark.FormatJSON && format != bookmark.FormatHTML) {
+		return httputil.NewHTTPError(http.StatusBadRequest, "format must be html or json")
+	}
+
+	bookmarks, err := h.bookmarkDBService.GetBookmarkByUser(user, nil)
+	if err != nil {
+		logger.Error("failed to get bookmarks", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	topics, tags, err := h.loadBookmarkTopics(h.accessFilter(reqUser), bookmarks)
+	if err != nil {
+		logger.Error("failed to load bookmarks", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	entries := bookmarkEntries(bookmarks, topics, tags)
+
+	now := time.Now()
+	filename := fmt.Sprintf("rss-zero-bookmarks-%s.%s", now.Format("20060102"), format)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	logger.Info("Export bookmarks successfully", zap.Int("count", len(entries)), zap.String("format", format))
+
+	if format == bookmark.FormatHTML {
+		// 没有链接的条目在浏览器里无法打开，HTML 导出中略去
+		entries = lo.Filter(entries, func(e bookmark.Entry, _ int) bool { return e.URL != "" })
+		return c.Blob(http.StatusOK, echo.MIMETextHTMLCharsetUTF8, bookmark.EncodeHTML(entries))
+	}
+	data, err := bookmark.EncodeJSON(entries, now)
+	if err != nil {
+		logger.Error("failed to encode bookmarks", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, data)
+}
+
+type ImportBookmarkResponse struct {
+	Imported   int      `json:"imported"`
+	Merged     int      `json:"merged"`
+	Unresolved []string `json:"unresolved"`
+}
+
+// ImportBookmarks 导入 Netscape HTML 或 JSON 书签文件。文件可作为请求体直接上传，
+// 也可作为 multipart 表单的 file 字段；?format 缺省时按内容探测。
+// 能反解为本站已存档内容的条目才会导入，已收藏的条目合并标签，并仅在原值为空时补写 comment/note。
+func (h *Controller) ImportBookmarks(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := contextUser(c)
+	if err != nil {
+		return err
+	}
+	access := h.accessFilter(user)
+
+	format, err := echo.QueryParamOr[string](c, "format", "")
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	data, err := common.ReadUploadFile(c, maxImportSize)
+	if err != nil {
+		logger.Error("failed to read import file", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	entries, err := bookmark.Decode(data, format)
+	if err != nil {
+		logger.Error("failed to decode import file", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resp := ImportBookmarkResponse{Unresolved: []string{}}
+	for _, e := range entries {
+		merged, err := h.importBookmark(access, user.Name, e)
+		switch {
+		case errors.Is(err, errBookmarkTarget), errors.Is(err, errBookmarkNotFound), errors.Is(err, subscription.ErrForbidden):
+			logger.Info("skip unresolved bookmark", zap.String("url", e.URL), zap.Error(err))
+			resp.Unresolved = append(resp.Unresolved, lo.CoalesceOrEmpty(e.URL, e.ArchiveURL, e.ContentID))
+		case err != nil:
+			logger.Error("failed to import bookmark", zap.String("url", e.URL), zap.Error(err))
+			return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+		case merged:
+			resp.Merged++
+		default:
+			resp.Imported++
+		}
+	}
+
+	logger.Info("Import bookmarks successfully", zap.Int("imported", resp.Imported),
+		zap.Int("merged", resp.Merged), zap.Int("unresolved", len(resp.Unresolved)))
+	return c.JSON(http.StatusOK, httputil.NewResp("success", resp))
+}
+
+// importBookmark 导入单条收藏，merged 表示该内容已收藏、本次只做了合并。
+// 无法反解时返回 errBookmarkTarget，内容不在库中时返回 errBookmarkNotFound，无权读取的付费内容返回
+// subscription.ErrForbidden。
+func (h *Controller) importBookmark(access *accessFilter, user string, e bookmark.Entry) (merged bool, err error) {
+	ref, ok := resolveEntryRef(e)
+	if !ok {
+		return false, fmt.Errorf("%w: unrecognized link", errBookmarkTarget)
+	}
+
+	b, err := h.bookmarkDBService.GetBookmarkByContent(user, ref)
+	switch {
+	case err == nil:
+		merged = true
+	case errors.Is(err, bookmarkDB.ErrNoBookmark):
+		if err = bookmarkSources[ref.Platform].check(h, access, ref); err != nil {
+			return false, err
+		}
+		if b, err = h.bookmarkDBService.NewBookmark(user, ref); err != nil {
+			return false, fmt.Errorf("failed to create bookmark: %w", err)
+		}
+	default:
+		return false, fmt.Errorf("failed to get bookmark: %w", err)
+	}
+
+	comment, note := lo.CoalesceOrEmpty(b.Comment, e.Comment), lo.CoalesceOrEmpty(b.Note, e.Note)
+	if comment != b.Comment || note != b.Note {
+		if _, err = h.bookmarkDBService.UpdateBookmark(b.ID, comment, note); err != nil {
+			return false, fmt.Errorf("failed to update bookmark: %w", err)
+		}
+	}
+	// 非法标签名（如空层级）直接丢弃，不影响收藏本身导入
+	tags := lo.Uniq(lo.FilterMap(e.Tags, func(name string, _ int) (string, bool) {
+		n, err := bookmarkDB.NormalizeTagName(name)
+		return n, err == nil
+	}))
+	if len(tags) > 0 {
+		if _, err = h.bookmarkDBService.AddTags(b.ID, tags); err != nil {
+			return false, fmt.Errorf("failed to add tags: %w", err)
+		}
+	}
+	return merged, nil
+}
+
+// GetBookmarkFeedURL 返回当前用户某个标签的私有 feed 地址（含访问令牌）。
+func (h *Controller) GetBookmarkFeedURL(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := contextUsername(c)
+	if err != nil {
+		return err
+	}
+	tag, err := bookmarkDB.NormalizeTagName(c.Param("*"))
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	secret := config.C.Settings.FeedSecret
+	if secret == "" {
+		logger.Error("bookmark feed secret is not configured")
+		return httputil.NewHTTPError(http.StatusNotFound, "bookmark feed is disabled")
+	}
+
+	// 层级标签逐级转义、保留分隔符，由通配路由整体取回
+	segments := lo.Map(strings.Split(tag, bookmarkDB.TagSeparator), func(s string, _ int) string { return url.PathEscape(s) })
+	feedURL := fmt.Sprintf("%s/rss/bookmark/%s/%s?token=%s", config.C.Settings.ServerURL,
+		url.PathEscape(user), strings.Join(segments, bookmarkDB.TagSeparator), feedtoken.Sign(secret, user, bookmark.FeedPath(user, tag)))
+	return c.JSON(http.StatusOK, httputil.NewResp("success", map[string]string{"url": feedURL}))
+}
+
+// BookmarkRSS 输出某用户某标签（含子标签）下的收藏 feed，按收藏时间倒序。
+// 收藏随用户操作随时变化且访问量小，不走 redis 缓存，每次请求现读现渲染。
+func (h *Controller) BookmarkRSS(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	user, err := echo.PathParam[string](c, "user")
+	if err != nil {
+		return c.String(http.StatusBadRequest, "user is empty")
+	}
+	// 与 GetBookmarkFeedURL 签名时一致地规范化，否则带空白的标签验签和查询都会落空
+	tag, err := bookmarkDB.NormalizeTagName(c.Param("*"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, "invalid tag")
+	}
+	token, _ := echo.QueryParamOr[string](c, "token", "")
+	if !feedtoken.Verify(config.C.Settings.FeedSecret, user, bookmark.FeedPath(user, tag), token) {
+		logger.Error("invalid bookmark feed token", zap.String("user", user), zap.String("tag", tag))
+		return c.String(http.StatusForbidden, "invalid token")
+	}
+
+	bookmarks, err := h.bookmarkDBService.GetBookmarkByTag(user, tag)
+	if err != nil {
+		logger.Error("failed to get bookmarks by tag", zap.Error(err))
+		return c.String(http.StatusInternalServerError, "failed to get rss content")
+	}
+	slices.SortFunc(bookmarks, func(a, b bookmarkDB.Bookmark) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	bookmarks = bookmarks[:min(len(bookmarks), rss.MaxFetch)]
+
+	// feed 请求不带登录信息，付费内容按该用户的授权过滤（管理员也需授权）
+	topics, _, err := h.loadBookmarkTopics(h.accessFilter(subscription.User{Name: user}), bookmarks)
+	if err != nil {
+		logger.Error("failed to load bookmarks", zap.Error(err))
+		return c.String(http.StatusInternalServerError, "failed to get rss content")
+	}
+	meta, items, err := feedFromBookmarks(tag, bookmarks, topics, config.C.Settings.ServerURL)
+	if err != nil {
+		logger.Error("failed to build bookmark feed", zap.Error(err))
+		return c.String(http.StatusInternalServerError, "failed to get rss content")
+	}
+
+	xml, err := rss.RenderAtom(meta, items)
+	if err != nil {
+		logger.Error("failed to render bookmark feed", zap.Error(err))
+		return c.String(http.StatusInternalServerError, "failed to render rss content")
+	}
+	return c.String(http.StatusOK, xml)
+}
+
+func feedFromBookmarks(tag string, bookmarks []bookmarkDB.Bookmark, topics map[string]Topic, serverBaseURL string) (rss.FeedMeta, []rss.Item, error) {
+	meta := rss.FeedMeta{Title: fmt.Sprintf("RSS-ZERO 收藏 · %s", tag), Link: serverBaseURL}
+	if len(bookmarks) == 0 {
+		meta.Updated = time.Now()
+		return meta, nil, nil
+	}
+	meta.Updated = bookmarks[0].CreatedAt
+
+	items := make([]rss.Item, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		topic, ok := topics[b.ID]
+		if !ok {
+			continue
+		}
+		var sb strings.Builder
+		for _, s := range []string{b.Comment, b.Note} {
+			if s != "" {
+				fmt.Fprintf(&sb, "> %s\n\n", strings.ReplaceAll(s, "\n", "\n> "))
+			}
+		}
+		sb.WriteString(topic.Body)
+		if topic.ArchiveURL != "" {
+			fmt.Fprintf(&sb, "\n\n[存档链接](%s)", topic.ArchiveURL)
+		}
+		contentHTML, err := render.FeedHTML(sb.String())
+		if err != nil {
+			return rss.FeedMeta{}, nil, fmt.Errorf("failed to render bookmark %s: %w", b.ID, err)
+		}
+		items = append(items, rss.Item{
+			ID:          b.ID,
+			Link:        topic.OriginalURL,
+			Title:       topic.Title,
+			Author:      topic.Author.Nickname,
+			Time:        b.CreatedAt,
+			Summary:     render.ExtractExcerpt(topic.Body),
+			ContentHTML: contentHTML,
+		})
+	}
+	return meta, items, nil
+}
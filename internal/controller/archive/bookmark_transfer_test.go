@@ -0,0 +1,123 @@
+package archive
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/pkg/bookmark"
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+	"github.com/eli-yip/rss-zero/pkg/feedtoken"
+)
+
+func TestResolveBookmarkURL(t *testing.T) {
+	cases := []struct {
+		link string
+		want bookmarkDB.ContentRef
+	}{
+		{"https://www.zhihu.com/question/1/answer/2", bookmarkDB.ContentRef{Platform: "zhihu", Kind: "answer", ID: "2"}},
+		{"https://www.zhihu.com/answer/3", bookmarkDB.ContentRef{Platform: "zhihu", Kind: "answer", ID: "3"}},
+		{"https://www.zhihu.com/pin/4", bookmarkDB.ContentRef{Platform: "zhihu", Kind: "pin", ID: "4"}},
+		{"https://wx.zsxq.com/group/5/topic/6", bookmarkDB.ContentRef{Platform: "zsxq", Kind: "topic", ID: "6"}},
+		{"https://wx.zsxq.com/mweb/views/topicdetail/topic_detail/7", bookmarkDB.ContentRef{Platform: "zsxq", Kind: "topic", ID: "7"}},
+		{"https://xiaobot.net/post/0a1b-2c", bookmarkDB.ContentRef{Platform: "xiaobot", Kind: "post", ID: "0a1b-2c"}},
+		{"https://tombkeeper.io/weibo/5012345678901234", bookmarkDB.ContentRef{Platform: "tombkeeper", Kind: "weibo", ID: "5012345678901234"}},
+		{"https://tombkeeper.io/xfocus/26ho", bookmarkDB.ContentRef{Platform: "tkblog", Kind: "blog", ID: "xfocus/26ho"}},
+		{"https://rss.test/api/v1/archive/https://www.zhihu.com/pin/8", bookmarkDB.ContentRef{Platform: "zhihu", Kind: "pin", ID: "8"}},
+		{"https://rss.test/api/v1/archive/https%3A%2F%2Fwx.zsxq.com%2Fgroup%2F1%2Ftopic%2F9", bookmarkDB.ContentRef{Platform: "zsxq", Kind: "topic", ID: "9"}},
+	}
+	for _, c := range cases {
+		t.Run(c.link, func(t *testing.T) {
+			got, ok := resolveBookmarkURL(c.link)
+			require.True(t, ok)
+			assert.Equal(t, c.want, got)
+		})
+	}
+
+	for _, link := range []string{"", "https://www.zhihu.com/p/1", "https://example.com/pin/1", "https://rss.test/api/v1/archive/"} {
+		_, ok := resolveBookmarkURL(link)
+		assert.False(t, ok, link)
+	}
+}
+
+func TestResolveEntryRef(t *testing.T) {
+	ref, ok := resolveEntryRef(bookmark.Entry{Platform: "zsxq", Kind: "topic", ContentID: "1", URL: "https://www.zhihu.com/pin/2"})
+	require.True(t, ok)
+	assert.Equal(t, bookmarkDB.ContentRef{Platform: "zsxq", Kind: "topic", ID: "1"}, ref, "explicit ref wins over url")
+
+	ref, ok = resolveEntryRef(bookmark.Entry{Platform: "unknown", Kind: "x", ContentID: "1", ArchiveURL: "https://rss.test/api/v1/archive/https://www.zhihu.com/pin/2"})
+	require.True(t, ok, "an invalid explicit ref falls back to the links")
+	assert.Equal(t, bookmarkDB.ContentRef{Platform: "zhihu", Kind: "pin", ID: "2"}, ref)
+
+	_, ok = resolveEntryRef(bookmark.Entry{URL: "https://example.com"})
+	assert.False(t, ok)
+}
+
+func TestFeedFromBookmarks(t *testing.T) {
+	newer := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	bookmarks := []bookmarkDB.Bookmark{
+		{ID: "b1", Comment: "好文", CreatedAt: newer},
+		{ID: "gone", CreatedAt: newer.Add(-time.Hour)},
+	}
+	topics := map[string]Topic{
+		"b1": {OriginalURL: "https://www.zhihu.com/pin/1", ArchiveURL: "https://rss.test/api/v1/archive/https://www.zhihu.com/pin/1",
+			Title: "想法", Body: "正文", Author: Author{Nickname: "作者"}},
+	}
+
+	meta, items, err := feedFromBookmarks("go", bookmarks, topics, "https://rss.test")
+	require.NoError(t, err)
+	assert.Equal(t, "RSS-ZERO 收藏 · go", meta.Title)
+	assert.Equal(t, newer, meta.Updated)
+	require.Len(t, items, 1, "bookmarks whose content is gone are skipped")
+	assert.Equal(t, "b1", items[0].ID)
+	assert.Equal(t, "https://www.zhihu.com/pin/1", items[0].Link)
+	assert.Equal(t, "作者", items[0].Author)
+	assert.Contains(t, items[0].ContentHTML, "<blockquote>")
+	assert.Contains(t, items[0].ContentHTML, "存档链接")
+
+	meta, items, err = feedFromBookmarks("go", nil, nil, "https://rss.test")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	assert.False(t, meta.Updated.IsZero())
+}
+
+type tagQueryBookmarkDB struct {
+	fakeBookmarkDB
+	queried []string
+}
+
+func (f *tagQueryBookmarkDB) GetBookmarkByTag(user, tag string) ([]bookmarkDB.Bookmark, error) {
+	f.queried = append(f.queried, tag)
+	return nil, nil
+}
+
+func TestBookmarkRSSNormalizesTag(t *testing.T) {
+	old := config.C.Settings.FeedSecret
+	config.C.Settings.FeedSecret = "secret"
+	t.Cleanup(func() { config.C.Settings.FeedSecret = old })
+
+	bd := &tagQueryBookmarkDB{}
+	h := &Controller{bookmarkDBService: bd}
+	serve := func(tag, token string) int {
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/?token="+token, nil), rec)
+		c.SetPathValues(echo.PathValues{{Name: "user", Value: "jason"}, {Name: "*", Value: tag}})
+		require.NoError(t, h.BookmarkRSS(c))
+		return rec.Code
+	}
+
+	// 签发时使用规范化后的标签，带空白的原始路径也应通过验签并按规范名查询
+	token := feedtoken.Sign("secret", "jason", bookmark.FeedPath("jason", "go/lang"))
+	assert.Equal(t, http.StatusOK, serve(" go / lang ", token))
+	assert.Equal(t, []string{"go/lang"}, bd.queried)
+
+	assert.Equal(t, http.StatusBadRequest, serve("go//lang", token))
+	assert.Equal(t, http.StatusForbidden, serve("go/other", token))
+	assert.Len(t, bd.queried, 1)
+}
@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"fmt"
+	"time"
+
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+)
+
+// 非知乎平台的归档列表：星球以 author 传 group id，tkblog 以 author 传分类（空为两个分类），
+// tombkeeper 只有一条时间线、忽略 author。列表项与知乎一样挂上当前用户的阅读与收藏状态。
+
+// listContentQuery 是一次分页归档列表查询，scope 由 readingScopes 按平台的 id 列生成。
+type listContentQuery struct {
+	req            *ArchiveRequest
+	user           string
+	offset         int
+	startAt, endAt time.Time
+}
+
+func (h *Controller) listZsxqTopics(q listContentQuery, gid int) ([]Topic, int, error) {
+	kind := bookmarkDB.KindZsxqTopic
+	fetchScopes, countScopes := readingScopes(q.req, q.user, bookmarkDB.PlatformZsxq, kind, "zsxq_topic.id", "time")
+	rows, err := h.zsxqDBService.FetchTopicsWithDateRange(gid, q.req.Count, q.offset, q.req.Order, q.startAt, q.endAt, fetchScopes...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch zsxq topics: %w", err)
+	}
+	topics, err := zsxqContentTopics(h, rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = h.attachUserState(q.user, bookmarkDB.PlatformZsxq, kind, topics); err != nil {
+		return nil, 0, err
+	}
+	count, err := h.zsxqDBService.CountTopicsWithDateRange(gid, q.startAt, q.endAt, countScopes...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count zsxq topics: %w", err)
+	}
+	return topics, count, nil
+}
+
+func (h *Controller) listTombkeeperPosts(q listContentQuery) ([]Topic, int, error) {
+	kind := bookmarkDB.KindTombkeeperPost
+	fetchScopes, countScopes := readingScopes(q.req, q.user, bookmarkDB.PlatformTombkeeper, kind, "tombkeeper_post.id", "published_at")
+	posts, err := h.tombkeeperDBService.FetchTimelineWithDateRange(q.req.Count, q.offset, q.req.Order, q.startAt, q.endAt, fetchScopes...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch tombkeeper posts: %w", err)
+	}
+	topics, err := tombkeeperContentTopics(h, posts)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = h.attachUserState(q.user, bookmarkDB.PlatformTombkeeper, kind, topics); err != nil {
+		return nil, 0, err
+	}
+	count, err := h.tombkeeperDBService.CountTimelineWithDateRange(q.startAt, q.endAt, countScopes...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count tombkeeper posts: %w", err)
+	}
+	return topics, count, nil
+}
+
+// tkblogIDColumn 拼出与收藏 content id 一致的 "{category}/{id}"。
+const tkblogIDColumn = "(tombkeeper_blog_post.category || '/' || tombkeeper_blog_post.id)"
+
+func (h *Controller) listTkblogPosts(q listContentQuery, category string) ([]Topic, int, error) {
+	kind := bookmarkDB.KindTkblogPost
+	fetchScopes, countScopes := readingScopes(q.req, q.user, bookmarkDB.PlatformTkblog, kind, tkblogIDColumn, "created_at")
+	posts, err := h.tkblogDBService.FetchPostsWithDateRange(category, q.req.Count, q.offset, q.req.Order, q.startAt, q.endAt, fetchScopes...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch tkblog posts: %w", err)
+	}
+	topics, err := tkblogContentTopics(h, posts)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = h.attachUserState(q.user, bookmarkDB.PlatformTkblog, kind, topics); err != nil {
+		return nil, 0, err
+	}
+	count, err := h.tkblogDBService.CountPostsWithDateRange(category, q.startAt, q.endAt, countScopes...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count tkblog posts: %w", err)
+	}
+	return topics, count, nil
+}
+
+// attachUserState 为一页列表填入当前用户的阅读状态与收藏、标签。
+func (h *Controller) attachUserState(user, platform, kind string, topics []Topic) error {
+	if err := attachReading(h.readingDBService, user, platform, kind, topics); err != nil {
+		return fmt.Errorf("failed to attach reading state: %w", err)
+	}
+	return attachBookmarks(h.bookmarkDBService, user, platform, kind, topics)
+}
@@ -6,13 +6,17 @@ import (
 	"github.com/eli-yip/rss-zero/config"
 	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
 	embeddingDB "github.com/eli-yip/rss-zero/pkg/embedding/db"
+	readingDB "github.com/eli-yip/rss-zero/pkg/reading/db"
 	"github.com/eli-yip/rss-zero/pkg/render"
 	tkblogDB "github.com/eli-yip/rss-zero/pkg/routers/tkblog"
 	tombkeeperDB "github.com/eli-yip/rss-zero/pkg/routers/tombkeeper"
+	xiaobotDB "github.com/eli-yip/rss-zero/pkg/routers/xiaobot/db"
 	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
 	zhihuRender "github.com/eli-yip/rss-zero/pkg/routers/zhihu/render"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/revision"
 	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
 	zsxqRender "github.com/eli-yip/rss-zero/pkg/routers/zsxq/render"
+	"github.com/eli-yip/rss-zero/pkg/statistics"
 )
 
 type Controller struct {
@@ -21,16 +25,21 @@ type Controller struct {
 	zhihuDBService             zhihuDB.DB
 	embeddingDBService         embeddingDB.DBIface
 	bookmarkDBService          bookmarkDB.DB
+	readingDBService           readingDB.DB
 	zhihuFullTextRenderService zhihuRender.FullTextRenderIface
 	zsxqDBService              zsxqDB.DB
 	zsxqFullTextRenderService  zsxqRender.FullTextRenderer
 	tombkeeperDBService        tombkeeperDB.DB
 	tkblogDBService            tkblogDB.DB
+	xiaobotDBService           xiaobotDB.DB
+	statisticsDBService        statistics.DB
+	zhihuRevisionService       *revision.Service
+	access                     AccessChecker
 
 	htmlRender render.HtmlRenderIface
 }
 
-func NewController(db *gorm.DB) *Controller {
+func NewController(db *gorm.DB, access AccessChecker) *Controller {
 	zsxqDBService := zsxqDB.NewDBService(db)
 	zhihuDBService := zhihuDB.NewDBService(db)
 	return &Controller{
@@ -38,11 +47,16 @@ func NewController(db *gorm.DB) *Controller {
 		zhihuDBService:             zhihuDBService,
 		embeddingDBService:         embeddingDB.NewDBService(db),
 		bookmarkDBService:          bookmarkDB.NewBookMarkDBImpl(db),
+		readingDBService:           readingDB.NewReadingDBImpl(db),
 		zhihuFullTextRenderService: zhihuRender.NewFullTextRender(zhihuDBService, config.C.Settings.ServerURL),
 		zsxqDBService:              zsxqDBService,
 		zsxqFullTextRenderService:  zsxqRender.NewFullTextRenderService(zsxqDBService),
 		tombkeeperDBService:        tombkeeperDB.NewDBService(db),
 		tkblogDBService:            tkblogDB.NewDBService(db),
+		xiaobotDBService:           xiaobotDB.NewDBService(db),
+		statisticsDBService:        statistics.NewDBService(db),
+		zhihuRevisionService:       revision.NewService(zhihuDBService, config.C.Settings.ServerURL),
+		access:                     access,
 
 		htmlRender: render.NewHtmlRenderService(),
 	}
@@ -22,9 +22,17 @@ type ArchiveRequest struct {
 	Page      int    `json:"page"`
 	StartDate string `json:"start_date"`
 	EndDate   string `json:"end_date"`
-	Order     int    `json:"order"` // 0: created_at desc, 1: created_at asc
+	Order     int    `json:"order"`    // 0: desc, 1: asc
+	OrderBy   int    `json:"order_by"` // 0: created_at, 1: last_read_at (never opened last)
+	// UnreadOnly 只返回当前用户未读的内容
+	UnreadOnly bool `json:"unread_only"`
 }
 
+const (
+	ArchiveOrderByCreatedAt = iota
+	ArchiveOrderByLastRead
+)
+
 type BookmarkRequest struct {
 	Page      int        `json:"page"`
 	Tags      *TagFilter `json:"tags"`
@@ -61,6 +69,14 @@ type ArchiveResponse struct {
 	ResponseBase
 }
 
+// ZhihuListResponse 是专栏或收藏夹的归档列表，Title 为专栏或收藏夹标题。
+type ZhihuListResponse struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	ArchiveResponse
+}
+
 type Author struct {
 	ID       string `json:"id"`
 	Nickname string `json:"nickname"`
@@ -77,6 +93,26 @@ type Topic struct {
 	Body        string  `json:"body"`
 	Author      Author  `json:"author"`
 	Custom      *Custom `json:"custom"`
+	// Reading 是当前用户的阅读状态，从未打开过时为空
+	Reading *Reading `json:"reading"`
+}
+
+type Reading struct {
+	Read       bool    `json:"read"`
+	Progress   float64 `json:"progress"`
+	ReadAt     string  `json:"read_at"`
+	LastReadAt string  `json:"last_read_at"`
+}
+
+// ReadingRequest 上报阅读进度或标记已读/未读，内容定位方式同 NewBookmarkRequest。
+// Progress 与 Read 至少提供一个；同时提供时先记进度，再按 Read 覆盖已读状态。
+type ReadingRequest struct {
+	Platform    string   `json:"platform"`
+	Kind        string   `json:"kind"`
+	ContentType int      `json:"content_type"`
+	ContentID   string   `json:"content_id"`
+	Progress    *float64 `json:"progress"`
+	Read        *bool    `json:"read"`
 }
 
 type Custom struct {
@@ -103,7 +139,11 @@ const (
 	PlatformZhihu = "zhihu"
 )
 
+// NewBookmarkRequest 为空 platform 时按旧协议视为知乎，并由 content_type（旧 int 编码）推出 kind；
+// 其他平台 kind 可省略。tkblog 的 content_id 形如 "{category}/{id}"。
 type NewBookmarkRequest struct {
+	Platform    string `json:"platform"`
+	Kind        string `json:"kind"`
 	ContentType int    `json:"content_type"`
 	ContentID   string `json:"content_id"`
 }
@@ -7,7 +7,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/eli-yip/rss-zero/internal/controller/common"
+	pkgCommon "github.com/eli-yip/rss-zero/pkg/common"
 	"github.com/eli-yip/rss-zero/pkg/httputil"
+	readingDB "github.com/eli-yip/rss-zero/pkg/reading/db"
 )
 
 // /api/v1/archive/random
@@ -28,21 +30,29 @@ func (h *Controller) Random(c *echo.Context) (err error) {
 		return httputil.NewHTTPError(http.StatusBadRequest, "invalid request")
 	}
 
-	answers, err := h.zhihuDBService.RandomSelect(req.Count, req.Author)
+	username, err := contextUsername(c)
 	if err != nil {
-		logger.Error("Failed to select random answers", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, "failed to select random answers")
+		return err
 	}
 
-	username, err := contextUsername(c)
+	// 优先抽当前用户未读的回答，全部读过后再从已读中抽
+	kind := pkgCommon.ZhihuAnswer.Slug()
+	unread := readingDB.Unread(username, PlatformZhihu, kind, "zhihu_answer.id")
+	answers, err := h.zhihuDBService.RandomSelect(req.Count, req.Author, unread)
 	if err != nil {
-		return err
+		logger.Error("Failed to select random answers", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "failed to select random answers")
 	}
+
 	topics, err := buildTopicsFromAnswer(answers, username, h.zhihuDBService, h.bookmarkDBService)
 	if err != nil {
 		logger.Error("Failed to build topics", zap.Error(err))
 		return httputil.NewHTTPError(http.StatusInternalServerError, "failed to build topics")
 	}
+	if err = attachReading(h.readingDBService, username, PlatformZhihu, kind, topics); err != nil {
+		logger.Error("Failed to attach reading state", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "failed to attach reading state")
+	}
 
 	return c.JSON(http.StatusOK, httputil.NewResp("success", ResponseBase{Topics: topics}))
 }
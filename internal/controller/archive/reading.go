@@ -0,0 +1,115 @@
+package archive
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	readingDB "github.com/eli-yip/rss-zero/pkg/reading/db"
+)
+
+// PutReading 上报阅读进度或标记已读/未读。
+func (h *Controller) PutReading(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := contextUsername(c)
+	if err != nil {
+		return err
+	}
+
+	var req ReadingRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("failed to bind request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.Progress == nil && req.Read == nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, "progress or read is required")
+	}
+
+	ref, err := resolveBookmarkRef(req.Platform, req.Kind, req.ContentType, req.ContentID)
+	if err != nil {
+		logger.Error("invalid reading target", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	var state *readingDB.ReadState
+	if req.Progress != nil {
+		if state, err = h.readingDBService.SaveProgress(user, ref, *req.Progress); err != nil {
+			logger.Error("failed to save reading progress", zap.Error(err))
+			return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+	}
+	if req.Read != nil {
+		if state, err = h.readingDBService.SetRead(user, ref, *req.Read); err != nil {
+			logger.Error("failed to set read state", zap.Error(err))
+			return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+	}
+	logger.Info("Update reading state successfully",
+		zap.String("platform", ref.Platform), zap.String("kind", ref.Kind), zap.String("content_id", ref.ID),
+		zap.Float64("progress", state.Progress), zap.Bool("read", state.Read))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", buildReading(*state)))
+}
+
+func buildReading(s readingDB.ReadState) *Reading {
+	r := &Reading{
+		Read:       s.Read,
+		Progress:   s.Progress,
+		LastReadAt: s.LastReadAt.Format(time.RFC3339),
+	}
+	if s.ReadAt != nil {
+		r.ReadAt = s.ReadAt.Format(time.RFC3339)
+	}
+	return r
+}
+
+// attachReading 为同一平台、同一类型的一组 Topic 填入当前用户的阅读状态。
+func attachReading(rd readingDB.DB, user, platform, kind string, topics []Topic) error {
+	ids := make([]string, 0, len(topics))
+	for _, t := range topics {
+		ids = append(ids, t.ID)
+	}
+	states, err := rd.GetReadStates(user, platform, kind, ids)
+	if err != nil {
+		return err
+	}
+	for i := range topics {
+		if s, ok := states[topics[i].ID]; ok {
+			topics[i].Reading = buildReading(s)
+		}
+	}
+	return nil
+}
+
+// readingScopes 把 ArchiveRequest 的未读过滤与按最近阅读排序翻译为查询 scope，
+// idColumn 为内容表的 id 列，timeColumn 为最近阅读时间相同时的次级排序列。
+func readingScopes(req *ArchiveRequest, user, platform, kind, idColumn, timeColumn string) (fetch, count []func(*gorm.DB) *gorm.DB) {
+	if req.UnreadOnly {
+		unread := readingDB.Unread(user, platform, kind, idColumn)
+		fetch, count = append(fetch, unread), append(count, unread)
+	}
+	if req.OrderBy == ArchiveOrderByLastRead {
+		then := timeColumn + " desc"
+		if req.Order != 0 {
+			then = timeColumn + " asc"
+		}
+		fetch = append(fetch, readingDB.OrderByLastRead(user, platform, kind, idColumn, req.Order == 0, then))
+	}
+	return fetch, count
+}
+
+// touchReading 记录打开了一条归档内容；无法识别的链接与写入失败都只记日志，不影响阅读。
+func (h *Controller) touchReading(logger *zap.Logger, user, link string) {
+	ref, ok := resolveBookmarkURL(link)
+	if !ok {
+		return
+	}
+	if err := h.readingDBService.Touch(user, ref); err != nil {
+		logger.Error("failed to record reading", zap.String("link", link), zap.Error(err))
+	}
+}
@@ -0,0 +1,53 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+	readingDB "github.com/eli-yip/rss-zero/pkg/reading/db"
+)
+
+type fakeReadingDB struct {
+	readingDB.DB
+	states map[string]readingDB.ReadState
+}
+
+func (f *fakeReadingDB) GetReadStates(_, _, _ string, ids []string) (map[string]readingDB.ReadState, error) {
+	result := make(map[string]readingDB.ReadState)
+	for _, id := range ids {
+		if s, ok := f.states[id]; ok {
+			result[id] = s
+		}
+	}
+	return result, nil
+}
+
+func TestAttachReading(t *testing.T) {
+	lastRead := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	rd := &fakeReadingDB{states: map[string]readingDB.ReadState{
+		"1": {ContentID: "1", Read: true, Progress: 1, ReadAt: &lastRead, LastReadAt: lastRead},
+		"2": {ContentID: "2", Progress: 0.3, LastReadAt: lastRead},
+	}}
+	topics := []Topic{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	require.NoError(t, attachReading(rd, "jason", bookmarkDB.PlatformZhihu, "answer", topics))
+	assert.Equal(t, &Reading{Read: true, Progress: 1, ReadAt: "2026-10-01T08:00:00Z", LastReadAt: "2026-10-01T08:00:00Z"}, topics[0].Reading)
+	assert.Equal(t, &Reading{Progress: 0.3, LastReadAt: "2026-10-01T08:00:00Z"}, topics[1].Reading)
+	assert.Nil(t, topics[2].Reading)
+}
+
+func TestReadingScopes(t *testing.T) {
+	fetch, count := readingScopes(&ArchiveRequest{}, "jason", bookmarkDB.PlatformZhihu, "answer", "zhihu_answer.id", "create_at")
+	assert.Empty(t, fetch)
+	assert.Empty(t, count)
+
+	fetch, count = readingScopes(&ArchiveRequest{UnreadOnly: true, OrderBy: ArchiveOrderByLastRead},
+		"jason", bookmarkDB.PlatformZhihu, "answer", "zhihu_answer.id", "create_at")
+	assert.Len(t, fetch, 2)
+	// 排序不进入计数查询
+	assert.Len(t, count, 1)
+}
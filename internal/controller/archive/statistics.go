@@ -1,6 +1,7 @@
 package archive
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -8,35 +9,103 @@ import (
 	"github.com/eli-yip/rss-zero/config"
 	"github.com/eli-yip/rss-zero/internal/controller/common"
 	"github.com/eli-yip/rss-zero/pkg/httputil"
-	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/statistics"
 	"github.com/labstack/echo/v5"
-	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
+// defaultStatisticsAuthor 是未配置 settings.statistics_author 时旧版日历统计的知乎作者。
+const defaultStatisticsAuthor = "canglimo"
+
+// GetStatistics 统计归档内容的发布情况。
+//
+// 不带 platform 时保持旧版行为：返回知乎作者近一年回答的 日期->数量 映射，供首页日历使用。
+// 带 platform 时返回 statistics.Result，参数：
+//   - platform: zhihu/zsxq/xiaobot/tombkeeper
+//   - author: 知乎作者 id、星球 group id、小报童 paper id，tombkeeper 可省略
+//   - type: 知乎 answer/article/pin，星球 talk/q&a 等
+//   - from, to: 北京时间日期 2006-01-02，含两端，缺省为近一年
+//   - interval: day/week/month，缺省 day
+//   - top: 热门列表长度，缺省 10
+//
+// GET /api/v1/archive/statistics
 func (h *Controller) GetStatistics(c *echo.Context) (err error) {
 	logger := common.ExtractLogger(c)
 
-	logger.Info("Retrieved statistics request successfully")
+	q, err := parseStatisticsQuery(c, time.Now())
+	if err != nil {
+		logger.Error("Failed to parse statistics query", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	logger.Info("Retrieved statistics request successfully", zap.Any("query", q))
 
-	var statistics map[string]int
-	if statistics, err = calculateStatistics(PlatformZhihu, "canglimo", h.zhihuDBService); err != nil {
+	legacy := q.Platform == ""
+	if legacy {
+		q.Platform, q.Type, q.Interval = statistics.PlatformZhihu, "answer", statistics.IntervalDay
+		if q.Author == "" {
+			q.Author = statisticsAuthor()
+		}
+	}
+	if err = q.Validate(); err != nil {
+		logger.Error("Invalid statistics query", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	// 统计含热门条目的标题，付费来源同样要求授权
+	user, err := contextUser(c)
+	if err != nil {
+		return err
+	}
+	if err = h.accessFilter(user).check(q.Platform, q.Author); err != nil {
+		return accessHTTPError(logger, err)
+	}
+
+	entries, wordsAvailable, err := h.statisticsDBService.Entries(q)
+	if err != nil {
 		logger.Error("Failed to calculate statistics", zap.Error(err))
+		if errors.Is(err, statistics.ErrInvalidQuery) {
+			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to calculate statistics")
 	}
+	result := statistics.Compute(q, entries, wordsAvailable, config.C.BJT)
+	logger.Info("Calculate statistics successfully", zap.Int("total", result.Total))
 
-	return c.JSON(http.StatusOK, httputil.NewResp("success", statistics))
+	if legacy {
+		return c.JSON(http.StatusOK, httputil.NewResp("success", result.Daily()))
+	}
+	return c.JSON(http.StatusOK, httputil.NewResp("success", result))
 }
 
-func calculateStatistics(_, author string, d zhihuDB.DB) (map[string]int, error) {
-	answers, err := d.GetAnswerAfter(author, getLastYearDate())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get answers: %w", err)
+// parseStatisticsQuery 解析查询参数，不做平台相关的校验。to 是含当天的日期，转为次日零点作为开区间终点。
+func parseStatisticsQuery(c *echo.Context, now time.Time) (q statistics.Query, err error) {
+	q.Platform = c.QueryParam("platform")
+	q.Author = c.QueryParam("author")
+	q.Type = c.QueryParam("type")
+	q.Interval = c.QueryParam("interval")
+	if q.Top, err = echo.QueryParamOr[int](c, "top", statistics.DefaultTop); err != nil {
+		return q, fmt.Errorf("invalid top: %w", err)
 	}
 
-	return lo.CountValuesBy(answers, func(a zhihuDB.Answer) string { return a.CreateAt.Format("2006-01-02") }), nil
+	today := now.In(config.C.BJT)
+	to := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, config.C.BJT)
+	if s := c.QueryParam("to"); s != "" {
+		if to, err = time.ParseInLocation(time.DateOnly, s, config.C.BJT); err != nil {
+			return q, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	from := to.AddDate(-1, 0, 0)
+	if s := c.QueryParam("from"); s != "" {
+		if from, err = time.ParseInLocation(time.DateOnly, s, config.C.BJT); err != nil {
+			return q, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	q.From, q.To = from, to.AddDate(0, 0, 1)
+	return q, nil
 }
 
-func getLastYearDate() time.Time {
-	return time.Date(time.Now().Year()-1, time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, config.C.BJT)
+func statisticsAuthor() string {
+	if author := config.C.Settings.StatisticsAuthor; author != "" {
+		return author
+	}
+	return defaultStatisticsAuthor
 }
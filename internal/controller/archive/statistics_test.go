@@ -0,0 +1,42 @@
+package archive
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/pkg/statistics"
+)
+
+func TestParseStatisticsQuery(t *testing.T) {
+	e := echo.New()
+	now := time.Date(2024, time.March, 10, 23, 30, 0, 0, time.UTC) // BJT 3 月 11 日
+
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	q, err := parseStatisticsQuery(c, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, time.March, 11, 0, 0, 0, 0, config.C.BJT), q.From)
+	assert.Equal(t, time.Date(2024, time.March, 12, 0, 0, 0, 0, config.C.BJT), q.To)
+	assert.Equal(t, statistics.DefaultTop, q.Top)
+
+	c = e.NewContext(httptest.NewRequest(http.MethodGet, "/?platform=zsxq&author=42&type=q%26a&from=2024-01-01&to=2024-01-31&interval=week&top=3", nil), httptest.NewRecorder())
+	q, err = parseStatisticsQuery(c, now)
+	require.NoError(t, err)
+	assert.Equal(t, statistics.Query{
+		Platform: "zsxq", Author: "42", Type: "q&a", Interval: "week", Top: 3,
+		From: time.Date(2024, time.January, 1, 0, 0, 0, 0, config.C.BJT),
+		To:   time.Date(2024, time.February, 1, 0, 0, 0, 0, config.C.BJT),
+	}, q)
+
+	for _, query := range []string{"/?from=2024-1-1", "/?to=yesterday", "/?top=many"} {
+		c = e.NewContext(httptest.NewRequest(http.MethodGet, query, nil), httptest.NewRecorder())
+		_, err = parseStatisticsQuery(c, now)
+		assert.Error(t, err, query)
+	}
+}
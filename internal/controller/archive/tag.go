@@ -0,0 +1,242 @@
+package archive
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+)
+
+// TagInfo 是标签列表中的一项。Count 只计直接打上该标签的收藏，不含子标签；
+// 仅作为层级祖先存在的标签 Count 为 0。
+type TagInfo struct {
+	Name        string `json:"name"`
+	Parent      string `json:"parent"`
+	Count       int    `json:"count"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type PatchTagRequest struct {
+	Name        string  `json:"name"`
+	Color       *string `json:"color"`
+	Description *string `json:"description"`
+}
+
+type RenameTagRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type MergeTagRequest struct {
+	From []string `json:"from"`
+	To   string   `json:"to"`
+}
+
+type DeleteTagRequest struct {
+	Names []string `json:"names"`
+}
+
+// normalizeTagNames 规整并去重一组标签名，任一非法即返回错误。
+func normalizeTagNames(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		n, err := bookmarkDB.NormalizeTagName(name)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, n)
+	}
+	return lo.Uniq(normalized), nil
+}
+
+// tagHTTPError 把标签层错误映射为 HTTP 状态码。
+func tagHTTPError(err error) error {
+	switch {
+	case errors.Is(err, bookmarkDB.ErrNoTag):
+		return httputil.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, bookmarkDB.ErrTagExists):
+		return httputil.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, bookmarkDB.ErrInvalidTag):
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+}
+
+// mergeTagInfos 以收藏计数的顺序（多者在前）输出，其后补上没有直接收藏的祖先标签。
+func mergeTagInfos(counts []bookmarkDB.TagCount, userTags []bookmarkDB.UserTag) []TagInfo {
+	byName := lo.KeyBy(userTags, func(t bookmarkDB.UserTag) string { return t.Name })
+	infos := make([]TagInfo, 0, len(userTags))
+	seen := make(map[string]struct{}, len(counts))
+	for _, c := range counts {
+		info := TagInfo{Name: c.Name, Parent: bookmarkDB.TagParent(c.Name), Count: c.Count}
+		if t, ok := byName[c.Name]; ok {
+			info.Color, info.Description = t.Color, t.Description
+		}
+		infos = append(infos, info)
+		seen[c.Name] = struct{}{}
+	}
+	for _, t := range userTags {
+		if _, ok := seen[t.Name]; !ok {
+			infos = append(infos, TagInfo{Name: t.Name, Parent: t.Parent, Color: t.Color, Description: t.Description})
+		}
+	}
+	return infos
+}
+
+func (h *Controller) GetAllTags(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := contextUsername(c)
+	if err != nil {
+		return err
+	}
+
+	tagCounts, err := h.bookmarkDBService.GetTagCountByUser(user)
+	if err != nil {
+		logger.Error("failed to get tag counts", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	userTags, err := h.bookmarkDBService.GetUserTags(user)
+	if err != nil {
+		logger.Error("failed to get user tags", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	logger.Info("Get tag counts successfully", zap.Int("count", len(tagCounts)))
+
+	response := struct {
+		Tags []TagInfo `json:"tags"`
+	}{
+		Tags: mergeTagInfos(tagCounts, userTags),
+	}
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", response))
+}
+
+// PatchTag 修改标签颜色与描述，未提供的字段保持不变。
+func (h *Controller) PatchTag(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := contextUsername(c)
+	if err != nil {
+		return err
+	}
+
+	var req PatchTagRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("failed to bind request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	name, err := bookmarkDB.NormalizeTagName(req.Name)
+	if err != nil {
+		return tagHTTPError(err)
+	}
+
+	tag, err := h.bookmarkDBService.UpdateUserTag(user, name, req.Color, req.Description)
+	if err != nil {
+		logger.Error("failed to update tag", zap.String("tag", name), zap.Error(err))
+		return tagHTTPError(err)
+	}
+	logger.Info("Update tag successfully", zap.String("tag", name))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", tag))
+}
+
+// RenameTag 在用户全部收藏上把标签（连同子标签）改名，目标已存在时返回 409，应改用合并。
+func (h *Controller) RenameTag(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := contextUsername(c)
+	if err != nil {
+		return err
+	}
+
+	var req RenameTagRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("failed to bind request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	names, err := normalizeTagNames([]string{req.From, req.To})
+	if err != nil {
+		return tagHTTPError(err)
+	}
+	if len(names) == 1 {
+		return httputil.NewHTTPError(http.StatusBadRequest, "from and to are the same tag")
+	}
+
+	if err = h.bookmarkDBService.RenameTag(user, names[0], names[1]); err != nil {
+		logger.Error("failed to rename tag", zap.Strings("tags", names), zap.Error(err))
+		return tagHTTPError(err)
+	}
+	logger.Info("Rename tag successfully", zap.String("from", names[0]), zap.String("to", names[1]))
+
+	return c.JSON(http.StatusOK, httputil.NewMessage("success"))
+}
+
+// MergeTags 把若干标签（连同子标签）并入目标标签，目标不存在时自动创建。
+func (h *Controller) MergeTags(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := contextUsername(c)
+	if err != nil {
+		return err
+	}
+
+	var req MergeTagRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("failed to bind request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if len(req.From) == 0 {
+		return httputil.NewHTTPError(http.StatusBadRequest, "from is empty")
+	}
+	from, err := normalizeTagNames(req.From)
+	if err != nil {
+		return tagHTTPError(err)
+	}
+	to, err := bookmarkDB.NormalizeTagName(req.To)
+	if err != nil {
+		return tagHTTPError(err)
+	}
+
+	if err = h.bookmarkDBService.MergeTags(user, from, to); err != nil {
+		logger.Error("failed to merge tags", zap.Strings("from", from), zap.String("to", to), zap.Error(err))
+		return tagHTTPError(err)
+	}
+	logger.Info("Merge tags successfully", zap.Strings("from", from), zap.String("to", to))
+
+	return c.JSON(http.StatusOK, httputil.NewMessage("success"))
+}
+
+// DeleteTags 从用户全部收藏上移除标签（连同子标签），收藏本身保留。
+func (h *Controller) DeleteTags(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := contextUsername(c)
+	if err != nil {
+		return err
+	}
+
+	var req DeleteTagRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("failed to bind request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if len(req.Names) == 0 {
+		return httputil.NewHTTPError(http.StatusBadRequest, "names is empty")
+	}
+	names, err := normalizeTagNames(req.Names)
+	if err != nil {
+		return tagHTTPError(err)
+	}
+
+	if err = h.bookmarkDBService.DeleteTags(user, names); err != nil {
+		logger.Error("failed to delete tags", zap.Strings("tags", names), zap.Error(err))
+		return tagHTTPError(err)
+	}
+	logger.Info("Delete tags successfully", zap.Strings("tags", names))
+
+	return c.JSON(http.StatusOK, httputil.NewMessage("success"))
+}
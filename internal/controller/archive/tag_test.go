@@ -0,0 +1,51 @@
+package archive
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+)
+
+func TestNormalizeTagNames(t *testing.T) {
+	got, err := normalizeTagNames([]string{" 投资 / 港股", "投资/港股", "读书"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"投资/港股", "读书"}, got)
+
+	_, err = normalizeTagNames([]string{"ok", "a//b"})
+	assert.ErrorIs(t, err, bookmarkDB.ErrInvalidTag)
+}
+
+func TestMergeTagInfos(t *testing.T) {
+	counts := []bookmarkDB.TagCount{{Name: "投资/港股", Count: 3}, {Name: "读书", Count: 1}}
+	userTags := []bookmarkDB.UserTag{
+		{Name: "投资", Color: "#ff0000"},
+		{Name: "投资/港股", Parent: "投资", Description: "港股通"},
+		{Name: "读书"},
+	}
+
+	assert.Equal(t, []TagInfo{
+		{Name: "投资/港股", Parent: "投资", Count: 3, Description: "港股通"},
+		{Name: "读书", Count: 1},
+		{Name: "投资", Color: "#ff0000"},
+	}, mergeTagInfos(counts, userTags))
+}
+
+func TestTagHTTPError(t *testing.T) {
+	cases := map[error]int{
+		bookmarkDB.ErrNoTag:                           http.StatusNotFound,
+		bookmarkDB.ErrTagExists:                       http.StatusConflict,
+		fmt.Errorf("%w: x", bookmarkDB.ErrInvalidTag): http.StatusBadRequest,
+		fmt.Errorf("boom"):                            http.StatusInternalServerError,
+	}
+	for err, want := range cases {
+		var httpErr *httputil.ResponseError
+		require.ErrorAs(t, tagHTTPError(err), &httpErr)
+		assert.Equal(t, want, httpErr.Code, err.Error())
+	}
+}
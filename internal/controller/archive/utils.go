@@ -87,7 +87,7 @@ func buildTopicsFromAnswer(answers []zhihuDB.Answer, userID string, d zhihuDB.DB
 		}
 
 		answerID := strconv.Itoa(answer.ID)
-		bookmark, err := bd.GetBookmarkByContent(userID, pkgCommon.ZhihuAnswer, answerID)
+		bookmark, err := bd.GetBookmarkByContent(userID, bookmarkDB.ZhihuRef(pkgCommon.ZhihuAnswer, answerID))
 		var custom *Custom
 		if err != nil {
 			if !errors.Is(err, bookmarkDB.ErrNoBookmark) {
@@ -213,7 +213,7 @@ func buildTopicsFromPin(pins []zhihuDB.Pin, userID string, d zhihuDB.DB, bd book
 		}
 
 		pinID := strconv.Itoa(p.ID)
-		bookmark, err := bd.GetBookmarkByContent(userID, pkgCommon.ZhihuPin, pinID)
+		bookmark, err := bd.GetBookmarkByContent(userID, bookmarkDB.ZhihuRef(pkgCommon.ZhihuPin, pinID))
 		var custom *Custom
 		if err != nil {
 			if !errors.Is(err, bookmarkDB.ErrNoBookmark) {
@@ -0,0 +1,203 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+	pkgCommon "github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/render"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	zhihuRender "github.com/eli-yip/rss-zero/pkg/routers/zhihu/render"
+)
+
+// ZhihuListItems 分页列出专栏或收藏夹里已抓取的回答与文章，按加入时间倒序。
+// 作者取知乎随内容返回的昵称，列表里的作者多半没有单独订阅。
+//
+// GET /api/v1/archive/zhihu/:type/:id/items?page=1&count=20
+func (h *Controller) ZhihuListItems(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	slug, _ := echo.PathParam[string](c, "type")
+	t, err := pkgCommon.ParseZhihuSlug(slug)
+	if err != nil || (t != pkgCommon.ZhihuColumn && t != pkgCommon.ZhihuCollection) {
+		return httputil.NewHTTPError(http.StatusBadRequest, "type must be column or collection")
+	}
+	listID, err := echo.PathParam[string](c, "id")
+	if err != nil || listID == "" {
+		return httputil.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+	page, err := echo.QueryParamOr[int](c, "page", 1)
+	if err != nil || page < 1 {
+		return httputil.NewHTTPError(http.StatusBadRequest, "invalid page")
+	}
+	count, err := echo.QueryParamOr[int](c, "count", 20)
+	if err != nil || count < 1 || count > 100 {
+		return httputil.NewHTTPError(http.StatusBadRequest, "count must be between 1 and 100")
+	}
+
+	list, err := h.zhihuDBService.GetList(t, listID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return httputil.NewHTTPError(http.StatusNotFound, fmt.Sprintf("%s not found", t.Slug()))
+		}
+		logger.Error("Failed to get zhihu list", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to get list")
+	}
+
+	items, err := h.zhihuDBService.GetListItems(t, listID, count, count*(page-1))
+	if err != nil {
+		logger.Error("Failed to get zhihu list items", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to get list items")
+	}
+	total, err := h.zhihuDBService.CountListItem(t, listID)
+	if err != nil {
+		logger.Error("Failed to count zhihu list items", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to count list items")
+	}
+
+	username, err := contextUsername(c)
+	if err != nil {
+		return err
+	}
+	topics, err := h.buildTopicsFromListItems(items, username)
+	if err != nil {
+		logger.Error("Failed to build topics", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to build topics")
+	}
+	logger.Info("Get zhihu list items successfully", zap.String("type", t.Slug()), zap.String("id", listID), zap.Int("count", len(topics)))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", ZhihuListResponse{
+		Type:  t.Slug(),
+		ID:    listID,
+		Title: list.Title,
+		ArchiveResponse: ArchiveResponse{
+			Count:        total,
+			Paging:       Paging{Total: (total + count - 1) / count, Current: page},
+			ResponseBase: ResponseBase{Topics: topics},
+		},
+	}))
+}
+
+// buildTopicsFromListItems 按列表顺序把回答与文章装成 Topic，CreatedAt 是加入列表的时间；
+// 内容缺失（尚未入库）的项跳过。阅读状态按内容类型分别填入。
+func (h *Controller) buildTopicsFromListItems(items []zhihuDB.ListItem, username string) ([]Topic, error) {
+	answerIDs := lo.FilterMap(items, func(item zhihuDB.ListItem, _ int) (int, bool) {
+		return item.ContentID, item.ContentType == pkgCommon.ZhihuAnswer
+	})
+	articleIDs := lo.FilterMap(items, func(item zhihuDB.ListItem, _ int) (int, bool) {
+		return item.ContentID, item.ContentType == pkgCommon.ZhihuArticle
+	})
+	answers, err := h.zhihuDBService.FetchAnswerByIDs(answerIDs)
+	if err != nil {
+		return nil, err
+	}
+	articles, err := h.zhihuDBService.FetchArticleByIDs(articleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := zhihuRender.NewContentLoader(h.zhihuDBService)
+	answerSnap, err := loader.LoadAnswers(lo.Values(answers))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load answer snapshot: %w", err)
+	}
+	articleSnap, err := loader.LoadArticles(lo.Values(articles))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article snapshot: %w", err)
+	}
+
+	topics := make([]Topic, 0, len(items))
+	for _, item := range items {
+		var (
+			topic Topic
+			snap  zhihuRender.ContentSnapshot
+		)
+		switch item.ContentType {
+		case pkgCommon.ZhihuAnswer:
+			a, ok := answers[item.ContentID]
+			if !ok {
+				continue
+			}
+			link := zhihuRender.GenerateAnswerLink(a.QuestionID, a.ID)
+			topic = Topic{OriginalURL: link, Title: zhihuRender.AnswerTitle(answerSnap, a.QuestionID),
+				Author: Author{ID: a.AuthorID, Nickname: zhihuRender.RawAuthorName(a.Raw, a.AuthorID)}}
+			snap = answerSnap
+		case pkgCommon.ZhihuArticle:
+			ar, ok := articles[item.ContentID]
+			if !ok {
+				continue
+			}
+			topic = Topic{OriginalURL: zhihuRender.GenerateArticleLink(ar.ID), Title: ar.Title,
+				Author: Author{ID: ar.AuthorID, Nickname: zhihuRender.RawAuthorName(ar.Raw, ar.AuthorID)}}
+			snap = articleSnap
+		default:
+			continue
+		}
+
+		body, err := zhihuRender.RenderMarkdown(item.ContentID, snap, config.C.Settings.ServerURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s %d: %w", item.ContentType, item.ContentID, err)
+		}
+		topic.ID = strconv.Itoa(item.ContentID)
+		topic.ArchiveURL = render.BuildArchiveLink(config.C.Settings.ServerURL, topic.OriginalURL)
+		topic.Platform = PlatformZhihu
+		topic.Type = mustLegacyTopicType(item.ContentType)
+		topic.CreatedAt = item.AddedAt.Format(time.RFC3339)
+		topic.Body = body
+		if topic.Custom, err = zhihuBookmarkCustom(h.bookmarkDBService, username, item.ContentType, topic.ID); err != nil {
+			return nil, err
+		}
+		topics = append(topics, topic)
+	}
+
+	for _, t := range []pkgCommon.ZhihuContentType{pkgCommon.ZhihuAnswer, pkgCommon.ZhihuArticle} {
+		var (
+			indexes []int
+			subset  []Topic
+		)
+		for i, topic := range topics {
+			if topic.Type == mustLegacyTopicType(t) {
+				indexes, subset = append(indexes, i), append(subset, topic)
+			}
+		}
+		if err = attachReading(h.readingDBService, username, PlatformZhihu, t.Slug(), subset); err != nil {
+			return nil, fmt.Errorf("failed to attach reading state: %w", err)
+		}
+		for j, i := range indexes {
+			topics[i] = subset[j]
+		}
+	}
+
+	return topics, nil
+}
+
+// zhihuBookmarkCustom 返回当前用户对该内容的书签信息，未收藏时为 nil。
+func zhihuBookmarkCustom(bd bookmarkDB.DB, username string, t pkgCommon.ZhihuContentType, id string) (*Custom, error) {
+	bookmark, err := bd.GetBookmarkByContent(username, bookmarkDB.ZhihuRef(t, id))
+	if err != nil {
+		if errors.Is(err, bookmarkDB.ErrNoBookmark) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to check bookmark: %w", err)
+	}
+	tags, err := bd.GetTag(bookmark.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return &Custom{Bookmark: true, BookmarkID: bookmark.ID, Tags: tags, Comment: bookmark.Comment, Note: bookmark.Note}, nil
+}
@@ -0,0 +1,87 @@
+package archive
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	pkgCommon "github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/revision"
+)
+
+// ZhihuRevisions 列出知乎回答或文章的版本，并给出发现删除的时间。
+//
+// GET /api/v1/archive/zhihu/:type/:id/revisions
+func (h *Controller) ZhihuRevisions(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	t, id, err := zhihuRevisionTarget(c)
+	if err != nil {
+		return err
+	}
+
+	history, err := h.zhihuRevisionService.History(t, id)
+	if err != nil {
+		logger.Error("Failed to get zhihu revisions", zap.Error(err))
+		return zhihuRevisionHTTPError(err)
+	}
+	logger.Info("Get zhihu revisions successfully", zap.Int("versions", len(history.Versions)))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", history))
+}
+
+// ZhihuRevisionDiff 比较两个版本的正文，from/to 为版本号，缺省比较当前版本与上一版本。
+//
+// GET /api/v1/archive/zhihu/:type/:id/diff?from=1&to=2
+func (h *Controller) ZhihuRevisionDiff(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	t, id, err := zhihuRevisionTarget(c)
+	if err != nil {
+		return err
+	}
+	from, err := echo.QueryParamOr[int](c, "from", 0)
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, "invalid from")
+	}
+	to, err := echo.QueryParamOr[int](c, "to", 0)
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, "invalid to")
+	}
+
+	diff, err := h.zhihuRevisionService.Diff(t, id, from, to)
+	if err != nil {
+		logger.Error("Failed to diff zhihu revisions", zap.Error(err))
+		return zhihuRevisionHTTPError(err)
+	}
+	logger.Info("Diff zhihu revisions successfully", zap.Int("from", diff.From), zap.Int("to", diff.To))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", diff))
+}
+
+func zhihuRevisionTarget(c *echo.Context) (pkgCommon.ZhihuContentType, int, error) {
+	slug, _ := echo.PathParam[string](c, "type")
+	t, err := pkgCommon.ParseZhihuSlug(slug)
+	if err != nil || (t != pkgCommon.ZhihuAnswer && t != pkgCommon.ZhihuArticle) {
+		return "", 0, httputil.NewHTTPError(http.StatusBadRequest, "type must be answer or article")
+	}
+	id, err := echo.PathParam[int](c, "id")
+	if err != nil {
+		return "", 0, httputil.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+	return t, id, nil
+}
+
+func zhihuRevisionHTTPError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return httputil.NewHTTPError(http.StatusNotFound, "Content not found")
+	case errors.Is(err, revision.ErrInvalidVersion):
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to get revisions")
+	}
+}
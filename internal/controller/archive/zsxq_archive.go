@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/eli-yip/rss-zero/config"
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
 	"github.com/eli-yip/rss-zero/pkg/render"
 	zsxqRender "github.com/eli-yip/rss-zero/pkg/routers/zsxq/render"
 )
@@ -31,8 +32,12 @@ func (h *Controller) HandleZsxqWebTopic(link string) (result *archiveResult, err
 		return nil, fmt.Errorf("failed to get topic by id: %w", err)
 	}
 
+	groupID := strconv.Itoa(topic.GroupID)
 	if isOldStyleZsxqLink(link) {
-		return &archiveResult{redirectTo: render.BuildArchiveLink(config.C.Settings.ServerURL, zsxqRender.BuildLink(topic.GroupID, topic.ID))}, nil
+		return &archiveResult{
+			redirectTo: render.BuildArchiveLink(config.C.Settings.ServerURL, zsxqRender.BuildLink(topic.GroupID, topic.ID)),
+			platform:   bookmarkDB.PlatformZsxq, targetID: groupID,
+		}, nil
 	}
 
 	fullTextMd, err := h.zsxqFullTextRenderService.FullText(topic)
@@ -40,7 +45,7 @@ func (h *Controller) HandleZsxqWebTopic(link string) (result *archiveResult, err
 		return nil, fmt.Errorf("failed to render full text: %w", err)
 	}
 
-	return &archiveResult{title: zsxqRender.BuildTitle(topic), markdown: fullTextMd}, nil
+	return &archiveResult{title: zsxqRender.BuildTitle(topic), markdown: fullTextMd, platform: bookmarkDB.PlatformZsxq, targetID: groupID}, nil
 }
 
 func isOldStyleZsxqLink(link string) bool {
@@ -0,0 +1,16 @@
+package common
+
+import (
+	"github.com/labstack/echo/v5"
+
+	apitokenDB "github.com/eli-yip/rss-zero/pkg/apitoken/db"
+)
+
+// ExtractAPIToken 返回 middleware.Authenticate 认证过的 API token，请求走反代认证时返回 nil。
+func ExtractAPIToken(c *echo.Context) *apitokenDB.Token {
+	token, err := echo.ContextGet[*apitokenDB.Token](c, "api_token")
+	if err != nil {
+		return nil
+	}
+	return token
+}
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+)
+
+// ReadUploadFile 读取上传的文件：multipart 请求取 file 字段，否则直接读请求体。超过 limit 字节时报错。
+func ReadUploadFile(c *echo.Context, limit int64) ([]byte, error) {
+	var r io.Reader = c.Request().Body
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		fh, err := c.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("missing file field: %w", err)
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("upload file exceeds %d bytes", limit)
+	}
+	return data, nil
+}
@@ -0,0 +1,36 @@
+package digest
+
+import (
+	"github.com/labstack/echo/v5"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/internal/digest"
+	"github.com/eli-yip/rss-zero/internal/redis"
+	"github.com/eli-yip/rss-zero/internal/rss"
+)
+
+type Controller struct {
+	redis redis.Redis
+	db    digest.DB
+}
+
+func NewController(redisService redis.Redis, db digest.DB) *Controller {
+	return &Controller{redis: redisService, db: db}
+}
+
+// RSS serves the AI daily digest feed through the unified pipeline. The digest
+// cron warms the items cache after each run; on a miss digest.BuildFeed rebuilds
+// it from the saved digests.
+func (h *Controller) RSS(c *echo.Context) error {
+	logger := common.ExtractLogger(c)
+
+	return rss.Serve(c, rss.ServeOptions{
+		Redis:  h.redis,
+		Logger: logger,
+		Key:    redis.DigestRSSPath,
+		TTL:    redis.RSSDefaultTTL,
+		Fetch: func() (rss.FeedMeta, []rss.Item, error) {
+			return digest.BuildFeed(h.db)
+		},
+	})
+}
@@ -18,7 +18,7 @@ import (
 	githubDB "github.com/eli-yip/rss-zero/pkg/routers/github/db"
 )
 
-// RSS serves a github release feed through the unified pipeline. checkRepo (with
+// RSS serves a github release feed through the unified pipeline. CheckRepo (with
 // the /rss/github/pre prefix selecting pre-releases) resolves/creates the
 // subscription before the generic Serve fetches and renders.
 func (h *Controller) RSS(c *echo.Context) (err error) {
@@ -37,7 +37,7 @@ func (h *Controller) RSS(c *echo.Context) (err error) {
 
 	pre := strings.HasPrefix(c.Request().URL.Path, "/rss/github/pre")
 
-	subID, err := h.checkRepo(user, repo, pre)
+	subID, err := h.CheckRepo(user, repo, pre)
 	if err != nil {
 		if errors.Is(err, ErrRepoNotFound) {
 			logger.Error("Error return rss", zap.String("user", user), zap.String("repo", repo), zap.Error(err))
@@ -62,7 +62,7 @@ func (h *Controller) RSS(c *echo.Context) (err error) {
 
 var ErrRepoNotFound = errors.New("repo not found")
 
-func (h *Controller) checkRepo(user, repoName string, pre bool) (subID string, err error) {
+func (h *Controller) CheckRepo(user, repoName string, pre bool) (subID string, err error) {
 	var repoID string
 
 	var repo *githubDB.Repo
@@ -132,35 +132,3 @@ func filterSubs(subs []db.Sub, config filterConfig) ([]db.Sub, error) {
 
 	return filteredSubs, nil
 }
-
-func (h *Controller) ActivateSub(c *echo.Context) (err error) {
-	logger := common.ExtractLogger(c)
-
-	id, err := echo.PathParam[string](c, "id")
-	if err != nil {
-		return httputil.NewHTTPError(http.StatusBadRequest, "missing subscription ID")
-	}
-	logger.Info("Start to activate github sub", zap.String("id", id))
-
-	if err = h.db.ActivateSub(id); err != nil {
-		logger.Error("Failed to activate github sub", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to activate github sub")
-	}
-	return c.JSON(http.StatusOK, httputil.NewMessage("Success"))
-}
-
-func (h *Controller) DeleteSub(c *echo.Context) (err error) {
-	logger := common.ExtractLogger(c)
-
-	id, err := echo.PathParam[string](c, "id")
-	if err != nil {
-		return httputil.NewHTTPError(http.StatusBadRequest, "missing subscription ID")
-	}
-	logger.Info("Start to delete github sub", zap.String("id", id))
-
-	if err = h.db.DeleteSub(id); err != nil {
-		logger.Error("Failed to delete github sub", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to delete github sub")
-	}
-	return c.JSON(http.StatusOK, httputil.NewMessage("Success"))
-}
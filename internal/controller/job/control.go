@@ -0,0 +1,136 @@
+package job
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/pkg/cron"
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+)
+
+// jobFromPath 读取路径中的 :id 并查出对应的运行，找不到时返回 404。
+func (h *Controller) jobFromPath(c *echo.Context, logger *zap.Logger) (*cronDB.CronJob, error) {
+	jobID, err := echo.PathParam[string](c, "id")
+	if err != nil || jobID == "" {
+		return nil, httputil.NewHTTPError(http.StatusBadRequest, "empty job ID")
+	}
+	job, err := h.cronDBService.GetJob(jobID)
+	if err != nil {
+		if errors.Is(err, cronDB.ErrJobNotFound) {
+			return nil, httputil.NewHTTPError(http.StatusNotFound, "job not found")
+		}
+		logger.Error("Failed to get job", zap.Error(err), zap.String("job_id", jobID))
+		return nil, httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return job, nil
+}
+
+// CancelJob 取消运行中或已暂停的任务。运行中的抓取会在当前目标结束后停下，状态记为 stopped。
+func (h *Controller) CancelJob(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	job, err := h.jobFromPath(c, logger)
+	if err != nil {
+		return err
+	}
+	if job.Status != cronDB.StatusRunning && job.Status != cronDB.StatusPaused {
+		return httputil.NewHTTPError(http.StatusConflict, "job is not running or paused")
+	}
+
+	if job.Status == cronDB.StatusRunning && cron.Stop(job.ID, cron.ErrCanceled) {
+		logger.Info("Cancel running job", zap.String("job_id", job.ID))
+		return c.JSON(http.StatusAccepted, httputil.NewMessage("job canceling"))
+	}
+
+	// 别的实例还持有租约，它的抓取仍在跑，这里改状态会和它冲突
+	if job.Owner != cronDB.InstanceID && job.LeaseHeld(time.Now()) {
+		return httputil.NewHTTPError(http.StatusConflict, "job is running on instance "+job.Owner)
+	}
+
+	// 已暂停，或租约已过期的 running 记录：没有抓取在跑，直接改状态
+	if err = h.cronDBService.UpdateStatus(job.ID, cronDB.StatusStopped); err != nil {
+		logger.Error("Failed to stop job", zap.Error(err), zap.String("job_id", job.ID))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	logger.Info("Mark job as stopped", zap.String("job_id", job.ID))
+	return c.JSON(http.StatusOK, httputil.NewMessage("job canceled"))
+}
+
+// PauseJob 暂停运行中的任务，detail 保留已抓到的位置，之后可用 /job/:id/resume 续跑。
+// 仅支持可续跑的来源。
+func (h *Controller) PauseJob(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	job, err := h.jobFromPath(c, logger)
+	if err != nil {
+		return err
+	}
+	if job.Status != cronDB.StatusRunning {
+		return httputil.NewHTTPError(http.StatusConflict, "job is not running")
+	}
+	if _, err = h.resumableSpec(job); err != nil {
+		return err
+	}
+
+	if !cron.Stop(job.ID, cron.ErrPaused) {
+		return httputil.NewHTTPError(http.StatusConflict, "job is not running on this instance, owner: "+job.Owner)
+	}
+	logger.Info("Pause running job", zap.String("job_id", job.ID))
+	return c.JSON(http.StatusAccepted, httputil.NewMessage("job pausing"))
+}
+
+// ResumeJob 从 detail 记录的位置续跑已暂停的任务。
+func (h *Controller) ResumeJob(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	job, err := h.jobFromPath(c, logger)
+	if err != nil {
+		return err
+	}
+	if job.Status != cronDB.StatusPaused {
+		return httputil.NewHTTPError(http.StatusConflict, "job is not paused")
+	}
+	definition, err := h.resumableSpec(job)
+	if err != nil {
+		return err
+	}
+
+	runningJobID, err := h.cronDBService.CheckRunningJob(job.TaskType)
+	if err != nil {
+		logger.Error("Failed to check running job", zap.Error(err), zap.String("task_id", job.TaskType))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if runningJobID != "" {
+		return httputil.NewHTTPError(http.StatusConflict, "another job of this task is running: "+runningJobID)
+	}
+
+	spec, _ := SpecByKind(definition.Kind)
+	crawlFunc := spec.Build(h.buildDeps(), definition, &ResumeInfo{JobID: job.ID, LastCrawled: job.Detail})
+	// 续跑不会往 channel 里回报，给个缓冲避免万一阻塞
+	go crawlFunc(cronDB.TriggerResume, make(chan cron.CronJobInfo, 1))
+	logger.Info("Resume paused job", zap.String("job_id", job.ID), zap.String("last_crawled", job.Detail))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("job resumed", job))
+}
+
+// resumableSpec 返回任务定义，来源不支持续跑时返回 400。
+func (h *Controller) resumableSpec(job *cronDB.CronJob) (*cronDB.CronTask, error) {
+	definition, err := h.cronDBService.GetDefinition(job.TaskType)
+	if err != nil {
+		if errors.Is(err, cronDB.ErrDefinitionNotFound) {
+			return nil, httputil.NewHTTPError(http.StatusBadRequest, "task definition not found")
+		}
+		return nil, httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	spec, ok := SpecByKind(definition.Kind)
+	if !ok || !spec.Resumable {
+		return nil, httputil.NewHTTPError(http.StatusBadRequest, "task type does not support pause/resume")
+	}
+	return definition, nil
+}
@@ -5,6 +5,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/eli-yip/rss-zero/internal/ai"
+	"github.com/eli-yip/rss-zero/internal/file"
 	notify "github.com/eli-yip/rss-zero/internal/notify"
 	"github.com/eli-yip/rss-zero/internal/redis"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
@@ -19,21 +20,22 @@ type Controller struct {
 	db            *gorm.DB
 	ai            ai.AI
 	notifier      notify.Notifier
+	fileService   file.File
 	cronDBService cronDB.DB
 	jobIndex      *JobIndex
 	logger        *zap.Logger
 }
 
-func NewController(cronService *cron.CronService, jobIndex *JobIndex, redisService redis.Redis, cs cookie.CookieIface, db *gorm.DB, ai ai.AI, notifier notify.Notifier, cronDBService cronDB.DB, logger *zap.Logger) *Controller {
+func NewController(cronService *cron.CronService, jobIndex *JobIndex, redisService redis.Redis, cs cookie.CookieIface, db *gorm.DB, ai ai.AI, notifier notify.Notifier, fileService file.File, cronDBService cronDB.DB, logger *zap.Logger) *Controller {
 	return &Controller{cronService: cronService,
-		redisService: redisService, cookie: cs, db: db, ai: ai, notifier: notifier,
+		redisService: redisService, cookie: cs, db: db, ai: ai, notifier: notifier, fileService: fileService,
 		cronDBService: cronDBService, jobIndex: jobIndex, logger: logger}
 }
 
 // buildDeps packs the controller's held dependencies into a BuildDeps so any
-// source's Build closure can reconstruct its crawlFunc on demand.
+// source's or static job's Build closure can reconstruct its func on demand.
 func (h *Controller) buildDeps() BuildDeps {
-	return BuildDeps{Redis: h.redisService, Cookie: h.cookie, DB: h.db, AI: h.ai, Notifier: h.notifier}
+	return BuildDeps{Redis: h.redisService, Cookie: h.cookie, DB: h.db, AI: h.ai, Notifier: h.notifier, File: h.fileService, Logger: h.logger}
 }
 
-type CrawlFunc func(chan cron.CronJobInfo)
+type CrawlFunc = cron.CrawlFunc
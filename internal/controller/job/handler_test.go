@@ -70,6 +70,18 @@ func (f *fakeCronDB) PatchDefinition(id string, cronExpr *string, include, exclu
 	return nil
 }
 
+func (f *fakeCronDB) SaveDefinition(task *cronDB.CronTask) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if task.ID == "" {
+		f.seq++
+		task.ID = fmt.Sprintf("task-%d", f.seq)
+	}
+	cp := *task
+	f.tasks[task.ID] = &cp
+	return task.ID, nil
+}
+
 func (f *fakeCronDB) DeleteDefinition(id string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -100,7 +112,7 @@ func (f *fakeCronDB) GetDefinitions() ([]*cronDB.CronTask, error) {
 }
 
 // CronJobIface 不在这些 handler 测试中执行，返回固定结果即可。
-func (f *fakeCronDB) AddJob(jobID, taskType string) (*cronDB.CronJob, error) {
+func (f *fakeCronDB) AddJob(jobID, taskType, trigger string) (*cronDB.CronJob, error) {
 	return &cronDB.CronJob{ID: jobID}, nil
 }
 func (f *fakeCronDB) StopJob(string) error                       { return nil }
@@ -109,13 +121,30 @@ func (f *fakeCronDB) FindRunningJob() ([]*cronDB.CronJob, error) { return nil, n
 func (f *fakeCronDB) FindErrorJob() ([]*cronDB.CronJob, error)   { return nil, nil }
 func (f *fakeCronDB) UpdateStatus(string, int) error             { return nil }
 func (f *fakeCronDB) RecordDetail(string, string) error          { return nil }
+func (f *fakeCronDB) ResumeJob(string) error                     { return nil }
+func (f *fakeCronDB) FinishJob(string, int, error, int) error    { return nil }
+func (f *fakeCronDB) MarkRetry(string, string, int) error        { return nil }
+func (f *fakeCronDB) GetJob(string) (*cronDB.CronJob, error)     { return nil, cronDB.ErrJobNotFound }
+func (f *fakeCronDB) FindJobs(string, int) ([]*cronDB.CronJob, error) {
+	return nil, nil
+}
+func (f *fakeCronDB) RecordTarget(*cronDB.CronJobTarget) error { return nil }
+func (f *fakeCronDB) GetTargets(...string) ([]*cronDB.CronJobTarget, error) {
+	return nil, nil
+}
+func (f *fakeCronDB) SaveLogs([]*cronDB.CronJobLog) error   { return nil }
+func (f *fakeCronDB) RenewLease(string, string) error       { return nil }
+func (f *fakeCronDB) ClaimJob(string, string) (bool, error) { return true, nil }
+func (f *fakeCronDB) GetLogs(string, ...string) ([]*cronDB.CronJobLog, error) {
+	return nil, nil
+}
 
 // withNoopRegistry 用不访问外部依赖的抓取闭包替换注册表。
 func withNoopRegistry(t *testing.T) {
 	orig := registry
 	t.Cleanup(func() { registry = orig })
 	noop := func(BuildDeps, *cronDB.CronTask, *ResumeInfo) CrawlFunc {
-		return func(ch chan cron.CronJobInfo) { ch <- cron.CronJobInfo{Job: &cronDB.CronJob{ID: "noop"}} }
+		return func(_ string, ch chan cron.CronJobInfo) { ch <- cron.CronJobInfo{Job: &cronDB.CronJob{ID: "noop"}} }
 	}
 	registry = []SourceSpec{
 		{Kind: "zsxq", Resumable: true, Build: noop},
@@ -144,7 +173,7 @@ func newHarness(t *testing.T, fake *fakeCronDB) *harness {
 
 // newTestController 的来源依赖可为空，因为 no-op registry 不会访问它们。
 func newTestController(cs *cron.CronService, index *JobIndex, fake cronDB.DB) *Controller {
-	return NewController(cs, index, nil, nil, nil, nil, nil, fake, zap.NewNop())
+	return NewController(cs, index, nil, nil, nil, nil, nil, nil, fake, zap.NewNop())
 }
 
 func (th *harness) ctx(method, body string) (*echo.Context, *httptest.ResponseRecorder) {
@@ -207,7 +236,7 @@ func TestConcurrentTaskMutationsUseJobIndexSafely(t *testing.T) {
 
 	patchID, err := th.fake.AddDefinition("zsxq", "0 0 * * *", nil, nil)
 	require.NoError(t, err)
-	jobID, err := th.cs.AddCrawlJob("zsxq_crawl", "0 0 * * *", func(chan cron.CronJobInfo) {})
+	jobID, err := th.cs.AddCrawlJob("zsxq_crawl", "0 0 * * *", func(string, chan cron.CronJobInfo) {})
 	require.NoError(t, err)
 	th.index.Set(patchID, jobID)
 
@@ -351,3 +380,37 @@ func TestListTaskReturnsKindsForAllDefinitions(t *testing.T) {
 	}
 	require.Equal(t, want, got)
 }
+
+// TestStaticTaskPatch 验证静态任务只能修改：改参数会重新调度，停用会移出调度器，不能新增或删除。
+func TestStaticTaskPatch(t *testing.T) {
+	th := newHarness(t, newFakeCronDB())
+	spec, ok := StaticSpecByKind("canglimo_random_select")
+	require.True(t, ok)
+	id, err := th.fake.SaveDefinition(spec.Definition())
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusBadRequest, th.add(`{"task_type":"canglimo_random_select","cron_expr":"0 0 * * *"}`).Code)
+	require.Equal(t, http.StatusBadRequest, th.patch(fmt.Sprintf(`{"id":%q,"params":{"count":"x"}}`, id)).Code)
+	def, err := th.fake.GetDefinition(id)
+	require.NoError(t, err)
+	require.Equal(t, "1", def.Params["count"], "invalid params must not be saved")
+
+	rec := th.patch(fmt.Sprintf(`{"id":%q,"max_delay_seconds":30,"params":{"count":"3"}}`, id))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var patched httputil.Resp[TaskInfo]
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &patched))
+	assert.True(t, patched.Data.Static)
+	assert.True(t, patched.Data.Enabled)
+	assert.Equal(t, 30, patched.Data.MaxDelaySeconds)
+	assert.Equal(t, "3", patched.Data.Params["count"])
+	jobID, ok := th.index.Get(id)
+	require.True(t, ok)
+	require.NotEmpty(t, jobID)
+
+	require.Equal(t, http.StatusOK, th.patch(fmt.Sprintf(`{"id":%q,"enabled":false}`, id)).Code)
+	_, ok = th.index.Get(id)
+	assert.False(t, ok)
+	assert.Error(t, th.cs.RemoveCrawlJob(jobID), "disabling should have removed the scheduler job")
+
+	assert.Equal(t, http.StatusBadRequest, th.delete(id).Code)
+}
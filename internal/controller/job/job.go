@@ -38,7 +38,7 @@ func (h *Controller) StartJob(c *echo.Context) (err error) {
 	logger.Info("Built crawl function successfully")
 
 	cronJobChanInfo := make(chan cron.CronJobInfo)
-	go crawlFunc(cronJobChanInfo)
+	go crawlFunc(cronDB.TriggerManual, cronJobChanInfo)
 	logger.Info("Start waiting for job info")
 	select {
 	case cronJobInfo := <-cronJobChanInfo:
@@ -75,8 +75,13 @@ func (h *Controller) GetJobs(c *echo.Context) (err error) {
 		logger.Error("Failed to find running jobs", zap.Error(err))
 		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	infos, err := h.runInfos(jobs)
+	if err != nil {
+		logger.Error("Failed to get job targets", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
 
-	return c.JSON(http.StatusOK, httputil.NewResp("success", jobs))
+	return c.JSON(http.StatusOK, httputil.NewResp("success", infos))
 }
 
 func (h *Controller) GetErrorJobs(c *echo.Context) (err error) {
@@ -87,6 +92,11 @@ func (h *Controller) GetErrorJobs(c *echo.Context) (err error) {
 		logger.Error("Failed to find error jobs", zap.Error(err))
 		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	infos, err := h.runInfos(jobs)
+	if err != nil {
+		logger.Error("Failed to get job targets", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
 
-	return c.JSON(http.StatusOK, httputil.NewResp("success", jobs))
+	return c.JSON(http.StatusOK, httputil.NewResp("success", infos))
 }
@@ -2,10 +2,13 @@ package job
 
 import (
 	"fmt"
+	"time"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/eli-yip/rss-zero/internal/ai"
+	"github.com/eli-yip/rss-zero/internal/file"
 	notify "github.com/eli-yip/rss-zero/internal/notify"
 	"github.com/eli-yip/rss-zero/internal/redis"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
@@ -15,16 +18,19 @@ import (
 	xiaobotCron "github.com/eli-yip/rss-zero/pkg/routers/xiaobot/cron"
 	zhihuCron "github.com/eli-yip/rss-zero/pkg/routers/zhihu/cron"
 	zsxqCron "github.com/eli-yip/rss-zero/pkg/routers/zsxq/cron"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
 )
 
-// BuildDeps bundles every dependency a source's Build closure may need. Only the
-// deps the four sources actually use live here (no fileService: no source needs it).
+// BuildDeps bundles every dependency a source's or static job's Build closure
+// may need. File and Logger are only used by static jobs.
 type BuildDeps struct {
 	Redis    redis.Redis
 	Cookie   cookie.CookieIface
 	DB       *gorm.DB
 	AI       ai.AI
 	Notifier notify.Notifier
+	File     file.File
+	Logger   *zap.Logger
 }
 
 // ResumeInfo is the cross-source resume input. Sources that can't resume ignore it;
@@ -35,17 +41,32 @@ type ResumeInfo struct{ JobID, LastCrawled string }
 type SourceSpec struct {
 	Kind      string // zsxq/zhihu/xiaobot/github
 	Resumable bool   // 仅 zsxq/zhihu 支持续跑
+	Retry     cron.RetryPolicy
 	Build     func(deps BuildDeps, def *cronDB.CronTask, resume *ResumeInfo) CrawlFunc
 }
 
 // JobName is the scheduler job name for this source, e.g. "zsxq_crawl".
 func (s SourceSpec) JobName() string { return s.Kind + "_crawl" }
 
+// defaultRetry 只重试暂时性的错误；cookie 失效、订阅已失效或解析失败重试也不会成功。
+var defaultRetry = cron.RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   10 * time.Minute,
+	MaxDelay:    time.Hour,
+	Retryable:   []string{subscriptionDB.ErrorClassNetwork, subscriptionDB.ErrorClassRateLimit, subscriptionDB.ErrorClassUpstream},
+}
+
 var registry = []SourceSpec{
-	{Kind: "zsxq", Resumable: true, Build: buildZsxq},
-	{Kind: "zhihu", Resumable: true, Build: buildZhihu},
-	{Kind: "xiaobot", Resumable: false, Build: buildXiaobot},
-	{Kind: "github", Resumable: false, Build: buildGitHub},
+	{Kind: "zsxq", Resumable: true, Retry: defaultRetry, Build: buildZsxq},
+	{Kind: "zhihu", Resumable: true, Retry: defaultRetry, Build: buildZhihu},
+	{Kind: "xiaobot", Resumable: false, Retry: defaultRetry, Build: buildXiaobot},
+	// GitHub 的限流按小时重置，退避从半小时起
+	{Kind: "github", Resumable: false, Retry: cron.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   30 * time.Minute,
+		MaxDelay:    2 * time.Hour,
+		Retryable:   defaultRetry.Retryable,
+	}, Build: buildGitHub},
 }
 
 func buildZsxq(deps BuildDeps, def *cronDB.CronTask, resume *ResumeInfo) CrawlFunc {
@@ -65,14 +86,14 @@ func buildZhihu(deps BuildDeps, def *cronDB.CronTask, resume *ResumeInfo) CrawlF
 }
 
 func buildXiaobot(deps BuildDeps, def *cronDB.CronTask, _ *ResumeInfo) CrawlFunc {
-	return xiaobotCron.BuildCronCrawlFunc(deps.Redis, deps.Cookie, deps.DB, deps.Notifier, &xiaobotCron.Filter{
+	return xiaobotCron.BuildCronCrawlFunc(def.ID, deps.Redis, deps.Cookie, deps.DB, deps.Notifier, &xiaobotCron.Filter{
 		Include: def.Include,
 		Exclude: def.Exclude,
 	})
 }
 
-func buildGitHub(deps BuildDeps, _ *cronDB.CronTask, _ *ResumeInfo) CrawlFunc {
-	return githubCron.Crawl(deps.Redis, deps.Cookie, deps.DB, deps.AI, deps.Notifier)
+func buildGitHub(deps BuildDeps, def *cronDB.CronTask, _ *ResumeInfo) CrawlFunc {
+	return githubCron.Crawl(def.ID, deps.Redis, deps.Cookie, deps.DB, deps.AI, deps.Notifier)
 }
 
 // AddToScheduler 构建抓取函数、注册调度任务，并记录任务定义与调度器任务的进程内映射。
@@ -0,0 +1,105 @@
+package job
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/cron"
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+)
+
+// Retrier 按来源的 SourceSpec.Retry 重试失败的抓取，实现 cron.Retrier。每次重试都是一条新的
+// cron_jobs 记录：trigger 为 retry，retry_of 指向最初失败的运行，attempt 为第几次重试。
+// 待执行的重试只在进程内，重启后不再补跑，等下一次调度。
+type Retrier struct {
+	cronDBService cronDB.DB
+	deps          BuildDeps
+	random        func(time.Duration) time.Duration
+	after         func(time.Duration, func())
+
+	// mu 从启动重试持有到写好 retry_of/attempt，这次重试若很快失败，Retry 读到的已是写好的记录
+	mu sync.Mutex
+}
+
+func NewRetrier(cronDBService cronDB.DB, deps BuildDeps) *Retrier {
+	return &Retrier{
+		cronDBService: cronDBService,
+		deps:          deps,
+		random:        rand.N[time.Duration],
+		after:         func(d time.Duration, f func()) { time.AfterFunc(d, f) },
+	}
+}
+
+// Retry 在策略允许时安排下一次尝试。logger 仍写入失败运行的日志，重试的决定因此留在运行历史里。
+func (r *Retrier) Retry(run cron.FailedRun, logger *zap.Logger) bool {
+	r.mu.Lock()
+	job, err := r.cronDBService.GetJob(run.JobID)
+	r.mu.Unlock()
+	if err != nil {
+		logger.Error("Failed to get job to retry", zap.Error(err))
+		return false
+	}
+	_, spec, ok := r.retryable(job.TaskType, logger)
+	if !ok {
+		return false
+	}
+	if !spec.Retry.ShouldRetry(job.Attempt, run.Classes) {
+		if job.Attempt > 0 {
+			logger.Info("Retries exhausted", zap.Int("attempt", job.Attempt), zap.Strings("error_classes", run.Classes))
+		}
+		return false
+	}
+
+	retryOf := job.RetryOf
+	if retryOf == "" {
+		retryOf = job.ID
+	}
+	attempt := job.Attempt + 1
+	delay := spec.Retry.Backoff(attempt, r.random)
+	logger.Info("Schedule retry of failed job",
+		zap.Int("attempt", attempt), zap.Duration("delay", delay), zap.Strings("error_classes", run.Classes))
+	r.after(delay, func() { r.launch(job.TaskType, retryOf, attempt) })
+	return true
+}
+
+// launch 用最新的任务定义重建抓取函数并运行，任务已删除或已停用时放弃。
+func (r *Retrier) launch(taskID, retryOf string, attempt int) {
+	logger := r.deps.Logger.With(zap.String("retry_of", retryOf), zap.Int("attempt", attempt))
+	definition, spec, ok := r.retryable(taskID, logger)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cronJobInfoChan := make(chan cron.CronJobInfo, 1)
+	go spec.Build(r.deps, definition, nil)(cronDB.TriggerRetry, cronJobInfoChan)
+	info := <-cronJobInfoChan
+	if info.Err != nil {
+		logger.Warn("Retry did not start", zap.Error(info.Err))
+		return
+	}
+	if err := r.cronDBService.MarkRetry(info.Job.ID, retryOf, attempt); err != nil {
+		logger.Error("Failed to mark retry job", zap.Error(err), zap.String("job_id", info.Job.ID))
+		return
+	}
+	logger.Info("Start retry of failed job", zap.String("job_id", info.Job.ID))
+}
+
+// retryable 返回任务定义及其来源的注册项，任务已删除、已停用或不是动态来源时返回 false。
+func (r *Retrier) retryable(taskID string, logger *zap.Logger) (*cronDB.CronTask, SourceSpec, bool) {
+	definition, err := r.cronDBService.GetDefinition(taskID)
+	if err != nil {
+		logger.Info("Task definition is gone, skip retry", zap.Error(err), zap.String("task_id", taskID))
+		return nil, SourceSpec{}, false
+	}
+	if definition.Disabled {
+		logger.Info("Task is disabled, skip retry", zap.String("task_id", taskID))
+		return nil, SourceSpec{}, false
+	}
+	spec, ok := SpecByKind(definition.Kind)
+	return definition, spec, ok
+}
@@ -0,0 +1,116 @@
+package job
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/cron"
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// retryCronDB 在 fakeCronDB 之上记录 MarkRetry。
+type retryCronDB struct {
+	*fakeCronDB
+	jobs   map[string]*cronDB.CronJob
+	marked []cronDB.CronJob
+}
+
+func (f *retryCronDB) GetJob(id string) (*cronDB.CronJob, error) {
+	if j, ok := f.jobs[id]; ok {
+		return j, nil
+	}
+	return nil, cronDB.ErrJobNotFound
+}
+
+func (f *retryCronDB) MarkRetry(jobID, retryOf string, attempt int) error {
+	f.marked = append(f.marked, cronDB.CronJob{ID: jobID, RetryOf: retryOf, Attempt: attempt})
+	return nil
+}
+
+// newTestRetrier 的重试同步执行，延迟记在 delays 里。
+func newTestRetrier(t *testing.T, db *retryCronDB, triggers *[]string) (*Retrier, *[]time.Duration) {
+	orig := registry
+	t.Cleanup(func() { registry = orig })
+	registry = []SourceSpec{{
+		Kind: "zsxq",
+		Retry: cron.RetryPolicy{
+			MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour,
+			Retryable: []string{subscriptionDB.ErrorClassNetwork},
+		},
+		Build: func(BuildDeps, *cronDB.CronTask, *ResumeInfo) CrawlFunc {
+			return func(trigger string, ch chan cron.CronJobInfo) {
+				*triggers = append(*triggers, trigger)
+				ch <- cron.CronJobInfo{Job: &cronDB.CronJob{ID: "retry-job"}}
+			}
+		},
+	}}
+
+	var delays []time.Duration
+	r := NewRetrier(db, BuildDeps{Logger: zap.NewNop()})
+	r.random = func(time.Duration) time.Duration { return 0 }
+	r.after = func(d time.Duration, f func()) {
+		delays = append(delays, d)
+		f()
+	}
+	return r, &delays
+}
+
+func TestRetrierRetry(t *testing.T) {
+	network := []string{subscriptionDB.ErrorClassNetwork}
+	tests := []struct {
+		name        string
+		job         cronDB.CronJob
+		classes     []string
+		disabled    bool
+		wantRetry   bool
+		wantRetryOf string
+		wantAttempt int
+	}{
+		{name: "first failure", job: cronDB.CronJob{ID: "job-1"}, classes: network,
+			wantRetry: true, wantRetryOf: "job-1", wantAttempt: 1},
+		{name: "second failure keeps the original run", job: cronDB.CronJob{ID: "job-2", RetryOf: "job-1", Attempt: 1}, classes: network,
+			wantRetry: true, wantRetryOf: "job-1", wantAttempt: 2},
+		{name: "attempts exhausted", job: cronDB.CronJob{ID: "job-3", RetryOf: "job-1", Attempt: 2}, classes: network},
+		{name: "cookie invalid is not retried", job: cronDB.CronJob{ID: "job-1"},
+			classes: []string{subscriptionDB.ErrorClassNetwork, subscriptionDB.ErrorClassAuth}},
+		{name: "disabled task", job: cronDB.CronJob{ID: "job-1"}, classes: network, disabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := tt.job
+			job.TaskType = "task-1"
+			db := &retryCronDB{fakeCronDB: newFakeCronDB(), jobs: map[string]*cronDB.CronJob{job.ID: &job}}
+			db.tasks["task-1"] = &cronDB.CronTask{ID: "task-1", Kind: "zsxq", Disabled: tt.disabled}
+			var triggers []string
+			r, delays := newTestRetrier(t, db, &triggers)
+
+			got := r.Retry(cron.FailedRun{JobID: job.ID, Err: errors.New("boom"), Classes: tt.classes}, zap.NewNop())
+			assert.Equal(t, tt.wantRetry, got)
+			if !tt.wantRetry {
+				assert.Empty(t, triggers)
+				assert.Empty(t, db.marked)
+				return
+			}
+			assert.Equal(t, []string{cronDB.TriggerRetry}, triggers)
+			require.Len(t, db.marked, 1)
+			assert.Equal(t, cronDB.CronJob{ID: "retry-job", RetryOf: tt.wantRetryOf, Attempt: tt.wantAttempt}, db.marked[0])
+			// 随机部分为 0 时取退避的一半：第一次 30 秒，第二次 1 分钟
+			assert.Equal(t, []time.Duration{time.Minute << (tt.wantAttempt - 1) / 2}, *delays)
+		})
+	}
+}
+
+func TestDefaultRetryClasses(t *testing.T) {
+	for _, spec := range registry {
+		assert.True(t, spec.Retry.ShouldRetry(0, []string{subscriptionDB.ErrorClassNetwork}), spec.Kind)
+		assert.False(t, spec.Retry.ShouldRetry(0, []string{subscriptionDB.ErrorClassAuth}), spec.Kind)
+		assert.False(t, spec.Retry.ShouldRetry(0, []string{subscriptionDB.ErrorClassGone}), spec.Kind)
+	}
+}
@@ -0,0 +1,115 @@
+package job
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+)
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 200
+)
+
+// RunInfo 是一次运行的历史：cron_jobs 行加上耗时与各目标的抓取结果。
+type RunInfo struct {
+	*cronDB.CronJob
+	// DurationSeconds 在运行未结束时为 nil
+	DurationSeconds *float64                `json:"duration_seconds"`
+	Targets         []*cronDB.CronJobTarget `json:"targets"`
+}
+
+func (h *Controller) runInfos(jobs []*cronDB.CronJob) ([]*RunInfo, error) {
+	if len(jobs) == 0 {
+		return []*RunInfo{}, nil
+	}
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	targets, err := h.cronDBService.GetTargets(ids...)
+	if err != nil {
+		return nil, err
+	}
+	byJob := make(map[string][]*cronDB.CronJobTarget, len(jobs))
+	for _, t := range targets {
+		byJob[t.JobID] = append(byJob[t.JobID], t)
+	}
+
+	infos := make([]*RunInfo, 0, len(jobs))
+	for _, job := range jobs {
+		info := &RunInfo{CronJob: job, Targets: byJob[job.ID]}
+		if info.Targets == nil {
+			info.Targets = []*cronDB.CronJobTarget{}
+		}
+		if job.EndedAt != nil && !job.StartedAt.IsZero() {
+			d := job.EndedAt.Sub(job.StartedAt).Seconds()
+			info.DurationSeconds = &d
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// GetJobHistory 返回最近的运行，可用 task 参数按任务定义过滤。
+func (h *Controller) GetJobHistory(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	taskID, err := echo.QueryParamOr[string](c, "task", "")
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	limit, err := echo.QueryParamOr[int](c, "limit", defaultHistoryLimit)
+	if err != nil || limit <= 0 || limit > maxHistoryLimit {
+		return httputil.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 200")
+	}
+
+	jobs, err := h.cronDBService.FindJobs(taskID, limit)
+	if err != nil {
+		logger.Error("Failed to find jobs", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	infos, err := h.runInfos(jobs)
+	if err != nil {
+		logger.Error("Failed to get job targets", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", infos))
+}
+
+// GetJobLogs 返回一次运行截获的日志，level 参数可重复，如 ?level=warn&level=error。
+func (h *Controller) GetJobLogs(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	jobID, err := echo.PathParam[string](c, "id")
+	if err != nil || jobID == "" {
+		return httputil.NewHTTPError(http.StatusBadRequest, "empty job ID")
+	}
+	levels, err := echo.QueryParamsOr[string](c, "level", nil)
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err = h.cronDBService.GetJob(jobID); err != nil {
+		if errors.Is(err, cronDB.ErrJobNotFound) {
+			return httputil.NewHTTPError(http.StatusNotFound, "job not found")
+		}
+		logger.Error("Failed to get job", zap.Error(err), zap.String("job_id", jobID))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	logs, err := h.cronDBService.GetLogs(jobID, levels...)
+	if err != nil {
+		logger.Error("Failed to get job logs", zap.Error(err), zap.String("job_id", jobID))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", logs))
+}
@@ -0,0 +1,190 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/cron"
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+)
+
+// runCronDB 在 fakeCronDB 之上提供运行历史的读取。
+type runCronDB struct {
+	*fakeCronDB
+	jobs    []*cronDB.CronJob
+	targets []*cronDB.CronJobTarget
+	logs    []*cronDB.CronJobLog
+	levels  []string
+}
+
+func (f *runCronDB) GetJob(id string) (*cronDB.CronJob, error) {
+	for _, j := range f.jobs {
+		if j.ID == id {
+			return j, nil
+		}
+	}
+	return nil, cronDB.ErrJobNotFound
+}
+
+func (f *runCronDB) FindJobs(string, int) ([]*cronDB.CronJob, error) { return f.jobs, nil }
+
+func (f *runCronDB) GetTargets(...string) ([]*cronDB.CronJobTarget, error) { return f.targets, nil }
+
+func (f *runCronDB) GetLogs(_ string, levels ...string) ([]*cronDB.CronJobLog, error) {
+	f.levels = levels
+	return f.logs, nil
+}
+
+func serveRun(t *testing.T, fake cronDB.DB, target string, pathValues echo.PathValues, handler func(*Controller, *echo.Context) error) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = httputil.NewHTTPErrorHandler(zap.NewNop())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, target, nil), rec)
+	c.Set("logger", zap.NewNop())
+	if pathValues != nil {
+		c.SetPathValues(pathValues)
+	}
+	h := newTestController(nil, NewJobIndex(), fake)
+	if err := handler(h, c); err != nil {
+		e.HTTPErrorHandler(c, err)
+	}
+	return rec
+}
+
+func TestGetJobHistory(t *testing.T) {
+	started := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+	ended := started.Add(90 * time.Second)
+	fake := &runCronDB{
+		fakeCronDB: newFakeCronDB(),
+		jobs: []*cronDB.CronJob{
+			{ID: "done", Trigger: cronDB.TriggerSchedule, StartedAt: started, EndedAt: &ended, Status: cronDB.StatusFinished},
+			{ID: "running", Trigger: cronDB.TriggerManual, StartedAt: started, Status: cronDB.StatusRunning},
+		},
+		targets: []*cronDB.CronJobTarget{{JobID: "done", Target: "100", ItemsFound: 3, ItemsNew: 1}},
+	}
+
+	rec := serveRun(t, fake, "/?limit=5", nil, (*Controller).GetJobHistory)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data []struct {
+			ID              string                  `json:"id"`
+			Trigger         string                  `json:"trigger"`
+			DurationSeconds *float64                `json:"duration_seconds"`
+			Targets         []*cronDB.CronJobTarget `json:"targets"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, "schedule", resp.Data[0].Trigger)
+	require.NotNil(t, resp.Data[0].DurationSeconds)
+	assert.Equal(t, 90.0, *resp.Data[0].DurationSeconds)
+	require.Len(t, resp.Data[0].Targets, 1)
+	assert.Equal(t, 1, resp.Data[0].Targets[0].ItemsNew)
+	assert.Nil(t, resp.Data[1].DurationSeconds)
+	assert.NotNil(t, resp.Data[1].Targets)
+
+	rec = serveRun(t, fake, "/?limit=0", nil, (*Controller).GetJobHistory)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetJobLogs(t *testing.T) {
+	fake := &runCronDB{
+		fakeCronDB: newFakeCronDB(),
+		jobs:       []*cronDB.CronJob{{ID: "job-1"}},
+		logs:       []*cronDB.CronJobLog{{JobID: "job-1", Level: "warn", Message: "slow", Fields: "{}"}},
+	}
+
+	rec := serveRun(t, fake, "/?level=warn&level=error", echo.PathValues{{Name: "id", Value: "job-1"}}, (*Controller).GetJobLogs)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"warn", "error"}, fake.levels)
+	assert.Contains(t, rec.Body.String(), `"message":"slow"`)
+
+	rec = serveRun(t, fake, "/", echo.PathValues{{Name: "id", Value: "missing"}}, (*Controller).GetJobLogs)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// controlCronDB 记录 UpdateStatus 并可指定任务定义。
+type controlCronDB struct {
+	*runCronDB
+	updated map[string]int
+}
+
+func (f *controlCronDB) UpdateStatus(id string, status int) error {
+	f.updated[id] = status
+	return nil
+}
+
+func newControlCronDB(jobs ...*cronDB.CronJob) *controlCronDB {
+	fake := &controlCronDB{runCronDB: &runCronDB{fakeCronDB: newFakeCronDB(), jobs: jobs}, updated: map[string]int{}}
+	fake.tasks["zsxq-task"] = &cronDB.CronTask{ID: "zsxq-task", Kind: "zsxq"}
+	fake.tasks["github-task"] = &cronDB.CronTask{ID: "github-task", Kind: "github"}
+	return fake
+}
+
+func jobPath(id string) echo.PathValues { return echo.PathValues{{Name: "id", Value: id}} }
+
+func TestCancelJob(t *testing.T) {
+	leaseUntil := time.Now().Add(time.Minute)
+	fake := newControlCronDB(
+		&cronDB.CronJob{ID: "live", TaskType: "zsxq-task", Status: cronDB.StatusRunning},
+		&cronDB.CronJob{ID: "orphan", TaskType: "zsxq-task", Status: cronDB.StatusRunning},
+		&cronDB.CronJob{ID: "done", TaskType: "zsxq-task", Status: cronDB.StatusFinished},
+		&cronDB.CronJob{ID: "remote", TaskType: "zsxq-task", Status: cronDB.StatusRunning, Owner: "other-host", LeaseUntil: &leaseUntil},
+	)
+	ctx, release := cron.Stoppable(context.Background(), "live")
+	defer release()
+
+	rec := serveRun(t, fake, "/", jobPath("live"), (*Controller).CancelJob)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.ErrorIs(t, cron.StopCause(ctx), cron.ErrCanceled)
+	assert.NotContains(t, fake.updated, "live")
+
+	rec = serveRun(t, fake, "/", jobPath("orphan"), (*Controller).CancelJob)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, cronDB.StatusStopped, fake.updated["orphan"])
+
+	rec = serveRun(t, fake, "/", jobPath("done"), (*Controller).CancelJob)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = serveRun(t, fake, "/", jobPath("remote"), (*Controller).CancelJob)
+	assert.Equal(t, http.StatusConflict, rec.Code, "a job another instance still leases must not be marked stopped")
+	assert.NotContains(t, fake.updated, "remote")
+
+	rec = serveRun(t, fake, "/", jobPath("missing"), (*Controller).CancelJob)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestPauseJob(t *testing.T) {
+	fake := newControlCronDB(
+		&cronDB.CronJob{ID: "zsxq-run", TaskType: "zsxq-task", Status: cronDB.StatusRunning},
+		&cronDB.CronJob{ID: "github-run", TaskType: "github-task", Status: cronDB.StatusRunning},
+	)
+	ctx, release := cron.Stoppable(context.Background(), "zsxq-run")
+	defer release()
+
+	rec := serveRun(t, fake, "/", jobPath("github-run"), (*Controller).PauseJob)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serveRun(t, fake, "/", jobPath("zsxq-run"), (*Controller).PauseJob)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.ErrorIs(t, cron.StopCause(ctx), cron.ErrPaused)
+}
+
+func TestResumeJob_RequiresPaused(t *testing.T) {
+	fake := newControlCronDB(&cronDB.CronJob{ID: "zsxq-run", TaskType: "zsxq-task", Status: cronDB.StatusRunning})
+
+	rec := serveRun(t, fake, "/", jobPath("zsxq-run"), (*Controller).ResumeJob)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
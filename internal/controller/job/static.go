@@ -0,0 +1,250 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	notify "github.com/eli-yip/rss-zero/internal/notify"
+	"github.com/eli-yip/rss-zero/internal/tracing"
+	"github.com/eli-yip/rss-zero/pkg/cookie"
+	"github.com/eli-yip/rss-zero/pkg/cron"
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/douyu"
+	"github.com/eli-yip/rss-zero/pkg/routers/macked"
+	"github.com/eli-yip/rss-zero/pkg/routers/tombkeeper"
+	zhihuCron "github.com/eli-yip/rss-zero/pkg/routers/zhihu/cron"
+	zhihuRandom "github.com/eli-yip/rss-zero/pkg/routers/zhihu/random"
+	zsxqCron "github.com/eli-yip/rss-zero/pkg/routers/zsxq/cron"
+	zsxqRandom "github.com/eli-yip/rss-zero/pkg/routers/zsxq/random"
+)
+
+// StaticSpec 是内置静态任务的注册项。静态任务每种只有一行 cron_tasks，Kind 同时作为调度器任务名，
+// 因此 /job/run/:job 仍可用 macked_crawl 这样的名字触发。
+type StaticSpec struct {
+	Kind     string
+	CronExpr string            // 首次写入 cron_tasks 时的默认调度
+	MaxDelay time.Duration     // 默认随机延迟上限，0 表示准点运行
+	Params   map[string]string // 默认参数，任务定义里的同名参数覆盖它
+	Build    func(deps BuildDeps, params map[string]string) (func(), error)
+}
+
+// StaticMaxDelay 是抓取类静态任务的默认随机延迟上限，避免整点集中请求。
+const StaticMaxDelay = 10 * time.Minute
+
+var staticRegistry = []StaticSpec{
+	{Kind: "check_cookies", CronExpr: "0 0 * * *", Build: buildCheckCookies},
+	{Kind: "macked_crawl", CronExpr: "0 * * * *", MaxDelay: StaticMaxDelay, Build: buildMacked},
+	{Kind: "tombkeeper_crawl", CronExpr: "0 * * * *", MaxDelay: StaticMaxDelay, Build: buildTombkeeper},
+	{
+		Kind: "canglimo_random_select", CronExpr: "0 0 * * *",
+		Params: map[string]string{"count": strconv.Itoa(zhihuRandom.DefaultPickCount)},
+		Build:  buildCanglimoRandomSelect,
+	},
+	{
+		Kind: "canglimo_digest_random_select", CronExpr: "0 0 * * *",
+		Params: map[string]string{"count": strconv.Itoa(zsxqRandom.DefaultPickCount)},
+		Build:  buildCanglimoDigestRandomSelect,
+	},
+	{
+		Kind: "zvideo_crawl", CronExpr: "0 0,3,6,9,12,15,18,21 * * *", MaxDelay: StaticMaxDelay,
+		Params: map[string]string{"author": "canglimo"},
+		Build:  buildZvideo,
+	},
+	{Kind: "douyu_crawl", CronExpr: "0 19 * * *", MaxDelay: StaticMaxDelay, Build: buildDouyu},
+	{
+		Kind: "zhihu_recheck", CronExpr: "0 4 * * *", MaxDelay: StaticMaxDelay,
+		Params: map[string]string{"days": strconv.Itoa(zhihuCron.DefaultRecheckDays)},
+		Build:  buildZhihuRecheck,
+	},
+	{Kind: "zhihu_encryption_probe", CronExpr: "*/30 * * * *", Build: buildZhihuEncryptionProbe},
+	{
+		Kind: "zsxq_comment_refresh", CronExpr: "0 5 * * *", MaxDelay: StaticMaxDelay,
+		Params: map[string]string{"days": strconv.Itoa(zsxqCron.DefaultCommentRefreshDays)},
+		Build:  buildZsxqCommentRefresh,
+	},
+	{
+		Kind: "zsxq_file_mirror", CronExpr: "30 3 * * *", MaxDelay: StaticMaxDelay,
+		Params: map[string]string{"count": strconv.Itoa(zsxqCron.DefaultFileMirrorCount)},
+		Build:  buildZsxqFileMirror,
+	},
+}
+
+func buildCheckCookies(deps BuildDeps, _ map[string]string) (func(), error) {
+	return checkCookies(deps.Cookie, deps.Notifier, deps.Logger), nil
+}
+
+func buildMacked(deps BuildDeps, _ map[string]string) (func(), error) {
+	return macked.CrawlFunc(deps.Redis, macked.NewDBService(deps.DB), deps.Logger), nil
+}
+
+func buildTombkeeper(deps BuildDeps, _ map[string]string) (func(), error) {
+	return tombkeeper.CrawlFunc(deps.Redis, tombkeeper.NewDBService(deps.DB), deps.File, deps.Notifier, deps.Logger), nil
+}
+
+func buildCanglimoRandomSelect(deps BuildDeps, params map[string]string) (func(), error) {
+	count, err := countParam(params)
+	if err != nil {
+		return nil, err
+	}
+	return zhihuCron.BuildRandomSelectCanglimoAnswerCronFunc(deps.DB, deps.Redis, count), nil
+}
+
+func buildCanglimoDigestRandomSelect(deps BuildDeps, params map[string]string) (func(), error) {
+	count, err := countParam(params)
+	if err != nil {
+		return nil, err
+	}
+	return zsxqCron.BuildRandomSelectCanglimoDigestTopicFunc(deps.DB, deps.Redis, count), nil
+}
+
+func buildZvideo(deps BuildDeps, params map[string]string) (func(), error) {
+	author := params["author"]
+	if author == "" {
+		return nil, errors.New("param author is required")
+	}
+	return zhihuCron.BuildZvideoCrawlFunc(author, deps.DB, deps.Notifier, deps.Cookie), nil
+}
+
+func buildDouyu(deps BuildDeps, _ map[string]string) (func(), error) {
+	return douyu.BuildCrawlFunc(deps.Notifier, deps.Redis), nil
+}
+
+func buildZhihuRecheck(deps BuildDeps, params map[string]string) (func(), error) {
+	days, err := strconv.Atoi(params["days"])
+	if err != nil || days <= 0 {
+		return nil, fmt.Errorf("param days must be a positive integer, got %q", params["days"])
+	}
+	return zhihuCron.BuildRecheckFunc(days, deps.DB, deps.Cookie, deps.AI, deps.Notifier), nil
+}
+
+func buildZhihuEncryptionProbe(deps BuildDeps, _ map[string]string) (func(), error) {
+	return zhihuCron.BuildProbeFunc(deps.DB, deps.Cookie, deps.Notifier), nil
+}
+
+func buildZsxqCommentRefresh(deps BuildDeps, params map[string]string) (func(), error) {
+	days, err := strconv.Atoi(params["days"])
+	if err != nil || days <= 0 {
+		return nil, fmt.Errorf("param days must be a positive integer, got %q", params["days"])
+	}
+	return zsxqCron.BuildCommentRefreshFunc(days, deps.DB, deps.Cookie, deps.AI, deps.Notifier), nil
+}
+
+func buildZsxqFileMirror(deps BuildDeps, params map[string]string) (func(), error) {
+	count, err := countParam(params)
+	if err != nil {
+		return nil, err
+	}
+	return zsxqCron.BuildFileMirrorFunc(count, deps.DB, deps.Cookie, deps.AI, deps.Notifier), nil
+}
+
+func countParam(params map[string]string) (int, error) {
+	count, err := strconv.Atoi(params["count"])
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("param count must be a positive integer, got %q", params["count"])
+	}
+	return count, nil
+}
+
+// StaticSpecByKind 按 Kind 查找静态任务注册项。
+func StaticSpecByKind(kind string) (StaticSpec, bool) {
+	for _, s := range staticRegistry {
+		if s.Kind == kind {
+			return s, true
+		}
+	}
+	return StaticSpec{}, false
+}
+
+// StaticSpecs 返回全部静态任务注册项，供启动时写入缺失的 cron_tasks 行。
+func StaticSpecs() []StaticSpec { return staticRegistry }
+
+// Definition 返回按默认值填好的任务定义，ID 留空。
+func (s StaticSpec) Definition() *cronDB.CronTask {
+	return &cronDB.CronTask{
+		Kind:     s.Kind,
+		CronExpr: s.CronExpr,
+		MaxDelay: int(s.MaxDelay / time.Second),
+		Params:   maps.Clone(s.Params),
+	}
+}
+
+// params 把任务定义里的参数叠加到默认参数上。
+func (s StaticSpec) params(def *cronDB.CronTask) map[string]string {
+	params := maps.Clone(s.Params)
+	if params == nil {
+		params = make(map[string]string, len(def.Params))
+	}
+	maps.Copy(params, def.Params)
+	return params
+}
+
+// AddStaticToScheduler 构建静态任务、按 def 的随机延迟包装后注册调度任务，并记录进程内映射。
+func AddStaticToScheduler(cronService *cron.CronService, jobIndex *JobIndex, spec StaticSpec, deps BuildDeps, def *cronDB.CronTask) (jobID string, err error) {
+	fn, err := spec.Build(deps, spec.params(def))
+	if err != nil {
+		return "", fmt.Errorf("failed to build %s: %w", spec.Kind, err)
+	}
+	fn = TraceStaticJob(spec.Kind, fn)
+	if def.MaxDelay > 0 {
+		fn = RandomDelayRunner(time.Duration(def.MaxDelay)*time.Second).Wrap(spec.Kind, deps.Logger, fn)
+	}
+	if jobID, err = cronService.AddJob(spec.Kind, def.CronExpr, fn); err != nil {
+		return "", fmt.Errorf("failed to add static job: %w", err)
+	}
+	jobIndex.Set(def.ID, jobID)
+	return jobID, nil
+}
+
+// TraceStaticJob wraps fn in a cron root span. Static jobs take no context, so the
+// span only records when the run started and how long it took.
+func TraceStaticJob(jobName string, fn func()) func() {
+	return func() {
+		_, span := tracing.StartCronRun(jobName, "")
+		defer span.End()
+		fn()
+	}
+}
+
+// DelayRunner sleeps a random duration below maxDelay before running a job.
+type DelayRunner struct {
+	maxDelay time.Duration
+	random   func(time.Duration) time.Duration
+	sleep    func(time.Duration)
+}
+
+func RandomDelayRunner(maxDelay time.Duration) DelayRunner {
+	return DelayRunner{maxDelay: maxDelay, random: rand.N[time.Duration], sleep: time.Sleep}
+}
+
+func (r DelayRunner) Wrap(jobName string, logger *zap.Logger, fn func()) func() {
+	return func() {
+		delay := r.random(r.maxDelay)
+		logger.Info("Delay static crawl job", zap.String("job_name", jobName), zap.Duration("delay", delay))
+		r.sleep(delay)
+		fn()
+	}
+}
+
+// checkCookies notifies when a cookie is missing or about to expire.
+func checkCookies(cookieService cookie.CookieIface, notifier notify.Notifier, logger *zap.Logger) func() {
+	return func() {
+		// Iterate the registry (not GetCookieTypes) so a never-set cookie is flagged too.
+		for _, spec := range cookie.AllSpecs() {
+			label := spec.Label()
+			err := cookieService.CheckTTL(spec.Type, 48*time.Hour)
+			if errors.Is(err, cookie.ErrKeyNotExist) {
+				logger.Error("Need to update cookies", zap.String("cookie_type", label))
+				notify.NoticeWithLogger(notifier, "Need to update cookies", fmt.Sprintf("Cookie type: %s", label), logger)
+			} else if err != nil {
+				logger.Error("Failed to check cookie", zap.String("cookie_type", label), zap.Error(err))
+				notify.NoticeWithLogger(notifier, "Failed to check cookie", fmt.Sprintf("Cookie type: %s", label), logger)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package job
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+)
+
+func TestDelayRunnerWrap(t *testing.T) {
+	const (
+		maxDelay = 10 * time.Minute
+		delay    = 3 * time.Minute
+	)
+	events := make([]string, 0, 3)
+	runner := DelayRunner{
+		maxDelay: maxDelay,
+		random: func(gotMax time.Duration) time.Duration {
+			assert.Equal(t, maxDelay, gotMax)
+			events = append(events, "random")
+			return delay
+		},
+		sleep: func(gotDelay time.Duration) {
+			assert.Equal(t, delay, gotDelay)
+			events = append(events, "sleep")
+		},
+	}
+
+	runner.Wrap("test_crawl", zap.NewNop(), func() { events = append(events, "run") })()
+
+	assert.Equal(t, []string{"random", "sleep", "run"}, events)
+}
+
+// TestStaticSpecDefaults 固定内置静态任务首次写入 cron_tasks 时的调度与随机延迟。
+func TestStaticSpecDefaults(t *testing.T) {
+	wantSchedules := map[string]string{
+		"check_cookies":                 "0 0 * * *",
+		"macked_crawl":                  "0 * * * *",
+		"tombkeeper_crawl":              "0 * * * *",
+		"canglimo_random_select":        "0 0 * * *",
+		"canglimo_digest_random_select": "0 0 * * *",
+		"zvideo_crawl":                  "0 0,3,6,9,12,15,18,21 * * *",
+		"douyu_crawl":                   "0 19 * * *",
+		"zhihu_recheck":                 "0 4 * * *",
+		"zhihu_encryption_probe":        "*/30 * * * *",
+		"zsxq_comment_refresh":          "0 5 * * *",
+		"zsxq_file_mirror":              "30 3 * * *",
+	}
+	wantDelayed := []string{"douyu_crawl", "macked_crawl", "tombkeeper_crawl", "zhihu_recheck", "zsxq_comment_refresh", "zsxq_file_mirror", "zvideo_crawl"}
+
+	var gotDelayed []string
+	for _, spec := range StaticSpecs() {
+		def := spec.Definition()
+		assert.Equal(t, wantSchedules[spec.Kind], def.CronExpr, spec.Kind)
+		assert.False(t, def.Disabled, spec.Kind)
+		if def.MaxDelay > 0 {
+			assert.Equal(t, 600, def.MaxDelay, spec.Kind)
+			gotDelayed = append(gotDelayed, spec.Kind)
+		}
+		_, ok := SpecByKind(spec.Kind)
+		assert.False(t, ok, "static kind %s collides with a source kind", spec.Kind)
+	}
+	slices.Sort(gotDelayed)
+	assert.Len(t, StaticSpecs(), len(wantSchedules))
+	assert.Equal(t, wantDelayed, gotDelayed)
+}
+
+func TestStaticSpecParams(t *testing.T) {
+	spec, ok := StaticSpecByKind("zvideo_crawl")
+	require.True(t, ok)
+
+	def := spec.Definition()
+	def.Params["author"] = "someone"
+	assert.Equal(t, "canglimo", spec.Params["author"], "Definition must not share the default params")
+	assert.Equal(t, map[string]string{"author": "someone"}, spec.params(def))
+
+	_, err := spec.Build(BuildDeps{}, map[string]string{})
+	assert.Error(t, err, "zvideo_crawl needs an author")
+
+	random, ok := StaticSpecByKind("canglimo_random_select")
+	require.True(t, ok)
+	_, err = random.Build(BuildDeps{}, random.params(&cronDB.CronTask{Params: map[string]string{"count": "0"}}))
+	assert.Error(t, err)
+	_, err = random.Build(BuildDeps{}, random.params(&cronDB.CronTask{Params: map[string]string{"count": "3"}}))
+	assert.NoError(t, err)
+
+	recheck, ok := StaticSpecByKind("zhihu_recheck")
+	require.True(t, ok)
+	_, err = recheck.Build(BuildDeps{}, recheck.params(&cronDB.CronTask{Params: map[string]string{"days": "-1"}}))
+	assert.Error(t, err)
+	_, err = recheck.Build(BuildDeps{}, recheck.params(&cronDB.CronTask{}))
+	assert.NoError(t, err)
+
+	refresh, ok := StaticSpecByKind("zsxq_comment_refresh")
+	require.True(t, ok)
+	_, err = refresh.Build(BuildDeps{}, refresh.params(&cronDB.CronTask{Params: map[string]string{"days": "x"}}))
+	assert.Error(t, err)
+	_, err = refresh.Build(BuildDeps{}, refresh.params(&cronDB.CronTask{}))
+	assert.NoError(t, err)
+
+	mirror, ok := StaticSpecByKind("zsxq_file_mirror")
+	require.True(t, ok)
+	_, err = mirror.Build(BuildDeps{}, mirror.params(&cronDB.CronTask{Params: map[string]string{"count": "-5"}}))
+	assert.Error(t, err)
+	_, err = mirror.Build(BuildDeps{}, mirror.params(&cronDB.CronTask{}))
+	assert.NoError(t, err)
+}
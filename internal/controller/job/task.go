@@ -2,6 +2,7 @@ package job
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
 
 	"github.com/labstack/echo/v5"
@@ -15,7 +16,7 @@ import (
 func (h *Controller) AddTask(c *echo.Context) (err error) {
 	type (
 		Req struct {
-			TaskType string   `json:"task_type"` // zsxq, zhihu, xiaobot
+			TaskType string   `json:"task_type"` // zsxq, zhihu, xiaobot, github
 			CronExpr string   `json:"cron_expr"`
 			Include  []string `json:"include"`
 			Exclude  []string `json:"exclude"`
@@ -30,6 +31,10 @@ func (h *Controller) AddTask(c *echo.Context) (err error) {
 		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	if _, ok := StaticSpecByKind(req.TaskType); ok {
+		logger.Error("Static task already exists", zap.String("task_type", req.TaskType))
+		return httputil.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("static task %s already exists, patch it instead", req.TaskType))
+	}
 	if _, ok := SpecByKind(req.TaskType); !ok {
 		logger.Error("Unknown task type", zap.String("task_type", req.TaskType))
 		return httputil.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("unknown task type: %s", req.TaskType))
@@ -54,16 +59,13 @@ func (h *Controller) AddTask(c *echo.Context) (err error) {
 	}
 	logger.Info("Add task to cron service successfully", zap.String("task_id", taskID), zap.String("cron_service_job_id", cronServiceJobID))
 
-	return c.JSON(http.StatusOK, httputil.NewResp("success", &TaskInfo{
-		ID:       taskID,
-		TaskType: req.TaskType,
-		CronExpr: req.CronExpr,
-		Include:  req.Include,
-		Exclude:  req.Exclude,
-	}))
+	return c.JSON(http.StatusOK, httputil.NewResp("success", newTaskInfo(def)))
 }
 
 func (h *Controller) addTaskToCronService(def *cronDB.CronTask) (jobID string, err error) {
+	if staticSpec, ok := StaticSpecByKind(def.Kind); ok {
+		return AddStaticToScheduler(h.cronService, h.jobIndex, staticSpec, h.buildDeps(), def)
+	}
 	spec, ok := SpecByKind(def.Kind)
 	if !ok {
 		return "", fmt.Errorf("unknown task type: %s", def.Kind)
@@ -81,13 +83,11 @@ func (h *Controller) PatchTask(c *echo.Context) (err error) {
 			CronExpr *string  `json:"cron_expr"`
 			Include  []string `json:"include"`
 			Exclude  []string `json:"exclude"`
-		}
-
-		Resp struct {
-			ID       string   `json:"id"`
-			CronExpr string   `json:"cron_expr"`
-			Include  []string `json:"include"`
-			Exclude  []string `json:"exclude"`
+			Enabled  *bool    `json:"enabled"`
+			// MaxDelaySeconds 与 Params 只对静态任务生效
+			MaxDelaySeconds *int `json:"max_delay_seconds"`
+			// Params 按键合并，值为空串表示删掉该键、回到默认值
+			Params map[string]string `json:"params"`
 		}
 	)
 
@@ -104,13 +104,28 @@ func (h *Controller) PatchTask(c *echo.Context) (err error) {
 		return httputil.NewHTTPError(http.StatusBadRequest, "empty task ID")
 	}
 
-	_, err = h.cronDBService.GetDefinition(req.ID)
+	original, err := h.cronDBService.GetDefinition(req.ID)
 	if err != nil {
 		logger.Error("Failed to get task definition", zap.Error(err), zap.String("def_id", req.ID))
 		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 	logger.Info("Get original task definition successfully", zap.String("task_id", req.ID))
 
+	if req.MaxDelaySeconds != nil && *req.MaxDelaySeconds < 0 {
+		return httputil.NewHTTPError(http.StatusBadRequest, "max_delay_seconds must not be negative")
+	}
+	optionsPatched := req.Enabled != nil || req.MaxDelaySeconds != nil || req.Params != nil
+	if optionsPatched {
+		applyTaskOptions(original, req.Enabled, req.MaxDelaySeconds, req.Params)
+	}
+	// 先校验静态任务的参数，避免存下一个启动时构建不出来的定义
+	if staticSpec, ok := StaticSpecByKind(original.Kind); ok {
+		if _, err = staticSpec.Build(h.buildDeps(), staticSpec.params(original)); err != nil {
+			logger.Error("Invalid static task params", zap.Error(err), zap.String("task_id", req.ID))
+			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+
 	if err = h.cronDBService.PatchDefinition(req.ID, req.CronExpr, req.Include, req.Exclude); err != nil {
 		logger.Error("Failed to patch task definition", zap.Error(err))
 		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
@@ -124,13 +139,26 @@ func (h *Controller) PatchTask(c *echo.Context) (err error) {
 	}
 	logger.Info("Get task definition successfully", zap.String("task_id", req.ID))
 
+	if optionsPatched {
+		applyTaskOptions(taskInfo, req.Enabled, req.MaxDelaySeconds, req.Params)
+		if _, err = h.cronDBService.SaveDefinition(taskInfo); err != nil {
+			logger.Error("Failed to save task options", zap.Error(err))
+			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		logger.Info("Save task options successfully", zap.String("task_id", req.ID))
+	}
+
 	oldJobID, ok := h.jobIndex.Get(req.ID)
-	cronServiceJobID, err := h.addTaskToCronService(taskInfo)
-	if err != nil {
-		logger.Error("Failed to add task to cron service", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	if taskInfo.Disabled {
+		h.jobIndex.Delete(req.ID)
+	} else {
+		cronServiceJobID, err := h.addTaskToCronService(taskInfo)
+		if err != nil {
+			logger.Error("Failed to add task to cron service", zap.Error(err))
+			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		logger.Info("Add task to cron service successfully", zap.String("task_id", req.ID), zap.String("cron_service_job_id", cronServiceJobID))
 	}
-	logger.Info("Add task to cron service successfully", zap.String("task_id", req.ID), zap.String("cron_service_job_id", cronServiceJobID))
 
 	if ok {
 		if err = h.cronService.RemoveCrawlJob(oldJobID); err != nil {
@@ -142,12 +170,30 @@ func (h *Controller) PatchTask(c *echo.Context) (err error) {
 		logger.Warn("Cron service job not found; skip removing it", zap.String("task_id", req.ID))
 	}
 
-	return c.JSON(http.StatusOK, httputil.NewResp("success", &Resp{
-		ID:       taskInfo.ID,
-		CronExpr: taskInfo.CronExpr,
-		Include:  taskInfo.Include,
-		Exclude:  taskInfo.Exclude,
-	}))
+	return c.JSON(http.StatusOK, httputil.NewResp("success", newTaskInfo(taskInfo)))
+}
+
+// applyTaskOptions 把 enabled / max_delay_seconds / params 的修改写到 def 上，nil 表示不改。
+func applyTaskOptions(def *cronDB.CronTask, enabled *bool, maxDelaySeconds *int, params map[string]string) {
+	if enabled != nil {
+		def.Disabled = !*enabled
+	}
+	if maxDelaySeconds != nil {
+		def.MaxDelay = *maxDelaySeconds
+	}
+	if params != nil {
+		def.Params = maps.Clone(def.Params)
+	}
+	for k, v := range params {
+		if v == "" {
+			delete(def.Params, k)
+			continue
+		}
+		if def.Params == nil {
+			def.Params = make(map[string]string, len(params))
+		}
+		def.Params[k] = v
+	}
 }
 
 type TaskInfo struct {
@@ -156,6 +202,26 @@ type TaskInfo struct {
 	CronExpr string   `json:"cron_expr"`
 	Include  []string `json:"include"`
 	Exclude  []string `json:"exclude"`
+	// Static 表示内置静态任务，只能修改不能新增或删除
+	Static          bool              `json:"static"`
+	Enabled         bool              `json:"enabled"`
+	MaxDelaySeconds int               `json:"max_delay_seconds"`
+	Params          map[string]string `json:"params,omitempty"`
+}
+
+func newTaskInfo(def *cronDB.CronTask) *TaskInfo {
+	_, static := StaticSpecByKind(def.Kind)
+	return &TaskInfo{
+		ID:              def.ID,
+		TaskType:        def.Kind,
+		CronExpr:        def.CronExpr,
+		Include:         def.Include,
+		Exclude:         def.Exclude,
+		Static:          static,
+		Enabled:         !def.Disabled,
+		MaxDelaySeconds: def.MaxDelay,
+		Params:          def.Params,
+	}
 }
 
 func (h *Controller) DeleteTask(c *echo.Context) (err error) {
@@ -176,6 +242,9 @@ func (h *Controller) DeleteTask(c *echo.Context) (err error) {
 		logger.Error("Failed to get task definition", zap.Error(err))
 		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	if _, ok := StaticSpecByKind(taskInfo.Kind); ok {
+		return httputil.NewHTTPError(http.StatusBadRequest, "static tasks can not be deleted, disable it instead")
+	}
 	jobID, ok := h.jobIndex.Get(taskID)
 	if ok {
 		if err = h.cronService.RemoveCrawlJob(jobID); err != nil {
@@ -194,13 +263,7 @@ func (h *Controller) DeleteTask(c *echo.Context) (err error) {
 	h.jobIndex.Delete(taskID)
 	logger.Info("Delete task definition successfully", zap.String("task_id", taskID))
 
-	return c.JSON(http.StatusOK, httputil.NewResp("task definition deleted", TaskInfo{
-		ID:       taskInfo.ID,
-		TaskType: taskInfo.Kind,
-		CronExpr: taskInfo.CronExpr,
-		Include:  taskInfo.Include,
-		Exclude:  taskInfo.Exclude,
-	}))
+	return c.JSON(http.StatusOK, httputil.NewResp("task definition deleted", newTaskInfo(taskInfo)))
 }
 
 func (h *Controller) ListTask(c *echo.Context) (err error) {
@@ -220,13 +283,7 @@ func (h *Controller) ListTask(c *echo.Context) (err error) {
 
 		taskInfo := make([]*TaskInfo, 0, len(taskDefs))
 		for _, def := range taskDefs {
-			taskInfo = append(taskInfo, &TaskInfo{
-				ID:       def.ID,
-				TaskType: def.Kind,
-				CronExpr: def.CronExpr,
-				Include:  def.Include,
-				Exclude:  def.Exclude,
-			})
+			taskInfo = append(taskInfo, newTaskInfo(def))
 		}
 
 		return c.JSON(http.StatusOK, httputil.NewResp("success", taskInfo))
@@ -240,13 +297,5 @@ func (h *Controller) ListTask(c *echo.Context) (err error) {
 	}
 	logger.Info("Get task definition successfully", zap.String("task_id", taskID))
 
-	return c.JSON(http.StatusOK, httputil.NewResp("success", &[]TaskInfo{
-		{
-			ID:       taskDef.ID,
-			TaskType: taskDef.Kind,
-			CronExpr: taskDef.CronExpr,
-			Include:  taskDef.Include,
-			Exclude:  taskDef.Exclude,
-		},
-	}))
+	return c.JSON(http.StatusOK, httputil.NewResp("success", []*TaskInfo{newTaskInfo(taskDef)}))
 }
@@ -0,0 +1,304 @@
+package opml
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/internal/middleware"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/opml"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// maxOPMLSize 限制导入文件大小，上千条订阅的 OPML 也不过几百 KB。
+const maxOPMLSize = 4 << 20
+
+// Export 导出全部启用中的订阅，按平台分组，feed 地址以 settings.server_url 为前缀，付费 feed 带管理员令牌。
+//
+// GET /api/v1/opml
+func (h *Controller) Export(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	groups, err := h.collectGroups(config.C.Settings.ServerURL)
+	if err != nil {
+		logger.Error("Failed to collect subscriptions", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return writeOPML(c, groups, logger)
+}
+
+// ExportMine 导出当前用户订阅的订阅与公开的固定 feed，付费 feed 带该用户的令牌。
+//
+// GET /api/v1/my/opml
+func (h *Controller) ExportMine(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	groups, err := h.collectUserGroups(config.C.Settings.ServerURL, user)
+	if err != nil {
+		logger.Error("Failed to collect user subscriptions", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return writeOPML(c, groups, logger)
+}
+
+func writeOPML(c *echo.Context, groups []opml.Group, logger *zap.Logger) error {
+	now := time.Now()
+	data, err := opml.Encode("rss-zero", now, groups)
+	if err != nil {
+		logger.Error("Failed to encode opml", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	count := 0
+	for _, g := range groups {
+		count += len(g.Feeds)
+	}
+	logger.Info("Export opml successfully", zap.Int("count", count))
+
+	filename := fmt.Sprintf("rss-zero-%s.opml", now.Format("20060102"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return c.Blob(http.StatusOK, "text/x-opml; charset=utf-8", data)
+}
+
+// currentUser 返回 InjectUser 放入的用户。
+func currentUser(c *echo.Context) (subscription.User, error) {
+	username, err := echo.ContextGet[string](c, "username")
+	if err != nil || username == "" {
+		return subscription.User{}, httputil.NewHTTPError(http.StatusInternalServerError, "missing username")
+	}
+	return subscription.User{Name: username, Admin: middleware.IsAdmin(c)}, nil
+}
+
+// groupNames 是导出时各平台的分组名，顺序即分组顺序。
+var groupNames = []struct{ platform, name string }{
+	{subscriptionDB.PlatformZhihu, "知乎"},
+	{subscriptionDB.PlatformZsxq, "知识星球"},
+	{subscriptionDB.PlatformXiaobot, "小报童"},
+	{subscriptionDB.PlatformGitHub, "GitHub"},
+}
+
+// collectGroups 汇总各平台启用中的订阅。固定 feed（macked、tombkeeper、随机推荐等）没有订阅表，归入“其他”。
+func (h *Controller) collectGroups(serverURL string) ([]opml.Group, error) {
+	active := true
+	subs, err := h.registry.List(subscription.Filter{Active: &active})
+	if err != nil {
+		return nil, err
+	}
+	admin := subscription.User{Admin: true}
+	for i := range subs {
+		subs[i].FeedPath = h.ownership.FeedPath(admin, subs[i])
+	}
+	return groupFeeds(serverURL, subs, h.staticFeeds(serverURL, admin)), nil
+}
+
+// collectUserGroups 只汇总用户订阅的订阅。
+func (h *Controller) collectUserGroups(serverURL string, user subscription.User) ([]opml.Group, error) {
+	active := true
+	subs, err := h.ownership.List(user, subscription.Filter{Active: &active})
+	if err != nil {
+		return nil, err
+	}
+	return groupFeeds(serverURL, subs, h.staticFeeds(serverURL, user)), nil
+}
+
+func groupFeeds(serverURL string, subs []subscription.Subscription, static []opml.Feed) []opml.Group {
+	feeds := make(map[string][]opml.Feed)
+	for _, sub := range subs {
+		feeds[sub.Platform] = append(feeds[sub.Platform], opml.Feed{
+			Title:   sub.Name,
+			XMLURL:  serverURL + sub.FeedPath,
+			HTMLURL: sub.URL,
+		})
+	}
+
+	groups := make([]opml.Group, 0, len(groupNames)+1)
+	for _, g := range groupNames {
+		groups = append(groups, opml.Group{Name: g.name, Feeds: feeds[g.platform]})
+	}
+	return append(groups, opml.Group{Name: "其他", Feeds: static})
+}
+
+// staticFeeds 中的星球随机精华是付费内容，只导出给管理员。
+func (h *Controller) staticFeeds(serverURL string, user subscription.User) []opml.Feed {
+	feeds := []opml.Feed{
+		{Title: "Macked", XMLURL: serverURL + "/rss/macked"},
+		{Title: "tombkeeper", XMLURL: serverURL + "/rss/tombkeeper"},
+		{Title: "墨苍离的随机知乎回答", XMLURL: serverURL + "/rss/zhihu/random"},
+	}
+	if user.Admin {
+		random := subscription.Subscription{Platform: subscriptionDB.PlatformZsxq, FeedPath: "/rss/zsxq/random"}
+		feeds = append(feeds, opml.Feed{Title: "墨苍离的随机星球精华", XMLURL: serverURL + h.ownership.FeedPath(user, random)})
+	}
+	if config.C.Digest.Enabled {
+		feeds = append(feeds, opml.Feed{Title: "AI 每日摘要", XMLURL: serverURL + "/rss/digest"})
+	}
+	return feeds
+}
+
+// 导入结果：本站可建订阅的 feed、本站 feed 但无需/无法建订阅、非本站 feed、建订阅失败。
+const (
+	importSubscribed   = "subscribed"
+	importSkipped      = "skipped"
+	importUnrecognized = "unrecognized"
+	importFailed       = "failed"
+)
+
+// ImportEntry 是 OPML 中一条 feed 的导入结果。订阅逐条建立、各自生效，失败的条目不影响其他条目，
+// 修正后重新导入即可（已存在的订阅保持原状）。
+type ImportEntry struct {
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	// Platform、SubscriptionID 与 FeedPath 仅在 subscribed 时给出，FeedPath 是当前用户可用的订阅地址
+	Platform       string `json:"platform,omitempty"`
+	SubscriptionID string `json:"subscription_id,omitempty"`
+	FeedPath       string `json:"feed_path,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// ImportResponse 按文件顺序给出每条 feed 的结果，并附各状态计数。
+type ImportResponse struct {
+	Entries      []ImportEntry `json:"entries"`
+	Subscribed   int           `json:"subscribed"`
+	Skipped      int           `json:"skipped"`
+	Unrecognized int           `json:"unrecognized"`
+	Failed       int           `json:"failed"`
+}
+
+// Import 导入 OPML 文件（请求体或 multipart 的 file 字段），识别本站的 zhihu、zsxq、xiaobot、github
+// feed 地址并经订阅注册表建立订阅；已存在（含已暂停）的订阅保持原状。不校验 host，便于从其他实例迁移。
+//
+// POST /api/v1/opml
+func (h *Controller) Import(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	return h.importOPML(c, func(req subscription.CreateRequest) (subscription.Subscription, error) {
+		return h.registry.Create(req, logger)
+	}, logger)
+}
+
+// ImportMine 与 Import 相同，但订阅记到当前用户名下，受平台、配额与付费授权限制。
+//
+// POST /api/v1/my/opml
+func (h *Controller) ImportMine(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	return h.importOPML(c, func(req subscription.CreateRequest) (subscription.Subscription, error) {
+		return h.ownership.Subscribe(user, req, logger)
+	}, logger)
+}
+
+type subscribeFunc func(subscription.CreateRequest) (subscription.Subscription, error)
+
+func (h *Controller) importOPML(c *echo.Context, subscribe subscribeFunc, logger *zap.Logger) (err error) {
+
+	data, err := common.ReadUploadFile(c, maxOPMLSize)
+	if err != nil {
+		logger.Error("Failed to read opml file", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	feeds, err := opml.Decode(data)
+	if err != nil {
+		logger.Error("Failed to decode opml file", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resp := ImportResponse{Entries: make([]ImportEntry, 0, len(feeds))}
+	for _, f := range feeds {
+		entry := importFeed(f, subscribe)
+		switch entry.Status {
+		case importSubscribed:
+			resp.Subscribed++
+		case importSkipped:
+			resp.Skipped++
+		case importFailed:
+			logger.Error("Failed to import feed", zap.String("url", f.XMLURL), zap.String("error", entry.Error))
+			resp.Failed++
+		default:
+			resp.Unrecognized++
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+
+	logger.Info("Import opml successfully", zap.Int("subscribed", resp.Subscribed),
+		zap.Int("skipped", resp.Skipped), zap.Int("unrecognized", resp.Unrecognized), zap.Int("failed", resp.Failed))
+	return c.JSON(http.StatusOK, httputil.NewResp("success", resp))
+}
+
+var (
+	reZhihuFeed   = regexp.MustCompile(`^zhihu/(answer|article|pin|question|column|collection)/([^/]+)$`)
+	reZsxqFeed    = regexp.MustCompile(`^zsxq/(\d+)$`)
+	reXiaobotFeed = regexp.MustCompile(`^xiaobot/([^/]+)$`)
+	reGitHubFeed  = regexp.MustCompile(`^github/(pre/)?([^/]+/[^/]+)$`)
+)
+
+// parseFeed 把本站 feed 路径还原为建订阅请求，固定 feed 返回 false。
+func parseFeed(path, title string) (subscription.CreateRequest, bool) {
+	if m := reZhihuFeed.FindStringSubmatch(path); m != nil {
+		return subscription.CreateRequest{Platform: subscriptionDB.PlatformZhihu, TargetID: m[2], Kind: m[1]}, true
+	}
+	if m := reZsxqFeed.FindStringSubmatch(path); m != nil {
+		return subscription.CreateRequest{Platform: subscriptionDB.PlatformZsxq, TargetID: m[1], Name: title}, true
+	}
+	if m := reXiaobotFeed.FindStringSubmatch(path); m != nil {
+		return subscription.CreateRequest{Platform: subscriptionDB.PlatformXiaobot, TargetID: m[1]}, true
+	}
+	if m := reGitHubFeed.FindStringSubmatch(path); m != nil {
+		kind := subscription.GitHubKindRelease
+		if m[1] != "" {
+			kind = subscription.GitHubKindPrerelease
+		}
+		return subscription.CreateRequest{Platform: subscriptionDB.PlatformGitHub, TargetID: m[2], Kind: kind}, true
+	}
+	return subscription.CreateRequest{}, false
+}
+
+func importFeed(f opml.Feed, subscribe subscribeFunc) ImportEntry {
+	entry := ImportEntry{URL: f.XMLURL}
+	path, ok := rssPath(f.XMLURL)
+	if !ok {
+		entry.Status = importUnrecognized
+		return entry
+	}
+	req, ok := parseFeed(path, f.Title)
+	if !ok {
+		entry.Status = importSkipped
+		return entry
+	}
+	sub, err := subscribe(req)
+	if err != nil {
+		entry.Status, entry.Error = importFailed, err.Error()
+		return entry
+	}
+	entry.Status = importSubscribed
+	entry.Platform, entry.SubscriptionID, entry.FeedPath = sub.Platform, sub.ID, sub.FeedPath
+	return entry
+}
+
+// rssPath 取出本站 feed 地址中 /rss/ 之后的部分，如 zhihu/answer/canglimo。
+func rssPath(feedURL string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(feedURL))
+	if err != nil {
+		return "", false
+	}
+	_, path, ok := strings.Cut(u.Path, "/rss/")
+	if !ok || path == "" {
+		return "", false
+	}
+	return strings.TrimSuffix(path, "/"), true
+}
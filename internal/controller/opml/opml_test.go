@@ -0,0 +1,158 @@
+package opml
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/feedtoken"
+	"github.com/eli-yip/rss-zero/pkg/opml"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+type fakeSource struct {
+	platform string
+	subs     []subscription.Subscription
+	calls    *[]string
+}
+
+func (f *fakeSource) Platform() string { return f.platform }
+func (f *fakeSource) List() ([]subscription.Subscription, error) {
+	return append([]subscription.Subscription(nil), f.subs...), nil
+}
+
+func (f *fakeSource) Create(req subscription.CreateRequest, _ *zap.Logger) (subscription.Subscription, error) {
+	if req.TargetID == "missing" {
+		return subscription.Subscription{}, errors.New("not found")
+	}
+	*f.calls = append(*f.calls, f.platform+":"+req.Kind+":"+req.TargetID+":"+req.Name)
+	sub := subscription.Subscription{Platform: f.platform, ID: req.TargetID}
+	f.subs = append(f.subs, sub)
+	return sub, nil
+}
+
+func (f *fakeSource) Pause(string) error  { return nil }
+func (f *fakeSource) Resume(string) error { return nil }
+func (f *fakeSource) Delete(string) error { return nil }
+func (f *fakeSource) RecentItemTimes(subscription.Subscription, int) ([]time.Time, error) {
+	return nil, nil
+}
+
+type fakeStatDB struct{ subscriptionDB.DB }
+
+func (fakeStatDB) GetStats(string) (map[string]subscriptionDB.Stat, error) { return nil, nil }
+
+func newTestController(calls *[]string, subs ...subscription.Subscription) *Controller {
+	sources := make([]subscription.Source, 0, 4)
+	for _, p := range []string{subscriptionDB.PlatformZhihu, subscriptionDB.PlatformZsxq, subscriptionDB.PlatformXiaobot, subscriptionDB.PlatformGitHub} {
+		s := &fakeSource{platform: p, calls: calls}
+		for _, sub := range subs {
+			if sub.Platform == p {
+				s.subs = append(s.subs, sub)
+			}
+		}
+		sources = append(sources, s)
+	}
+	registry := subscription.NewRegistry(fakeStatDB{}, sources...)
+	return NewController(registry, subscription.NewOwnership(registry, nil, 0, ""))
+}
+
+func (h *Controller) create(req subscription.CreateRequest) (subscription.Subscription, error) {
+	return h.registry.Create(req, zap.NewNop())
+}
+
+func TestImportFeed(t *testing.T) {
+	var calls []string
+	h := newTestController(&calls)
+
+	cases := []struct {
+		url    string
+		result string
+	}{
+		{"https://rss.example.com/rss/zhihu/answer/canglimo", importSubscribed},
+		{"https://other.example.com/rss/zhihu/pin/canglimo/", importSubscribed},
+		{"https://rss.example.com/rss/zhihu/question/19550225", importSubscribed},
+		{"https://rss.example.com/rss/zhihu/column/c_1234567890", importSubscribed},
+		{"https://rss.example.com/rss/zsxq/123", importSubscribed},
+		{"https://rss.example.com/rss/xiaobot/paper1", importSubscribed},
+		{"https://rss.example.com/rss/xiaobot/missing", importFailed},
+		{"https://rss.example.com/rss/github/golang/go", importSubscribed},
+		{"https://rss.example.com/rss/github/pre/golang/go", importSubscribed},
+		{"https://rss.example.com/rss/zhihu/random", importSkipped},
+		{"https://rss.example.com/rss/zsxq/random", importSkipped},
+		{"https://rss.example.com/rss/macked", importSkipped},
+		{"https://blog.example.com/feed.xml", importUnrecognized},
+	}
+	for _, c := range cases {
+		entry := importFeed(opml.Feed{Title: "星球", XMLURL: c.url}, h.create)
+		assert.Equal(t, c.url, entry.URL)
+		assert.Equal(t, c.result, entry.Status, c.url)
+		assert.Equal(t, c.result == importFailed, entry.Error != "", c.url)
+	}
+	assert.Equal(t, []string{
+		"zhihu:answer:canglimo:",
+		"zhihu:pin:canglimo:",
+		"zhihu:question:19550225:",
+		"zhihu:column:c_1234567890:",
+		"zsxq::123:星球",
+		"xiaobot::paper1:",
+		"github:release:golang/go:",
+		"github:prerelease:golang/go:",
+	}, calls)
+}
+
+func TestCollectGroups(t *testing.T) {
+	var calls []string
+	h := newTestController(&calls,
+		subscription.Subscription{Platform: "zhihu", ID: "1", Name: "墨苍离的知乎想法", Active: true,
+			FeedPath: "/rss/zhihu/pin/canglimo", URL: "https://www.zhihu.com/people/canglimo/pins"},
+		subscription.Subscription{Platform: "zhihu", ID: "2", Name: "已暂停", Active: false, FeedPath: "/rss/zhihu/answer/paused"},
+		subscription.Subscription{Platform: "zsxq", ID: "28855218411241", Name: "墨苍离的星球", Active: true, FeedPath: "/rss/zsxq/28855218411241"},
+		subscription.Subscription{Platform: "github", ID: "3", Name: "golang/go (pre-release)", Active: true, FeedPath: "/rss/github/pre/golang/go"},
+	)
+	groups, err := h.collectGroups("https://rss.example.com")
+	require.NoError(t, err)
+	require.Len(t, groups, 5)
+
+	assert.Equal(t, "知乎", groups[0].Name)
+	assert.Equal(t, []opml.Feed{{Title: "墨苍离的知乎想法", XMLURL: "https://rss.example.com/rss/zhihu/pin/canglimo",
+		HTMLURL: "https://www.zhihu.com/people/canglimo/pins"}}, groups[0].Feeds)
+	assert.Equal(t, "https://rss.example.com/rss/zsxq/28855218411241", groups[1].Feeds[0].XMLURL)
+	assert.Empty(t, groups[2].Feeds)
+	assert.Equal(t, "https://rss.example.com/rss/github/pre/golang/go", groups[3].Feeds[0].XMLURL)
+
+	// 导出的本站 feed 都能被导入识别
+	for _, g := range groups {
+		for _, f := range g.Feeds {
+			entry := importFeed(f, h.create)
+			assert.NotEqual(t, importUnrecognized, entry.Status, f.XMLURL)
+			assert.NotEqual(t, importFailed, entry.Status, f.XMLURL)
+		}
+	}
+}
+
+func TestCollectGroupsSignsPaidFeeds(t *testing.T) {
+	var calls []string
+	h := newTestController(&calls,
+		subscription.Subscription{Platform: "zhihu", ID: "1", Active: true, FeedPath: "/rss/zhihu/pin/canglimo"},
+		subscription.Subscription{Platform: "zsxq", ID: "42", Active: true, FeedPath: "/rss/zsxq/42"},
+	)
+	h.ownership = subscription.NewOwnership(h.registry, nil, 0, "secret")
+
+	groups, err := h.collectGroups("https://rss.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://rss.example.com/rss/zhihu/pin/canglimo", groups[0].Feeds[0].XMLURL)
+	assert.Equal(t, "https://rss.example.com/rss/zsxq/42?token="+feedtoken.Sign("secret", "", "/rss/zsxq/42"),
+		groups[1].Feeds[0].XMLURL)
+
+	// 带令牌的地址仍能导入
+	entry := importFeed(groups[1].Feeds[0], h.create)
+	assert.Equal(t, importSubscribed, entry.Status)
+	assert.Equal(t, "zsxq", entry.Platform)
+	assert.Equal(t, "42", entry.SubscriptionID)
+}
@@ -0,0 +1,14 @@
+package subscription
+
+import (
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+)
+
+type Controller struct {
+	registry  *subscription.Registry
+	ownership *subscription.Ownership
+}
+
+func NewController(registry *subscription.Registry, ownership *subscription.Ownership) *Controller {
+	return &Controller{registry: registry, ownership: ownership}
+}
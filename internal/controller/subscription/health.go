@@ -0,0 +1,69 @@
+package subscription
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+)
+
+type HealthResponse struct {
+	// Summary 是各健康状态的订阅数
+	Summary       map[string]int        `json:"summary"`
+	Subscriptions []subscription.Health `json:"subscriptions"`
+}
+
+// Health 评估启用中订阅的抓取健康状态，可按 platform 过滤；unhealthy=true 时只返回非 ok 的订阅。
+//
+// GET /api/v1/health/subscriptions
+func (h *Controller) Health(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	platform, err := echo.QueryParamOr[string](c, "platform", "")
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	unhealthyOnly, err := parseUnhealthy(c)
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	result, err := h.registry.Health(platform, time.Now())
+	if err != nil {
+		logger.Error("Failed to evaluate subscription health", zap.Error(err))
+		return subscriptionHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", buildHealthResponse(result, unhealthyOnly)))
+}
+
+func parseUnhealthy(c *echo.Context) (bool, error) {
+	raw, err := echo.QueryParamOr[string](c, "unhealthy", "")
+	if err != nil || raw == "" {
+		return false, err
+	}
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid unhealthy %q: %w", raw, err)
+	}
+	return b, nil
+}
+
+func buildHealthResponse(result []subscription.Health, unhealthyOnly bool) HealthResponse {
+	resp := HealthResponse{Summary: make(map[string]int), Subscriptions: make([]subscription.Health, 0, len(result))}
+	for _, h := range result {
+		resp.Summary[h.Status]++
+		if unhealthyOnly && h.Status == subscription.HealthOK {
+			continue
+		}
+		resp.Subscriptions = append(resp.Subscriptions, h)
+	}
+	return resp
+}
@@ -0,0 +1,152 @@
+package subscription
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/internal/middleware"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+)
+
+// currentUser 返回 InjectUser 放入的用户。
+func currentUser(c *echo.Context) (subscription.User, error) {
+	username, err := echo.ContextGet[string](c, "username")
+	if err != nil || username == "" {
+		return subscription.User{}, httputil.NewHTTPError(http.StatusInternalServerError, "missing username")
+	}
+	return subscription.User{Name: username, Admin: middleware.IsAdmin(c)}, nil
+}
+
+// MyList 列出当前用户订阅的订阅，付费 feed 的 feed_path 带有该用户的访问令牌。
+//
+// GET /api/v1/my/subscriptions
+func (h *Controller) MyList(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	filter, err := parseFilter(c)
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	subs, err := h.ownership.List(user, filter)
+	if err != nil {
+		logger.Error("Failed to list user subscriptions", zap.Error(err))
+		return subscriptionHTTPError(err)
+	}
+	logger.Info("List user subscriptions successfully", zap.Int("count", len(subs)))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", subs))
+}
+
+// MySubscribe 为当前用户订阅，订阅已存在时只记到用户名下。非管理员受平台、配额与付费授权限制。
+//
+// POST /api/v1/my/subscriptions
+func (h *Controller) MySubscribe(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	user, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	var req subscription.CreateRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("Failed to bind request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	logger.Info("Start to subscribe", zap.Any("request", req))
+
+	sub, err := h.ownership.Subscribe(user, req, logger)
+	if err != nil {
+		logger.Error("Failed to subscribe", zap.Error(err))
+		return subscriptionHTTPError(err)
+	}
+	logger.Info("Subscribe successfully", zap.String("platform", sub.Platform), zap.String("id", sub.ID))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", sub))
+}
+
+// MyUnsubscribe 把订阅从当前用户名下移除，不影响平台订阅本身。
+//
+// DELETE /api/v1/my/subscriptions/:platform/:id
+func (h *Controller) MyUnsubscribe(c *echo.Context) (err error) {
+	user, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	return h.mutate(c, "unsubscribe", func(platform, id string) error {
+		return h.ownership.Unsubscribe(user, platform, id)
+	})
+}
+
+type GrantRequest struct {
+	Platform string `json:"platform"`
+	TargetID string `json:"target_id"`
+	Username string `json:"username"`
+}
+
+// ListGrants 列出付费来源的授权，可按 username 过滤。
+//
+// GET /api/v1/grants
+func (h *Controller) ListGrants(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	username, err := echo.QueryParamOr[string](c, "username", "")
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	grants, err := h.ownership.Grants(username)
+	if err != nil {
+		logger.Error("Failed to list grants", zap.Error(err))
+		return subscriptionHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, httputil.NewResp("success", grants))
+}
+
+// CreateGrant 授权用户访问星球 group 或小报童 paper。
+//
+// POST /api/v1/grants
+func (h *Controller) CreateGrant(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	var req GrantRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("Failed to bind request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	by := c.Request().Header.Get("Remote-User")
+	if token := common.ExtractAPIToken(c); token != nil {
+		by = token.Username
+	}
+
+	if err = h.ownership.Grant(req.Platform, req.TargetID, req.Username, by); err != nil {
+		logger.Error("Failed to create grant", zap.Error(err))
+		return subscriptionHTTPError(err)
+	}
+	logger.Info("Create grant successfully", zap.Any("grant", req))
+
+	return c.JSON(http.StatusOK, httputil.NewMessage("Success"))
+}
+
+// DeleteGrant 收回授权，用户名下的订阅保留但不再可见，其 feed 令牌失效。
+//
+// DELETE /api/v1/grants/:platform/:target/:username
+func (h *Controller) DeleteGrant(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+	platform, _ := echo.PathParam[string](c, "platform")
+	target, _ := echo.PathParam[string](c, "target")
+	username, _ := echo.PathParam[string](c, "username")
+
+	if err = h.ownership.Revoke(platform, target, username); err != nil {
+		logger.Error("Failed to delete grant", zap.Error(err))
+		return subscriptionHTTPError(err)
+	}
+	logger.Info("Delete grant successfully", zap.String("platform", platform), zap.String("target", target), zap.String("username", username))
+
+	return c.JSON(http.StatusOK, httputil.NewMessage("Success"))
+}
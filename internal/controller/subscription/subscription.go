@@ -0,0 +1,151 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+)
+
+// subscriptionHTTPError 把订阅层错误映射为 HTTP 状态码。
+func subscriptionHTTPError(err error) error {
+	switch {
+	case errors.Is(err, subscription.ErrUnknownPlatform), errors.Is(err, subscription.ErrNotFound):
+		return httputil.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, subscription.ErrInvalidTarget):
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, subscription.ErrDeleteUnsupported):
+		return httputil.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, subscription.ErrForbidden), errors.Is(err, subscription.ErrQuotaExceeded):
+		return httputil.NewHTTPError(http.StatusForbidden, err.Error())
+	default:
+		return httputil.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+}
+
+// List 列出订阅，可按 platform 与 active（true/false）过滤。
+//
+// GET /api/v1/subscriptions
+func (h *Controller) List(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	filter, err := parseFilter(c)
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	subs, err := h.registry.List(filter)
+	if err != nil {
+		logger.Error("Failed to list subscriptions", zap.Error(err))
+		return subscriptionHTTPError(err)
+	}
+	logger.Info("List subscriptions successfully", zap.Int("count", len(subs)))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", subs))
+}
+
+func parseFilter(c *echo.Context) (filter subscription.Filter, err error) {
+	if filter.Platform, err = echo.QueryParamOr[string](c, "platform", ""); err != nil {
+		return filter, err
+	}
+	active, err := echo.QueryParamOr[string](c, "active", "")
+	if err != nil {
+		return filter, err
+	}
+	if active != "" {
+		b, err := strconv.ParseBool(active)
+		if err != nil {
+			return filter, fmt.Errorf("invalid active %q: %w", active, err)
+		}
+		filter.Active = &b
+	}
+	return filter, nil
+}
+
+// Create 建立订阅，已存在（含已暂停）时原样返回。
+//
+// POST /api/v1/subscriptions
+func (h *Controller) Create(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	var req subscription.CreateRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("Failed to bind request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	logger.Info("Start to create subscription", zap.Any("request", req))
+
+	sub, err := h.registry.Create(req, logger)
+	if err != nil {
+		logger.Error("Failed to create subscription", zap.Error(err))
+		return subscriptionHTTPError(err)
+	}
+	logger.Info("Create subscription successfully", zap.String("platform", sub.Platform), zap.String("id", sub.ID))
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", sub))
+}
+
+// Pause 暂停订阅：cron 不再抓取，feed 仍可访问。
+//
+// POST /api/v1/subscriptions/:platform/:id/pause
+func (h *Controller) Pause(c *echo.Context) error {
+	return h.mutate(c, "pause", h.registry.Pause)
+}
+
+// Resume 恢复已暂停的订阅。
+//
+// POST /api/v1/subscriptions/:platform/:id/resume
+func (h *Controller) Resume(c *echo.Context) error {
+	return h.mutate(c, "resume", h.registry.Resume)
+}
+
+// Delete 彻底删除订阅，已抓取的内容保留。小报童与星球的订阅记录兼作 feed 元数据，只能暂停。
+//
+// DELETE /api/v1/subscriptions/:platform/:id
+func (h *Controller) Delete(c *echo.Context) error {
+	return h.mutate(c, "delete", h.registry.Delete)
+}
+
+func (h *Controller) mutate(c *echo.Context, action string, fn func(platform, id string) error) (err error) {
+	platform, err := echo.PathParam[string](c, "platform")
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, "missing platform")
+	}
+	return h.apply(c, action, platform, fn)
+}
+
+// Legacy 把旧的 /api/v1/sub/<platform>/... 接口适配到注册表：旧接口的 delete 语义是停用，即 pause，
+// activate 即 resume。
+func (h *Controller) Legacy(platform, action string) echo.HandlerFunc {
+	fn := h.registry.Pause
+	if action == "resume" {
+		fn = h.registry.Resume
+	}
+	return func(c *echo.Context) error { return h.apply(c, action, platform, fn) }
+}
+
+func (h *Controller) apply(c *echo.Context, action, platform string, fn func(platform, id string) error) (err error) {
+	logger := common.ExtractLogger(c)
+
+	id, err := echo.PathParam[string](c, "id")
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, "missing subscription ID")
+	}
+	logger = logger.With(zap.String("platform", platform), zap.String("id", id))
+	logger.Info("Start to " + action + " subscription")
+
+	if err = fn(platform, id); err != nil {
+		logger.Error("Failed to "+action+" subscription", zap.Error(err))
+		return subscriptionHTTPError(err)
+	}
+	logger.Info("Apply subscription " + action + " successfully")
+
+	return c.JSON(http.StatusOK, httputil.NewMessage("Success"))
+}
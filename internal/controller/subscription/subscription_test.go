@@ -0,0 +1,82 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+)
+
+func TestParseFilter(t *testing.T) {
+	active, paused := true, false
+	tests := []struct {
+		name  string
+		query string
+		want  subscription.Filter
+	}{
+		{name: "无筛选", want: subscription.Filter{}},
+		{name: "平台与启用", query: "?platform=zhihu&active=true", want: subscription.Filter{Platform: "zhihu", Active: &active}},
+		{name: "已暂停", query: "?active=false", want: subscription.Filter{Active: &paused}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/subscriptions"+tt.query, nil), httptest.NewRecorder())
+
+			got, err := parseFilter(c)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/subscriptions?active=maybe", nil), httptest.NewRecorder())
+	_, err := parseFilter(c)
+	assert.Error(t, err)
+}
+
+func TestSubscriptionHTTPError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: weibo", subscription.ErrUnknownPlatform), http.StatusNotFound},
+		{fmt.Errorf("%w: zhihu/x", subscription.ErrNotFound), http.StatusNotFound},
+		{subscription.ErrInvalidTarget, http.StatusBadRequest},
+		{subscription.ErrDeleteUnsupported, http.StatusConflict},
+		{fmt.Errorf("%w: zsxq/1", subscription.ErrForbidden), http.StatusForbidden},
+		{subscription.ErrQuotaExceeded, http.StatusForbidden},
+		{errors.New("db down"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		var respErr *httputil.ResponseError
+		require.ErrorAs(t, subscriptionHTTPError(tt.err), &respErr)
+		assert.Equal(t, tt.code, respErr.Code, tt.err.Error())
+	}
+}
+
+func TestBuildHealthResponse(t *testing.T) {
+	result := []subscription.Health{
+		{Subscription: subscription.Subscription{ID: "a"}, Status: subscription.HealthOK},
+		{Subscription: subscription.Subscription{ID: "b"}, Status: subscription.HealthStale},
+		{Subscription: subscription.Subscription{ID: "c"}, Status: subscription.HealthFailing},
+	}
+
+	resp := buildHealthResponse(result, false)
+	assert.Len(t, resp.Subscriptions, 3)
+	assert.Equal(t, map[string]int{subscription.HealthOK: 1, subscription.HealthStale: 1, subscription.HealthFailing: 1}, resp.Summary)
+
+	// 只看异常时 summary 仍统计全部
+	resp = buildHealthResponse(result, true)
+	require.Len(t, resp.Subscriptions, 2)
+	assert.Equal(t, "b", resp.Subscriptions[0].ID)
+	assert.Equal(t, 1, resp.Summary[subscription.HealthOK])
+}
@@ -0,0 +1,141 @@
+// Package controller 提供个人 API token 的创建、列出与吊销接口。
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/internal/middleware"
+	"github.com/eli-yip/rss-zero/pkg/apitoken"
+	apitokenDB "github.com/eli-yip/rss-zero/pkg/apitoken/db"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+)
+
+// TokenService 是 controller 用到的 apitoken.Service 方法。
+type TokenService interface {
+	Create(username, name string, scopes []string, expiresAt *time.Time) (string, *apitokenDB.Token, error)
+	List(username string) ([]*apitokenDB.Token, error)
+	Delete(username, id string) error
+}
+
+type Controller struct{ tokens TokenService }
+
+func NewController(tokens TokenService) *Controller { return &Controller{tokens: tokens} }
+
+type CreateRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// ExpiresAt 为 RFC3339 时间，留空表示永不过期
+	ExpiresAt *string `json:"expires_at"`
+}
+
+type CreateResponse struct {
+	// Token 是明文，只在创建时返回这一次
+	Token string            `json:"token"`
+	Info  *apitokenDB.Token `json:"info"`
+}
+
+// Create 为当前用户创建 token。非管理员只能创建 archive:read，用 token 调用本接口需要 admin scope。
+//
+// POST /api/v1/token
+func (h *Controller) Create(c *echo.Context) error {
+	logger := common.ExtractLogger(c)
+	username, err := h.caller(c)
+	if err != nil {
+		return err
+	}
+
+	var req CreateRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("Failed to bind token request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	if req.Name == "" {
+		return httputil.NewHTTPError(http.StatusBadRequest, "name is required")
+	}
+	if !middleware.IsAdmin(c) && slices.ContainsFunc(req.Scopes, func(s string) bool { return s != apitoken.ScopeArchiveRead }) {
+		return httputil.NewHTTPError(http.StatusForbidden, "only admins can create tokens beyond "+apitoken.ScopeArchiveRead)
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		parsed, err := time.Parse(time.RFC3339, *req.ExpiresAt)
+		if err != nil {
+			return httputil.NewHTTPError(http.StatusBadRequest, "expires_at must be RFC3339")
+		}
+		if !parsed.After(time.Now()) {
+			return httputil.NewHTTPError(http.StatusBadRequest, "expires_at must be in the future")
+		}
+		expiresAt = &parsed
+	}
+
+	plain, token, err := h.tokens.Create(username, req.Name, req.Scopes, expiresAt)
+	if err != nil {
+		if errors.Is(err, apitoken.ErrUnknownScope) {
+			return httputil.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		logger.Error("Failed to create api token", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "failed to create api token")
+	}
+	logger.Info("Created api token", zap.String("token_id", token.ID), zap.Strings("scopes", token.Scopes))
+	return c.JSON(http.StatusOK, httputil.NewResp("token created, it will not be shown again", CreateResponse{Token: plain, Info: token}))
+}
+
+// List 列出当前用户的 token，不含明文。
+//
+// GET /api/v1/token
+func (h *Controller) List(c *echo.Context) error {
+	logger := common.ExtractLogger(c)
+	username, err := h.caller(c)
+	if err != nil {
+		return err
+	}
+	tokens, err := h.tokens.List(username)
+	if err != nil {
+		logger.Error("Failed to list api tokens", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "failed to list api tokens")
+	}
+	return c.JSON(http.StatusOK, httputil.NewResp("success", tokens))
+}
+
+// Delete 吊销当前用户的一个 token。
+//
+// DELETE /api/v1/token/:id
+func (h *Controller) Delete(c *echo.Context) error {
+	logger := common.ExtractLogger(c)
+	username, err := h.caller(c)
+	if err != nil {
+		return err
+	}
+	id, err := echo.PathParam[string](c, "id")
+	if err != nil || id == "" {
+		return httputil.NewHTTPError(http.StatusBadRequest, "empty token id")
+	}
+	if err = h.tokens.Delete(username, id); err != nil {
+		if errors.Is(err, apitokenDB.ErrTokenNotFound) {
+			return httputil.NewHTTPError(http.StatusNotFound, "token not found")
+		}
+		logger.Error("Failed to delete api token", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "failed to delete api token")
+	}
+	logger.Info("Deleted api token", zap.String("token_id", id))
+	return c.JSON(http.StatusOK, httputil.NewMessage("token deleted"))
+}
+
+// caller 返回 InjectUser 放入的用户名。用 token 管理 token 需要 admin scope，免得只读 token 自行扩权。
+func (h *Controller) caller(c *echo.Context) (string, error) {
+	if token := common.ExtractAPIToken(c); token != nil && !apitoken.Allows(token, apitoken.ScopeAdmin) {
+		return "", httputil.NewHTTPError(http.StatusForbidden, "API token lacks scope "+apitoken.ScopeAdmin)
+	}
+	username, err := echo.ContextGet[string](c, "username")
+	if err != nil {
+		return "", httputil.NewHTTPError(http.StatusInternalServerError, "missing username")
+	}
+	return username, nil
+}
@@ -103,7 +103,7 @@ func (h *Controller) Export(c *echo.Context) (err error) {
 			defer cancel()
 
 			uploadErrCh2 := make(chan error, 1)
-			uploadErrCh2 <- minioService.SaveStream(objectKey, pr, -1)
+			uploadErrCh2 <- minioService.SaveStream(context.Background(), objectKey, pr, -1)
 
 			select {
 			case err := <-uploadErrCh2:
@@ -145,7 +145,7 @@ func (h *Controller) Export(c *echo.Context) (err error) {
 			return
 		}
 
-		if err = minioService.Delete(objectKey); err != nil {
+		if err = minioService.Delete(context.Background(), objectKey); err != nil {
 			logger.Error("Failed to delete object", zap.Error(err))
 			notify.NoticeWithLogger(h.notifier, "Failed to delete object", err.Error(), logger)
 		} else {
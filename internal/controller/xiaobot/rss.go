@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,7 +17,7 @@ import (
 	"github.com/eli-yip/rss-zero/pkg/routers/xiaobot/request"
 )
 
-// RSS serves a xiaobot paper feed through the unified pipeline. checkPaper
+// RSS serves a xiaobot paper feed through the unified pipeline. CheckPaper
 // auto-subscribes an unknown paper before the generic Serve fetches and renders.
 func (h *Controller) RSS(c *echo.Context) error {
 	logger := common.ExtractLogger(c)
@@ -27,7 +28,7 @@ func (h *Controller) RSS(c *echo.Context) error {
 	}
 	logger.Info("Retrieved rss request", zap.String("paper id", paperID))
 
-	if err := h.checkPaper(paperID, logger); err != nil {
+	if err := h.CheckPaper(paperID, logger); err != nil {
 		if errors.Is(err, errPaperNotExistInXiaobot) {
 			err = errors.Join(err, errors.New("paper does not exist in xiaobot"))
 			logger.Error("Error return rss", zap.String("paper id", paperID), zap.Error(err))
@@ -52,7 +53,7 @@ func (h *Controller) RSS(c *echo.Context) error {
 
 var errPaperNotExistInXiaobot = errors.New("paper does not exist in xiaobot")
 
-func (h *Controller) checkPaper(paperID string, logger *zap.Logger) (err error) {
+func (h *Controller) CheckPaper(paperID string, logger *zap.Logger) (err error) {
 	exist, err := h.db.CheckPaperIncludeDeleted(paperID)
 	if err != nil {
 		return err
@@ -68,7 +69,7 @@ func (h *Controller) checkPaper(paperID string, logger *zap.Logger) (err error)
 		logger.Info("Retrieved xiaobot token from db")
 
 		requestService := request.NewRequestService(h.cookie, token, h.logger)
-		data, err := requestService.Limit(fmt.Sprintf("https://api.xiaobot.net/paper/%s?refer_channel=", paperID))
+		data, err := requestService.Limit(context.Background(), fmt.Sprintf("https://api.xiaobot.net/paper/%s?refer_channel=", paperID))
 		if err != nil {
 			return err
 		}
@@ -40,38 +40,3 @@ func (h *Controller) GetSubs(c *echo.Context) (err error) {
 
 	return c.JSON(http.StatusOK, httputil.NewResp("success", resp))
 }
-
-func (h *Controller) ActivateSub(c *echo.Context) (err error) {
-	logger := common.ExtractLogger(c)
-
-	subID, err := echo.PathParam[string](c, "id")
-	if err != nil {
-		return httputil.NewHTTPError(http.StatusBadRequest, "missing subscription ID")
-	}
-	logger.Info("Activate xiaobot sub", zap.String("sub_id", subID))
-
-	err = h.db.ActivatePaper(subID)
-	if err != nil {
-		logger.Error("Failed to activate xiaobot sub", zap.String("sub_id", subID), zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to activate xiaobot sub")
-	}
-	logger.Info("Activate xiaobot sub successfully", zap.String("sub_id", subID))
-
-	return c.JSON(http.StatusOK, httputil.NewMessage("Activate xiaobot sub successfully"))
-}
-
-func (h *Controller) DeleteSub(c *echo.Context) (err error) {
-	logger := common.ExtractLogger(c)
-
-	subID, err := echo.PathParam[string](c, "id")
-	if err != nil {
-		return httputil.NewHTTPError(http.StatusBadRequest, "missing subscription ID")
-	}
-	logger.Info("Delete xiaobot sub", zap.String("sub_id", subID))
-
-	if err = h.db.DeletePaper(subID); err != nil {
-		logger.Error("Failed to delete xiaobot sub", zap.String("sub_id", subID), zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to delete xiaobot sub")
-	}
-	return c.JSON(http.StatusOK, httputil.NewMessage("Delete xiaobot sub successfully"))
-}
@@ -136,10 +136,26 @@ func (h *Controller) Activate(c *echo.Context) (err error) {
 	if err != nil {
 		return httputil.NewHTTPError(http.StatusBadRequest, "ID is required")
 	}
-	if err = h.db.MarkAvailable(id); err != nil {
+	if err = h.db.EnableService(id); err != nil {
 		logger.Error("Failed to activate zhihu encryption service", zap.Error(err))
 		return httputil.NewHTTPError(http.StatusInternalServerError, "failed to activate service")
 	}
 
 	return c.JSON(http.StatusOK, httputil.NewMessage("success"))
 }
+
+// Deactivate 手动停用加密服务，健康探测不会再自动上线它，直到调用 Activate。
+func (h *Controller) Deactivate(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	id, err := echo.PathParam[string](c, "id")
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, "ID is required")
+	}
+	if err = h.db.DisableService(id); err != nil {
+		logger.Error("Failed to deactivate zhihu encryption service", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "failed to deactivate service")
+	}
+
+	return c.JSON(http.StatusOK, httputil.NewMessage("success"))
+}
@@ -16,5 +16,7 @@ import (
 func (h *Controller) RandomCanglimoAnswers(c *echo.Context) error {
 	logger := serverCommon.ExtractLogger(c)
 	return rss.ServeCachedString(c, h.redis, logger, redis.ZhihuRandomCanglimoAnswersPath, redis.RSSRandomTTL,
-		func() (string, error) { return random.GenerateRandomCanglimoAnswerRSS(h.db, logger) })
+		func() (string, error) {
+			return random.GenerateRandomCanglimoAnswerRSS(h.db, random.DefaultPickCount, logger)
+		})
 }
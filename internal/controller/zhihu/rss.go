@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v5"
 	"go.uber.org/zap"
@@ -29,6 +30,18 @@ func (h *Controller) AnswerRSS(c *echo.Context) error  { return h.serveZhihu(c,
 func (h *Controller) ArticleRSS(c *echo.Context) error { return h.serveZhihu(c, common.ZhihuArticle) }
 func (h *Controller) PinRSS(c *echo.Context) error     { return h.serveZhihu(c, common.ZhihuPin) }
 
+// QuestionRSS serves every new answer to a zhihu question, adding the question
+// subscription on first request (400 if the question is unknown to zhihu).
+func (h *Controller) QuestionRSS(c *echo.Context) error { return h.serveZhihu(c, common.ZhihuQuestion) }
+
+// ColumnRSS / CollectionRSS serve the answers and articles added to a zhihu column
+// or public collection, adding the subscription on first request (400 if the
+// column or collection is unknown to zhihu or private).
+func (h *Controller) ColumnRSS(c *echo.Context) error { return h.serveZhihu(c, common.ZhihuColumn) }
+func (h *Controller) CollectionRSS(c *echo.Context) error {
+	return h.serveZhihu(c, common.ZhihuCollection)
+}
+
 func (h *Controller) serveZhihu(c *echo.Context, contentType common.ZhihuContentType) error {
 	logger := serverCommon.ExtractLogger(c)
 
@@ -38,11 +51,19 @@ func (h *Controller) serveZhihu(c *echo.Context, contentType common.ZhihuContent
 	}
 	logger.Info("Retrieve rss request", zap.String("author_id", authorID))
 
-	if err := h.checkSub(contentType, authorID, logger); err != nil {
+	if err := h.CheckSub(contentType, authorID, logger); err != nil {
 		if errors.Is(err, errAuthorNotExistInZhihu) {
 			logger.Error("Failed to find author in zhihu website", zap.String("author_id", authorID))
 			return httputil.NewHTTPError(http.StatusBadRequest, "Author does not exist in zhihu website")
 		}
+		if errors.Is(err, errQuestionNotExistInZhihu) {
+			logger.Error("Failed to find question in zhihu website", zap.String("question_id", authorID))
+			return httputil.NewHTTPError(http.StatusBadRequest, "Question does not exist in zhihu website")
+		}
+		if errors.Is(err, errListNotExistInZhihu) {
+			logger.Error("Failed to find list in zhihu website", zap.String("list_id", authorID))
+			return httputil.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s does not exist in zhihu website", contentType.Slug()))
+		}
 		logger.Error("Failed to check sub", zap.Error(err))
 		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to check sub")
 	}
@@ -59,8 +80,33 @@ func (h *Controller) serveZhihu(c *echo.Context, contentType common.ZhihuContent
 	})
 }
 
-// checkSub checks if the sub exists in db, if not, add it to db.
-func (h *Controller) checkSub(t common.ZhihuContentType, authorID string, logger *zap.Logger) (err error) {
+// ChangesRSS serves the edits and deletions found in a zhihu author's answers and
+// articles. It reads archived revisions only, so no subscription is created.
+func (h *Controller) ChangesRSS(c *echo.Context) error {
+	logger := serverCommon.ExtractLogger(c)
+
+	authorID, err := echo.ContextGet[string](c, "feed_id")
+	if err != nil {
+		return fmt.Errorf("failed to get feed id: %w", err)
+	}
+	logger.Info("Retrieve zhihu changes rss request", zap.String("author_id", authorID))
+
+	return rss.Serve(c, rss.ServeOptions{
+		Redis:        h.redis,
+		Logger:       logger,
+		Key:          fmt.Sprintf(redis.ZhihuChangesRSSPath, authorID),
+		TTL:          redis.RSSDefaultTTL,
+		DefaultLimit: 20,
+		Fetch: func() (rss.FeedMeta, []rss.Item, error) {
+			return rss.FetchZhihuChanges(authorID, h.db, logger)
+		},
+	})
+}
+
+// CheckSub checks if the sub exists in db, if not, add it to db.
+// It is shared by the RSS route, OPML import and the sub api.
+// For question, column and collection subs authorID is the question, column or collection id.
+func (h *Controller) CheckSub(t common.ZhihuContentType, authorID string, logger *zap.Logger) (err error) {
 	// Use CheckSubIncludeDeleted instead of CheckSubByID to check if the sub exists,
 	// as we will return history rss content even if the sub is deleted.
 	exist, err := h.db.CheckSubIncludeDeleted(authorID, t)
@@ -74,8 +120,19 @@ func (h *Controller) checkSub(t common.ZhihuContentType, authorID string, logger
 	}
 
 	logger.Info("Start to add zhihu subscription")
-	if _, err = h.parseAuthorName(authorID, logger); err != nil {
-		return fmt.Errorf("failed to parse author name: %w", err)
+	switch t {
+	case common.ZhihuQuestion:
+		if _, err = h.parseQuestionTitle(authorID, logger); err != nil {
+			return fmt.Errorf("failed to parse question title: %w", err)
+		}
+	case common.ZhihuColumn, common.ZhihuCollection:
+		if _, err = h.parseListTitle(t, authorID, logger); err != nil {
+			return fmt.Errorf("failed to parse %s title: %w", t, err)
+		}
+	default:
+		if _, err = h.parseAuthorName(authorID, logger); err != nil {
+			return fmt.Errorf("failed to parse author name: %w", err)
+		}
 	}
 
 	if err = h.db.AddSub(authorID, t); err != nil {
@@ -85,20 +142,32 @@ func (h *Controller) checkSub(t common.ZhihuContentType, authorID string, logger
 	return nil
 }
 
-var errAuthorNotExistInZhihu = errors.New("author does not exist in zhihu")
+var (
+	errAuthorNotExistInZhihu   = errors.New("author does not exist in zhihu")
+	errQuestionNotExistInZhihu = errors.New("question does not exist in zhihu")
+	errListNotExistInZhihu     = errors.New("column or collection does not exist in zhihu")
+)
 
-// parseAuthorName parses author name from authorID, and returns the author name.
-// It will save the author name to db if it's not found in db.
-func (h *Controller) parseAuthorName(authorID string, logger *zap.Logger) (authorName string, err error) {
+func (h *Controller) newRequestService(logger *zap.Logger) (request.Requester, error) {
 	zhihuCookies, err := cookie.GetZhihuCookies(h.cookie, logger)
 	if err != nil {
-		return "", fmt.Errorf("failed to get zhihu cookies: %w", err)
+		return nil, fmt.Errorf("failed to get zhihu cookies: %w", err)
 	}
 	logger.Info("Get zhihu cookies successfully", zap.Any("cookies", zhihuCookies))
 
 	requestService, err := request.NewRequestService(logger, h.db, notify.NewBarkNotifier(config.C.Bark.URL), zhihuCookies, request.WithLimiter(request.NewLimiter()))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request service: %w", err)
+		return nil, fmt.Errorf("failed to create request service: %w", err)
+	}
+	return requestService, nil
+}
+
+// parseAuthorName parses author name from authorID, and returns the author name.
+// It will save the author name to db if it's not found in db.
+func (h *Controller) parseAuthorName(authorID string, logger *zap.Logger) (authorName string, err error) {
+	requestService, err := h.newRequestService(logger)
+	if err != nil {
+		return "", err
 	}
 
 	bytes, err := requestService.LimitRaw(context.Background(), zhihuCrawl.GenerateAnswerApiURL(authorID, 0), logger)
@@ -125,3 +194,78 @@ func (h *Controller) parseAuthorName(authorID string, logger *zap.Logger) (autho
 
 	return authorName, nil
 }
+
+// parseQuestionTitle requests the question from zhihu, saves it to db and returns its title.
+func (h *Controller) parseQuestionTitle(questionID string, logger *zap.Logger) (title string, err error) {
+	if _, err = strconv.Atoi(questionID); err != nil {
+		return "", errQuestionNotExistInZhihu
+	}
+
+	requestService, err := h.newRequestService(logger)
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := requestService.LimitRaw(context.Background(), zhihuCrawl.GenerateQuestionApiURL(questionID), logger)
+	if err != nil {
+		if errors.Is(err, request.ErrUnreachable) {
+			logger.Info("Question does not exist in zhihu website", zap.String("question_id", questionID))
+			return "", errQuestionNotExistInZhihu
+		}
+		return "", fmt.Errorf("failed to get question: %w", err)
+	}
+
+	var parser parse.QuestionParser
+	parser, err = parse.NewParseService(parse.WithDB(h.db))
+	if err != nil {
+		return "", fmt.Errorf("failed to create parse service: %w", err)
+	}
+
+	title, err = parser.ParseQuestion(bytes, logger)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse question: %w", err)
+	}
+	logger.Info("Get question title from zhihu successfully", zap.String("title", title))
+
+	return title, nil
+}
+
+// parseListTitle requests the column or collection from zhihu, saves it to db and returns its title.
+// Private collections are unreachable and reported as not existing.
+func (h *Controller) parseListTitle(t common.ZhihuContentType, listID string, logger *zap.Logger) (title string, err error) {
+	infoURL := zhihuCrawl.GenerateColumnApiURL(listID)
+	if t == common.ZhihuCollection {
+		if _, err = strconv.Atoi(listID); err != nil {
+			return "", errListNotExistInZhihu
+		}
+		infoURL = zhihuCrawl.GenerateCollectionApiURL(listID)
+	}
+
+	requestService, err := h.newRequestService(logger)
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := requestService.LimitRaw(context.Background(), infoURL, logger)
+	if err != nil {
+		if errors.Is(err, request.ErrUnreachable) {
+			logger.Info("List does not exist in zhihu website", zap.String("list_type", t.Slug()), zap.String("list_id", listID))
+			return "", errListNotExistInZhihu
+		}
+		return "", fmt.Errorf("failed to get %s: %w", t, err)
+	}
+
+	var parser parse.ListParser
+	parser, err = parse.NewParseService(parse.WithDB(h.db))
+	if err != nil {
+		return "", fmt.Errorf("failed to create parse service: %w", err)
+	}
+
+	title, err = parser.ParseListInfo(t, listID, bytes, logger)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", t, err)
+	}
+	logger.Info("Get list title from zhihu successfully", zap.String("title", title))
+
+	return title, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strconv"
 
 	"github.com/labstack/echo/v5"
 	"go.uber.org/zap"
@@ -71,7 +72,7 @@ func (h *Controller) GetSubs(c *echo.Context) error {
 	for _, sub := range filteredSubs {
 		nickname, ok := nicknameMap[sub.AuthorID]
 		if !ok {
-			nickname, err = h.db.GetAuthorName(sub.AuthorID)
+			nickname, err = h.subTargetName(sub)
 			if err != nil {
 				logger.Error("Failed to get author name", zap.String("author_id", sub.AuthorID), zap.Error(err))
 				return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to get author name")
@@ -82,7 +83,7 @@ func (h *Controller) GetSubs(c *echo.Context) error {
 		singleSub := SingleSubInfo{
 			ID:      sub.ID,
 			SubType: sub.Type.Slug(),
-			Url:     fmt.Sprintf("https://www.zhihu.com/people/%s/%s", sub.AuthorID, sub.Type.ProfilePath()),
+			Url:     sub.Type.TargetURL(sub.AuthorID),
 			Deleted: sub.DeletedAt.Valid,
 		}
 
@@ -100,6 +101,75 @@ func (h *Controller) GetSubs(c *echo.Context) error {
 	return c.JSON(http.StatusOK, httputil.NewResp("success", &Resp{Subs: respMap}))
 }
 
+// subTargetName 返回订阅对象的名称：作者订阅是作者昵称，问题、专栏与收藏夹订阅是各自的标题。
+func (h *Controller) subTargetName(sub db.Sub) (string, error) {
+	switch sub.Type {
+	case pkgCommon.ZhihuQuestion:
+		id, err := strconv.Atoi(sub.AuthorID)
+		if err != nil {
+			return "", fmt.Errorf("invalid question id %q: %w", sub.AuthorID, err)
+		}
+		question, err := h.db.GetQuestion(id)
+		if err != nil {
+			return "", err
+		}
+		return question.Title, nil
+	case pkgCommon.ZhihuColumn, pkgCommon.ZhihuCollection:
+		list, err := h.db.GetList(sub.Type, sub.AuthorID)
+		if err != nil {
+			return "", err
+		}
+		return list.Title, nil
+	default:
+		return h.db.GetAuthorName(sub.AuthorID)
+	}
+}
+
+type AddSubRequest struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// AddSub 建立知乎订阅，已存在（含已暂停）时不做改动。type 为 answer/article/pin 时 id 是作者
+// url token，为 question 时 id 是问题 id，订阅该问题下所有人的新回答；为 column 或 collection 时
+// id 是专栏或公开收藏夹 id，订阅其中新加入的回答与文章。
+//
+// POST /api/v1/sub/zhihu
+func (h *Controller) AddSub(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	var req AddSubRequest
+	if err = c.Bind(&req); err != nil {
+		logger.Error("Failed to bind zhihu sub request", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	t, err := pkgCommon.ParseZhihuSlug(req.Type)
+	if err != nil || req.ID == "" {
+		return httputil.NewHTTPError(http.StatusBadRequest, "type must be answer, article, pin, question, column or collection, and id is required")
+	}
+	logger.Info("Start to add zhihu sub", zap.String("type", req.Type), zap.String("id", req.ID))
+
+	if err = h.CheckSub(t, req.ID, logger); err != nil {
+		switch {
+		case errors.Is(err, errAuthorNotExistInZhihu):
+			return httputil.NewHTTPError(http.StatusBadRequest, "Author does not exist in zhihu website")
+		case errors.Is(err, errQuestionNotExistInZhihu):
+			return httputil.NewHTTPError(http.StatusBadRequest, "Question does not exist in zhihu website")
+		case errors.Is(err, errListNotExistInZhihu):
+			return httputil.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s does not exist in zhihu website", t.Slug()))
+		}
+		logger.Error("Failed to add zhihu sub", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to add zhihu sub")
+	}
+	logger.Info("Add zhihu sub successfully")
+
+	return c.JSON(http.StatusOK, httputil.NewResp("success", map[string]string{
+		"type":      t.Slug(),
+		"id":        req.ID,
+		"feed_path": fmt.Sprintf("/rss/zhihu/%s/%s", t.Slug(), req.ID),
+	}))
+}
+
 func parseFilterConfig(c *echo.Context) (filterConfig filterConfig, err error) {
 	filterConfig.AuthorID, err = echo.QueryParamsOr[string](c, "author", nil)
 	if err != nil {
@@ -174,35 +244,3 @@ func buildContentTypeFilter(contentType []string) func(subContentType pkgCommon.
 		return slices.Contains(contentType, subContentType.Slug())
 	}
 }
-
-func (h *Controller) ActivateSub(c *echo.Context) (err error) {
-	logger := common.ExtractLogger(c)
-
-	id, err := echo.PathParam[string](c, "id")
-	if err != nil {
-		return httputil.NewHTTPError(http.StatusBadRequest, "missing subscription ID")
-	}
-	logger.Info("Start to activate zhihu sub", zap.String("id", id))
-
-	if err = h.db.ActivateSub(id); err != nil {
-		logger.Error("Failed to activate zhihu sub", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to activate zhihu sub")
-	}
-	return c.JSON(http.StatusOK, httputil.NewMessage("Success"))
-}
-
-func (h *Controller) DeleteSub(c *echo.Context) (err error) {
-	logger := common.ExtractLogger(c)
-
-	id, err := echo.PathParam[string](c, "id")
-	if err != nil {
-		return httputil.NewHTTPError(http.StatusBadRequest, "missing subscription ID")
-	}
-	logger.Info("Start to delete zhihu sub", zap.String("id", id))
-
-	if err = h.db.DeleteSub(id); err != nil {
-		logger.Error("Failed to delete zhihu sub", zap.Error(err))
-		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to delete zhihu sub")
-	}
-	return c.JSON(http.StatusOK, httputil.NewMessage("Success"))
-}
@@ -15,14 +15,16 @@ type Controller struct {
 	db       *gorm.DB
 	logger   *zap.Logger
 	notifier notify.Notifier
+	access   AccessChecker
 }
 
-func NewZsxqController(redis redis.Redis, cookie cookie.CookieIface, db *gorm.DB, notifier notify.Notifier, logger *zap.Logger) *Controller {
+func NewZsxqController(redis redis.Redis, cookie cookie.CookieIface, db *gorm.DB, notifier notify.Notifier, access AccessChecker, logger *zap.Logger) *Controller {
 	return &Controller{
 		redis:    redis,
 		cookie:   cookie,
 		db:       db,
 		logger:   logger,
 		notifier: notifier,
+		access:   access,
 	}
 }
@@ -109,7 +109,7 @@ func (h *Controller) Export(c *echo.Context) (err error) {
 			defer cancel()
 
 			uploadErrCh2 := make(chan error, 1)
-			uploadErrCh2 <- minioService.SaveStream(objectKey, pr, -1)
+			uploadErrCh2 <- minioService.SaveStream(context.Background(), objectKey, pr, -1)
 
 			select {
 			case err := <-uploadErrCh2:
@@ -151,7 +151,7 @@ func (h *Controller) Export(c *echo.Context) (err error) {
 			return
 		}
 
-		if err = minioService.Delete(objectKey); err != nil {
+		if err = minioService.Delete(context.Background(), objectKey); err != nil {
 			logger.Error("Failed to delete object", zap.Error(err))
 			notify.NoticeWithLogger(h.notifier, "Failed to delete object", err.Error(), logger)
 		} else {
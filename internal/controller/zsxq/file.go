@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/internal/middleware"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+	"github.com/eli-yip/rss-zero/pkg/render"
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	zsxqRender "github.com/eli-yip/rss-zero/pkg/routers/zsxq/render"
+	"github.com/eli-yip/rss-zero/pkg/subscription"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// AccessChecker 检查用户能否读取付费来源并签发 feed 地址，由 subscription.Ownership 实现。
+type AccessChecker interface {
+	CheckAccess(user subscription.User, platform, targetID string) error
+	FeedPath(user subscription.User, sub subscription.Subscription) string
+}
+
+type File struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Size       int64  `json:"size"`
+	SHA256     string `json:"sha256"` // 为空表示尚未校验转存
+	URL        string `json:"url"`
+	TopicID    int    `json:"topic_id"`
+	TopicTitle string `json:"topic_title"`
+	TopicURL   string `json:"topic_url"`
+	ArchiveURL string `json:"archive_url"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type Paging struct {
+	Total   int `json:"total"`
+	Current int `json:"current"`
+}
+
+type FilesResponse struct {
+	GroupID   int    `json:"group_id"`
+	GroupName string `json:"group_name"`
+	FeedPath  string `json:"feed_path"` // 附件 feed 地址，带当前用户的访问令牌
+	Count     int    `json:"count"`
+	Paging    Paging `json:"paging"`
+	Files     []File `json:"files"`
+}
+
+// Files 分页列出星球里已转存的附件及其所属 topic，按发布时间倒序。非管理员须有该星球的授权。
+//
+// GET /api/v1/zsxq/:group/files?page=1&count=20
+func (h *Controller) Files(c *echo.Context) (err error) {
+	logger := common.ExtractLogger(c)
+
+	groupID, err := echo.PathParam[int](c, "group")
+	if err != nil {
+		return httputil.NewHTTPError(http.StatusBadRequest, "invalid group id")
+	}
+	page, err := echo.QueryParamOr[int](c, "page", 1)
+	if err != nil || page < 1 {
+		return httputil.NewHTTPError(http.StatusBadRequest, "invalid page")
+	}
+	count, err := echo.QueryParamOr[int](c, "count", 20)
+	if err != nil || count < 1 || count > 100 {
+		return httputil.NewHTTPError(http.StatusBadRequest, "count must be between 1 and 100")
+	}
+
+	username, err := echo.ContextGet[string](c, "username")
+	if err != nil || username == "" {
+		return httputil.NewHTTPError(http.StatusInternalServerError, "missing username")
+	}
+	user := subscription.User{Name: username, Admin: middleware.IsAdmin(c)}
+	if err = h.access.CheckAccess(user, subscriptionDB.PlatformZsxq, strconv.Itoa(groupID)); err != nil {
+		if errors.Is(err, subscription.ErrForbidden) {
+			return httputil.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+		logger.Error("Failed to check zsxq group access", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to check access")
+	}
+
+	dbService := zsxqDB.NewDBService(h.db)
+	groupName, err := dbService.GetGroupName(groupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return httputil.NewHTTPError(http.StatusNotFound, "group not found")
+		}
+		logger.Error("Failed to get zsxq group name", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to get group")
+	}
+
+	files, err := dbService.FetchFiles(groupID, count, count*(page-1))
+	if err != nil {
+		logger.Error("Failed to fetch zsxq files", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to fetch files")
+	}
+	total, err := dbService.CountFiles(groupID)
+	if err != nil {
+		logger.Error("Failed to count zsxq files", zap.Error(err))
+		return httputil.NewHTTPError(http.StatusInternalServerError, "Failed to count files")
+	}
+	logger.Info("Get zsxq files successfully", zap.Int("group_id", groupID), zap.Int("count", len(files)))
+
+	feed := subscription.Subscription{Platform: subscriptionDB.PlatformZsxq, FeedPath: "/rss/zsxq/files/" + strconv.Itoa(groupID)}
+	return c.JSON(http.StatusOK, httputil.NewResp("success", FilesResponse{
+		GroupID:   groupID,
+		GroupName: groupName,
+		FeedPath:  h.access.FeedPath(user, feed),
+		Count:     total,
+		Paging:    Paging{Total: (total + count - 1) / count, Current: page},
+		Files:     buildFiles(files, logger),
+	}))
+}
+
+func buildFiles(files []zsxqDB.TopicFile, logger *zap.Logger) []File {
+	result := make([]File, 0, len(files))
+	for _, f := range files {
+		uri, err := f.URI()
+		if err != nil {
+			logger.Warn("Failed to build zsxq file uri", zap.Int("file_id", f.ID), zap.Error(err))
+		}
+		title := strconv.Itoa(f.TopicID)
+		if f.TopicTitle != nil {
+			title = *f.TopicTitle
+		}
+		topicURL := zsxqRender.BuildLink(f.GroupID, f.TopicID)
+		result = append(result, File{
+			ID:         f.ID,
+			Name:       f.FileName(),
+			Size:       f.Size,
+			SHA256:     f.SHA256,
+			URL:        uri,
+			TopicID:    f.TopicID,
+			TopicTitle: title,
+			TopicURL:   topicURL,
+			ArchiveURL: render.BuildArchiveLink(config.C.Settings.ServerURL, topicURL),
+			CreatedAt:  f.Time.Format(time.RFC3339),
+		})
+	}
+	return result
+}
@@ -16,5 +16,7 @@ import (
 func (h *Controller) RandomCanglimoDigest(c *echo.Context) error {
 	logger := serverCommon.ExtractLogger(c)
 	return rss.ServeCachedString(c, h.redis, logger, redis.ZsxqRandomCanglimoDigestPath, redis.RSSRandomTTL,
-		func() (string, error) { return random.GenerateRandomCanglimoDigestRss(h.db, logger) })
+		func() (string, error) {
+			return random.GenerateRandomCanglimoDigestRss(h.db, random.DefaultPickCount, logger)
+		})
 }
@@ -19,15 +19,11 @@ import (
 func (h *Controller) RSS(c *echo.Context) error {
 	logger := common.ExtractLogger(c)
 
-	groupIDStr, err := echo.ContextGet[string](c, "feed_id")
+	groupID, ok, err := feedGroupID(c, logger)
 	if err != nil {
-		return fmt.Errorf("failed to get feed id: %w", err)
+		return err
 	}
-	logger.Info("Retrieved zsxq rss group id", zap.String("group_id", groupIDStr))
-
-	groupID, err := strconv.Atoi(groupIDStr)
-	if err != nil {
-		logger.Error("Invalid zsxq group id", zap.String("group_id", groupIDStr), zap.Error(err))
+	if !ok {
 		return c.String(http.StatusBadRequest, "invalid group id")
 	}
 
@@ -45,3 +41,45 @@ func (h *Controller) RSS(c *echo.Context) error {
 		},
 	})
 }
+
+// FilesRSS serves the "new files" feed of a zsxq group. It shares the group
+// feed's access check, so the group feed token also reads this feed.
+func (h *Controller) FilesRSS(c *echo.Context) error {
+	logger := common.ExtractLogger(c)
+
+	groupID, ok, err := feedGroupID(c, logger)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return c.String(http.StatusBadRequest, "invalid group id")
+	}
+
+	dbService := zsxqDB.NewDBService(h.db)
+	return rss.Serve(c, rss.ServeOptions{
+		Redis:        h.redis,
+		Logger:       logger,
+		Key:          fmt.Sprintf(redis.ZsxqFilesRSSPath, strconv.Itoa(groupID)),
+		TTL:          redis.RSSDefaultTTL,
+		DefaultLimit: 20,
+		Fetch: func() (rss.FeedMeta, []rss.Item, error) {
+			return rss.FetchZSXQFiles(groupID, dbService, logger)
+		},
+	})
+}
+
+// feedGroupID parses the numeric group id set by ExtractFeedID; ok is false
+// when the feed id is not a number.
+func feedGroupID(c *echo.Context, logger *zap.Logger) (groupID int, ok bool, err error) {
+	groupIDStr, err := echo.ContextGet[string](c, "feed_id")
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get feed id: %w", err)
+	}
+	logger.Info("Retrieved zsxq rss group id", zap.String("group_id", groupIDStr))
+
+	if groupID, err = strconv.Atoi(groupIDStr); err != nil {
+		logger.Error("Invalid zsxq group id", zap.String("group_id", groupIDStr), zap.Error(err))
+		return 0, false, nil
+	}
+	return groupID, true, nil
+}
@@ -11,6 +11,7 @@ import (
 	"gorm.io/gorm/logger"
 
 	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 )
 
 func NewPostgresDB(c config.DatabaseConfig) (db *gorm.DB, err error) {
@@ -36,6 +37,9 @@ func NewPostgresDB(c config.DatabaseConfig) (db *gorm.DB, err error) {
 	if db, err = gorm.Open(postgres.Open(mdsn), gormConfig); err != nil {
 		panic(err)
 	}
+	if err = db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
 
 	mdb, _ := db.DB()
 	mdb.SetMaxIdleConns(20)
@@ -0,0 +1,42 @@
+package digest
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Digest 是一天的 AI 摘要，以北京时间日期（2006-01-02）为主键，同一天重跑会覆盖。
+type Digest struct {
+	Date      string    `gorm:"column:date;type:text;primaryKey"`
+	Content   string    `gorm:"column:content;type:text"`
+	ItemCount int       `gorm:"column:item_count;type:int"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (*Digest) TableName() string { return "ai_digest" }
+
+type DB interface {
+	// SaveDigest 按日期 upsert 一篇摘要。
+	SaveDigest(d *Digest) error
+	// GetLatestDigests 返回最近 n 天的摘要，按日期倒序。
+	GetLatestDigests(n int) ([]Digest, error)
+}
+
+type DBService struct{ *gorm.DB }
+
+func NewDBService(db *gorm.DB) DB { return &DBService{db} }
+
+func (d *DBService) SaveDigest(digest *Digest) error {
+	return d.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"content", "item_count", "updated_at"}),
+	}).Create(digest).Error
+}
+
+func (d *DBService) GetLatestDigests(n int) (digests []Digest, err error) {
+	err = d.Order("date DESC").Limit(n).Find(&digests).Error
+	return digests, err
+}
@@ -0,0 +1,187 @@
+// Package digest 生成 AI 每日摘要：汇总前一天各订阅来源的新内容，交给 AI 写成一篇摘要并以 RSS 输出。
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/xid"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/ai"
+	"github.com/eli-yip/rss-zero/internal/notify"
+	"github.com/eli-yip/rss-zero/internal/redis"
+	"github.com/eli-yip/rss-zero/internal/rss"
+	tk "github.com/eli-yip/rss-zero/pkg/routers/tombkeeper"
+	xiaobotDB "github.com/eli-yip/rss-zero/pkg/routers/xiaobot/db"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+)
+
+const (
+	// maxItems 限制一次送给 AI 的条目数，避免超出上下文窗口。
+	maxItems = 150
+	// maxExcerptRunes 限制每条内容摘录的长度。
+	maxExcerptRunes = 300
+)
+
+// Deps 是生成摘要所需的全部依赖。
+type Deps struct {
+	DB         DB
+	Zhihu      zhihuDB.DB
+	Zsxq       zsxqDB.DB
+	Xiaobot    xiaobotDB.DB
+	Tombkeeper tk.DB
+	AI         ai.AI
+	Redis      redis.Redis
+	Notifier   notify.Notifier
+}
+
+func NewDeps(db *gorm.DB, aiService ai.AI, redisService redis.Redis, notifier notify.Notifier) Deps {
+	return Deps{
+		DB:         NewDBService(db),
+		Zhihu:      zhihuDB.NewDBService(db),
+		Zsxq:       zsxqDB.NewDBService(db),
+		Xiaobot:    xiaobotDB.NewDBService(db),
+		Tombkeeper: tk.NewDBService(db),
+		AI:         aiService,
+		Redis:      redisService,
+		Notifier:   notifier,
+	}
+}
+
+// Window 返回 now 所在日期（loc 时区）前一天的 [start, end) 时间窗口。
+func Window(now time.Time, loc *time.Location) (start, end time.Time) {
+	now = now.In(loc)
+	end = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	return end.AddDate(0, 0, -1), end
+}
+
+// CrawlFunc 返回摘要任务的 cron 函数：为前一天（BJT）生成摘要，失败时通过 Bark 通知。
+func CrawlFunc(deps Deps, cfg config.DigestConfig, logger *zap.Logger) func() {
+	return func() {
+		logger := logger.With(zap.String("cron_job_id", xid.New().String()))
+		start, end := Window(time.Now(), config.C.BJT)
+		if _, err := Generate(deps, cfg, start, end, logger); err != nil {
+			logger.Error("Failed to generate ai digest", zap.Error(err))
+			if notifyErr := deps.Notifier.Notify("Failed to generate ai digest", err.Error()); notifyErr != nil {
+				logger.Error("Failed to send notification", zap.Error(notifyErr))
+			}
+		}
+	}
+}
+
+// Generate 汇总 [start, end) 内各来源的新内容，调用 AI 生成摘要后保存并刷新 RSS 缓存。
+// 窗口内没有任何内容时不调用 AI，返回 nil。单个来源失败只记录日志，全部来源失败才返回错误。
+func Generate(deps Deps, cfg config.DigestConfig, start, end time.Time, logger *zap.Logger) (*Digest, error) {
+	enabled := enabledSources(cfg)
+	if len(enabled) == 0 {
+		return nil, errors.New("no digest source enabled")
+	}
+
+	var groups [][]ai.DigestItem
+	var errs []error
+	for _, s := range enabled {
+		feedItems, err := s.fetch(deps, cfg, logger)
+		if err != nil {
+			logger.Error("Failed to fetch digest source", zap.String("source", s.name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+			continue
+		}
+		sourceItems := digestItems(s.label, feedItems, start, end)
+		logger.Info("Collected digest source", zap.String("source", s.name), zap.Int("count", len(sourceItems)))
+		groups = append(groups, sourceItems)
+	}
+	if len(errs) == len(enabled) {
+		return nil, fmt.Errorf("failed to fetch all digest sources: %w", errors.Join(errs...))
+	}
+
+	items := capItems(groups, maxItems)
+	if len(items) == 0 {
+		logger.Info("Found no content in digest window, skip")
+		return nil, nil
+	}
+	if total := lo.SumBy(groups, func(g []ai.DigestItem) int { return len(g) }); total > len(items) {
+		logger.Warn("Too many digest items, truncate", zap.Int("count", total), zap.Int("max", maxItems))
+	}
+
+	content, err := deps.AI.SummarizeMany(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize digest items: %w", err)
+	}
+
+	digest := &Digest{Date: start.Format(time.DateOnly), Content: content, ItemCount: len(items)}
+	if err = deps.DB.SaveDigest(digest); err != nil {
+		return nil, fmt.Errorf("failed to save digest: %w", err)
+	}
+	logger.Info("Saved ai digest", zap.String("date", digest.Date), zap.Int("item_count", digest.ItemCount))
+
+	if err = rss.WarmCache(context.Background(), deps.Redis, redis.DigestRSSPath, redis.RSSDefaultTTL, func() (rss.FeedMeta, []rss.Item, error) {
+		return BuildFeed(deps.DB)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to warm digest rss cache: %w", err)
+	}
+	return digest, nil
+}
+
+// digestItems 筛出发布时间落在 [start, end) 内的条目，按时间升序转换为 AI 输入。
+func digestItems(label string, items []rss.Item, start, end time.Time) []ai.DigestItem {
+	inWindow := make([]rss.Item, 0, len(items))
+	for _, item := range items {
+		if !item.Time.Before(start) && item.Time.Before(end) {
+			inWindow = append(inWindow, item)
+		}
+	}
+	slices.SortStableFunc(inWindow, func(a, b rss.Item) int { return a.Time.Compare(b.Time) })
+
+	result := make([]ai.DigestItem, 0, len(inWindow))
+	for _, item := range inWindow {
+		result = append(result, ai.DigestItem{
+			Source:  label,
+			Author:  item.Author,
+			Title:   item.Title,
+			Link:    item.Link,
+			Excerpt: truncate(item.Summary, maxExcerptRunes),
+		})
+	}
+	return result
+}
+
+// capItems 在各来源间平分 max 个名额，某来源用不完的名额让给其他来源，每个来源保留最新的条目，
+// 以免按来源顺序截断时排在后面的来源总被挤掉。groups 各自按时间升序，结果仍按来源分组。
+func capItems(groups [][]ai.DigestItem, max int) []ai.DigestItem {
+	quota := make([]int, len(groups))
+	for left := max; left > 0; {
+		given := false
+		for i, g := range groups {
+			if left > 0 && quota[i] < len(g) {
+				quota[i]++
+				left--
+				given = true
+			}
+		}
+		if !given {
+			break
+		}
+	}
+
+	var items []ai.DigestItem
+	for i, g := range groups {
+		items = append(items, g[len(g)-quota[i]:]...)
+	}
+	return items
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
@@ -0,0 +1,225 @@
+package digest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/ai"
+	"github.com/eli-yip/rss-zero/internal/redis"
+	"github.com/eli-yip/rss-zero/internal/rss"
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+)
+
+type fakeDB struct{ digests map[string]Digest }
+
+func (d *fakeDB) SaveDigest(digest *Digest) error {
+	digest.UpdatedAt = time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	d.digests[digest.Date] = *digest
+	return nil
+}
+
+func (d *fakeDB) GetLatestDigests(int) ([]Digest, error) {
+	out := make([]Digest, 0, len(d.digests))
+	for _, digest := range d.digests {
+		out = append(out, digest)
+	}
+	return out, nil
+}
+
+type fakeAI struct {
+	ai.AI
+	got []ai.DigestItem
+}
+
+func (f *fakeAI) SummarizeMany(items []ai.DigestItem) (string, error) {
+	f.got = items
+	return "## 摘要\n\n- 内容", nil
+}
+
+type fakeRedis struct{ data map[string]string }
+
+func (f *fakeRedis) Set(key string, value any, _ time.Duration) error {
+	f.data[key] = value.(string)
+	return nil
+}
+func (f *fakeRedis) Get(key string) (string, error) {
+	if v, ok := f.data[key]; ok {
+		return v, nil
+	}
+	return "", redis.ErrKeyNotExist
+}
+func (f *fakeRedis) Del(key string) error              { delete(f.data, key); return nil }
+func (f *fakeRedis) TTL(string) (time.Duration, error) { return 0, nil }
+
+func stubSources(t *testing.T, stubs []source) {
+	t.Helper()
+	old := sources
+	sources = stubs
+	t.Cleanup(func() { sources = old })
+}
+
+func itemAt(id string, at time.Time) rss.Item {
+	return rss.Item{ID: id, Title: "title-" + id, Link: "https://example.com/" + id, Author: "author", Time: at, Summary: "summary-" + id}
+}
+
+func TestWindow(t *testing.T) {
+	bjt := time.FixedZone("BJT", 8*3600)
+	// 2026-10-19 01:30 BJT is still 2026-10-18 in UTC; the window must follow BJT.
+	start, end := Window(time.Date(2026, 10, 18, 17, 30, 0, 0, time.UTC), bjt)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, bjt), start)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, bjt), end)
+}
+
+func TestDigestItems(t *testing.T) {
+	start := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	items := []rss.Item{
+		itemAt("late", end.Add(-time.Minute)),
+		itemAt("before", start.Add(-time.Second)),
+		itemAt("start", start),
+		itemAt("end", end),
+	}
+	items[0].Summary = strings.Repeat("字", maxExcerptRunes+10)
+
+	got := digestItems("知乎", items, start, end)
+	require.Len(t, got, 2)
+	assert.Equal(t, "title-start", got[0].Title)
+	assert.Equal(t, "title-late", got[1].Title)
+	assert.Equal(t, "知乎", got[1].Source)
+	assert.Equal(t, maxExcerptRunes+1, len([]rune(got[1].Excerpt)))
+}
+
+func TestEnabledSources(t *testing.T) {
+	assert.Len(t, enabledSources(config.DigestConfig{}), len(sources))
+
+	got := enabledSources(config.DigestConfig{Sources: []string{"xiaobot", "zhihu", "unknown"}})
+	names := make([]string, 0, len(got))
+	for _, s := range got {
+		names = append(names, s.name)
+	}
+	assert.Equal(t, []string{"zhihu", "xiaobot"}, names)
+}
+
+func TestGenerate(t *testing.T) {
+	start := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	stubSources(t, []source{
+		{name: "a", label: "A", fetch: func(Deps, config.DigestConfig, *zap.Logger) ([]rss.Item, error) {
+			return []rss.Item{itemAt("a1", start.Add(time.Hour)), itemAt("old", start.Add(-time.Hour))}, nil
+		}},
+		{name: "b", label: "B", fetch: func(Deps, config.DigestConfig, *zap.Logger) ([]rss.Item, error) {
+			return nil, errors.New("boom")
+		}},
+	})
+
+	db := &fakeDB{digests: map[string]Digest{}}
+	fakeAI := &fakeAI{}
+	r := &fakeRedis{data: map[string]string{}}
+	deps := Deps{DB: db, AI: fakeAI, Redis: r}
+
+	digest, err := Generate(deps, config.DigestConfig{}, start, end, zap.NewNop())
+	require.NoError(t, err, "one failing source must not fail the whole digest")
+	require.NotNil(t, digest)
+	assert.Equal(t, "2026-10-18", digest.Date)
+	assert.Equal(t, 1, digest.ItemCount)
+	require.Len(t, fakeAI.got, 1)
+	assert.Equal(t, "A", fakeAI.got[0].Source)
+	assert.Contains(t, db.digests, "2026-10-18")
+	assert.Contains(t, r.data, "v2:"+redis.DigestRSSPath, "generate must warm the feed cache")
+}
+
+func TestGenerateEmptyWindowSkipsAI(t *testing.T) {
+	start := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	stubSources(t, []source{
+		{name: "a", label: "A", fetch: func(Deps, config.DigestConfig, *zap.Logger) ([]rss.Item, error) { return nil, nil }},
+	})
+	fakeAI := &fakeAI{}
+	db := &fakeDB{digests: map[string]Digest{}}
+
+	digest, err := Generate(Deps{DB: db, AI: fakeAI}, config.DigestConfig{}, start, start.Add(24*time.Hour), zap.NewNop())
+	require.NoError(t, err)
+	assert.Nil(t, digest)
+	assert.Nil(t, fakeAI.got)
+	assert.Empty(t, db.digests)
+}
+
+func TestGenerateAllSourcesFailed(t *testing.T) {
+	start := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	stubSources(t, []source{
+		{name: "a", label: "A", fetch: func(Deps, config.DigestConfig, *zap.Logger) ([]rss.Item, error) {
+			return nil, errors.New("boom")
+		}},
+	})
+
+	_, err := Generate(Deps{}, config.DigestConfig{}, start, start.Add(24*time.Hour), zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestFeedFromDigests(t *testing.T) {
+	updated := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	meta, items, err := feedFromDigests([]Digest{{Date: "2026-10-18", Content: "## 知乎\n\n- 内容", ItemCount: 3, UpdatedAt: updated}}, "https://rss.test")
+	require.NoError(t, err)
+	assert.Equal(t, feedTitle, meta.Title)
+	assert.Equal(t, updated, meta.Updated)
+	require.Len(t, items, 1)
+	assert.Equal(t, "2026-10-18", items[0].ID)
+	assert.Equal(t, "每日摘要 2026-10-18（3 条）", items[0].Title)
+	assert.Contains(t, items[0].ContentHTML, "<h2")
+
+	meta, items, err = feedFromDigests(nil, "https://rss.test")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	assert.False(t, meta.Updated.IsZero())
+}
+
+func TestCapItems(t *testing.T) {
+	group := func(label string, n int) []ai.DigestItem {
+		items := make([]ai.DigestItem, 0, n)
+		for i := range n {
+			items = append(items, ai.DigestItem{Source: label, Title: fmt.Sprintf("%s%d", label, i)})
+		}
+		return items
+	}
+
+	got := capItems([][]ai.DigestItem{group("a", 10), group("b", 1), group("c", 10)}, 7)
+	require.Len(t, got, 7)
+	titles := make([]string, 0, len(got))
+	for _, item := range got {
+		titles = append(titles, item.Title)
+	}
+	// b 只有 1 条，让出的名额由 a、c 分走；每个来源保留最新（末尾）的条目
+	assert.Equal(t, []string{"a7", "a8", "a9", "b0", "c7", "c8", "c9"}, titles)
+
+	assert.Len(t, capItems([][]ai.DigestItem{group("a", 2), nil}, 7), 2)
+}
+
+// fakeZsxqDB 只实现 fetchZsxq 用到的方法，groupName 中没有的 group 查名字时报错。
+type fakeZsxqDB struct {
+	zsxqDB.DB
+	groupName map[int]string
+}
+
+func (f *fakeZsxqDB) GetZsxqGroupIDs() ([]int, error) { return []int{1, 2}, nil }
+
+func (f *fakeZsxqDB) GetGroupName(id int) (string, error) {
+	if name, ok := f.groupName[id]; ok {
+		return name, nil
+	}
+	return "", errors.New("boom")
+}
+
+func (f *fakeZsxqDB) GetLatestNTopics(int, int) ([]zsxqDB.Topic, error) { return nil, nil }
+
+func TestFetchZsxqSkipsFailingFeed(t *testing.T) {
+	_, err := fetchZsxq(Deps{Zsxq: &fakeZsxqDB{groupName: map[int]string{2: "星球"}}}, config.DigestConfig{}, zap.NewNop())
+	assert.NoError(t, err, "one failing group must not drop the whole platform")
+}
@@ -0,0 +1,51 @@
+package digest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/rss"
+	"github.com/eli-yip/rss-zero/pkg/render"
+)
+
+const (
+	feedTitle  = "RSS-ZERO 每日摘要"
+	feedAuthor = "RSS-ZERO"
+)
+
+// BuildFeed 把最近的每日摘要装配成 feed，每天一条。
+func BuildFeed(db DB) (rss.FeedMeta, []rss.Item, error) {
+	digests, err := db.GetLatestDigests(rss.MaxFetch)
+	if err != nil {
+		return rss.FeedMeta{}, nil, fmt.Errorf("failed to get latest digests: %w", err)
+	}
+	return feedFromDigests(digests, config.C.Settings.ServerURL)
+}
+
+func feedFromDigests(digests []Digest, serverBaseURL string) (rss.FeedMeta, []rss.Item, error) {
+	meta := rss.FeedMeta{Title: feedTitle, Link: serverBaseURL}
+	if len(digests) == 0 {
+		meta.Updated = time.Now()
+		return meta, nil, nil
+	}
+	meta.Updated = digests[0].UpdatedAt
+
+	items := make([]rss.Item, 0, len(digests))
+	for _, d := range digests {
+		contentHTML, err := render.FeedHTML(d.Content)
+		if err != nil {
+			return rss.FeedMeta{}, nil, fmt.Errorf("failed to render digest %s: %w", d.Date, err)
+		}
+		items = append(items, rss.Item{
+			ID:          d.Date,
+			Link:        fmt.Sprintf("%s/rss/digest#%s", serverBaseURL, d.Date),
+			Title:       fmt.Sprintf("每日摘要 %s（%d 条）", d.Date, d.ItemCount),
+			Author:      feedAuthor,
+			Time:        d.UpdatedAt,
+			Summary:     render.ExtractExcerpt(d.Content),
+			ContentHTML: contentHTML,
+		})
+	}
+	return meta, items, nil
+}
@@ -0,0 +1,116 @@
+package digest
+
+import (
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/rss"
+	tk "github.com/eli-yip/rss-zero/pkg/routers/tombkeeper"
+)
+
+// source 是一个可纳入摘要的来源：name 对应配置 sources 中的取值，label 是摘要里的分组标题，
+// fetch 复用该来源已有的 RSS 抓取路径，返回范围内各 feed 的最新条目（每个 feed 至多 rss.MaxFetch 条），
+// 时间窗口过滤由调用方统一完成。单个 feed 失败只记日志并跳过，只有列不出 feed 时才返回错误。
+type source struct {
+	name  string
+	label string
+	fetch func(deps Deps, cfg config.DigestConfig, logger *zap.Logger) ([]rss.Item, error)
+}
+
+// sources 的顺序即摘要中各来源分组的顺序。
+var sources = []source{
+	{name: "zhihu", label: "知乎", fetch: fetchZhihu},
+	{name: "zsxq", label: "知识星球", fetch: fetchZsxq},
+	{name: "xiaobot", label: "小报童", fetch: fetchXiaobot},
+	{name: "tombkeeper", label: "tombkeeper 微博", fetch: fetchTombkeeper},
+}
+
+// enabledSources 按配置筛选来源；cfg.Sources 为空表示全部来源。
+func enabledSources(cfg config.DigestConfig) []source {
+	if len(cfg.Sources) == 0 {
+		return sources
+	}
+	enabled := make([]source, 0, len(sources))
+	for _, s := range sources {
+		if slices.Contains(cfg.Sources, s.name) {
+			enabled = append(enabled, s)
+		}
+	}
+	return enabled
+}
+
+// inScope 判断 id 是否在范围列表内；列表为空表示不限制。
+func inScope[T comparable](scope []T, id T) bool {
+	return len(scope) == 0 || slices.Contains(scope, id)
+}
+
+func fetchZhihu(deps Deps, cfg config.DigestConfig, logger *zap.Logger) ([]rss.Item, error) {
+	subs, err := deps.Zhihu.GetSubs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zhihu subs: %w", err)
+	}
+	var items []rss.Item
+	for _, sub := range subs {
+		if !inScope(cfg.ZhihuAuthors, sub.AuthorID) {
+			continue
+		}
+		_, feedItems, err := rss.FetchZhihu(sub.Type, sub.AuthorID, deps.Zhihu, logger)
+		if err != nil {
+			logger.Error("Failed to fetch zhihu feed for digest, skip", zap.Stringer("type", sub.Type), zap.String("author_id", sub.AuthorID), zap.Error(err))
+			continue
+		}
+		items = append(items, feedItems...)
+	}
+	return items, nil
+}
+
+func fetchZsxq(deps Deps, cfg config.DigestConfig, logger *zap.Logger) ([]rss.Item, error) {
+	groupIDs, err := deps.Zsxq.GetZsxqGroupIDs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zsxq group ids: %w", err)
+	}
+	var items []rss.Item
+	for _, groupID := range groupIDs {
+		if !inScope(cfg.ZsxqGroups, groupID) {
+			continue
+		}
+		_, feedItems, err := rss.FetchZSXQ(groupID, deps.Zsxq, logger)
+		if err != nil {
+			logger.Error("Failed to fetch zsxq feed for digest, skip", zap.Int("group_id", groupID), zap.Error(err))
+			continue
+		}
+		items = append(items, feedItems...)
+	}
+	return items, nil
+}
+
+func fetchXiaobot(deps Deps, cfg config.DigestConfig, logger *zap.Logger) ([]rss.Item, error) {
+	papers, err := deps.Xiaobot.GetPapers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get xiaobot papers: %w", err)
+	}
+	var items []rss.Item
+	for _, paper := range papers {
+		if !inScope(cfg.XiaobotPapers, paper.ID) {
+			continue
+		}
+		_, feedItems, err := rss.FetchXiaobot(paper.ID, deps.Xiaobot, logger)
+		if err != nil {
+			logger.Error("Failed to fetch xiaobot feed for digest, skip", zap.String("paper_id", paper.ID), zap.Error(err))
+			continue
+		}
+		items = append(items, feedItems...)
+	}
+	return items, nil
+}
+
+func fetchTombkeeper(deps Deps, _ config.DigestConfig, _ *zap.Logger) ([]rss.Item, error) {
+	_, items, err := tk.BuildFeed(deps.Tombkeeper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tombkeeper feed: %w", err)
+	}
+	return items, nil
+}
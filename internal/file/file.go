@@ -1,21 +1,23 @@
 package file
 
 import (
+	"context"
 	"io"
 )
 
 // File interface is for file related services.
+// Methods take a ctx so object storage calls join the caller's trace.
 type File interface {
 	// SaveStream method will take a name, save the stream to the file service
-	SaveStream(path string, readCloser io.ReadCloser, size int64) error
+	SaveStream(ctx context.Context, path string, readCloser io.ReadCloser, size int64) error
 	// GetStream method will take a name, and return the stream from the file service
-	GetStream(string) (io.ReadCloser, error)
+	GetStream(ctx context.Context, path string) (io.ReadCloser, error)
 	// AssetsDomain is a getter for the assets domain
 	AssetsDomain() string
 	// Delete method will take a name, and delete the file from the file service
-	Delete(string) error
+	Delete(ctx context.Context, path string) error
 	// Check existance of a file
-	Exist(string) (bool, error)
+	Exist(ctx context.Context, path string) (bool, error)
 	// Size returns the byte size of a stored object.
-	Size(string) (int64, error)
+	Size(ctx context.Context, path string) (int64, error)
 }
@@ -8,8 +8,11 @@ import (
 
 	gomime "github.com/cubewise-code/go-mime"
 	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -37,9 +40,12 @@ func NewFileServiceMinio(minioConfig config.MinioConfig, logger *zap.Logger) (Fi
 	}, nil
 }
 
-func (s *FileServiceMinio) SaveStream(objectKey string, stream io.ReadCloser, size int64) (err error) {
+func (s *FileServiceMinio) SaveStream(ctx context.Context, objectKey string, stream io.ReadCloser, size int64) (err error) {
 	s.logger.Info("Start to save stream to minio", zap.String("key", objectKey))
 
+	ctx, span := s.startSpan(ctx, "minio.put_object", objectKey)
+	defer func() { tracing.End(span, err) }()
+
 	if stream == nil {
 		return errors.New("no body")
 	}
@@ -49,7 +55,7 @@ func (s *FileServiceMinio) SaveStream(objectKey string, stream io.ReadCloser, si
 
 	var info minio.UploadInfo
 	if info, err = s.minioClient.PutObject(
-		context.Background(),
+		ctx,
 		s.bucketName,
 		objectKey,
 		stream,
@@ -59,6 +65,7 @@ func (s *FileServiceMinio) SaveStream(objectKey string, stream io.ReadCloser, si
 		return err
 	}
 
+	span.SetAttributes(attribute.Int64("minio.size", info.Size))
 	s.logger.Info("Upload to minio successfully",
 		zap.String("bucket", info.Bucket),
 		zap.String("key", info.Key),
@@ -79,26 +86,35 @@ func (s *FileServiceMinio) getContentType(objectKey string) (contentType string)
 	return contentType
 }
 
-func (s *FileServiceMinio) GetStream(objectKey string) (stream io.ReadCloser, err error) {
-	return s.minioClient.GetObject(context.Background(), s.bucketName, objectKey, minio.GetObjectOptions{})
+// GetStream 的 span 只覆盖打开对象，读取 stream 的耗时不计入。
+func (s *FileServiceMinio) GetStream(ctx context.Context, objectKey string) (stream io.ReadCloser, err error) {
+	ctx, span := s.startSpan(ctx, "minio.get_object", objectKey)
+	defer func() { tracing.End(span, err) }()
+	return s.minioClient.GetObject(ctx, s.bucketName, objectKey, minio.GetObjectOptions{})
 }
 
 func (s *FileServiceMinio) AssetsDomain() (url string) { return s.assetsDomain }
 
-func (s *FileServiceMinio) Delete(key string) (err error) {
-	return s.minioClient.RemoveObject(context.Background(), s.bucketName, key, minio.RemoveObjectOptions{})
+func (s *FileServiceMinio) Delete(ctx context.Context, key string) (err error) {
+	ctx, span := s.startSpan(ctx, "minio.remove_object", key)
+	defer func() { tracing.End(span, err) }()
+	return s.minioClient.RemoveObject(ctx, s.bucketName, key, minio.RemoveObjectOptions{})
 }
 
-func (s *FileServiceMinio) Size(key string) (size int64, err error) {
-	info, err := s.minioClient.StatObject(context.Background(), s.bucketName, key, minio.StatObjectOptions{})
+func (s *FileServiceMinio) Size(ctx context.Context, key string) (size int64, err error) {
+	ctx, span := s.startSpan(ctx, "minio.stat_object", key)
+	defer func() { tracing.End(span, err) }()
+	info, err := s.minioClient.StatObject(ctx, s.bucketName, key, minio.StatObjectOptions{})
 	if err != nil {
 		return 0, err
 	}
 	return info.Size, nil
 }
 
-func (s *FileServiceMinio) Exist(key string) (exist bool, err error) {
-	_, err = s.minioClient.StatObject(context.Background(), s.bucketName, key, minio.StatObjectOptions{})
+func (s *FileServiceMinio) Exist(ctx context.Context, key string) (exist bool, err error) {
+	ctx, span := s.startSpan(ctx, "minio.stat_object", key)
+	defer func() { tracing.End(span, err) }()
+	_, err = s.minioClient.StatObject(ctx, s.bucketName, key, minio.StatObjectOptions{})
 	if err != nil {
 		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
 			return false, nil
@@ -107,3 +123,8 @@ func (s *FileServiceMinio) Exist(key string) (exist bool, err error) {
 	}
 	return true, nil
 }
+
+func (s *FileServiceMinio) startSpan(ctx context.Context, name, objectKey string) (context.Context, trace.Span) {
+	return tracing.StartChild(ctx, name,
+		attribute.String("minio.bucket", s.bucketName), attribute.String("minio.key", objectKey))
+}
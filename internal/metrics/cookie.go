@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CookieExpiry 是一个凭据（cookie.Spec）的剩余有效期，缺失或已过期时 TTL 为 0。
+type CookieExpiry struct {
+	Platform, Name string
+	TTL            time.Duration
+}
+
+// cookieCollector 在每次抓取 /metrics 时现读各凭据的剩余有效期。读取逻辑由调用方注入：
+// pkg/cookie 依赖平台 request 包，而后者依赖本包，这里不能反向引用 pkg/cookie。
+type cookieCollector struct {
+	read func() []CookieExpiry
+	desc *prometheus.Desc
+}
+
+// RegisterCookieCollector 启动时调用一次。
+func RegisterCookieCollector(read func() []CookieExpiry) error {
+	return prometheus.Register(newCookieCollector(read))
+}
+
+func newCookieCollector(read func() []CookieExpiry) *cookieCollector {
+	return &cookieCollector{
+		read: read,
+		desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cookie_expiry_seconds"),
+			"Seconds until each registered cookie or token expires, 0 when missing or expired.",
+			[]string{"platform", "name"}, nil),
+	}
+}
+
+func (c *cookieCollector) Describe(ch chan<- *prometheus.Desc) { ch <- c.desc }
+
+func (c *cookieCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, e := range c.read() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, max(e.TTL.Seconds(), 0), e.Platform, e.Name)
+	}
+}
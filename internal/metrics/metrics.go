@@ -0,0 +1,119 @@
+// Package metrics 定义进程内的 Prometheus 指标，由 /metrics 经管理员鉴权暴露。
+// 各处埋点只调用这里的 Observe/Inc 函数，不直接接触 prometheus 类型。
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "rss_zero"
+
+// 动态来源一次抓取的结果。
+const (
+	CrawlSuccess = "success"
+	CrawlError   = "error"
+	// CrawlSkipped 表示未真正开始抓取，如已有同类任务在跑、来源被禁用
+	CrawlSkipped = "skipped"
+	CrawlPanic   = "panic"
+	// CrawlStopped 表示运行中被 /api/v1/job/:id/cancel 或 /pause 停下
+	CrawlStopped = "stopped"
+)
+
+// UnmatchedRoute 是没有命中任何路由（404）时的 route 标签。
+const UnmatchedRoute = "unmatched"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by echo route name, method and status code.",
+	}, []string{"route", "method", "status"})
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by echo route name and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	rssCache = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rss_cache_requests_total",
+		Help:      "RSS feed cache lookups in rss.Serve by route name and result (hit/miss).",
+	}, []string{"route", "result"})
+
+	crawlRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "crawl_runs_total",
+		Help:      "Dynamic source crawl runs by source kind and outcome.",
+	}, []string{"kind", "outcome"})
+	crawlDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "crawl_duration_seconds",
+		Help:      "Dynamic source crawl run duration by source kind.",
+		// 10s ~ 85min，覆盖 github 的秒级到知乎全量的小时级
+		Buckets: prometheus.ExponentialBuckets(10, 2, 10),
+	}, []string{"kind"})
+
+	requestRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "request_retries_total",
+		Help:      "Retried upstream requests in the platform request services.",
+	}, []string{"source"})
+
+	aiCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ai_calls_total",
+		Help:      "AI calls by capability and outcome (success/error).",
+	}, []string{"capability", "outcome"})
+	aiDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ai_call_duration_seconds",
+		Help:      "AI call latency by capability.",
+		Buckets:   prometheus.ExponentialBuckets(0.25, 2, 10),
+	}, []string{"capability"})
+)
+
+// Handler 输出默认 registry 中的全部指标（含 Go runtime 与进程指标）。
+func Handler() http.Handler { return promhttp.Handler() }
+
+func ObserveHTTP(route, method string, status int, d time.Duration) {
+	if route == "" {
+		route = UnmatchedRoute
+	}
+	httpRequests.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+	httpDuration.WithLabelValues(route, method).Observe(d.Seconds())
+}
+
+func ObserveRSSCache(route string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	rssCache.WithLabelValues(route, result).Inc()
+}
+
+// ObserveCrawl 记录一次动态来源抓取，kind 即 SourceSpec.Kind。
+func ObserveCrawl(kind, outcome string, d time.Duration) {
+	crawlRuns.WithLabelValues(kind, outcome).Inc()
+	if outcome != CrawlSkipped {
+		crawlDuration.WithLabelValues(kind).Observe(d.Seconds())
+	}
+}
+
+// IncRequestRetry 在请求服务进入第二次及以后的尝试时调用，source 为 zsxq/zhihu。
+func IncRequestRetry(source string) { requestRetries.WithLabelValues(source).Inc() }
+
+func ObserveAI(capability string, err error, d time.Duration) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	aiCalls.WithLabelValues(capability, outcome).Inc()
+	aiDuration.WithLabelValues(capability).Observe(d.Seconds())
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCookieCollector(t *testing.T) {
+	c := newCookieCollector(func() []CookieExpiry {
+		return []CookieExpiry{
+			{Platform: "zhihu", Name: "z_c0", TTL: 90 * time.Second},
+			{Platform: "zsxq", Name: "access_token", TTL: -time.Minute},
+		}
+	})
+
+	expected := `
+# HELP rss_zero_cookie_expiry_seconds Seconds until each registered cookie or token expires, 0 when missing or expired.
+# TYPE rss_zero_cookie_expiry_seconds gauge
+rss_zero_cookie_expiry_seconds{name="access_token",platform="zsxq"} 0
+rss_zero_cookie_expiry_seconds{name="z_c0",platform="zhihu"} 90
+`
+	require.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected)))
+}
+
+func TestObserveCrawlSkipsDurationForSkippedRuns(t *testing.T) {
+	const kind = "test-kind"
+	ObserveCrawl(kind, CrawlSkipped, time.Minute)
+	ObserveCrawl(kind, CrawlSuccess, time.Minute)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(crawlRuns.WithLabelValues(kind, CrawlSkipped)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(crawlRuns.WithLabelValues(kind, CrawlSuccess)))
+	assert.Equal(t, 1, testutil.CollectAndCount(crawlDuration.MustCurryWith(map[string]string{"kind": kind})))
+}
+
+func TestObserveHTTPUnmatchedRoute(t *testing.T) {
+	ObserveHTTP("", "GET", 404, time.Millisecond)
+	assert.Equal(t, float64(1), testutil.ToFloat64(httpRequests.WithLabelValues(UnmatchedRoute, "GET", "404")))
+}
@@ -1,24 +1,65 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"slices"
 	"strings"
 
-	"github.com/eli-yip/rss-zero/config"
 	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/pkg/apitoken"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
 )
 
-func AllowAdmin() echo.MiddlewareFunc {
+// 请求有两种认证方式：反代（forward-auth）注入的 Remote-* 请求头，或 Authorization: Bearer 个人 API token。
+// 带了 token 的请求只按 token 的 scope 判断，不再看反代请求头。
+
+// Authenticate 校验 Bearer token 并放进 context，供 AllowScope / InjectUser 判断。
+// 不带 Bearer token 的请求原样放行。
+func Authenticate(verifier apitoken.Verifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			plain, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				return next(c)
+			}
+
+			logger := common.ExtractLogger(c)
+			token, err := verifier.Verify(strings.TrimSpace(plain))
+			if err != nil {
+				if errors.Is(err, apitoken.ErrInvalidToken) || errors.Is(err, apitoken.ErrTokenExpired) {
+					return httputil.NewHTTPError(http.StatusUnauthorized, err.Error())
+				}
+				logger.Error("Failed to verify api token", zap.Error(err))
+				return httputil.NewHTTPError(http.StatusInternalServerError, "failed to verify api token")
+			}
+			logger.Info("api token", zap.String("token_id", token.ID), zap.String("username", token.Username))
+			c.Set("api_token", token)
+			return next(c)
+		}
+	}
+}
+
+// AllowScope 放行反代认证的管理员，或具备 scope 的 API token。
+func AllowScope(scope string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) error {
 			if config.C.Settings.Debug {
 				return next(c)
 			}
 
-			remoteGroups := strings.Split(c.Request().Header.Get("Remote-Groups"), ",")
-			const validAdminGroup = "lldap_admin"
-			if slices.Contains(remoteGroups, validAdminGroup) {
+			if token := common.ExtractAPIToken(c); token != nil {
+				if apitoken.Allows(token, scope) {
+					return next(c)
+				}
+				return c.JSON(http.StatusForbidden, map[string]string{"error": "API token lacks scope " + scope})
+			}
+
+			if IsProxyAdmin(c) {
 				return next(c)
 			}
 
@@ -26,3 +67,35 @@ func AllowAdmin() echo.MiddlewareFunc {
 		}
 	}
 }
+
+func AllowAdmin() echo.MiddlewareFunc { return AllowScope(apitoken.ScopeAdmin) }
+
+// TokenScope 要求 API token 具备 scope，反代认证的请求不受限制，用于普通用户也能访问的个人路由。
+func TokenScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if token := common.ExtractAPIToken(c); token != nil && !apitoken.Allows(token, scope) {
+				return c.JSON(http.StatusForbidden, map[string]string{"error": "API token lacks scope " + scope})
+			}
+			return next(c)
+		}
+	}
+}
+
+// IsAdmin 报告请求是否有管理员权限：debug 模式、带 admin scope 的 token，或反代认证的管理员。
+func IsAdmin(c *echo.Context) bool {
+	if config.C.Settings.Debug {
+		return true
+	}
+	if token := common.ExtractAPIToken(c); token != nil {
+		return apitoken.Allows(token, apitoken.ScopeAdmin)
+	}
+	return IsProxyAdmin(c)
+}
+
+// IsProxyAdmin 报告反代认证的用户是否在管理员组。
+func IsProxyAdmin(c *echo.Context) bool {
+	remoteGroups := strings.Split(c.Request().Header.Get("Remote-Groups"), ",")
+	const validAdminGroup = "lldap_admin"
+	return slices.Contains(remoteGroups, validAdminGroup)
+}
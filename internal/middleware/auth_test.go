@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/apitoken"
+	apitokenDB "github.com/eli-yip/rss-zero/pkg/apitoken/db"
+	"github.com/eli-yip/rss-zero/pkg/httputil"
+)
+
+type fakeVerifier map[string]*apitokenDB.Token
+
+func (f fakeVerifier) Verify(plain string) (*apitokenDB.Token, error) {
+	switch plain {
+	case "rzt_expired":
+		return nil, apitoken.ErrTokenExpired
+	case "rzt_broken":
+		return nil, errors.New("db down")
+	}
+	if token, ok := f[plain]; ok {
+		return token, nil
+	}
+	return nil, apitoken.ErrInvalidToken
+}
+
+func TestAllowScope(t *testing.T) {
+	verifier := fakeVerifier{
+		"rzt_jobs":  {ID: "t1", Username: "jason", Scopes: []string{apitoken.ScopeJobsRun}},
+		"rzt_admin": {ID: "t2", Username: "jason", Scopes: []string{apitoken.ScopeAdmin}},
+	}
+	e := echo.New()
+	e.HTTPErrorHandler = httputil.NewHTTPErrorHandler(zap.NewNop())
+	g := e.Group("/api/v1", Authenticate(verifier))
+	ok := func(c *echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	g.Group("/job", AllowScope(apitoken.ScopeJobsRun)).GET("", ok)
+	g.Group("/migrate", AllowAdmin()).GET("", ok)
+	g.GET("/health", ok)
+
+	tests := []struct {
+		name   string
+		path   string
+		header map[string]string
+		want   int
+	}{
+		{name: "no credentials", path: "/api/v1/job", want: http.StatusForbidden},
+		{name: "proxy admin", path: "/api/v1/job", header: map[string]string{"Remote-Groups": "users,lldap_admin"}, want: http.StatusNoContent},
+		{name: "proxy user", path: "/api/v1/migrate", header: map[string]string{"Remote-Groups": "users"}, want: http.StatusForbidden},
+		{name: "token with scope", path: "/api/v1/job", header: map[string]string{"Authorization": "Bearer rzt_jobs"}, want: http.StatusNoContent},
+		{name: "token without scope", path: "/api/v1/migrate", header: map[string]string{"Authorization": "Bearer rzt_jobs"}, want: http.StatusForbidden},
+		{name: "token ignores proxy headers", path: "/api/v1/migrate",
+			header: map[string]string{"Authorization": "Bearer rzt_jobs", "Remote-Groups": "lldap_admin"}, want: http.StatusForbidden},
+		{name: "admin token", path: "/api/v1/migrate", header: map[string]string{"Authorization": "Bearer rzt_admin"}, want: http.StatusNoContent},
+		{name: "invalid token", path: "/api/v1/health", header: map[string]string{"Authorization": "Bearer rzt_nope"}, want: http.StatusUnauthorized},
+		{name: "expired token", path: "/api/v1/job", header: map[string]string{"Authorization": "Bearer rzt_expired"}, want: http.StatusUnauthorized},
+		{name: "verify error", path: "/api/v1/job", header: map[string]string{"Authorization": "Bearer rzt_broken"}, want: http.StatusInternalServerError},
+		{name: "public route", path: "/api/v1/health", want: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestInjectUserWithToken(t *testing.T) {
+	verifier := fakeVerifier{
+		"rzt_reader": {ID: "t1", Username: "jason", Scopes: []string{apitoken.ScopeArchiveRead}},
+		"rzt_jobs":   {ID: "t2", Username: "jason", Scopes: []string{apitoken.ScopeJobsRun}},
+	}
+	e := echo.New()
+	e.HTTPErrorHandler = httputil.NewHTTPErrorHandler(zap.NewNop())
+	e.Use(Authenticate(verifier))
+	e.GET("/bookmark", func(c *echo.Context) error {
+		username, _ := echo.ContextGet[string](c, "username")
+		return c.String(http.StatusOK, username)
+	}, InjectUser())
+
+	req := httptest.NewRequest(http.MethodGet, "/bookmark", nil)
+	req.Header.Set("Authorization", "Bearer rzt_reader")
+	req.Header.Set("Remote-User", "someone-else")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "jason", rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/bookmark", nil)
+	req.Header.Set("Authorization", "Bearer rzt_jobs")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestTokenScope(t *testing.T) {
+	verifier := fakeVerifier{
+		"rzt_reader": {ID: "t1", Username: "jason", Scopes: []string{apitoken.ScopeArchiveRead}},
+		"rzt_subs":   {ID: "t2", Username: "jason", Scopes: []string{apitoken.ScopeArchiveRead, apitoken.ScopeSubsManage}},
+	}
+	e := echo.New()
+	e.HTTPErrorHandler = httputil.NewHTTPErrorHandler(zap.NewNop())
+	e.Use(Authenticate(verifier))
+	e.POST("/my/subscriptions", func(c *echo.Context) error { return c.NoContent(http.StatusNoContent) }, TokenScope(apitoken.ScopeSubsManage))
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{name: "proxy user", header: map[string]string{"Remote-User": "jason", "Remote-Groups": "users"}, want: http.StatusNoContent},
+		{name: "token with scope", header: map[string]string{"Authorization": "Bearer rzt_subs"}, want: http.StatusNoContent},
+		{name: "read-only token", header: map[string]string{"Authorization": "Bearer rzt_reader"}, want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/my/subscriptions", nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/controller/common"
+)
+
+// FeedChecker 校验付费 feed 的访问令牌，由 subscription.Ownership 实现。
+type FeedChecker interface {
+	CanReadFeed(platform, targetID, feedPath, username, token string) (bool, error)
+}
+
+// RequireFeedAccess 要求付费 feed（星球、小报童）的请求带上 user 与 token 查询参数，须在 ExtractFeedID 之后执行。
+// 令牌针对规范路径签发：路由路径里的 :feed 换成去掉 .atom 等后缀的 feed id，如 /rss/zsxq/<group id> 与
+// /rss/zsxq/files/<group id> 各用各的令牌；没有 :feed 的路由（如星球随机精华）即路由路径本身。
+func RequireFeedAccess(checker FeedChecker, platform string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			logger := common.ExtractLogger(c)
+
+			feedID, _ := echo.ContextGet[string](c, "feed_id")
+			feedPath := strings.Replace(c.Path(), ":feed", feedID, 1)
+			username := c.QueryParam("user")
+
+			ok, err := checker.CanReadFeed(platform, feedID, feedPath, username, c.QueryParam("token"))
+			if err != nil {
+				logger.Error("Failed to check feed access", zap.Error(err))
+				return c.String(http.StatusInternalServerError, "failed to check feed access")
+			}
+			if !ok {
+				logger.Error("Invalid feed token", zap.String("feed_path", feedPath), zap.String("user", username))
+				return c.String(http.StatusForbidden, "invalid token")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFeedChecker 记录被校验的路径，只放行 token 为 ok 的请求。
+type fakeFeedChecker struct{ paths []string }
+
+func (f *fakeFeedChecker) CanReadFeed(platform, targetID, feedPath, username, token string) (bool, error) {
+	f.paths = append(f.paths, platform+" "+targetID+" "+feedPath+" "+username)
+	return token == "ok", nil
+}
+
+func TestRequireFeedAccess(t *testing.T) {
+	checker := &fakeFeedChecker{}
+	e := echo.New()
+	g := e.Group("/rss", ExtractFeedID())
+	ok := func(c *echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	g.GET("/zsxq/:feed", ok, RequireFeedAccess(checker, "zsxq"))
+	g.GET("/zsxq/random", ok, RequireFeedAccess(checker, "zsxq"))
+	g.GET("/zsxq/files/:feed", ok, RequireFeedAccess(checker, "zsxq"))
+
+	for target, want := range map[string]int{
+		"/rss/zsxq/42.atom?user=bob&token=ok":  http.StatusNoContent,
+		"/rss/zsxq/random?token=ok":            http.StatusNoContent,
+		"/rss/zsxq/42?user=bob&token=bad":      http.StatusForbidden,
+		"/rss/zsxq/files/42?user=bob&token=ok": http.StatusNoContent,
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, want, rec.Code, target)
+	}
+	// 附件 feed 的令牌按自己的路径签发，不能拿 group feed 的令牌去读
+	assert.ElementsMatch(t, []string{"zsxq 42 /rss/zsxq/42 bob", "zsxq  /rss/zsxq/random ", "zsxq 42 /rss/zsxq/42 bob",
+		"zsxq 42 /rss/zsxq/files/42 bob"}, checker.paths)
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/eli-yip/rss-zero/internal/metrics"
+)
+
+// Metrics 按 echo 路由名（registerNamedRoute 设置，未命名时为 METHOD:path）记录请求数与延迟。
+// 需放在 LogRequest 之前：LogRequest 会把 handler 返回的错误写成响应，这里才能拿到最终状态码。
+func Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			status := 0
+			if response, unwrapErr := echo.UnwrapResponse(c.Response()); unwrapErr == nil {
+				status = response.Status
+			}
+			metrics.ObserveHTTP(c.RouteInfo().Name, c.Request().Method, status, time.Since(start))
+			return err
+		}
+	}
+}
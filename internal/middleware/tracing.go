@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/eli-yip/rss-zero/internal/metrics"
+	"github.com/eli-yip/rss-zero/internal/tracing"
+)
+
+// Tracing 为每个请求开启 server span，以 echo 路由名命名，并带上 RequestID 生成的请求 ID。
+// 需放在 RequestID 之后、LogRequest 之前：前者提供请求 ID，后者会把错误写成响应，这里才能拿到最终状态码。
+// span 写回 c.Request().Context()，handler 把它传给下游即可挂上子 span。
+func Tracing() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			route := c.RouteInfo().Name
+			if route == "" {
+				route = metrics.UnmatchedRoute
+			}
+			ctx, span := tracing.Start(ctx, route,
+				tracing.RequestIDKey.String(c.Response().Header().Get(echo.HeaderXRequestID)),
+				attribute.String("http.request.method", req.Method),
+				attribute.String("http.route", c.Path()),
+				attribute.String("url.path", req.URL.Path),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+
+			if response, unwrapErr := echo.UnwrapResponse(c.Response()); unwrapErr == nil {
+				span.SetAttributes(attribute.Int("http.response.status_code", response.Status))
+				if response.Status >= http.StatusInternalServerError {
+					span.SetStatus(codes.Error, http.StatusText(response.Status))
+				}
+			}
+			if err != nil {
+				span.RecordError(err)
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	echoMiddleware "github.com/labstack/echo/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/eli-yip/rss-zero/internal/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	var handlerSpan trace.SpanContext
+	e := echo.New()
+	e.Use(echoMiddleware.RequestID(), Tracing())
+	_, err := e.AddRoute(echo.Route{
+		Method: http.MethodGet,
+		Path:   "/feeds/:id",
+		Name:   "Feed route",
+		Handler: func(c *echo.Context) error {
+			handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+			return c.NoContent(http.StatusBadGateway)
+		},
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feeds/1", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "Feed route", span.Name())
+	assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID(), "handler 拿到的 ctx 应携带请求 span")
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	attrs := map[string]string{}
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.NotEmpty(t, attrs[string(tracing.RequestIDKey)])
+	assert.Equal(t, rec.Header().Get(echo.HeaderXRequestID), attrs[string(tracing.RequestIDKey)])
+	assert.Equal(t, "/feeds/:id", attrs["http.route"])
+	assert.Equal(t, "502", attrs["http.response.status_code"])
+}
@@ -5,11 +5,14 @@ import (
 
 	"github.com/eli-yip/rss-zero/config"
 	"github.com/eli-yip/rss-zero/internal/controller/common"
+	"github.com/eli-yip/rss-zero/pkg/apitoken"
 	"github.com/eli-yip/rss-zero/pkg/httputil"
 	"github.com/labstack/echo/v5"
 	"go.uber.org/zap"
 )
 
+// InjectUser 把当前用户放进 context，供个人路由（归档、收藏、标签）使用。API token 需具备
+// archive:read，用户即 token 的所有者。
 func InjectUser() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) error {
@@ -17,6 +20,12 @@ func InjectUser() echo.MiddlewareFunc {
 
 			username := c.Request().Header.Get("Remote-User")
 			nickname := c.Request().Header.Get("Remote-Name")
+			if token := common.ExtractAPIToken(c); token != nil {
+				if !apitoken.Allows(token, apitoken.ScopeArchiveRead) {
+					return httputil.NewHTTPError(http.StatusForbidden, "API token lacks scope "+apitoken.ScopeArchiveRead)
+				}
+				username, nickname = token.Username, token.Username
+			}
 
 			if config.C.Settings.Debug {
 				username, nickname = "jason", "Jason"
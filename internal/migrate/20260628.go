@@ -1,6 +1,7 @@
 package migrate
 
 import (
+	"context"
 	"fmt"
 
 	"go.uber.org/zap"
@@ -44,7 +45,7 @@ func migrateTombkeeperZeroByteImages(db *gorm.DB, logger *zap.Logger) error {
 			continue
 		}
 		scanned++
-		size, err := f.Size(o.ObjectKey)
+		size, err := f.Size(context.Background(), o.ObjectKey)
 		if err != nil {
 			logger.Warn("failed to stat object, skipping",
 				zap.String("object_key", o.ObjectKey), zap.Error(err))
@@ -1,16 +1,17 @@
 package migrate
 
 import (
+	"context"
 	"errors"
 	"io"
 	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 
 	"github.com/eli-yip/rss-zero/pkg/routers/tombkeeper"
 )
@@ -113,17 +114,17 @@ func TestTombkeeperLivePageOneAfterFreshMigration(t *testing.T) {
 
 type discardImageFile struct{}
 
-func (discardImageFile) SaveStream(_ string, stream io.ReadCloser, _ int64) error {
+func (discardImageFile) SaveStream(_ context.Context, _ string, stream io.ReadCloser, _ int64) error {
 	defer stream.Close()
 	_, err := io.Copy(io.Discard, stream)
 	return err
 }
 
-func (discardImageFile) GetStream(string) (io.ReadCloser, error) {
+func (discardImageFile) GetStream(context.Context, string) (io.ReadCloser, error) {
 	return nil, errors.New("unsupported")
 }
 
-func (discardImageFile) AssetsDomain() string       { return "https://assets.test" }
-func (discardImageFile) Delete(string) error        { return nil }
-func (discardImageFile) Exist(string) (bool, error) { return false, nil }
-func (discardImageFile) Size(string) (int64, error) { return 0, nil }
+func (discardImageFile) AssetsDomain() string                        { return "https://assets.test" }
+func (discardImageFile) Delete(context.Context, string) error        { return nil }
+func (discardImageFile) Exist(context.Context, string) (bool, error) { return false, nil }
+func (discardImageFile) Size(context.Context, string) (int64, error) { return 0, nil }
@@ -0,0 +1,55 @@
+package migrate
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+)
+
+func init() {
+	Register(Migration{
+		Version:              20261019000000,
+		Name:                 "bookmark-platform-ref",
+		Auto:                 true,
+		RequiresPredecessors: false,
+		Run:                  migrateBookmarkPlatformRef,
+	})
+}
+
+// migrateBookmarkPlatformRef 把历史上只能指向知乎的 int content_type 映射为平台限定引用
+// （platform + kind），并删除旧列。以旧列是否存在作幂等标记。
+func migrateBookmarkPlatformRef(db *gorm.DB, logger *zap.Logger) error {
+	if !db.Migrator().HasColumn(&bookmarkDB.Bookmark{}, "content_type") {
+		logger.Info("bookmark platform ref schema already active")
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var unknownCount int64
+		if err := tx.Table("bookmarks").Where("content_type IS NULL OR content_type NOT IN ?", []int{0, 1, 2}).Count(&unknownCount).Error; err != nil {
+			return fmt.Errorf("check unknown bookmark content types: %w", err)
+		}
+		if unknownCount > 0 {
+			return fmt.Errorf("found %d bookmarks with unknown content type", unknownCount)
+		}
+
+		const backfill = `UPDATE bookmarks
+SET platform = 'zhihu',
+    kind = CASE content_type
+    WHEN 0 THEN 'answer'
+    WHEN 1 THEN 'article'
+    WHEN 2 THEN 'pin'
+END`
+		if err := tx.Exec(backfill).Error; err != nil {
+			return fmt.Errorf("backfill bookmark platform and kind: %w", err)
+		}
+		if err := tx.Exec("ALTER TABLE bookmarks DROP COLUMN IF EXISTS content_type").Error; err != nil {
+			return fmt.Errorf("drop bookmark content type column: %w", err)
+		}
+		logger.Info("migrated bookmark content type to platform ref")
+		return nil
+	})
+}
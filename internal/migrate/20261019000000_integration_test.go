@@ -0,0 +1,61 @@
+package migrate
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+)
+
+func TestBookmarkPlatformRefMigrationBackfillsZhihuRows(t *testing.T) {
+	db := openBookmarkMigrationTestDB(t)
+	require.NoError(t, db.Exec(`CREATE TABLE bookmarks (
+		id text PRIMARY KEY, user_id text, content_type int, content_id text,
+		comment text, note text, created_at timestamptz, updated_at timestamptz, deleted_at timestamptz)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO bookmarks (id, user_id, content_type, content_id) VALUES
+		('a', 'u', 0, '1'), ('p', 'u', 2, '2')`).Error)
+	require.NoError(t, db.AutoMigrate(&bookmarkDB.Bookmark{}))
+
+	require.NoError(t, migrateBookmarkPlatformRef(db, zap.NewNop()))
+	assert.False(t, db.Migrator().HasColumn(&bookmarkDB.Bookmark{}, "content_type"))
+
+	store := bookmarkDB.NewBookMarkDBImpl(db)
+	answer, err := store.GetBookmark("u", "a")
+	require.NoError(t, err)
+	assert.Equal(t, bookmarkDB.ContentRef{Platform: "zhihu", Kind: "answer", ID: "1"}, answer.Ref())
+	pin, err := store.GetBookmark("u", "p")
+	require.NoError(t, err)
+	assert.Equal(t, bookmarkDB.ContentRef{Platform: "zhihu", Kind: "pin", ID: "2"}, pin.Ref())
+
+	// Second run is a no-op once the legacy column is gone.
+	require.NoError(t, migrateBookmarkPlatformRef(db, zap.NewNop()))
+}
+
+func TestBookmarkPlatformRefMigrationRejectsUnknownType(t *testing.T) {
+	db := openBookmarkMigrationTestDB(t)
+	require.NoError(t, db.Exec(`CREATE TABLE bookmarks (id text PRIMARY KEY, user_id text, content_type int, content_id text)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO bookmarks (id, user_id, content_type, content_id) VALUES ('x', 'u', 9, '1')`).Error)
+	require.NoError(t, db.AutoMigrate(&bookmarkDB.Bookmark{}))
+
+	require.Error(t, migrateBookmarkPlatformRef(db, zap.NewNop()))
+	assert.True(t, db.Migrator().HasColumn(&bookmarkDB.Bookmark{}, "content_type"), "failed migration must roll back")
+}
+
+func openBookmarkMigrationTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("BOOKMARK_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("set BOOKMARK_TEST_DATABASE_URL to run the Postgres integration test")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("DROP TABLE IF EXISTS bookmarks").Error)
+	t.Cleanup(func() { _ = db.Exec("DROP TABLE IF EXISTS bookmarks").Error })
+	return db
+}
@@ -0,0 +1,18 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmarkPlatformRefMigrationRegistered(t *testing.T) {
+	require.NoError(t, validateRegistry(registry))
+	migration := registeredMigration(20261019000000)
+	if assert.NotNil(t, migration) {
+		assert.Equal(t, "bookmark-platform-ref", migration.Name)
+		assert.True(t, migration.Auto)
+		assert.False(t, migration.RequiresPredecessors)
+	}
+}
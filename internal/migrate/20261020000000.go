@@ -0,0 +1,60 @@
+package migrate
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+)
+
+func init() {
+	Register(Migration{
+		Version:              20261020000000,
+		Name:                 "bookmark-user-tags",
+		Auto:                 true,
+		RequiresPredecessors: false,
+		Run:                  migrateBookmarkUserTags,
+	})
+}
+
+// migrateBookmarkUserTags 为已有的收藏标签补建 user_tags 实体（含层级标签的全部祖先）。
+// 新打的标签由 bookmark db 层自动补齐；插入冲突即跳过，重复执行无副作用。
+func migrateBookmarkUserTags(db *gorm.DB, logger *zap.Logger) error {
+	var pairs []struct {
+		UserID string
+		Name   string
+	}
+	if err := db.Table("tags").
+		Select("DISTINCT bookmarks.user_id, tags.name").
+		Joins("JOIN bookmarks ON bookmarks.id = tags.bookmark_id").
+		Where("tags.deleted_at IS NULL AND bookmarks.deleted_at IS NULL").
+		Scan(&pairs).Error; err != nil {
+		return fmt.Errorf("load bookmark tags: %w", err)
+	}
+
+	seen := make(map[[2]string]struct{})
+	userTags := make([]bookmarkDB.UserTag, 0, len(pairs))
+	for _, p := range pairs {
+		for name := p.Name; name != ""; name = bookmarkDB.TagParent(name) {
+			key := [2]string{p.UserID, name}
+			if _, ok := seen[key]; ok {
+				break
+			}
+			seen[key] = struct{}{}
+			userTags = append(userTags, bookmarkDB.UserTag{UserID: p.UserID, Name: name, Parent: bookmarkDB.TagParent(name)})
+		}
+	}
+	if len(userTags) == 0 {
+		logger.Info("no bookmark tags to backfill")
+		return nil
+	}
+
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&userTags, 500).Error; err != nil {
+		return fmt.Errorf("backfill user tags: %w", err)
+	}
+	logger.Info("backfilled user tags", zap.Int("count", len(userTags)))
+	return nil
+}
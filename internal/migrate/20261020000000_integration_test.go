@@ -0,0 +1,39 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+)
+
+func TestBookmarkUserTagsMigrationBackfillsLineage(t *testing.T) {
+	db := openBookmarkMigrationTestDB(t)
+	require.NoError(t, db.Exec("DROP TABLE IF EXISTS tags, user_tags").Error)
+	t.Cleanup(func() { _ = db.Exec("DROP TABLE IF EXISTS tags, user_tags").Error })
+	require.NoError(t, db.AutoMigrate(&bookmarkDB.Bookmark{}, &bookmarkDB.Tag{}, &bookmarkDB.UserTag{}))
+	require.NoError(t, db.Exec(`INSERT INTO bookmarks (id, user_id, platform, kind, content_id) VALUES
+		('b1', 'u', 'zhihu', 'answer', '1'), ('b2', 'v', 'zhihu', 'pin', '2')`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO tags (bookmark_id, name) VALUES
+		('b1', '投资/港股'), ('b1', '投资'), ('b2', '读书')`).Error)
+
+	require.NoError(t, migrateBookmarkUserTags(db, zap.NewNop()))
+	require.NoError(t, migrateBookmarkUserTags(db, zap.NewNop()), "second run must be a no-op")
+
+	store := bookmarkDB.NewBookMarkDBImpl(db)
+	tags, err := store.GetUserTags("u")
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "投资", tags[0].Name)
+	assert.Equal(t, "", tags[0].Parent)
+	assert.Equal(t, "投资/港股", tags[1].Name)
+	assert.Equal(t, "投资", tags[1].Parent)
+
+	tags, err = store.GetUserTags("v")
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "读书", tags[0].Name)
+}
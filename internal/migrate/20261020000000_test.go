@@ -0,0 +1,18 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmarkUserTagsMigrationRegistered(t *testing.T) {
+	require.NoError(t, validateRegistry(registry))
+	migration := registeredMigration(20261020000000)
+	if assert.NotNil(t, migration) {
+		assert.Equal(t, "bookmark-user-tags", migration.Name)
+		assert.True(t, migration.Auto)
+		assert.False(t, migration.RequiresPredecessors)
+	}
+}
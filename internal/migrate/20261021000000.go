@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+)
+
+func init() {
+	Register(Migration{
+		Version:              20261021000000,
+		Name:                 "zhihu-article-deleted",
+		Auto:                 true,
+		RequiresPredecessors: false,
+		Run:                  migrateZhihuArticleDeleted,
+	})
+}
+
+// migrateZhihuArticleDeleted 为已记过删除的文章补上 deleted 标记：此前文章没有状态列，
+// 是否已删除只看最近一条修订。只把标记置为 true，重复执行无副作用。
+func migrateZhihuArticleDeleted(db *gorm.DB, logger *zap.Logger) error {
+	const backfill = `UPDATE zhihu_article SET deleted = true
+WHERE NOT deleted AND id IN (
+	SELECT r.content_id FROM zhihu_revision r
+	WHERE r.type = ? AND r.deleted
+	AND r.id = (SELECT max(id) FROM zhihu_revision WHERE type = r.type AND content_id = r.content_id)
+)`
+	result := db.Exec(backfill, string(common.ZhihuArticle))
+	if result.Error != nil {
+		return fmt.Errorf("backfill deleted zhihu articles: %w", result.Error)
+	}
+	logger.Info("backfilled deleted zhihu articles", zap.Int64("count", result.RowsAffected))
+	return nil
+}
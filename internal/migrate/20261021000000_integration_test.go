@@ -0,0 +1,35 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+)
+
+func TestZhihuArticleDeletedMigrationBackfillsLatestDeletion(t *testing.T) {
+	db := openBookmarkMigrationTestDB(t)
+	require.NoError(t, db.Exec("DROP TABLE IF EXISTS zhihu_article, zhihu_revision").Error)
+	t.Cleanup(func() { _ = db.Exec("DROP TABLE IF EXISTS zhihu_article, zhihu_revision").Error })
+	require.NoError(t, db.AutoMigrate(&zhihuDB.Article{}, &zhihuDB.Revision{}))
+	require.NoError(t, db.Create(&[]zhihuDB.Article{{ID: 1}, {ID: 2}, {ID: 3}}).Error)
+	article := string(common.ZhihuArticle)
+	require.NoError(t, db.Create(&[]zhihuDB.Revision{
+		{Type: article, ContentID: 1, Deleted: true},
+		// 2 删除后又被重新抓到新版本，最近一条修订不是删除
+		{Type: article, ContentID: 2, Deleted: true},
+		{Type: article, ContentID: 2},
+		{Type: string(common.ZhihuAnswer), ContentID: 3, Deleted: true},
+	}).Error)
+
+	require.NoError(t, migrateZhihuArticleDeleted(db, zap.NewNop()))
+	require.NoError(t, migrateZhihuArticleDeleted(db, zap.NewNop()), "second run must be a no-op")
+
+	var deleted []int
+	require.NoError(t, db.Model(&zhihuDB.Article{}).Where("deleted").Order("id").Pluck("id", &deleted).Error)
+	assert.Equal(t, []int{1}, deleted)
+}
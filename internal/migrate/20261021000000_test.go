@@ -0,0 +1,18 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZhihuArticleDeletedMigrationRegistered(t *testing.T) {
+	require.NoError(t, validateRegistry(registry))
+	migration := registeredMigration(20261021000000)
+	if assert.NotNil(t, migration) {
+		assert.Equal(t, "zhihu-article-deleted", migration.Name)
+		assert.True(t, migration.Auto)
+		assert.False(t, migration.RequiresPredecessors)
+	}
+}
@@ -3,9 +3,12 @@ package migrate
 import (
 	"gorm.io/gorm"
 
+	"github.com/eli-yip/rss-zero/internal/digest"
+	apitokenDB "github.com/eli-yip/rss-zero/pkg/apitoken/db"
 	bookmark "github.com/eli-yip/rss-zero/pkg/bookmark/db"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+	reading "github.com/eli-yip/rss-zero/pkg/reading/db"
 	githubDB "github.com/eli-yip/rss-zero/pkg/routers/github/db"
 	"github.com/eli-yip/rss-zero/pkg/routers/macked"
 	"github.com/eli-yip/rss-zero/pkg/routers/tkblog"
@@ -14,6 +17,7 @@ import (
 	xiaobotDB "github.com/eli-yip/rss-zero/pkg/routers/xiaobot/db"
 	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
 	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
 )
 
 func MigrateDB(db *gorm.DB) (err error) {
@@ -23,6 +27,7 @@ func MigrateDB(db *gorm.DB) (err error) {
 		&zsxqDB.Author{},
 		&zsxqDB.Object{},
 		&zsxqDB.Article{},
+		&zsxqDB.Comment{},
 
 		&zhihuDB.Answer{},
 		&zhihuDB.Question{},
@@ -33,6 +38,9 @@ func MigrateDB(db *gorm.DB) (err error) {
 		&zhihuDB.Sub{},
 		&zhihuDB.EncryptionService{},
 		&zhihuDB.Zvideo{},
+		&zhihuDB.Revision{},
+		&zhihuDB.List{},
+		&zhihuDB.ListItem{},
 
 		&xiaobotDB.Paper{},
 		&xiaobotDB.Post{},
@@ -44,6 +52,8 @@ func MigrateDB(db *gorm.DB) (err error) {
 
 		&cronDB.CronTask{},
 		&cronDB.CronJob{},
+		&cronDB.CronJobTarget{},
+		&cronDB.CronJobLog{},
 
 		&githubDB.Release{},
 		&githubDB.Sub{},
@@ -61,6 +71,17 @@ func MigrateDB(db *gorm.DB) (err error) {
 
 		&bookmark.Bookmark{},
 		&bookmark.Tag{},
+		&bookmark.UserTag{},
+
+		&reading.ReadState{},
+
+		&subscriptionDB.Stat{},
+		&subscriptionDB.Owner{},
+		&subscriptionDB.Grant{},
+
+		&digest.Digest{},
+
+		&apitokenDB.Token{},
 
 		&SchemaMigration{},
 	)
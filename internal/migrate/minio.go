@@ -33,7 +33,7 @@ func MigrateMinio20240905(file file.File, db *gorm.DB, logger *zap.Logger) {
 	}
 
 	for _, obj := range objects {
-		exist, err := file.Exist(obj.ObjectKey)
+		exist, err := file.Exist(context.Background(), obj.ObjectKey)
 		if err != nil {
 			logger.Error("Failed to check file existance", zap.Error(err), zap.String("object_key", obj.ObjectKey))
 			return
@@ -50,7 +50,7 @@ func MigrateMinio20240905(file file.File, db *gorm.DB, logger *zap.Logger) {
 			return
 		}
 
-		if err = file.SaveStream(obj.ObjectKey, resp.Body, resp.ContentLength); err != nil {
+		if err = file.SaveStream(context.Background(), obj.ObjectKey, resp.Body, resp.ContentLength); err != nil {
 			logger.Error("Failed to save image stream to file service", zap.Error(err), zap.String("object_key", obj.ObjectKey))
 			return
 		}
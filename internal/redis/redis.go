@@ -16,6 +16,8 @@ const (
 	// 陈旧 canonical items。cron warm 与 controller cache-miss 共用同一 const，一并切换。
 	ZsxqRSSPath                  = "zsxq_rss_v2_%s"
 	ZsxqRandomCanglimoDigestPath = "zsxq_rss_random_canglimo_digest_v2"
+	// 星球附件 feed，不做 cron 预热，靠 TTL 过期刷新
+	ZsxqFilesRSSPath = "zsxq_rss_files_%s"
 
 	XiaobotRSSPath = "xiaobot_rss_%s"
 
@@ -23,6 +25,8 @@ const (
 	// 生成的陈旧 items。cron warm（cron/random.go）与 controller serve（controller/zhihu/random.go）
 	// 共用同一 const，一并切换。每作者 feed 的 v2 键见 common.ZhihuContentType.RedisKey。
 	ZhihuRandomCanglimoAnswersPath = "zhihu_rss_random_canglimo_answers_v2"
+	// 作者回答与文章的修改/删除 feed，内容来自 zhihu_revision
+	ZhihuChangesRSSPath = "zhihu_rss_changes_%s"
 
 	EndOfLifePath = "endoflife_rss_%s"
 
@@ -31,6 +35,8 @@ const (
 	RssMackedPath = "macked_rss"
 
 	RssTombkeeperTimelinePath = "tombkeeper_timeline_rss"
+
+	DigestRSSPath = "digest_rss"
 )
 
 const (
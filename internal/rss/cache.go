@@ -1,11 +1,15 @@
 package rss
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/eli-yip/rss-zero/internal/redis"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 )
 
 // v2Key namespaces a legacy redis path under the v2 items-cache, keeping the JSON
@@ -51,7 +55,10 @@ func storeCache(r redis.Redis, key string, cf cachedFeed, ttl time.Duration) err
 // WarmCache builds a feed via fetch and writes it to the items cache. crawl crons
 // call this after updating the DB so the cached items stay fresh — a 1:1
 // replacement of the old "render XML and Set" warming step.
-func WarmCache(r redis.Redis, key string, ttl time.Duration, fetch func() (FeedMeta, []Item, error)) error {
+func WarmCache(ctx context.Context, r redis.Redis, key string, ttl time.Duration, fetch func() (FeedMeta, []Item, error)) (err error) {
+	_, span := tracing.StartChild(ctx, "rss.warm_cache", attribute.String("rss.cache_key", key))
+	defer func() { tracing.End(span, err) }()
+
 	meta, items, err := fetch()
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package rss
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
@@ -120,7 +121,7 @@ func TestWarmCache(t *testing.T) {
 	meta := FeedMeta{Title: "源", Link: "https://example.com", Updated: time.Now().UTC()}
 	items := sampleItems(2)
 
-	if err := WarmCache(r, key, time.Hour, func() (FeedMeta, []Item, error) {
+	if err := WarmCache(context.Background(), r, key, time.Hour, func() (FeedMeta, []Item, error) {
 		return meta, items, nil
 	}); err != nil {
 		t.Fatalf("WarmCache: %v", err)
@@ -134,7 +135,7 @@ func TestWarmCache(t *testing.T) {
 	}
 
 	wantErr := fmt.Errorf("boom")
-	if err := WarmCache(r, key, time.Hour, func() (FeedMeta, []Item, error) {
+	if err := WarmCache(context.Background(), r, key, time.Hour, func() (FeedMeta, []Item, error) {
 		return FeedMeta{}, nil, wantErr
 	}); err != wantErr {
 		t.Fatalf("WarmCache error = %v, want %v", err, wantErr)
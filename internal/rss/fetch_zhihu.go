@@ -3,6 +3,8 @@ package rss
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"time"
 
@@ -26,16 +28,23 @@ type ZhihuRow struct {
 	CreateTime   time.Time
 	Title        string
 	Text         string
+	// Author overrides the feed-level author name for this entry, set for question
+	// feeds where every answer has its own author.
+	Author string
 }
 
 // FetchZhihu builds the canonical feed for a zhihu author's answers/articles/pins,
 // loading up to MaxFetch items. Content decoration (origin link appended, archive
 // proxy as the entry link, excerpt summary) is preserved; the former calculateTime
 // hack is dropped — it has been the identity since 2024-06-22.
+//
+// For ZhihuQuestion, authorID is the question id and the feed is named after the
+// question title; each entry carries its own answer author. ZhihuColumn and
+// ZhihuCollection work the same way with the column or collection id and title.
 func FetchZhihu(contentType common.ZhihuContentType, authorID string, db zhihuDB.DB, logger *zap.Logger) (FeedMeta, []Item, error) {
-	authorName, err := db.GetAuthorName(authorID)
+	authorName, err := zhihuFeedName(contentType, authorID, db)
 	if err != nil {
-		return FeedMeta{}, nil, fmt.Errorf("failed to get zhihu author name from database: %w", err)
+		return FeedMeta{}, nil, err
 	}
 
 	rows, err := zhihuRows(contentType, authorID, db)
@@ -49,6 +58,33 @@ func FetchZhihu(contentType common.ZhihuContentType, authorID string, db zhihuDB
 	return BuildZhihuFeed(contentType, authorID, authorName, rows)
 }
 
+func zhihuFeedName(contentType common.ZhihuContentType, targetID string, db zhihuDB.DB) (string, error) {
+	switch contentType {
+	case common.ZhihuQuestion:
+		questionID, err := strconv.Atoi(targetID)
+		if err != nil {
+			return "", fmt.Errorf("invalid zhihu question id %q: %w", targetID, err)
+		}
+		question, err := db.GetQuestion(questionID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get zhihu question from database: %w", err)
+		}
+		return question.Title, nil
+	case common.ZhihuColumn, common.ZhihuCollection:
+		list, err := db.GetList(contentType, targetID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get zhihu %s from database: %w", contentType, err)
+		}
+		return list.Title, nil
+	default:
+		authorName, err := db.GetAuthorName(targetID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get zhihu author name from database: %w", err)
+		}
+		return authorName, nil
+	}
+}
+
 // zhihuRows loads a page of roots of one content type, assembles their side facts
 // once via ContentLoader (no per-item N+1), and renders each body from raw — the
 // text column is no longer read. The answer entry title comes from AnswerTitle,
@@ -83,6 +119,38 @@ func zhihuRows(contentType common.ZhihuContentType, authorID string, db zhihuDB.
 			})
 		}
 		return rows, nil
+	case common.ZhihuQuestion:
+		questionID, err := strconv.Atoi(authorID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zhihu question id %q: %w", authorID, err)
+		}
+		answers, err := db.GetLatestNVisibleQuestionAnswer(MaxFetch, questionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest question answers from database: %w", err)
+		}
+		snap, err := loader.LoadAnswers(answers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load answer snapshot: %w", err)
+		}
+		rows := make([]ZhihuRow, 0, len(answers))
+		for _, a := range answers {
+			body, err := zhihuRender.RenderMarkdown(a.ID, snap, serverBaseURL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render answer %d: %w", a.ID, err)
+			}
+			author := answerAuthorName(a)
+			rows = append(rows, ZhihuRow{
+				ID:           a.ID,
+				OfficialLink: zhihuRender.GenerateAnswerLink(a.QuestionID, a.ID),
+				CreateTime:   a.CreateAt,
+				Title:        author + "的回答",
+				Text:         body,
+				Author:       author,
+			})
+		}
+		return rows, nil
+	case common.ZhihuColumn, common.ZhihuCollection:
+		return zhihuListRows(contentType, authorID, db)
 	case common.ZhihuArticle:
 		articles, err := db.GetLatestNArticle(MaxFetch, authorID)
 		if err != nil {
@@ -144,7 +212,7 @@ func zhihuRows(contentType common.ZhihuContentType, authorID string, db zhihuDB.
 // by FetchZhihu and the random endpoint (which supplies its own random ids/time).
 func BuildZhihuFeed(contentType common.ZhihuContentType, authorID, authorName string, rows []ZhihuRow) (FeedMeta, []Item, error) {
 	feedTitle := "[知乎-" + contentType.TitleZH() + "]" + authorName
-	profileLink := fmt.Sprintf("https://www.zhihu.com/people/%s/%s", authorID, contentType.ProfilePath())
+	profileLink := contentType.TargetURL(authorID)
 	if len(rows) == 0 {
 		return FeedMeta{Title: feedTitle, Link: profileLink, Updated: defaultTime}, nil, nil
 	}
@@ -156,11 +224,15 @@ func BuildZhihuFeed(contentType common.ZhihuContentType, authorID, authorName st
 		if err != nil {
 			return FeedMeta{}, nil, fmt.Errorf("failed to render zhihu content: %w", err)
 		}
+		author := authorName
+		if row.Author != "" {
+			author = row.Author
+		}
 		items = append(items, Item{
 			ID:          fmt.Sprintf("%d", row.ID),
 			Link:        render.BuildArchiveLink(config.C.Settings.ServerURL, row.OfficialLink),
 			Title:       row.Title,
-			Author:      authorName,
+			Author:      author,
 			Time:        row.CreateTime,
 			Summary:     render.ExtractExcerpt(row.Text),
 			ContentHTML: contentHTML,
@@ -168,3 +240,89 @@ func BuildZhihuFeed(contentType common.ZhihuContentType, authorID, authorName st
 	}
 	return meta, items, nil
 }
+
+// answerAuthorName reads the author name zhihu returned with the answer; the author
+// table only holds subscribed authors.
+func answerAuthorName(answer zhihuDB.Answer) string {
+	return zhihuRender.RawAuthorName(answer.Raw, answer.AuthorID)
+}
+
+// zhihuListRows renders the latest answers and articles of a column or collection,
+// newest added first. Items whose content is missing or hidden by content detection
+// are left out.
+func zhihuListRows(contentType common.ZhihuContentType, listID string, db zhihuDB.DB) ([]ZhihuRow, error) {
+	items, err := db.GetListItems(contentType, listID, MaxFetch, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest %s items from database: %w", contentType, err)
+	}
+
+	var answerIDs, articleIDs []int
+	for _, item := range items {
+		if item.ContentType == common.ZhihuArticle {
+			articleIDs = append(articleIDs, item.ContentID)
+		} else {
+			answerIDs = append(answerIDs, item.ContentID)
+		}
+	}
+	answers, err := db.FetchAnswerByIDs(answerIDs)
+	if err != nil {
+		return nil, err
+	}
+	maps.DeleteFunc(answers, func(_ int, a zhihuDB.Answer) bool { return a.DetectStatus == zhihuDB.DetectStatusSkipped })
+	articles, err := db.FetchArticleByIDs(articleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := zhihuRender.NewContentLoader(db)
+	serverBaseURL := config.C.Settings.ServerURL
+	answerSnap, err := loader.LoadAnswers(slices.Collect(maps.Values(answers)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load answer snapshot: %w", err)
+	}
+	articleSnap, err := loader.LoadArticles(slices.Collect(maps.Values(articles)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article snapshot: %w", err)
+	}
+
+	rows := make([]ZhihuRow, 0, len(items))
+	for _, item := range items {
+		if item.ContentType == common.ZhihuArticle {
+			ar, ok := articles[item.ContentID]
+			if !ok {
+				continue
+			}
+			body, err := zhihuRender.RenderMarkdown(ar.ID, articleSnap, serverBaseURL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render article %d: %w", ar.ID, err)
+			}
+			rows = append(rows, ZhihuRow{
+				ID:           ar.ID,
+				OfficialLink: zhihuRender.GenerateArticleLink(ar.ID),
+				CreateTime:   item.AddedAt,
+				Title:        ar.Title,
+				Text:         body,
+				Author:       zhihuRender.RawAuthorName(ar.Raw, ar.AuthorID),
+			})
+			continue
+		}
+
+		a, ok := answers[item.ContentID]
+		if !ok {
+			continue
+		}
+		body, err := zhihuRender.RenderMarkdown(a.ID, answerSnap, serverBaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render answer %d: %w", a.ID, err)
+		}
+		rows = append(rows, ZhihuRow{
+			ID:           a.ID,
+			OfficialLink: zhihuRender.GenerateAnswerLink(a.QuestionID, a.ID),
+			CreateTime:   item.AddedAt,
+			Title:        zhihuRender.AnswerTitle(answerSnap, a.QuestionID),
+			Text:         body,
+			Author:       answerAuthorName(a),
+		})
+	}
+	return rows, nil
+}
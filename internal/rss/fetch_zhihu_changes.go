@@ -0,0 +1,71 @@
+package rss
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/render"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/revision"
+)
+
+// FetchZhihuChanges builds the feed of edits and deletions the recheck job found in
+// a zhihu author's answers and articles. An edit entry carries the unified diff of
+// the edited version against the next one; a deletion carries the last seen body.
+func FetchZhihuChanges(authorID string, db zhihuDB.DB, logger *zap.Logger) (FeedMeta, []Item, error) {
+	authorName, err := db.GetAuthorName(authorID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		authorName = authorID
+	} else if err != nil {
+		return FeedMeta{}, nil, fmt.Errorf("failed to get zhihu author name from database: %w", err)
+	}
+
+	changes, err := revision.NewService(db, config.C.Settings.ServerURL).Changes(authorID, MaxFetch)
+	if err != nil {
+		return FeedMeta{}, nil, fmt.Errorf("failed to get zhihu changes: %w", err)
+	}
+	if len(changes) == 0 {
+		logger.Info("found no zhihu changes, building empty feed")
+	}
+	return BuildZhihuChangesFeed(authorID, authorName, changes)
+}
+
+// BuildZhihuChangesFeed builds the envelope and items from already-resolved changes.
+func BuildZhihuChangesFeed(authorID, authorName string, changes []revision.Change) (FeedMeta, []Item, error) {
+	meta := FeedMeta{
+		Title:   "[知乎-修改与删除]" + authorName,
+		Link:    fmt.Sprintf("https://www.zhihu.com/people/%s", authorID),
+		Updated: defaultTime,
+	}
+	if len(changes) > 0 {
+		meta.Updated = changes[0].DetectedAt
+	}
+
+	items := make([]Item, 0, len(changes))
+	for _, change := range changes {
+		label, text := "已修改", "```diff\n"+strings.TrimRight(change.Text, "\n")+"\n```\n"
+		if change.Deleted {
+			label, text = "已删除", change.Text
+		}
+		contentHTML, err := render.FeedHTML(render.AppendOriginLink(text, change.Link))
+		if err != nil {
+			return FeedMeta{}, nil, fmt.Errorf("failed to render zhihu change: %w", err)
+		}
+		items = append(items, Item{
+			ID:          fmt.Sprintf("%s-%d-%d", change.Type, change.ID, change.DetectedAt.Unix()),
+			Link:        render.BuildArchiveLink(config.C.Settings.ServerURL, change.Link),
+			Title:       fmt.Sprintf("[%s][%s]%s", label, common.ZhihuContentType(change.Type).TitleZH(), change.Title),
+			Author:      authorName,
+			Time:        change.DetectedAt,
+			Summary:     label,
+			ContentHTML: contentHTML,
+		})
+	}
+	return meta, items, nil
+}
@@ -0,0 +1,83 @@
+package rss
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/pkg/common"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	apiModels "github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse/api_models"
+)
+
+// fakeZhihuRevisionDB 在 fakeZhihuDB 之上补齐修订读取：单条根行、修订列表与作者最近的修订。
+type fakeZhihuRevisionDB struct {
+	fakeZhihuDB
+	revisions []zhihuDB.Revision
+}
+
+func (f *fakeZhihuRevisionDB) GetAnswer(id int) (*zhihuDB.Answer, error) {
+	for _, a := range f.answers {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+	return nil, assert.AnError
+}
+
+func (f *fakeZhihuRevisionDB) ListRevisions(t common.ZhihuContentType, id int) (out []zhihuDB.Revision, err error) {
+	for _, r := range f.revisions {
+		if r.Type == string(t) && r.ContentID == id {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeZhihuRevisionDB) GetLatestNRevision(int, string) ([]zhihuDB.Revision, error) {
+	out := make([]zhihuDB.Revision, 0, len(f.revisions))
+	for i := len(f.revisions) - 1; i >= 0; i-- {
+		out = append(out, f.revisions[i])
+	}
+	return out, nil
+}
+
+func TestFetchZhihuChanges(t *testing.T) {
+	config.C.Settings.ServerURL = "https://srv.test"
+
+	answerRaw := func(html string) []byte { return mustJSON(t, apiModels.Answer{HTML: html, AnswerType: "normal"}) }
+	edited := time.Date(2026, 6, 22, 10, 0, 0, 0, time.UTC)
+	deleted := edited.Add(24 * time.Hour)
+
+	fake := &fakeZhihuRevisionDB{
+		fakeZhihuDB: fakeZhihuDB{
+			authorName: "墨苍离",
+			answers:    []zhihuDB.Answer{{ID: 111, QuestionID: 1, AuthorID: "canglimo", Raw: answerRaw("<p>第二版</p>")}},
+			questions:  map[int]zhihuDB.Question{1: {ID: 1, Title: "问题标题"}},
+		},
+		revisions: []zhihuDB.Revision{
+			{ID: 1, Type: "answer", ContentID: 111, AuthorID: "canglimo", Raw: answerRaw("<p>第一版</p>"), DetectedAt: edited},
+			{ID: 2, Type: "answer", ContentID: 111, AuthorID: "canglimo", Raw: answerRaw("<p>第二版</p>"), DetectedAt: deleted, Deleted: true},
+		},
+	}
+
+	meta, items, err := FetchZhihuChanges("canglimo", fake, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "[知乎-修改与删除]墨苍离", meta.Title)
+	assert.Equal(t, deleted, meta.Updated)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "[已删除][回答]问题标题", items[0].Title)
+	assert.Contains(t, items[0].ContentHTML, "第二版")
+
+	assert.Equal(t, "[已修改][回答]问题标题", items[1].Title)
+	assert.Equal(t, "answer-111-"+strconv.FormatInt(edited.Unix(), 10), items[1].ID)
+	assert.Contains(t, items[1].ContentHTML, "-第一版")
+	assert.Contains(t, items[1].ContentHTML, "+第二版")
+	assert.Contains(t, items[1].Link, "https://srv.test")
+}
@@ -2,6 +2,7 @@ package rss
 
 import (
 	"encoding/json"
+	"slices"
 	"strconv"
 	"testing"
 	"time"
@@ -29,6 +30,8 @@ type fakeZhihuDB struct {
 	pins       []zhihuDB.Pin
 	questions  map[int]zhihuDB.Question
 	objects    map[int]zhihuDB.Object
+	lists      map[string]zhihuDB.List
+	listItems  []zhihuDB.ListItem
 }
 
 func (f *fakeZhihuDB) GetAuthorName(string) (string, error) { return f.authorName, nil }
@@ -41,6 +44,49 @@ func (f *fakeZhihuDB) GetLatestNArticle(int, string) ([]zhihuDB.Article, error)
 }
 func (f *fakeZhihuDB) GetLatestNPin(int, string) ([]zhihuDB.Pin, error) { return f.pins, nil }
 
+func (f *fakeZhihuDB) GetLatestNVisibleQuestionAnswer(_ int, questionID int) (out []zhihuDB.Answer, err error) {
+	for _, a := range f.answers {
+		if a.QuestionID == questionID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeZhihuDB) GetList(_ common.ZhihuContentType, id string) (*zhihuDB.List, error) {
+	l := f.lists[id]
+	return &l, nil
+}
+
+func (f *fakeZhihuDB) GetListItems(common.ZhihuContentType, string, int, int) ([]zhihuDB.ListItem, error) {
+	return f.listItems, nil
+}
+
+func (f *fakeZhihuDB) FetchAnswerByIDs(ids []int) (map[int]zhihuDB.Answer, error) {
+	out := make(map[int]zhihuDB.Answer)
+	for _, a := range f.answers {
+		if slices.Contains(ids, a.ID) {
+			out[a.ID] = a
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeZhihuDB) FetchArticleByIDs(ids []int) (map[int]zhihuDB.Article, error) {
+	out := make(map[int]zhihuDB.Article)
+	for _, a := range f.articles {
+		if slices.Contains(ids, a.ID) {
+			out[a.ID] = a
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeZhihuDB) GetQuestion(id int) (*zhihuDB.Question, error) {
+	q := f.questions[id]
+	return &q, nil
+}
+
 func (f *fakeZhihuDB) GetQuestions(ids []int) (out []zhihuDB.Question, err error) {
 	for _, id := range ids {
 		if q, ok := f.questions[id]; ok {
@@ -192,4 +238,90 @@ func TestZhihuAnswerMissingQuestionDegrades(t *testing.T) {
 	}
 }
 
+// TestFetchZhihuQuestion 验证问题 feed：标题取问题标题，每条回答署名为各自作者，匿名回答退回作者 id。
+func TestFetchZhihuQuestion(t *testing.T) {
+	config.C.Settings.ServerURL = "https://srv.test"
+
+	fake := &fakeZhihuDB{
+		answers: []zhihuDB.Answer{
+			{ID: 111, QuestionID: 42, AuthorID: "alice", CreateAt: time.Date(2026, 6, 22, 10, 0, 0, 0, time.UTC), Raw: mustJSON(t, apiModels.Answer{
+				HTML:   `<p>第一条回答。</p>`,
+				Author: apiModels.Author{ID: "alice", Name: "爱丽丝"},
+			})},
+			{ID: 222, QuestionID: 42, AuthorID: "0", CreateAt: time.Date(2026, 6, 21, 9, 0, 0, 0, time.UTC), Raw: mustJSON(t, apiModels.Answer{
+				HTML: `<p>匿名回答。</p>`,
+			})},
+			{ID: 333, QuestionID: 7, AuthorID: "bob"},
+		},
+		questions: map[int]zhihuDB.Question{42: {ID: 42, Title: "问题标题"}},
+		objects:   map[int]zhihuDB.Object{},
+	}
+
+	meta, items, err := FetchZhihu(common.ZhihuQuestion, "42", fake, zap.NewNop())
+	if err != nil {
+		t.Fatalf("FetchZhihu(question): %v", err)
+	}
+	if meta.Title != "[知乎-问题]问题标题" || meta.Link != "https://www.zhihu.com/question/42" {
+		t.Fatalf("unexpected feed meta: %+v", meta)
+	}
+	if len(items) != 2 {
+		t.Fatalf("want 2 items, got %d", len(items))
+	}
+	if items[0].Author != "爱丽丝" || items[0].Title != "爱丽丝的回答" {
+		t.Fatalf("unexpected first item: author %q title %q", items[0].Author, items[0].Title)
+	}
+	if items[1].Author != "0" {
+		t.Fatalf("anonymous answer should fall back to author id, got %q", items[1].Author)
+	}
+}
+
+// TestFetchZhihuCollection 验证收藏夹 feed：按收藏时间排列回答与文章，时间取收藏时间，
+// 各自署名；尚未入库与被检测隐藏的内容不出现。
+func TestFetchZhihuCollection(t *testing.T) {
+	config.C.Settings.ServerURL = "https://srv.test"
+
+	collected := time.Date(2026, 7, 1, 8, 0, 0, 0, time.UTC)
+	fake := &fakeZhihuDB{
+		answers: []zhihuDB.Answer{
+			{ID: 111, QuestionID: 42, AuthorID: "alice", Raw: mustJSON(t, apiModels.Answer{
+				HTML:   `<p>收藏的回答。</p>`,
+				Author: apiModels.Author{ID: "alice", Name: "爱丽丝"},
+			})},
+			{ID: 222, QuestionID: 42, AuthorID: "bob", DetectStatus: zhihuDB.DetectStatusSkipped},
+		},
+		articles: []zhihuDB.Article{
+			{ID: 333, AuthorID: "carol", Title: "文章标题", Raw: mustJSON(t, apiModels.Article{
+				HTML:   `<p>收藏的文章。</p>`,
+				Author: apiModels.Author{ID: "carol", Name: "卡罗尔"},
+			})},
+		},
+		questions: map[int]zhihuDB.Question{42: {ID: 42, Title: "问题标题"}},
+		objects:   map[int]zhihuDB.Object{},
+		lists:     map[string]zhihuDB.List{"9527": {ID: "9527", Type: common.ZhihuCollection, Title: "好文收藏"}},
+		listItems: []zhihuDB.ListItem{
+			{ContentType: common.ZhihuArticle, ContentID: 333, AddedAt: collected},
+			{ContentType: common.ZhihuAnswer, ContentID: 222, AddedAt: collected.Add(-time.Hour)},
+			{ContentType: common.ZhihuAnswer, ContentID: 444, AddedAt: collected.Add(-2 * time.Hour)},
+			{ContentType: common.ZhihuAnswer, ContentID: 111, AddedAt: collected.Add(-3 * time.Hour)},
+		},
+	}
+
+	meta, items, err := FetchZhihu(common.ZhihuCollection, "9527", fake, zap.NewNop())
+	if err != nil {
+		t.Fatalf("FetchZhihu(collection): %v", err)
+	}
+	if meta.Title != "[知乎-收藏夹]好文收藏" || meta.Link != "https://www.zhihu.com/collection/9527" {
+		t.Fatalf("unexpected feed meta: %+v", meta)
+	}
+	if len(items) != 2 {
+		t.Fatalf("want 2 items, got %d", len(items))
+	}
+	if items[0].Title != "文章标题" || items[0].Author != "卡罗尔" || !items[0].Time.Equal(collected) {
+		t.Fatalf("unexpected first item: %+v", items[0])
+	}
+	if items[1].Title != "问题标题" || items[1].Author != "爱丽丝" {
+		t.Fatalf("unexpected second item: title %q author %q", items[1].Title, items[1].Author)
+	}
+}
+
 // autocorrect-enable
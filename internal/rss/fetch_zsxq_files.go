@@ -0,0 +1,90 @@
+package rss
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/pkg/render"
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	zsxqRender "github.com/eli-yip/rss-zero/pkg/routers/zsxq/render"
+)
+
+// FetchZSXQFiles builds the "new files" feed of a zsxq group: one entry per
+// mirrored attachment, newest first, linking the mirrored object and its topic.
+func FetchZSXQFiles(groupID int, db zsxqDB.DB, logger *zap.Logger) (FeedMeta, []Item, error) {
+	groupName, err := db.GetGroupName(groupID)
+	if err != nil {
+		return FeedMeta{}, nil, fmt.Errorf("failed to get zsxq group name from database: %w", err)
+	}
+
+	files, err := db.FetchFiles(groupID, MaxFetch, 0)
+	if err != nil {
+		return FeedMeta{}, nil, fmt.Errorf("failed to get latest files from database: %w", err)
+	}
+	if len(files) == 0 {
+		logger.Info("found no zsxq files, building empty feed")
+	}
+	return BuildZSXQFilesFeed(groupID, groupName, files)
+}
+
+// BuildZSXQFilesFeed builds the envelope and items from already-loaded files.
+func BuildZSXQFilesFeed(groupID int, groupName string, files []zsxqDB.TopicFile) (FeedMeta, []Item, error) {
+	meta := FeedMeta{
+		Title:   "[星球-附件]" + groupName,
+		Link:    zsxqRender.BuildGroupLink(groupID),
+		Updated: defaultTime,
+	}
+	if len(files) > 0 {
+		meta.Updated = files[0].Time
+	}
+
+	items := make([]Item, 0, len(files))
+	for _, f := range files {
+		name := f.FileName()
+		topicTitle := strconv.Itoa(f.TopicID)
+		if f.TopicTitle != nil {
+			topicTitle = *f.TopicTitle
+		}
+
+		text := name + "\n"
+		if uri, err := f.URI(); err == nil {
+			text = fmt.Sprintf("[%s](%s)\n", name, uri)
+		}
+		if f.Size > 0 {
+			text += "\n大小：" + formatFileSize(f.Size) + "\n"
+		}
+		text += "\n来自：" + topicTitle + "\n"
+
+		officialLink := zsxqRender.BuildLink(f.GroupID, f.TopicID)
+		contentHTML, err := render.FeedHTML(render.AppendOriginLink(text, officialLink))
+		if err != nil {
+			return FeedMeta{}, nil, fmt.Errorf("failed to render zsxq file %d: %w", f.ID, err)
+		}
+		items = append(items, Item{
+			ID:          strconv.Itoa(f.ID),
+			Link:        render.BuildArchiveLink(config.C.Settings.ServerURL, officialLink),
+			Title:       name,
+			Time:        f.Time,
+			Summary:     topicTitle,
+			ContentHTML: contentHTML,
+		})
+	}
+	return meta, items, nil
+}
+
+// formatFileSize 以 1024 进制显示文件大小，保留一位小数。
+func formatFileSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+	value, unit := float64(size), 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
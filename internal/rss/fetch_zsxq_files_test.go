@@ -0,0 +1,63 @@
+package rss
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/config"
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+)
+
+// fakeZSXQFilesReader 在 fakeZSXQReader 之上补齐附件列表。
+type fakeZSXQFilesReader struct {
+	fakeZSXQReader
+	files []zsxqDB.TopicFile
+}
+
+func (f *fakeZSXQFilesReader) FetchFiles(int, int, int) ([]zsxqDB.TopicFile, error) {
+	return f.files, nil
+}
+
+func TestFetchZSXQFiles(t *testing.T) {
+	config.C.Settings.ServerURL = "https://srv.test"
+
+	title := "周报"
+	newer := time.Date(2026, 6, 22, 10, 0, 0, 0, time.UTC)
+	fake := &fakeZSXQFilesReader{
+		fakeZSXQReader: fakeZSXQReader{groupName: "星球"},
+		files: []zsxqDB.TopicFile{
+			{
+				Object: zsxqDB.Object{ID: 9001, TopicID: 1001, Time: newer, Type: "file", Name: "研报.pdf",
+					ObjectKey: "zsxq/9001-研报.pdf", StorageProvider: pq.StringArray{"https://oss.example.com"}, Size: 3 << 20},
+				TopicTitle: &title, GroupID: 123,
+			},
+			// 旧记录：没有 name 与大小，标题为空时退回 topic id
+			{
+				Object:  zsxqDB.Object{ID: 9002, TopicID: 1002, Time: newer.Add(-time.Hour), Type: "file", ObjectKey: "zsxq/9002-old.docx"},
+				GroupID: 123,
+			},
+		},
+	}
+
+	meta, items, err := FetchZSXQFiles(123, fake, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "[星球-附件]星球", meta.Title)
+	assert.Equal(t, newer, meta.Updated)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "9001", items[0].ID)
+	assert.Equal(t, "研报.pdf", items[0].Title)
+	assert.Equal(t, "周报", items[0].Summary)
+	assert.Contains(t, items[0].ContentHTML, "https://oss.example.com/zsxq/9001-")
+	assert.Contains(t, items[0].ContentHTML, "3.0 MB")
+	assert.Contains(t, items[0].Link, "https://srv.test")
+
+	assert.Equal(t, "old.docx", items[1].Title)
+	assert.Equal(t, "1002", items[1].Summary)
+	assert.NotContains(t, items[1].ContentHTML, "大小")
+}
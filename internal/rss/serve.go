@@ -8,6 +8,7 @@ import (
 	"github.com/labstack/echo/v5"
 	"go.uber.org/zap"
 
+	"github.com/eli-yip/rss-zero/internal/metrics"
 	"github.com/eli-yip/rss-zero/internal/redis"
 )
 
@@ -28,7 +29,8 @@ type ServeOptions struct {
 func Serve(c *echo.Context, o ServeOptions) error {
 	limit := parseLimit(c, o.DefaultLimit)
 
-	cf, err := o.getOrBuild()
+	cf, hit, err := o.getOrBuild()
+	metrics.ObserveRSSCache(c.RouteInfo().Name, hit)
 	if err != nil {
 		o.Logger.Error("failed to build rss feed", zap.String("key", o.Key), zap.Error(err))
 		return c.String(http.StatusInternalServerError, "failed to get rss content")
@@ -42,33 +44,33 @@ func Serve(c *echo.Context, o ServeOptions) error {
 	return c.String(http.StatusOK, xml)
 }
 
-// getOrBuild returns the cached feed, building and caching it on a miss. When
+// getOrBuild returns the cached feed and whether it came from cache, building and caching it on a miss. When
 // Fetch is nil (e.g. macked, whose cache is populated only by its cron) a miss
 // yields an empty feed that is not cached, so a later cron/prewarm write shows
 // through immediately.
-func (o ServeOptions) getOrBuild() (cachedFeed, error) {
-	cf, err := loadCache(o.Redis, o.Key)
+func (o ServeOptions) getOrBuild() (cf cachedFeed, hit bool, err error) {
+	cf, err = loadCache(o.Redis, o.Key)
 	if err == nil {
-		return cf, nil
+		return cf, true, nil
 	}
 	if !errors.Is(err, redis.ErrKeyNotExist) {
-		return cachedFeed{}, err
+		return cachedFeed{}, false, err
 	}
 
 	if o.Fetch == nil {
-		return cachedFeed{Meta: o.EmptyMeta}, nil
+		return cachedFeed{Meta: o.EmptyMeta}, false, nil
 	}
 
 	meta, items, ferr := o.Fetch()
 	if ferr != nil {
-		return cachedFeed{}, ferr
+		return cachedFeed{}, false, ferr
 	}
 	cf = cachedFeed{Meta: meta, Items: items}
 	if serr := storeCache(o.Redis, o.Key, cf, o.TTL); serr != nil {
 		// A cache write failure must not fail the request — serve what we built.
 		o.Logger.Warn("failed to cache rss feed", zap.String("key", o.Key), zap.Error(serr))
 	}
-	return cf, nil
+	return cf, false, nil
 }
 
 // ServeCachedString serves a whole cached string (the random feeds' rendered Atom
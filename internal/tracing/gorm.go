@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "rss_zero:tracing_span"
+
+// GormPlugin 为每条 SQL 语句开启子 span，只在 db.WithContext 传入的 ctx 已处于链路中时生效。
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string { return "rss_zero:tracing" }
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("rss_zero:tracing_before_create", beforeStatement("create")),
+		cb.Create().After("gorm:create").Register("rss_zero:tracing_after_create", afterStatement),
+		cb.Query().Before("gorm:query").Register("rss_zero:tracing_before_query", beforeStatement("query")),
+		cb.Query().After("gorm:query").Register("rss_zero:tracing_after_query", afterStatement),
+		cb.Update().Before("gorm:update").Register("rss_zero:tracing_before_update", beforeStatement("update")),
+		cb.Update().After("gorm:update").Register("rss_zero:tracing_after_update", afterStatement),
+		cb.Delete().Before("gorm:delete").Register("rss_zero:tracing_before_delete", beforeStatement("delete")),
+		cb.Delete().After("gorm:delete").Register("rss_zero:tracing_after_delete", afterStatement),
+		cb.Row().Before("gorm:row").Register("rss_zero:tracing_before_row", beforeStatement("row")),
+		cb.Row().After("gorm:row").Register("rss_zero:tracing_after_row", afterStatement),
+		cb.Raw().Before("gorm:raw").Register("rss_zero:tracing_before_raw", beforeStatement("raw")),
+		cb.Raw().After("gorm:raw").Register("rss_zero:tracing_after_raw", afterStatement),
+	)
+}
+
+func beforeStatement(op string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := StartChild(db.Statement.Context, "gorm."+op, attribute.String("db.system", "postgresql"))
+		if !span.SpanContext().IsValid() {
+			return
+		}
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func afterStatement(db *gorm.DB) {
+	v, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span := v.(trace.Span)
+	span.SetAttributes(
+		attribute.String("db.collection.name", db.Statement.Table),
+		attribute.String("db.query.text", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	End(span, err)
+}
+
+// Transaction 在名为 name 的 span 内执行 db.Transaction，事务内语句的 span 挂在它下面。
+func Transaction(ctx context.Context, db *gorm.DB, name string, fc func(tx *gorm.DB) error) (err error) {
+	ctx, span := StartChild(ctx, name, attribute.String("db.system", "postgresql"))
+	defer func() { End(span, err) }()
+	return db.WithContext(ctx).Transaction(fc)
+}
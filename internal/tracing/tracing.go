@@ -0,0 +1,113 @@
+// Package tracing 封装 OpenTelemetry 链路追踪：启动时按配置选择导出器，
+// 各处埋点只调用这里的函数，不直接接触 SDK 类型。
+//
+// span 的父子关系靠 context.Context 传递。根 span 只有两处：echo 请求（middleware.Tracing）
+// 与每次 cron 运行（StartCronRun）；往下的请求服务、对象存储、数据库用 StartChild 挂子 span，
+// 尚未透传 ctx 的调用（传入 context.Background()）不产生 span，免得刷出大量孤立根 span。
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/version"
+)
+
+const (
+	serviceName    = "rss-zero"
+	instrumentName = "github.com/eli-yip/rss-zero"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// 与日志字段同名的 span 属性，便于从日志里的 id 找到对应链路。
+const (
+	RequestIDKey = attribute.Key("request_id")
+	CronJobIDKey = attribute.Key("cron_job_id")
+)
+
+// Init 按配置安装全局 TracerProvider，返回的 shutdown 在退出前调用以刷出未导出的 span。
+// Exporter 为空时不安装，全局仍是 otel 默认的 no-op 实现。
+func Init(c config.TracingConfig) (shutdown func(context.Context) error, err error) {
+	var exporter sdktrace.SpanExporter
+	switch c.Exporter {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if c.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(c.Endpoint))
+		}
+		if exporter, err = otlptracehttp.New(context.Background(), opts...); err != nil {
+			return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+		}
+	case ExporterStdout:
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout)); err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", c.Exporter)
+	}
+
+	ratio := c.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Start 在 ctx 当前 span 下开启子 span，ctx 中没有 span 时开启根 span。只用于链路入口。
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartChild 只在 ctx 已处于某条链路中时开启子 span，否则返回原 ctx 与 no-op span。
+func StartChild(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return Start(ctx, name, attrs...)
+}
+
+// StartCronRun 为一次 cron 运行开启根 span，kind 为 SourceSpec.Kind 或静态任务名，cronJobID 可为空。
+func StartCronRun(kind, cronJobID string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{attribute.String("cron.kind", kind)}
+	if cronJobID != "" {
+		attrs = append(attrs, CronJobIDKey.String(cronJobID))
+	}
+	return Start(context.Background(), "cron."+kind, attrs...)
+}
+
+// End 结束 span，err 非空时记录错误并把状态置为 Error。
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
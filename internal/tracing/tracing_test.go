@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/eli-yip/rss-zero/config"
+)
+
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+func TestStartChild(t *testing.T) {
+	recorder := useRecorder(t)
+
+	// 不在链路中：不产生孤立根 span
+	ctx, span := StartChild(context.Background(), "orphan")
+	assert.False(t, span.SpanContext().IsValid())
+	assert.Equal(t, context.Background(), ctx)
+	End(span, nil)
+
+	rootCtx, root := StartCronRun("zsxq", "job-1")
+	_, child := StartChild(rootCtx, "zsxq.request")
+	End(child, errors.New("boom"))
+	End(root, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "zsxq.request", spans[0].Name())
+	assert.Equal(t, root.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "cron.zsxq", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), CronJobIDKey.String("job-1"))
+}
+
+func TestInit(t *testing.T) {
+	shutdown, err := Init(config.TracingConfig{})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+
+	_, err = Init(config.TracingConfig{Exporter: "jaeger"})
+	assert.Error(t, err)
+}
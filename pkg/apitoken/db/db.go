@@ -0,0 +1,85 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// Token 是个人 API token。明文只在创建时返回一次，库里只存 SHA-256 摘要；Prefix 是明文开头几位，
+// 供列表里辨认是哪一个。
+type Token struct {
+	ID       string         `gorm:"column:id;type:text;primaryKey" json:"id"`
+	Username string         `gorm:"column:username;type:text;not null;index" json:"username"`
+	Name     string         `gorm:"column:name;type:text;not null" json:"name"`
+	Prefix   string         `gorm:"column:prefix;type:text;not null" json:"prefix"`
+	Hash     string         `gorm:"column:hash;type:text;not null;uniqueIndex" json:"-"`
+	Scopes   pq.StringArray `gorm:"column:scopes;type:text[]" json:"scopes"`
+	// ExpiresAt 为空表示永不过期
+	ExpiresAt  *time.Time `gorm:"column:expires_at" json:"expires_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at" json:"last_used_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+func (*Token) TableName() string { return "api_tokens" }
+
+var ErrTokenNotFound = errors.New("api token not found")
+
+type DB interface {
+	CreateToken(token *Token) error
+	// GetTokenByHash 按摘要查找 token，找不到时返回 ErrTokenNotFound。
+	GetTokenByHash(hash string) (*Token, error)
+	// ListTokens 返回用户的全部 token，新建的在前。
+	ListTokens(username string) ([]*Token, error)
+	// DeleteToken 删除用户自己的 token，找不到时返回 ErrTokenNotFound。
+	DeleteToken(username, id string) error
+	TouchToken(id string, usedAt time.Time) error
+}
+
+type DBService struct{ *gorm.DB }
+
+func NewDBService(db *gorm.DB) DB { return &DBService{db} }
+
+func (ds *DBService) CreateToken(token *Token) error {
+	if err := ds.Create(token).Error; err != nil {
+		return fmt.Errorf("failed to create api token: %w", err)
+	}
+	return nil
+}
+
+func (ds *DBService) GetTokenByHash(hash string) (*Token, error) {
+	token := &Token{}
+	err := ds.Where("hash = ?", hash).First(token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+	return token, nil
+}
+
+func (ds *DBService) ListTokens(username string) (tokens []*Token, err error) {
+	if err = ds.Where("username = ?", username).Order("created_at desc").Find(&tokens).Error; err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+func (ds *DBService) DeleteToken(username, id string) error {
+	result := ds.Where("id = ? AND username = ?", id, username).Delete(&Token{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete api token: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+func (ds *DBService) TouchToken(id string, usedAt time.Time) error {
+	return ds.Model(&Token{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
@@ -0,0 +1,126 @@
+// Package apitoken 实现个人 API token：生成、按摘要校验、scope 判断与最近使用时间记录。
+// 脚本、CLI 与浏览器扩展用它以 Authorization: Bearer 访问 /api/v1，不必经过反代登录。
+package apitoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/eli-yip/rss-zero/pkg/apitoken/db"
+)
+
+// token 的权限范围。ScopeAdmin 包含其余全部。
+const (
+	ScopeArchiveRead = "archive:read" // 归档、收藏、标签与阅读状态等个人路由
+	ScopeSubsManage  = "subs:manage"  // /subscriptions、/sub 与 /my 下改动订阅的路由
+	ScopeJobsRun     = "jobs:run"     // /job
+	ScopeAdmin       = "admin"        // 全部管理路由
+)
+
+// Scopes 是全部合法 scope。
+var Scopes = []string{ScopeArchiveRead, ScopeSubsManage, ScopeJobsRun, ScopeAdmin}
+
+// prefix 让泄露的 token 容易被扫描工具识别。
+const prefix = "rzt_"
+
+// lastUsedInterval 内重复使用同一 token 不再写库，避免每个请求一次写入。
+const lastUsedInterval = time.Minute
+
+var (
+	ErrInvalidToken = errors.New("invalid api token")
+	ErrTokenExpired = errors.New("api token expired")
+	ErrUnknownScope = errors.New("unknown scope")
+)
+
+// Allows 报告 token 是否具备 scope。
+func Allows(token *db.Token, scope string) bool {
+	return slices.Contains(token.Scopes, scope) || slices.Contains(token.Scopes, ScopeAdmin)
+}
+
+// Hash 返回明文 token 的摘要。token 本身是 32 字节随机数，无需加盐或慢哈希。
+func Hash(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verifier 校验请求带来的明文 token，供 middleware 使用。
+type Verifier interface {
+	Verify(plain string) (*db.Token, error)
+}
+
+type Service struct {
+	db  db.DB
+	now func() time.Time
+}
+
+func NewService(dbService db.DB) *Service {
+	return &Service{db: dbService, now: time.Now}
+}
+
+// Create 为用户生成新 token，返回只出现这一次的明文。expiresAt 为空表示永不过期。
+func (s *Service) Create(username, name string, scopes []string, expiresAt *time.Time) (plain string, token *db.Token, err error) {
+	if len(scopes) == 0 {
+		return "", nil, fmt.Errorf("%w: at least one scope is required", ErrUnknownScope)
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(Scopes, scope) {
+			return "", nil, fmt.Errorf("%w: %s", ErrUnknownScope, scope)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		return "", nil, fmt.Errorf("failed to generate api token: %w", err)
+	}
+	plain = prefix + hex.EncodeToString(secret)
+	token = &db.Token{
+		ID:        xid.New().String(),
+		Username:  username,
+		Name:      name,
+		Prefix:    plain[:len(prefix)+6],
+		Hash:      Hash(plain),
+		Scopes:    slices.Clone(scopes),
+		ExpiresAt: expiresAt,
+	}
+	if err = s.db.CreateToken(token); err != nil {
+		return "", nil, err
+	}
+	return plain, token, nil
+}
+
+// Verify 返回明文对应的 token，并在需要时刷新最近使用时间。刷新失败不影响本次请求。
+func (s *Service) Verify(plain string) (*db.Token, error) {
+	if !strings.HasPrefix(plain, prefix) {
+		return nil, ErrInvalidToken
+	}
+	token, err := s.db.GetTokenByHash(Hash(plain))
+	if errors.Is(err, db.ErrTokenNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if token.ExpiresAt != nil && !token.ExpiresAt.After(now) {
+		return nil, ErrTokenExpired
+	}
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedInterval {
+		if err = s.db.TouchToken(token.ID, now); err == nil {
+			token.LastUsedAt = &now
+		}
+	}
+	return token, nil
+}
+
+func (s *Service) List(username string) ([]*db.Token, error) { return s.db.ListTokens(username) }
+
+func (s *Service) Delete(username, id string) error { return s.db.DeleteToken(username, id) }
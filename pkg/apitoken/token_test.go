@@ -0,0 +1,131 @@
+package apitoken
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eli-yip/rss-zero/pkg/apitoken/db"
+)
+
+type fakeDB struct {
+	tokens  map[string]*db.Token // by hash
+	touches int
+}
+
+func newFakeDB() *fakeDB { return &fakeDB{tokens: map[string]*db.Token{}} }
+
+func (f *fakeDB) CreateToken(token *db.Token) error {
+	f.tokens[token.Hash] = token
+	return nil
+}
+
+func (f *fakeDB) GetTokenByHash(hash string) (*db.Token, error) {
+	token, ok := f.tokens[hash]
+	if !ok {
+		return nil, db.ErrTokenNotFound
+	}
+	copied := *token
+	return &copied, nil
+}
+
+func (f *fakeDB) ListTokens(username string) (tokens []*db.Token, err error) {
+	for _, token := range f.tokens {
+		if token.Username == username {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (f *fakeDB) DeleteToken(username, id string) error {
+	for hash, token := range f.tokens {
+		if token.ID == id && token.Username == username {
+			delete(f.tokens, hash)
+			return nil
+		}
+	}
+	return db.ErrTokenNotFound
+}
+
+func (f *fakeDB) TouchToken(id string, usedAt time.Time) error {
+	f.touches++
+	for _, token := range f.tokens {
+		if token.ID == id {
+			token.LastUsedAt = &usedAt
+		}
+	}
+	return nil
+}
+
+func TestCreateAndVerify(t *testing.T) {
+	fake := newFakeDB()
+	s := NewService(fake)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	plain, token, err := s.Create("jason", "cli", []string{ScopeJobsRun}, nil)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plain, prefix))
+	assert.True(t, strings.HasPrefix(plain, token.Prefix))
+	assert.Equal(t, Hash(plain), token.Hash)
+
+	got, err := s.Verify(plain)
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, got.ID)
+	assert.Equal(t, now, *got.LastUsedAt)
+
+	// 间隔内再次使用不写库
+	now = now.Add(30 * time.Second)
+	_, err = s.Verify(plain)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.touches)
+
+	now = now.Add(lastUsedInterval)
+	_, err = s.Verify(plain)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fake.touches)
+
+	_, err = s.Verify(plain + "0")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = s.Verify("not-a-token")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestVerifyExpired(t *testing.T) {
+	s := NewService(newFakeDB())
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	expiresAt := now.Add(time.Hour)
+	plain, _, err := s.Create("jason", "ci", []string{ScopeArchiveRead}, &expiresAt)
+	require.NoError(t, err)
+	_, err = s.Verify(plain)
+	require.NoError(t, err)
+
+	now = expiresAt
+	_, err = s.Verify(plain)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestCreateRejectsUnknownScope(t *testing.T) {
+	s := NewService(newFakeDB())
+	_, _, err := s.Create("jason", "cli", []string{"root"}, nil)
+	assert.ErrorIs(t, err, ErrUnknownScope)
+	_, _, err = s.Create("jason", "cli", nil, nil)
+	assert.ErrorIs(t, err, ErrUnknownScope)
+}
+
+func TestAllows(t *testing.T) {
+	admin := &db.Token{Scopes: []string{ScopeAdmin}}
+	reader := &db.Token{Scopes: []string{ScopeArchiveRead}}
+	for _, scope := range Scopes {
+		assert.True(t, Allows(admin, scope), scope)
+	}
+	assert.True(t, Allows(reader, ScopeArchiveRead))
+	assert.False(t, Allows(reader, ScopeJobsRun))
+	assert.False(t, Allows(reader, ScopeAdmin))
+}
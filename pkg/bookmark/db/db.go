@@ -11,8 +11,6 @@ import (
 	"github.com/samber/lo"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
-
-	"github.com/eli-yip/rss-zero/pkg/common"
 )
 
 var (
@@ -48,12 +46,15 @@ type TagCount struct {
 }
 
 type DB interface {
-	NewBookmark(userID string, contentType common.ZhihuContentType, contentID string) (*Bookmark, error)
+	NewBookmark(userID string, ref ContentRef) (*Bookmark, error)
 	GetBookmark(userID, bookmarkID string) (*Bookmark, error)
 	GetBookmarkByUser(userID string, q *BookmarkQuery) ([]Bookmark, error)
 	CountBookmarkByUser(userID string, q *BookmarkQuery) (int, error)
 	// GetBookmarkByContent returns ErrNoBookmark if no bookmark found
-	GetBookmarkByContent(userID string, contentType common.ZhihuContentType, contentID string) (*Bookmark, error)
+	GetBookmarkByContent(userID string, ref ContentRef) (*Bookmark, error)
+	// GetBookmarksByContents returns the user's bookmarks on the given contents of one platform and kind;
+	// contents without a bookmark are skipped
+	GetBookmarksByContents(userID, platform, kind string, contentIDs []string) ([]Bookmark, error)
 	GetBookmarkByTag(userID, tagName string) ([]Bookmark, error)
 	GetBookmarkByTags(userID string, tagNames []string) ([]Bookmark, error)
 	UpdateBookmark(id string, comment, note string) (*Bookmark, error)
@@ -75,18 +76,31 @@ type DB interface {
 	GetTagByUser(userID string) ([]string, error)
 	// GetTagCountByUser returns the count of each tag for a user, ordered by count
 	GetTagCountByUser(userID string) ([]TagCount, error)
+
+	// GetUserTags returns all tag entities of a user, ordered by name
+	GetUserTags(userID string) ([]UserTag, error)
+	// UpdateUserTag updates color and/or description; nil fields are left unchanged.
+	// It returns ErrNoTag if the tag does not exist.
+	UpdateUserTag(userID, name string, color, description *string) (*UserTag, error)
+	// RenameTag renames a tag together with its descendants across all bookmarks of the user
+	RenameTag(userID, from, to string) error
+	// MergeTags merges tags (with their descendants) into another tag
+	MergeTags(userID string, from []string, to string) error
+	// DeleteTags removes tags (with their descendants) from all bookmarks of the user
+	DeleteTags(userID string, names []string) error
 }
 
 type BookmarkDBImpl struct{ *gorm.DB }
 
 func NewBookMarkDBImpl(db *gorm.DB) DB { return &BookmarkDBImpl{db} }
 
-func (db *BookmarkDBImpl) NewBookmark(userID string, contentType common.ZhihuContentType, contentID string) (*Bookmark, error) {
+func (db *BookmarkDBImpl) NewBookmark(userID string, ref ContentRef) (*Bookmark, error) {
 	bookmark := &Bookmark{
-		ID:          xid.New().String(),
-		UserID:      userID,
-		ContentType: contentType,
-		ContentID:   contentID,
+		ID:        xid.New().String(),
+		UserID:    userID,
+		Platform:  ref.Platform,
+		Kind:      ref.Kind,
+		ContentID: ref.ID,
 	}
 
 	if err := db.Create(&bookmark).Error; err != nil {
@@ -155,7 +169,7 @@ func (db *BookmarkDBImpl) GetBookmarkByUser(userID string, q *BookmarkQuery) ([]
 			} else {
 				if len(q.Tag.Include) > 0 {
 					var bookmarkIDs []string
-					db.Model(&Tag{}).Where("name IN ?", q.Tag.Include).Distinct().Pluck("bookmark_id", &bookmarkIDs)
+					db.Model(&Tag{}).Scopes(tagSubtree("name", q.Tag.Include)).Distinct().Pluck("bookmark_id", &bookmarkIDs)
 					if len(bookmarkIDs) > 0 {
 						stmt = stmt.Where("id IN ?", bookmarkIDs)
 					} else {
@@ -165,7 +179,7 @@ func (db *BookmarkDBImpl) GetBookmarkByUser(userID string, q *BookmarkQuery) ([]
 
 				if len(q.Tag.Exclude) > 0 {
 					var bookmarkIDs []string
-					db.Model(&Tag{}).Where("name IN ?", q.Tag.Exclude).Distinct().Pluck("bookmark_id", &bookmarkIDs)
+					db.Model(&Tag{}).Scopes(tagSubtree("name", q.Tag.Exclude)).Distinct().Pluck("bookmark_id", &bookmarkIDs)
 					if len(bookmarkIDs) > 0 {
 						stmt = stmt.Where("id NOT IN ?", bookmarkIDs)
 					}
@@ -208,7 +222,7 @@ func (db *BookmarkDBImpl) CountBookmarkByUser(userID string, q *BookmarkQuery) (
 			} else {
 				if len(q.Tag.Include) > 0 {
 					var bookmarkIDs []string
-					db.Model(&Tag{}).Where("name IN ?", q.Tag.Include).Distinct().Pluck("bookmark_id", &bookmarkIDs)
+					db.Model(&Tag{}).Scopes(tagSubtree("name", q.Tag.Include)).Distinct().Pluck("bookmark_id", &bookmarkIDs)
 					if len(bookmarkIDs) > 0 {
 						stmt = stmt.Where("id IN ?", bookmarkIDs)
 					} else {
@@ -218,7 +232,7 @@ func (db *BookmarkDBImpl) CountBookmarkByUser(userID string, q *BookmarkQuery) (
 
 				if len(q.Tag.Exclude) > 0 {
 					var bookmarkIDs []string
-					db.Model(&Tag{}).Where("name IN ?", q.Tag.Exclude).Distinct().Pluck("bookmark_id", &bookmarkIDs)
+					db.Model(&Tag{}).Scopes(tagSubtree("name", q.Tag.Exclude)).Distinct().Pluck("bookmark_id", &bookmarkIDs)
 					if len(bookmarkIDs) > 0 {
 						stmt = stmt.Where("id NOT IN ?", bookmarkIDs)
 					}
@@ -234,9 +248,9 @@ func (db *BookmarkDBImpl) CountBookmarkByUser(userID string, q *BookmarkQuery) (
 	return int(count), nil
 }
 
-func (db *BookmarkDBImpl) GetBookmarkByContent(userID string, contentType common.ZhihuContentType, contentID string) (*Bookmark, error) {
+func (db *BookmarkDBImpl) GetBookmarkByContent(userID string, ref ContentRef) (*Bookmark, error) {
 	bookmark := &Bookmark{}
-	result := db.Where("user_id = ? AND content_type = ? AND content_id = ?", userID, contentType, contentID).First(&bookmark)
+	result := db.Where("user_id = ? AND platform = ? AND kind = ? AND content_id = ?", userID, ref.Platform, ref.Kind, ref.ID).First(&bookmark)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, ErrNoBookmark
@@ -246,6 +260,18 @@ func (db *BookmarkDBImpl) GetBookmarkByContent(userID string, contentType common
 	return bookmark, nil
 }
 
+func (db *BookmarkDBImpl) GetBookmarksByContents(userID, platform, kind string, contentIDs []string) ([]Bookmark, error) {
+	bookmarks := make([]Bookmark, 0)
+	if len(contentIDs) == 0 {
+		return bookmarks, nil
+	}
+	result := db.Where("user_id = ? AND platform = ? AND kind = ? AND content_id IN ?", userID, platform, kind, contentIDs).Find(&bookmarks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return bookmarks, nil
+}
+
 func (db *BookmarkDBImpl) UpdateBookmark(id string, comment, note string) (*Bookmark, error) {
 	updatedBookmark := &Bookmark{}
 	result := db.Model(updatedBookmark).Where("id = ?", id).Clauses(clause.Returning{}).Updates(map[string]any{
@@ -322,6 +348,9 @@ func (db *BookmarkDBImpl) AddTag(bookmarkID string, name string) (*Tag, error) {
 	if err := db.Save(&tag).Error; err != nil {
 		return nil, err
 	}
+	if err := ensureUserTagsForBookmark(db.DB, bookmarkID, []string{name}); err != nil {
+		return nil, fmt.Errorf("failed to ensure user tags: %w", err)
+	}
 	return tag, nil
 }
 
@@ -338,6 +367,9 @@ func (db *BookmarkDBImpl) AddTags(bookmarkID string, tagNames []string) ([]Tag,
 	if err := db.Save(&tags).Error; err != nil {
 		return nil, err
 	}
+	if err := ensureUserTagsForBookmark(db.DB, bookmarkID, tagNames); err != nil {
+		return nil, fmt.Errorf("failed to ensure user tags: %w", err)
+	}
 	return tags, nil
 }
 
@@ -414,8 +446,9 @@ func (db *BookmarkDBImpl) GetTagByUser(userID string) ([]string, error) {
 func (db *BookmarkDBImpl) GetBookmarkByTag(userID, tagName string) ([]Bookmark, error) {
 	bookmarks := make([]Bookmark, 0)
 
-	err := db.Model(&Bookmark{}).Joins("JOIN tags ON tags.bookmark_id = bookmarks.id").
-		Where("tags.name = ? AND tags.deleted_at IS NULL AND bookmarks.user_id = ?", tagName, userID).
+	err := db.Model(&Bookmark{}).Distinct().Joins("JOIN tags ON tags.bookmark_id = bookmarks.id").
+		Where("tags.deleted_at IS NULL AND bookmarks.user_id = ?", userID).
+		Scopes(tagSubtree("tags.name", []string{tagName})).
 		Find(&bookmarks).Error
 
 	if err != nil {
@@ -434,7 +467,8 @@ func (db *BookmarkDBImpl) GetBookmarkByTags(userID string, tagNames []string) ([
 
 	err := db.Model(&Bookmark{}).Distinct().
 		Joins("JOIN tags ON tags.bookmark_id = bookmarks.id").
-		Where("tags.name IN ? AND tags.deleted_at IS NULL AND bookmarks.user_id = ?", tagNames, userID).
+		Where("tags.deleted_at IS NULL AND bookmarks.user_id = ?", userID).
+		Scopes(tagSubtree("tags.name", tagNames)).
 		Find(&bookmarks).Error
 
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/eli-yip/rss-zero/internal/db"
 	"github.com/eli-yip/rss-zero/pkg/common"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,7 +18,7 @@ func TestBookmarkDB(t *testing.T) {
 	assert.Nil(err)
 	dbService := &BookmarkDBImpl{postgresDB}
 	t.Run("TestSingleBookmark", func(t *testing.T) {
-		b, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test single bookmarks")
+		b, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test single bookmarks"))
 		assert.Nil(err)
 		_, err = dbService.AddTag(b.ID, "test tag1")
 		assert.Nil(err)
@@ -40,9 +41,9 @@ func TestBookmarkDB(t *testing.T) {
 	})
 
 	t.Run("TestMultiBookmark", func(t *testing.T) {
-		b1, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test multi bookmark1")
+		b1, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test multi bookmark1"))
 		assert.Nil(err)
-		b2, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test multi bookmark2")
+		b2, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test multi bookmark2"))
 		assert.Nil(err)
 
 		_, err = dbService.AddTag(b1.ID, "test tag1")
@@ -89,7 +90,7 @@ func TestBookmarkDB(t *testing.T) {
 	})
 
 	t.Run("TestDeleteBookmark", func(t *testing.T) {
-		b1, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test multi bookmark1")
+		b1, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test multi bookmark1"))
 		assert.Nil(err)
 
 		err = dbService.RemoveBookmark(b1.ID)
@@ -100,7 +101,7 @@ func TestBookmarkDB(t *testing.T) {
 	})
 
 	t.Run("TestDeleteTag", func(t *testing.T) {
-		b1, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test multi bookmark1")
+		b1, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test multi bookmark1"))
 		assert.Nil(err)
 
 		_, err = dbService.AddTag(b1.ID, "test tag1")
@@ -121,7 +122,7 @@ func TestBookmarkDB(t *testing.T) {
 
 	t.Run("TestGetBookmarkByUser", func(t *testing.T) {
 		t.Run("SingleBookmark", func(t *testing.T) {
-			b1, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test content")
+			b1, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test content"))
 			assert.Nil(err)
 
 			_, err = dbService.AddTag(b1.ID, "tag1")
@@ -208,21 +209,21 @@ func TestBookmarkDB(t *testing.T) {
 		})
 
 		t.Run("MultiBookmark", func(t *testing.T) {
-			b1, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "content 1")
+			b1, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "content 1"))
 			assert.Nil(err)
 			_, err = dbService.AddTag(b1.ID, "tag1")
 			assert.Nil(err)
 			_, err = dbService.AddTag(b1.ID, "tag2")
 			assert.Nil(err)
 
-			b2, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "content 2")
+			b2, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "content 2"))
 			assert.Nil(err)
 			_, err = dbService.AddTag(b2.ID, "tag2")
 			assert.Nil(err)
 			_, err = dbService.AddTag(b2.ID, "tag3")
 			assert.Nil(err)
 
-			b3, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "content 3")
+			b3, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "content 3"))
 			assert.Nil(err)
 
 			t.Run("IncludeSingleTag", func(t *testing.T) {
@@ -296,9 +297,9 @@ func TestBookmarkDB(t *testing.T) {
 	})
 
 	t.Run("TestGetTag", func(t *testing.T) {
-		b1, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test get tag")
+		b1, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test get tag"))
 		assert.Nil(err)
-		b2, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test get tag")
+		b2, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test get tag"))
 		assert.Nil(err)
 		defer dbService.RemoveBookmark(b1.ID)
 		defer dbService.RemoveBookmark(b2.ID)
@@ -333,9 +334,9 @@ func TestBookmarkDB(t *testing.T) {
 	})
 
 	t.Run("GetTagCountByUser", func(t *testing.T) {
-		b1, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test get tag count")
+		b1, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test get tag count"))
 		assert.Nil(err)
-		b2, err := dbService.NewBookmark("jason", common.ZhihuAnswer, "test get tag count")
+		b2, err := dbService.NewBookmark("jason", ZhihuRef(common.ZhihuAnswer, "test get tag count"))
 		assert.Nil(err)
 		defer dbService.RemoveBookmark(b1.ID)
 		defer dbService.RemoveBookmark(b2.ID)
@@ -372,4 +373,50 @@ func TestBookmarkDB(t *testing.T) {
 			assert.Len(tagCount, 0)
 		})
 	})
+
+	t.Run("TestHierarchicalTags", func(t *testing.T) {
+		const user = "hierarchy-test-user"
+		b1, err := dbService.NewBookmark(user, ZhihuRef(common.ZhihuAnswer, "hierarchy 1"))
+		assert.Nil(err)
+		b2, err := dbService.NewBookmark(user, ZhihuRef(common.ZhihuAnswer, "hierarchy 2"))
+		assert.Nil(err)
+		_, err = dbService.AddTags(b1.ID, []string{"投资/港股"})
+		assert.Nil(err)
+		_, err = dbService.AddTags(b2.ID, []string{"投资", "投资_x"})
+		assert.Nil(err)
+
+		userTags, err := dbService.GetUserTags(user)
+		assert.Nil(err)
+		assert.Equal([]string{"投资", "投资/港股", "投资_x"}, lo.Map(userTags, func(t UserTag, _ int) string { return t.Name }))
+
+		bs, err := dbService.GetBookmarkByUser(user, &BookmarkQuery{Tag: &TagFilter{Include: []string{"投资"}}})
+		assert.Nil(err)
+		assert.Len(bs, 2, "including a parent includes its children")
+		bs, err = dbService.GetBookmarkByUser(user, &BookmarkQuery{Tag: &TagFilter{Exclude: []string{"投资"}}})
+		assert.Nil(err)
+		assert.Len(bs, 0)
+		bs, err = dbService.GetBookmarkByTag(user, "投资/港股")
+		assert.Nil(err)
+		assert.Len(bs, 1)
+
+		assert.ErrorIs(dbService.RenameTag(user, "投资", "投资_x"), ErrTagExists)
+		assert.ErrorIs(dbService.RenameTag(user, "投资", "投资/港股/x"), ErrInvalidTag)
+		assert.Nil(dbService.RenameTag(user, "投资", "理财"))
+		ts, err := dbService.GetTag(b1.ID)
+		assert.Nil(err)
+		assert.Equal([]string{"理财/港股"}, ts)
+
+		assert.Nil(dbService.MergeTags(user, []string{"投资_x"}, "理财"))
+		ts, err = dbService.GetTag(b2.ID)
+		assert.Nil(err)
+		assert.Equal([]string{"理财"}, ts)
+
+		assert.Nil(dbService.DeleteTags(user, []string{"理财"}))
+		userTags, err = dbService.GetUserTags(user)
+		assert.Nil(err)
+		assert.Len(userTags, 0)
+
+		assert.Nil(dbService.RemoveBookmark(b1.ID))
+		assert.Nil(dbService.RemoveBookmark(b2.ID))
+	})
 }
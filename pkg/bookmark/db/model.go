@@ -7,19 +7,55 @@ import (
 	"gorm.io/gorm"
 )
 
+// 收藏可指向的平台。Platform + Kind + ContentID 共同唯一确定一条被收藏的内容。
+const (
+	PlatformZhihu      = "zhihu"
+	PlatformZsxq       = "zsxq"
+	PlatformXiaobot    = "xiaobot"
+	PlatformTombkeeper = "tombkeeper"
+	PlatformTkblog     = "tkblog"
+)
+
+// 非知乎平台各只有一种内容；知乎的 Kind 沿用 common.ZhihuContentType 的 slug。
+const (
+	KindZsxqTopic      = "topic"
+	KindXiaobotPost    = "post"
+	KindTombkeeperPost = "weibo"
+	// KindTkblogPost 的 ContentID 形如 "{category}/{id}"，因博文 id 只在分类内唯一。
+	KindTkblogPost = "blog"
+)
+
+// ContentRef 是带平台限定的内容引用。
+type ContentRef struct {
+	Platform string
+	Kind     string
+	ID       string
+}
+
+// ZhihuRef 构造一条知乎内容引用。
+func ZhihuRef(t common.ZhihuContentType, id string) ContentRef {
+	return ContentRef{Platform: PlatformZhihu, Kind: t.Slug(), ID: id}
+}
+
 type Bookmark struct {
-	ID          string                  `gorm:"primaryKey"`
-	UserID      string                  `gorm:"type:text;index"`
-	ContentType common.ZhihuContentType `gorm:"type:int;index"`
-	ContentID   string                  `gorm:"type:text;index"`
-	Comment     string                  `gorm:"type:text"`
-	Note        string                  `gorm:"type:text"`
+	ID        string `gorm:"primaryKey"`
+	UserID    string `gorm:"type:text;index"`
+	Platform  string `gorm:"type:text;index"`
+	Kind      string `gorm:"type:text;index"`
+	ContentID string `gorm:"type:text;index"`
+	Comment   string `gorm:"type:text"`
+	Note      string `gorm:"type:text"`
 
 	CreatedAt time.Time      `gorm:"autoCreateTime"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
+// Ref 返回该收藏指向的内容引用。
+func (b *Bookmark) Ref() ContentRef {
+	return ContentRef{Platform: b.Platform, Kind: b.Kind, ID: b.ContentID}
+}
+
 type Tag struct {
 	BookmarkID string `gorm:"type:text;index;primaryKey"`
 	Name       string `gorm:"type:text;primaryKey"`
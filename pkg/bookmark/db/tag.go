@@ -0,0 +1,260 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagSeparator 分隔层级标签的各级，如 "投资/港股" 是 "投资" 的子标签。
+const TagSeparator = "/"
+
+var (
+	ErrNoTag      = errors.New("no tag found")
+	ErrTagExists  = errors.New("tag already exists")
+	ErrInvalidTag = errors.New("invalid tag")
+)
+
+var reTagColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// UserTag 是用户维度的标签实体。收藏与标签的关联仍在 Tag 表，以完整路径作标签名；
+// UserTag 只承载层级与展示信息，Parent 为父标签完整路径，顶层标签为空。
+// 给收藏打标签时自动补齐该标签及其全部祖先的 UserTag。
+type UserTag struct {
+	UserID      string `gorm:"type:text;primaryKey" json:"-"`
+	Name        string `gorm:"type:text;primaryKey" json:"name"`
+	Parent      string `gorm:"type:text;index" json:"parent"`
+	Color       string `gorm:"type:text" json:"color"`
+	Description string `gorm:"type:text" json:"description"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// NormalizeTagName 去掉各级首尾空白，拒绝空标签与空层级（如 "投资//港股"）。
+func NormalizeTagName(name string) (string, error) {
+	segments := strings.Split(name, TagSeparator)
+	for i, s := range segments {
+		if segments[i] = strings.TrimSpace(s); segments[i] == "" {
+			return "", fmt.Errorf("%w: %q has an empty segment", ErrInvalidTag, name)
+		}
+	}
+	return strings.Join(segments, TagSeparator), nil
+}
+
+// ValidateTagColor 接受空串（不设颜色）或 #RRGGBB。
+func ValidateTagColor(color string) error {
+	if color != "" && !reTagColor.MatchString(color) {
+		return fmt.Errorf("%w: color %q must be #RRGGBB", ErrInvalidTag, color)
+	}
+	return nil
+}
+
+// TagParent 返回父标签完整路径，顶层标签返回空串。
+func TagParent(name string) string {
+	i := strings.LastIndex(name, TagSeparator)
+	if i < 0 {
+		return ""
+	}
+	return name[:i]
+}
+
+// tagLineage 返回标签自身及其全部祖先，如 "a/b/c" → a、a/b、a/b/c。
+func tagLineage(name string) []string {
+	segments := strings.Split(name, TagSeparator)
+	lineage := make([]string, 0, len(segments))
+	for i := range segments {
+		lineage = append(lineage, strings.Join(segments[:i+1], TagSeparator))
+	}
+	return lineage
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// tagSubtree 把 column 限定为 names 中任一标签或其子孙标签。
+func tagSubtree(column string, names []string) func(*gorm.DB) *gorm.DB {
+	return func(stmt *gorm.DB) *gorm.DB {
+		conds := make([]string, 0, len(names))
+		args := make([]any, 0, 2*len(names))
+		for _, name := range names {
+			conds = append(conds, fmt.Sprintf(`%s = ? OR %s LIKE ? ESCAPE '\'`, column, column))
+			args = append(args, name, likeEscaper.Replace(name)+TagSeparator+"%")
+		}
+		return stmt.Where("("+strings.Join(conds, " OR ")+")", args...)
+	}
+}
+
+// userBookmarkIDs 是某用户全部收藏 id 的子查询，Tag 表没有 user_id，按用户操作标签时以此限定。
+func userBookmarkIDs(tx *gorm.DB, userID string) *gorm.DB {
+	return tx.Model(&Bookmark{}).Select("id").Where("user_id = ?", userID)
+}
+
+// ensureUserTags 为 names 及其全部祖先补齐 UserTag，已存在的保持不变。
+func ensureUserTags(tx *gorm.DB, userID string, names []string) error {
+	lineage := lo.Uniq(lo.FlatMap(names, func(name string, _ int) []string { return tagLineage(name) }))
+	if len(lineage) == 0 {
+		return nil
+	}
+	tags := lo.Map(lineage, func(name string, _ int) UserTag {
+		return UserTag{UserID: userID, Name: name, Parent: TagParent(name)}
+	})
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+}
+
+// ensureUserTagsForBookmark 按收藏所属用户补齐 UserTag。
+func ensureUserTagsForBookmark(tx *gorm.DB, bookmarkID string, names []string) error {
+	var userID string
+	if err := tx.Model(&Bookmark{}).Where("id = ?", bookmarkID).Pluck("user_id", &userID).Error; err != nil {
+		return fmt.Errorf("failed to get bookmark owner: %w", err)
+	}
+	if userID == "" {
+		return ErrNoBookmark
+	}
+	return ensureUserTags(tx, userID, names)
+}
+
+func (db *BookmarkDBImpl) GetUserTags(userID string) ([]UserTag, error) {
+	tags := make([]UserTag, 0)
+	if err := db.Where("user_id = ?", userID).Order("name ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (db *BookmarkDBImpl) UpdateUserTag(userID, name string, color, description *string) (*UserTag, error) {
+	tag := &UserTag{}
+	if err := db.Where("user_id = ? AND name = ?", userID, name).First(tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoTag
+		}
+		return nil, err
+	}
+	updates := make(map[string]any)
+	if color != nil {
+		if err := ValidateTagColor(*color); err != nil {
+			return nil, err
+		}
+		updates["color"] = *color
+	}
+	if description != nil {
+		updates["description"] = *description
+	}
+	if len(updates) == 0 {
+		return tag, nil
+	}
+	if err := db.Model(&UserTag{}).Where("user_id = ? AND name = ?", userID, name).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	if color != nil {
+		tag.Color = *color
+	}
+	if description != nil {
+		tag.Description = *description
+	}
+	return tag, nil
+}
+
+// RenameTag 把 from 及其子孙标签整体改名到 to 之下，目标已存在时返回 ErrTagExists（应改用合并）。
+func (db *BookmarkDBImpl) RenameTag(userID, from, to string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&UserTag{}).Where("user_id = ? AND name = ?", userID, from).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrNoTag
+		}
+		if err := tx.Model(&UserTag{}).Where("user_id = ? AND name = ?", userID, to).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrTagExists
+		}
+		return moveTag(tx, userID, from, to)
+	})
+}
+
+// MergeTags 把 from 中每个标签（含子孙）并入 to，同一收藏上的重复标签自然去重。
+// to 不存在时自动创建；to 已有的颜色与描述优先保留。
+func (db *BookmarkDBImpl) MergeTags(userID string, from []string, to string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, name := range from {
+			if err := moveTag(tx, userID, name, to); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteTags 从用户全部收藏上移除 names 及其子孙标签，并删除对应的 UserTag。
+func (db *BookmarkDBImpl) DeleteTags(userID string, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bookmark_id IN (?)", userBookmarkIDs(tx, userID)).
+			Scopes(tagSubtree("name", names)).Delete(&Tag{}).Error; err != nil {
+			return fmt.Errorf("failed to delete bookmark tags: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Scopes(tagSubtree("name", names)).Delete(&UserTag{}).Error; err != nil {
+			return fmt.Errorf("failed to delete user tags: %w", err)
+		}
+		return nil
+	})
+}
+
+// moveTag 把 from 子树整体搬到 to 下：from/x 变为 to/x。不能搬进自己的子树。
+func moveTag(tx *gorm.DB, userID, from, to string) error {
+	if from == to {
+		return nil
+	}
+	if strings.HasPrefix(to, from+TagSeparator) {
+		return fmt.Errorf("%w: cannot move %q into its own subtree %q", ErrInvalidTag, from, to)
+	}
+	rename := func(name string) string { return to + strings.TrimPrefix(name, from) }
+
+	var bookmarkTags []Tag
+	if err := tx.Where("bookmark_id IN (?)", userBookmarkIDs(tx, userID)).
+		Scopes(tagSubtree("name", []string{from})).Find(&bookmarkTags).Error; err != nil {
+		return fmt.Errorf("failed to get bookmark tags: %w", err)
+	}
+	if len(bookmarkTags) > 0 {
+		moved := lo.Map(bookmarkTags, func(t Tag, _ int) Tag { return Tag{BookmarkID: t.BookmarkID, Name: rename(t.Name)} })
+		// 与 AddTag 用 Save 的原因相同：主键可能撞上软删除的旧行，冲突时复活它
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "bookmark_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"deleted_at", "updated_at"}),
+		}).Create(&moved).Error; err != nil {
+			return fmt.Errorf("failed to create moved bookmark tags: %w", err)
+		}
+		if err := tx.Where("bookmark_id IN (?)", userBookmarkIDs(tx, userID)).
+			Scopes(tagSubtree("name", []string{from})).Delete(&Tag{}).Error; err != nil {
+			return fmt.Errorf("failed to delete old bookmark tags: %w", err)
+		}
+	}
+
+	var userTags []UserTag
+	if err := tx.Where("user_id = ?", userID).Scopes(tagSubtree("name", []string{from})).Find(&userTags).Error; err != nil {
+		return fmt.Errorf("failed to get user tags: %w", err)
+	}
+	if len(userTags) > 0 {
+		moved := lo.Map(userTags, func(t UserTag, _ int) UserTag {
+			name := rename(t.Name)
+			return UserTag{UserID: userID, Name: name, Parent: TagParent(name), Color: t.Color, Description: t.Description}
+		})
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&moved).Error; err != nil {
+			return fmt.Errorf("failed to create moved user tags: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Scopes(tagSubtree("name", []string{from})).Delete(&UserTag{}).Error; err != nil {
+			return fmt.Errorf("failed to delete old user tags: %w", err)
+		}
+	}
+	return ensureUserTags(tx, userID, []string{to})
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTagName(t *testing.T) {
+	got, err := NormalizeTagName(" 投资 / 港股 ")
+	require.NoError(t, err)
+	assert.Equal(t, "投资/港股", got)
+
+	for _, name := range []string{"", " ", "投资//港股", "/投资", "投资/"} {
+		_, err := NormalizeTagName(name)
+		assert.ErrorIs(t, err, ErrInvalidTag, name)
+	}
+}
+
+func TestTagHierarchy(t *testing.T) {
+	assert.Equal(t, "", TagParent("投资"))
+	assert.Equal(t, "投资", TagParent("投资/港股"))
+	assert.Equal(t, "a/b", TagParent("a/b/c"))
+	assert.Equal(t, []string{"a", "a/b", "a/b/c"}, tagLineage("a/b/c"))
+}
+
+func TestValidateTagColor(t *testing.T) {
+	assert.NoError(t, ValidateTagColor(""))
+	assert.NoError(t, ValidateTagColor("#1a2B3c"))
+	assert.ErrorIs(t, ValidateTagColor("red"), ErrInvalidTag)
+	assert.ErrorIs(t, ValidateTagColor("#12345"), ErrInvalidTag)
+}
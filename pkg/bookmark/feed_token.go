@@ -0,0 +1,6 @@
+package bookmark
+
+// FeedPath 返回 user 的标签 feed 路径（未转义），作为 feedtoken 签名的对象。
+func FeedPath(user, tag string) string {
+	return "/rss/bookmark/" + user + "/" + tag
+}
@@ -0,0 +1,158 @@
+package bookmark
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	FormatHTML = "html"
+	FormatJSON = "json"
+)
+
+var ErrUnknownFormat = errors.New("unknown bookmark file format")
+
+// Entry 是导入导出时的一条收藏。导出时 Platform/Kind/ContentID 与 URL 全部填写；
+// 导入时优先使用 Platform/Kind/ContentID，缺失时由调用方按 URL、ArchiveURL 反解。
+type Entry struct {
+	Platform   string    `json:"platform,omitempty"`
+	Kind       string    `json:"kind,omitempty"`
+	ContentID  string    `json:"content_id,omitempty"`
+	URL        string    `json:"url"`
+	ArchiveURL string    `json:"archive_url,omitempty"`
+	Title      string    `json:"title"`
+	Tags       []string  `json:"tags"`
+	Comment    string    `json:"comment"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Export 是 JSON 导出文件的顶层结构。
+type Export struct {
+	ExportedAt time.Time `json:"exported_at"`
+	Bookmarks  []Entry   `json:"bookmarks"`
+}
+
+func EncodeJSON(entries []Entry, exportedAt time.Time) ([]byte, error) {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	return json.MarshalIndent(Export{ExportedAt: exportedAt, Bookmarks: entries}, "", "  ")
+}
+
+// EncodeHTML 输出 Netscape 书签格式，浏览器与多数书签服务都能导入。
+// 标签写入 TAGS 属性，comment 写入 <DD>，note 没有通行位置，写入自定义 NOTE 属性。
+func EncodeHTML(entries []Entry) []byte {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE NETSCAPE-Bookmark-file-1>\n")
+	b.WriteString(`<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">` + "\n")
+	b.WriteString("<TITLE>Bookmarks</TITLE>\n<H1>Bookmarks</H1>\n<DL><p>\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, `    <DT><A HREF="%s" ADD_DATE="%d" LAST_MODIFIED="%d"`,
+			html.EscapeString(e.URL), e.CreatedAt.Unix(), e.UpdatedAt.Unix())
+		if len(e.Tags) > 0 {
+			fmt.Fprintf(&b, ` TAGS="%s"`, html.EscapeString(strings.Join(e.Tags, ",")))
+		}
+		if e.Note != "" {
+			fmt.Fprintf(&b, ` NOTE="%s"`, html.EscapeString(e.Note))
+		}
+		fmt.Fprintf(&b, ">%s</A>\n", html.EscapeString(e.Title))
+		if e.Comment != "" {
+			fmt.Fprintf(&b, "    <DD>%s\n", html.EscapeString(e.Comment))
+		}
+	}
+	b.WriteString("</DL><p>\n")
+	return []byte(b.String())
+}
+
+// Decode 解析导入文件。format 为空时按内容探测：以 { 或 [ 开头视为 JSON，否则视为 Netscape HTML。
+// JSON 既接受 Export 结构，也接受裸的 Entry 数组。
+func Decode(data []byte, format string) ([]Entry, error) {
+	if format == "" {
+		format = detectFormat(data)
+	}
+	switch format {
+	case FormatJSON:
+		return decodeJSON(data)
+	case FormatHTML:
+		return decodeHTML(data)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+func detectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return FormatJSON
+	}
+	return FormatHTML
+}
+
+func decodeJSON(data []byte) ([]Entry, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(data) > 0 && data[0] == '[' {
+		var entries []Entry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("failed to decode json bookmarks: %w", err)
+		}
+		return entries, nil
+	}
+	var export Export
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to decode json bookmarks: %w", err)
+	}
+	return export.Bookmarks, nil
+}
+
+func decodeHTML(data []byte) ([]Entry, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bookmark html: %w", err)
+	}
+
+	entries := make([]Entry, 0)
+	doc.Find("dt > a[href]").Each(func(_ int, a *goquery.Selection) {
+		e := Entry{
+			URL:       strings.TrimSpace(a.AttrOr("href", "")),
+			Title:     strings.TrimSpace(a.Text()),
+			Tags:      splitTags(a.AttrOr("tags", "")),
+			Note:      a.AttrOr("note", ""),
+			CreatedAt: parseUnix(a.AttrOr("add_date", "")),
+			UpdatedAt: parseUnix(a.AttrOr("last_modified", "")),
+		}
+		// HTML 解析器会在 <DD> 处闭合 <DT>，描述因此是 <DT> 的下一个兄弟节点
+		if dd := a.Parent().Next(); dd.Is("dd") {
+			e.Comment = strings.TrimSpace(dd.Contents().First().Text())
+		}
+		entries = append(entries, e)
+	})
+	return entries, nil
+}
+
+func splitTags(s string) []string {
+	tags := make([]string, 0)
+	for t := range strings.SplitSeq(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+func parseUnix(s string) time.Time {
+	sec, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
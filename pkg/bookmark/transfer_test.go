@@ -0,0 +1,86 @@
+package bookmark
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleEntries() []Entry {
+	created := time.Unix(1760000000, 0)
+	return []Entry{
+		{
+			Platform: "zhihu", Kind: "answer", ContentID: "123",
+			URL: "https://www.zhihu.com/question/1/answer/123", Title: `标题 <a & "b">`,
+			Tags: []string{"go", "读书"}, Comment: "值得一读", Note: "第二遍",
+			CreatedAt: created, UpdatedAt: created.Add(time.Hour),
+		},
+		{URL: "https://wx.zsxq.com/group/1/topic/2", Title: "无标签", Tags: []string{}, CreatedAt: created, UpdatedAt: created},
+	}
+}
+
+func TestHTMLRoundTrip(t *testing.T) {
+	data := EncodeHTML(sampleEntries())
+	assert.Contains(t, string(data), "<!DOCTYPE NETSCAPE-Bookmark-file-1>")
+
+	got, err := Decode(data, "")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	want := sampleEntries()
+	assert.Equal(t, want[0].URL, got[0].URL)
+	assert.Equal(t, want[0].Title, got[0].Title)
+	assert.Equal(t, want[0].Tags, got[0].Tags)
+	assert.Equal(t, want[0].Comment, got[0].Comment)
+	assert.Equal(t, want[0].Note, got[0].Note)
+	assert.True(t, want[0].CreatedAt.Equal(got[0].CreatedAt))
+	assert.True(t, want[0].UpdatedAt.Equal(got[0].UpdatedAt))
+	assert.Empty(t, got[1].Comment, "a following DT must not leak into the previous entry's comment")
+	assert.Empty(t, got[1].Tags)
+}
+
+func TestDecodeBrowserHTML(t *testing.T) {
+	data := []byte(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><H3>文件夹</H3>
+    <DL><p>
+        <DT><A HREF="https://www.zhihu.com/pin/42" ADD_DATE="1700000000">想法</A>
+        <DD>描述
+    </DL><p>
+</DL><p>`)
+	got, err := Decode(data, FormatHTML)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "https://www.zhihu.com/pin/42", got[0].URL)
+	assert.Equal(t, "描述", got[0].Comment)
+	assert.Equal(t, time.Unix(1700000000, 0), got[0].CreatedAt)
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	exportedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	data, err := EncodeJSON(sampleEntries(), exportedAt)
+	require.NoError(t, err)
+
+	got, err := Decode(data, "")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "zhihu", got[0].Platform)
+	assert.Equal(t, "123", got[0].ContentID)
+	assert.Equal(t, []string{"go", "读书"}, got[0].Tags)
+
+	got, err = Decode([]byte(` [{"url":"https://xiaobot.net/post/abc"}]`), "")
+	require.NoError(t, err, "a bare entry array must be accepted")
+	require.Len(t, got, 1)
+	assert.Equal(t, "https://xiaobot.net/post/abc", got[0].URL)
+}
+
+func TestDecodeUnknownFormat(t *testing.T) {
+	_, err := Decode([]byte("x"), "csv")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestFeedPath(t *testing.T) {
+	assert.Equal(t, "/rss/bookmark/jason/投资/港股", FeedPath("jason", "投资/港股"))
+}
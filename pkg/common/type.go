@@ -14,6 +14,11 @@ const (
 	ZhihuAnswer  ZhihuContentType = "answer"
 	ZhihuArticle ZhihuContentType = "article"
 	ZhihuPin     ZhihuContentType = "pin"
+	// ZhihuQuestion 只作订阅类型：订阅一个问题下所有人的新回答，内容仍按回答入库。
+	ZhihuQuestion ZhihuContentType = "question"
+	// ZhihuColumn 与 ZhihuCollection 也只作订阅类型：订阅专栏或公开收藏夹里新加入的回答与文章。
+	ZhihuColumn     ZhihuContentType = "column"
+	ZhihuCollection ZhihuContentType = "collection"
 )
 
 const (
@@ -21,6 +26,9 @@ const (
 	legacyZhihuAnswer = iota
 	legacyZhihuArticle
 	legacyZhihuPin
+	legacyZhihuQuestion
+	legacyZhihuColumn
+	legacyZhihuCollection
 )
 
 type zhihuSpec struct {
@@ -33,6 +41,10 @@ var zhihuSpecs = map[ZhihuContentType]zhihuSpec{
 	ZhihuAnswer:  {legacyID: legacyZhihuAnswer, profilePath: "answers", titleZH: "回答"},
 	ZhihuArticle: {legacyID: legacyZhihuArticle, profilePath: "posts", titleZH: "文章"},
 	ZhihuPin:     {legacyID: legacyZhihuPin, profilePath: "pins", titleZH: "想法"},
+	// 问题、专栏与收藏夹没有个人主页路径，见 TargetURL
+	ZhihuQuestion:   {legacyID: legacyZhihuQuestion, titleZH: "问题"},
+	ZhihuColumn:     {legacyID: legacyZhihuColumn, titleZH: "专栏"},
+	ZhihuCollection: {legacyID: legacyZhihuCollection, titleZH: "收藏夹"},
 }
 
 var zhihuByLegacyID = func() map[int]ZhihuContentType {
@@ -90,6 +102,19 @@ func (t ZhihuContentType) ProfilePath() string {
 	return t.mustSpec().profilePath
 }
 
+// TargetURL 返回订阅对象在知乎的地址：作者订阅是个人主页的对应栏目，问题、专栏与收藏夹订阅是各自的页面。
+func (t ZhihuContentType) TargetURL(targetID string) string {
+	switch t {
+	case ZhihuQuestion:
+		return "https://www.zhihu.com/question/" + targetID
+	case ZhihuColumn:
+		return "https://zhuanlan.zhihu.com/" + targetID
+	case ZhihuCollection:
+		return "https://www.zhihu.com/collection/" + targetID
+	}
+	return fmt.Sprintf("https://www.zhihu.com/people/%s/%s", targetID, t.ProfilePath())
+}
+
 func (t ZhihuContentType) TitleZH() string {
 	return t.mustSpec().titleZH
 }
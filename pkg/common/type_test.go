@@ -78,11 +78,29 @@ func TestZhihuContentTypeValues(t *testing.T) {
 	}
 }
 
+func TestZhihuTargetURL(t *testing.T) {
+	if got := ZhihuAnswer.TargetURL("canglimo"); got != "https://www.zhihu.com/people/canglimo/answers" {
+		t.Fatalf("ZhihuAnswer.TargetURL() = %q", got)
+	}
+	if got := ZhihuQuestion.TargetURL("42"); got != "https://www.zhihu.com/question/42" {
+		t.Fatalf("ZhihuQuestion.TargetURL() = %q", got)
+	}
+	if got := ZhihuColumn.TargetURL("c_42"); got != "https://zhuanlan.zhihu.com/c_42" {
+		t.Fatalf("ZhihuColumn.TargetURL() = %q", got)
+	}
+	if got := ZhihuCollection.TargetURL("42"); got != "https://www.zhihu.com/collection/42" {
+		t.Fatalf("ZhihuCollection.TargetURL() = %q", got)
+	}
+}
+
 func TestParseZhihuSlug(t *testing.T) {
 	tests := map[string]ZhihuContentType{
-		"answer":  ZhihuAnswer,
-		"article": ZhihuArticle,
-		"pin":     ZhihuPin,
+		"answer":     ZhihuAnswer,
+		"article":    ZhihuArticle,
+		"pin":        ZhihuPin,
+		"question":   ZhihuQuestion,
+		"column":     ZhihuColumn,
+		"collection": ZhihuCollection,
 	}
 
 	for slug, want := range tests {
@@ -111,6 +129,9 @@ func TestZhihuLegacyID(t *testing.T) {
 		{contentType: ZhihuAnswer, legacyID: 0},
 		{contentType: ZhihuArticle, legacyID: 1},
 		{contentType: ZhihuPin, legacyID: 2},
+		{contentType: ZhihuQuestion, legacyID: 3},
+		{contentType: ZhihuColumn, legacyID: 4},
+		{contentType: ZhihuCollection, legacyID: 5},
 	}
 
 	for _, tt := range tests {
@@ -149,6 +170,8 @@ func TestZhihuContentTypeSQLRoundTrip(t *testing.T) {
 		{value: int64(2), want: ZhihuPin},
 		{value: []byte("0"), want: ZhihuAnswer},
 		{value: "1", want: ZhihuArticle},
+		{value: int64(3), want: ZhihuQuestion},
+		{value: int64(5), want: ZhihuCollection},
 	}
 
 	for _, tt := range tests {
@@ -172,8 +195,8 @@ func TestZhihuContentTypeInvalidValues(t *testing.T) {
 	}
 
 	var scanned ZhihuContentType
-	if err := scanned.Scan(int64(3)); err == nil {
-		t.Fatal("Scan(3) should return an error")
+	if err := scanned.Scan(int64(6)); err == nil {
+		t.Fatal("Scan(6) should return an error")
 	}
 	if err := scanned.Scan(nil); err == nil {
 		t.Fatal("Scan(nil) should return an error")
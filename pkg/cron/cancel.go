@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+)
+
+// Causes passed to Stop. A crawl loop that sees its context done stops between
+// targets and reports context.Cause as the reason.
+var (
+	ErrCanceled = errors.New("job canceled")
+	ErrPaused   = errors.New("job paused")
+)
+
+// running holds the cancel funcs of the crawls running in this process, keyed
+// by cron job id.
+var running = struct {
+	sync.Mutex
+	m map[string]*stoppable
+}{m: make(map[string]*stoppable)}
+
+type stoppable struct{ cancel context.CancelCauseFunc }
+
+// Stoppable derives a context that Stop(jobID, ...) cancels. Call release when
+// the run ends.
+func Stoppable(parent context.Context, jobID string) (ctx context.Context, release func()) {
+	ctx, cancel := context.WithCancelCause(parent)
+	s := &stoppable{cancel: cancel}
+	running.Lock()
+	running.m[jobID] = s
+	running.Unlock()
+	return ctx, func() {
+		running.Lock()
+		// a resumed run may have registered the same id meanwhile
+		if running.m[jobID] == s {
+			delete(running.m, jobID)
+		}
+		running.Unlock()
+		cancel(nil)
+	}
+}
+
+// Stop cancels the running crawl of jobID with cause. It reports false when no
+// such crawl runs in this process.
+func Stop(jobID string, cause error) bool {
+	running.Lock()
+	s, ok := running.m[jobID]
+	running.Unlock()
+	if ok {
+		s.cancel(cause)
+	}
+	return ok
+}
+
+// StopCause returns ErrCanceled or ErrPaused when ctx was stopped by Stop, nil otherwise.
+func StopCause(ctx context.Context) error {
+	if cause := context.Cause(ctx); errors.Is(cause, ErrCanceled) || errors.Is(cause, ErrPaused) {
+		return cause
+	}
+	return nil
+}
+
+// StoppedStatus is the cron_jobs status for a run stopped with cause.
+func StoppedStatus(cause error) int {
+	if errors.Is(cause, ErrPaused) {
+		return cronDB.StatusPaused
+	}
+	return cronDB.StatusStopped
+}
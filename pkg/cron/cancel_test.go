@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+)
+
+func TestStop(t *testing.T) {
+	ctx, release := Stoppable(context.Background(), "job-stop")
+	defer release()
+
+	assert.Nil(t, StopCause(ctx))
+	assert.False(t, Stop("job-other", ErrCanceled))
+	assert.True(t, Stop("job-stop", ErrPaused))
+
+	<-ctx.Done()
+	assert.ErrorIs(t, StopCause(ctx), ErrPaused)
+	assert.Equal(t, cronDB.StatusPaused, StoppedStatus(StopCause(ctx)))
+	assert.Equal(t, cronDB.StatusStopped, StoppedStatus(ErrCanceled))
+}
+
+func TestStoppable_ReleaseIsNotAStop(t *testing.T) {
+	ctx, release := Stoppable(context.Background(), "job-release")
+	release()
+
+	<-ctx.Done()
+	assert.Nil(t, StopCause(ctx))
+	assert.False(t, Stop("job-release", ErrCanceled))
+}
+
+func TestStoppable_StaleReleaseKeepsNewerRun(t *testing.T) {
+	_, releaseOld := Stoppable(context.Background(), "job-resumed")
+	ctx, release := Stoppable(context.Background(), "job-resumed")
+	defer release()
+
+	releaseOld()
+	assert.True(t, Stop("job-resumed", ErrCanceled))
+	assert.ErrorIs(t, StopCause(ctx), ErrCanceled)
+}
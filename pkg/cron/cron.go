@@ -2,33 +2,77 @@ package cron
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/go-co-op/gocron/v2"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/eli-yip/rss-zero/config"
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
 )
 
+// CrawlFunc is a dynamic crawl job. trigger is one of cronDB.Trigger* and is
+// recorded in the job's run history.
+type CrawlFunc func(trigger string, cronJobInfoChan chan CronJobInfo)
+
 type CronService struct {
 	s      gocron.Scheduler
 	logger *zap.Logger
+
+	// elector, when set, limits scheduled runs to the leader instance
+	elector *PGElector
+
+	mu sync.Mutex
+	// crawlFuncs maps scheduler job IDs to their crawl funcs, so RunJobNow can
+	// start them with TriggerManual instead of replaying the scheduled task.
+	crawlFuncs map[uuid.UUID]CrawlFunc
+	// funcs does the same for static jobs; running them directly also skips the
+	// leader check, which only applies to scheduled runs.
+	funcs map[uuid.UUID]func()
 }
 
-func NewCronService(logger *zap.Logger) (*CronService, error) {
-	s, err := gocron.NewScheduler(gocron.WithLocation(config.C.BJT))
+type Option func(*CronService)
+
+// WithElector runs scheduled jobs only on the instance elector picks, so
+// replicas don't crawl every source twice.
+func WithElector(elector *PGElector) Option {
+	return func(c *CronService) { c.elector = elector }
+}
+
+func NewCronService(logger *zap.Logger, opts ...Option) (*CronService, error) {
+	c := &CronService{logger: logger, crawlFuncs: make(map[uuid.UUID]CrawlFunc), funcs: make(map[uuid.UUID]func())}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	schedulerOpts := []gocron.SchedulerOption{gocron.WithLocation(config.C.BJT)}
+	if c.elector != nil {
+		schedulerOpts = append(schedulerOpts, gocron.WithDistributedElector(c.elector))
+	}
+	s, err := gocron.NewScheduler(schedulerOpts...)
 	if err != nil {
 		return nil, err
 	}
 	s.Start()
+	c.s = s
+
+	return c, nil
+}
 
-	return &CronService{s: s, logger: logger}, nil
+// Shutdown stops scheduling and hands leadership over to another instance.
+func (c *CronService) Shutdown() error {
+	err := c.s.Shutdown()
+	if c.elector != nil {
+		c.elector.Resign()
+	}
+	return err
 }
 
-func (c *CronService) AddCrawlJob(name, cronExpr string, taskFunc func(chan CronJobInfo)) (jobID string, err error) {
+func (c *CronService) AddCrawlJob(name, cronExpr string, taskFunc CrawlFunc) (jobID string, err error) {
 	j, err := c.s.NewJob(
 		gocron.CronJob(cronExpr, false),
-		gocron.NewTask(GenerateRealCrawlFunc(taskFunc)),
+		gocron.NewTask(GenerateRealCrawlFunc(taskFunc, cronDB.TriggerSchedule)),
 		gocron.WithSingletonMode(gocron.LimitModeReschedule),
 		gocron.WithName(name),
 	)
@@ -36,6 +80,9 @@ func (c *CronService) AddCrawlJob(name, cronExpr string, taskFunc func(chan Cron
 		return "", fmt.Errorf("failed to add job %s: %w", name, err)
 	}
 
+	c.mu.Lock()
+	c.crawlFuncs[j.ID()] = taskFunc
+	c.mu.Unlock()
 	return j.ID().String(), nil
 }
 
@@ -49,6 +96,10 @@ func (c *CronService) AddJob(name, cronExpr string, taskFunc func()) (jobID stri
 	if err != nil {
 		return "", fmt.Errorf("failed to add job %s: %w", name, err)
 	}
+
+	c.mu.Lock()
+	c.funcs[j.ID()] = taskFunc
+	c.mu.Unlock()
 	return j.ID().String(), nil
 }
 
@@ -60,23 +111,38 @@ func (c *CronService) RemoveCrawlJob(jobID string) (err error) {
 	if err := c.s.RemoveJob(id); err != nil {
 		return fmt.Errorf("failed to remove job %s: %w", jobID, err)
 	}
+	c.mu.Lock()
+	delete(c.crawlFuncs, id)
+	delete(c.funcs, id)
+	c.mu.Unlock()
 	return nil
 }
 
 func (c *CronService) RunJobNow(jobName string) (err error) {
 	for _, j := range c.s.Jobs() {
 		if j.Name() == jobName {
-			go func() { _ = j.RunNow() }()
+			c.mu.Lock()
+			crawlFunc, isCrawl := c.crawlFuncs[j.ID()]
+			fn, isStatic := c.funcs[j.ID()]
+			c.mu.Unlock()
+			switch {
+			case isCrawl:
+				go GenerateRealCrawlFunc(crawlFunc, cronDB.TriggerManual)()
+			case isStatic:
+				go fn()
+			default:
+				go func() { _ = j.RunNow() }()
+			}
 			return nil
 		}
 	}
 	return fmt.Errorf("job %s not found", jobName)
 }
 
-func GenerateRealCrawlFunc(crawlFunc func(chan CronJobInfo)) func() {
+func GenerateRealCrawlFunc(crawlFunc CrawlFunc, trigger string) func() {
 	return func() {
 		emptyChan := make(chan CronJobInfo, 1)
-		go crawlFunc(emptyChan)
+		go crawlFunc(trigger, emptyChan)
 		<-emptyChan
 		close(emptyChan)
 	}
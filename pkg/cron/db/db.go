@@ -7,6 +7,8 @@ type DBService struct{ *gorm.DB }
 type DB interface {
 	CronTaskIface
 	CronJobIface
+	CronRunIface
+	CronLeaseIface
 }
 
 func NewDBService(db *gorm.DB) DB { return &DBService{db} }
@@ -14,10 +14,18 @@ type CronTask struct {
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
 	DeleteAt  gorm.DeletedAt
-	Kind      string         `gorm:"column:kind;type:string"` // zsxq/zhihu/xiaobot/github
+	Kind      string         `gorm:"column:kind;type:string"` // zsxq/zhihu/xiaobot/github, or a static job such as macked_crawl
 	CronExpr  string         `gorm:"column:cron_expr;type:string"`
 	Include   pq.StringArray `gorm:"column:include;type:text[]"`
 	Exclude   pq.StringArray `gorm:"column:exclude;type:text[]"`
+	// Disabled tasks stay in the table but are not scheduled. It is stored negated
+	// so rows created before the column existed stay enabled.
+	Disabled bool `gorm:"column:disabled;type:bool"`
+	// MaxDelay is the upper bound in seconds of the random delay before a static
+	// job runs; 0 runs it on schedule.
+	MaxDelay int `gorm:"column:max_delay;type:int"`
+	// Params are the static job's parameters, e.g. {"author": "canglimo"} for zvideo_crawl.
+	Params map[string]string `gorm:"column:params;type:jsonb;serializer:json"`
 }
 
 func (*CronTask) TableName() string { return "cron_tasks" }
@@ -28,6 +36,8 @@ type CronTaskIface interface {
 	DeleteDefinition(id string) (err error)
 	GetDefinition(id string) (task *CronTask, err error)
 	GetDefinitions() (tasks []*CronTask, err error)
+	// SaveDefinition creates or replaces the whole row; an empty ID gets a new one.
+	SaveDefinition(task *CronTask) (id string, err error)
 }
 
 func (ds *DBService) AddDefinition(kind string, cronExpr string, include, exclude []string) (id string, err error) {
@@ -60,6 +70,13 @@ func (ds *DBService) PatchDefinition(id string, cronExpr *string, include, exclu
 	return ds.Save(task).Error
 }
 
+func (ds *DBService) SaveDefinition(task *CronTask) (id string, err error) {
+	if task.ID == "" {
+		task.ID = xid.New().String()
+	}
+	return task.ID, ds.Save(task).Error
+}
+
 func (ds *DBService) DeleteDefinition(id string) (err error) {
 	return ds.Delete(&CronTask{}, "id = ?", id).Error
 }
@@ -17,6 +17,21 @@ type CronJob struct {
 	// Detail usage notes:
 	// 1. For zhihu, it's the last crawled sub id raw string
 	Detail string `gorm:"column:detail;type:string" json:"detail"`
+	// Trigger is how the latest run started: schedule/manual/resume/retry
+	Trigger   string     `gorm:"column:trigger;type:string" json:"trigger"`
+	StartedAt time.Time  `gorm:"column:started_at" json:"started_at"`
+	EndedAt   *time.Time `gorm:"column:ended_at" json:"ended_at"`
+	// Error is the error that ended the run, empty when it finished cleanly
+	Error    string `gorm:"column:error;type:text" json:"error"`
+	Warnings int    `gorm:"column:warnings;type:int" json:"warnings"`
+	// Owner is the InstanceID running the job; LeaseUntil is renewed while it
+	// runs and cleared when it ends, see CronLeaseIface.
+	Owner      string     `gorm:"column:owner;type:string" json:"owner"`
+	LeaseUntil *time.Time `gorm:"column:lease_until" json:"lease_until"`
+	// Attempt counts the retries of a failed run, 0 for the original run;
+	// RetryOf is the id of that original run, empty for it.
+	Attempt int    `gorm:"column:attempt;type:int" json:"attempt"`
+	RetryOf string `gorm:"column:retry_of;type:string" json:"retry_of"`
 }
 
 func (*CronJob) TableName() string { return "cron_jobs" }
@@ -27,33 +42,60 @@ const (
 	StatusStopped
 	StatusError
 	StatusFinished
+	// StatusPaused keeps Detail so the job can be resumed via /job/:id/resume
+	StatusPaused
+)
+
+const (
+	TriggerSchedule = "schedule" // fired by the scheduler
+	TriggerManual   = "manual"   // POST /job/start/:task or /job/run/:job
+	TriggerResume   = "resume"   // resumed after a restart or a pause
+	TriggerRetry    = "retry"    // retried after a failed run, see MarkRetry
 )
 
 type CronJobIface interface {
-	AddJob(jobID, taskType string) (job *CronJob, err error)
+	AddJob(jobID, taskType, trigger string) (job *CronJob, err error)
 	StopJob(jobID string) (err error)
+	// CheckRunningJob returns a running job of taskType whose lease is still held.
 	CheckRunningJob(taskType string) (jobID string, err error)
 	FindRunningJob() ([]*CronJob, error)
 	FindErrorJob() ([]*CronJob, error)
 	UpdateStatus(jobID string, status int) (err error)
 	RecordDetail(jobID, detail string) (err error)
+	// ResumeJob marks a running or paused job as picked up again.
+	ResumeJob(jobID string) (err error)
+	// FinishJob records the terminal status of a run; runErr may be nil.
+	FinishJob(jobID string, status int, runErr error, warnings int) (err error)
+	// MarkRetry links a retry run to the original failed run.
+	MarkRetry(jobID, retryOf string, attempt int) (err error)
+	GetJob(jobID string) (job *CronJob, err error)
+	// FindJobs returns the latest runs, newest first; empty taskType matches all.
+	FindJobs(taskType string, limit int) ([]*CronJob, error)
 }
 
-func (ds *DBService) AddJob(jobID, taskType string) (job *CronJob, err error) {
+func (ds *DBService) AddJob(jobID, taskType, trigger string) (job *CronJob, err error) {
 	if jobID == "" {
 		jobID = xid.New().String()
 	}
+	leaseUntil := time.Now().Add(LeaseTTL)
 	job = &CronJob{
-		ID:       jobID,
-		TaskType: taskType,
-		Status:   StatusRunning}
+		ID:         jobID,
+		TaskType:   taskType,
+		Status:     StatusRunning,
+		Trigger:    trigger,
+		StartedAt:  time.Now(),
+		Owner:      InstanceID,
+		LeaseUntil: &leaseUntil,
+	}
 	err = ds.Save(job).Error
 	return job, err
 }
 
 func (ds *DBService) CheckRunningJob(taskType string) (jobID string, err error) {
 	var job CronJob
-	err = ds.Where("task_type = ? AND status = ?", taskType, StatusRunning).First(&job).Error
+	// A run whose lease lapsed was left behind by a dead instance and will be
+	// reclaimed; it must not block new runs of the task meanwhile.
+	err = ds.Where("task_type = ? AND status = ? AND lease_until > ?", taskType, StatusRunning, time.Now()).First(&job).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return "", nil
@@ -84,3 +126,53 @@ func (ds *DBService) UpdateStatus(jobID string, status int) (err error) {
 func (ds *DBService) RecordDetail(jobID, detail string) (err error) {
 	return ds.Model(&CronJob{}).Where("id = ?", jobID).Update("detail", detail).Error
 }
+
+func (ds *DBService) ResumeJob(jobID string) (err error) {
+	return ds.Model(&CronJob{}).Where("id = ?", jobID).Updates(withLease(map[string]any{
+		"status":     StatusRunning,
+		"trigger":    TriggerResume,
+		"started_at": time.Now(),
+		"ended_at":   nil,
+	})).Error
+}
+
+func (ds *DBService) FinishJob(jobID string, status int, runErr error, warnings int) (err error) {
+	errMsg := ""
+	if runErr != nil {
+		errMsg = runErr.Error()
+	}
+	return ds.Model(&CronJob{}).Where("id = ?", jobID).Updates(map[string]any{
+		"status":      status,
+		"ended_at":    time.Now(),
+		"error":       errMsg,
+		"warnings":    warnings,
+		"lease_until": nil,
+	}).Error
+}
+
+func (ds *DBService) MarkRetry(jobID, retryOf string, attempt int) (err error) {
+	return ds.Model(&CronJob{}).Where("id = ?", jobID).Updates(map[string]any{
+		"retry_of": retryOf,
+		"attempt":  attempt,
+	}).Error
+}
+
+var ErrJobNotFound = errors.New("job not found")
+
+func (ds *DBService) GetJob(jobID string) (job *CronJob, err error) {
+	job = &CronJob{}
+	err = ds.First(job, "id = ?", jobID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrJobNotFound
+	}
+	return job, err
+}
+
+func (ds *DBService) FindJobs(taskType string, limit int) (jobs []*CronJob, err error) {
+	query := ds.Order("started_at DESC").Limit(limit)
+	if taskType != "" {
+		query = query.Where("task_type = ?", taskType)
+	}
+	err = query.Find(&jobs).Error
+	return jobs, err
+}
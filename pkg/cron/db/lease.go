@@ -0,0 +1,61 @@
+package db
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// LeaseTTL is how long a running job stays owned by its instance without a
+// heartbeat. Once it lapses another instance may resume the job.
+const LeaseTTL = 2 * time.Minute
+
+// InstanceID names this process as the owner of the jobs it runs. The host
+// name keeps it readable in job listings; the random suffix keeps replicas
+// that share a host name (and a restarted process) from holding each other's
+// leases. A restarted instance resumes its old jobs once their lease lapses.
+var InstanceID = instanceID()
+
+func instanceID() string {
+	suffix := xid.New().String()
+	if name, err := os.Hostname(); err == nil && name != "" {
+		return name + "-" + suffix
+	}
+	return suffix
+}
+
+type CronLeaseIface interface {
+	// RenewLease extends the lease of a running job owned by owner.
+	RenewLease(jobID, owner string) (err error)
+	// ClaimJob takes a running job over for owner when its lease expired or
+	// owner already holds it. It reports false when another instance still does.
+	ClaimJob(jobID, owner string) (claimed bool, err error)
+}
+
+func (ds *DBService) RenewLease(jobID, owner string) (err error) {
+	return ds.Model(&CronJob{}).
+		Where("id = ? AND owner = ? AND status = ?", jobID, owner, StatusRunning).
+		Update("lease_until", time.Now().Add(LeaseTTL)).Error
+}
+
+func (ds *DBService) ClaimJob(jobID, owner string) (claimed bool, err error) {
+	now := time.Now()
+	result := ds.Model(&CronJob{}).
+		Where("id = ? AND status = ?", jobID, StatusRunning).
+		Where("lease_until IS NULL OR lease_until < ? OR owner = ?", now, owner).
+		Updates(map[string]any{"owner": owner, "lease_until": now.Add(LeaseTTL)})
+	return result.RowsAffected == 1, result.Error
+}
+
+// LeaseHeld reports whether another instance may still be running job.
+func (j *CronJob) LeaseHeld(now time.Time) bool {
+	return j.Status == StatusRunning && j.LeaseUntil != nil && j.LeaseUntil.After(now)
+}
+
+// withLease adds this instance's ownership to the columns of a starting run.
+func withLease(columns map[string]any) map[string]any {
+	columns["owner"] = InstanceID
+	columns["lease_until"] = time.Now().Add(LeaseTTL)
+	return columns
+}
@@ -0,0 +1,65 @@
+package db
+
+import "time"
+
+// CronJobTarget is the crawl result of one target (zsxq group, zhihu sub,
+// xiaobot paper, github sub) within a run.
+type CronJobTarget struct {
+	ID         uint      `gorm:"primaryKey;column:id" json:"-"`
+	JobID      string    `gorm:"column:job_id;type:string;index" json:"-"`
+	Target     string    `gorm:"column:target;type:string" json:"target"`
+	ItemsFound int       `gorm:"column:items_found;type:int" json:"items_found"`
+	ItemsNew   int       `gorm:"column:items_new;type:int" json:"items_new"`
+	Error      string    `gorm:"column:error;type:text" json:"error,omitempty"`
+	ErrorClass string    `gorm:"column:error_class;type:string" json:"error_class,omitempty"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+func (*CronJobTarget) TableName() string { return "cron_job_targets" }
+
+// CronJobLog is a log line captured from a run's logger.
+type CronJobLog struct {
+	ID      uint      `gorm:"primaryKey;column:id" json:"-"`
+	JobID   string    `gorm:"column:job_id;type:string;index" json:"-"`
+	Time    time.Time `gorm:"column:time" json:"time"`
+	Level   string    `gorm:"column:level;type:string" json:"level"`
+	Message string    `gorm:"column:message;type:text" json:"message"`
+	// Fields is the JSON object of the zap fields attached to the line
+	Fields string `gorm:"column:fields;type:text" json:"fields"`
+}
+
+func (*CronJobLog) TableName() string { return "cron_job_logs" }
+
+type CronRunIface interface {
+	RecordTarget(target *CronJobTarget) (err error)
+	// GetTargets returns the targets of the given jobs in crawl order.
+	GetTargets(jobIDs ...string) ([]*CronJobTarget, error)
+	SaveLogs(logs []*CronJobLog) (err error)
+	// GetLogs returns the captured log lines of a job in order; empty levels match all.
+	GetLogs(jobID string, levels ...string) ([]*CronJobLog, error)
+}
+
+func (ds *DBService) RecordTarget(target *CronJobTarget) (err error) {
+	return ds.Create(target).Error
+}
+
+func (ds *DBService) GetTargets(jobIDs ...string) (targets []*CronJobTarget, err error) {
+	err = ds.Where("job_id IN ?", jobIDs).Order("id").Find(&targets).Error
+	return targets, err
+}
+
+func (ds *DBService) SaveLogs(logs []*CronJobLog) (err error) {
+	if len(logs) == 0 {
+		return nil
+	}
+	return ds.Create(logs).Error
+}
+
+func (ds *DBService) GetLogs(jobID string, levels ...string) (logs []*CronJobLog, err error) {
+	query := ds.Where("job_id = ?", jobID)
+	if len(levels) > 0 {
+		query = query.Where("level IN ?", levels)
+	}
+	err = query.Order("id").Find(&logs).Error
+	return logs, err
+}
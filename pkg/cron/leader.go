@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// leaderLockKey is the Postgres advisory lock key every instance competes for.
+const leaderLockKey int64 = 0x7273737a65726f // "rsszero"
+
+// ErrNotLeader is returned by IsLeader on instances that must not run scheduled jobs.
+var ErrNotLeader = errors.New("not the scheduling leader")
+
+// PGElector elects the instance that runs scheduled jobs with a session level
+// Postgres advisory lock. The lock lives as long as the connection holding it,
+// so a crashed leader releases it at once and the next IsLeader call of another
+// instance takes over. It implements gocron.Elector.
+type PGElector struct {
+	db     *sql.DB
+	logger *zap.Logger
+
+	mu   sync.Mutex
+	conn *sql.Conn // holds the lock while this instance leads
+}
+
+func NewPGElector(db *sql.DB, logger *zap.Logger) *PGElector {
+	return &PGElector{db: db, logger: logger}
+}
+
+// IsLeader returns nil when this instance holds, or just acquired, the lock.
+func (e *PGElector) IsLeader(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil {
+		if err := e.conn.PingContext(ctx); err == nil {
+			return nil
+		}
+		e.logger.Warn("Lost scheduling leadership")
+		e.release(ctx)
+	}
+
+	conn, err := e.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	var locked bool
+	if err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", leaderLockKey).Scan(&locked); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if !locked {
+		_ = conn.Close()
+		return ErrNotLeader
+	}
+	e.conn = conn
+	e.logger.Info("Became scheduling leader")
+	return nil
+}
+
+// Resign releases the lock, e.g. on shutdown, so another instance can lead.
+func (e *PGElector) Resign() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.conn != nil {
+		e.release(context.Background())
+	}
+}
+
+// release unlocks before handing the connection back: closing a sql.Conn only
+// returns it to the pool, and the session would keep the lock. When the session
+// is really gone the unlock fails and the lock went with it.
+func (e *PGElector) release(ctx context.Context) {
+	_, _ = e.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", leaderLockKey)
+	_ = e.conn.Close()
+	e.conn = nil
+}
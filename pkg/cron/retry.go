@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RetryPolicy decides whether and when a failed crawl run is tried again.
+type RetryPolicy struct {
+	MaxAttempts int           // runs in total, the original one included; <= 1 disables retries
+	BaseDelay   time.Duration // backoff before the first retry, doubled for each further one
+	MaxDelay    time.Duration // cap of the backoff
+	Retryable   []string      // error classes worth another attempt, see subscriptionDB.ErrorClass*
+}
+
+// ShouldRetry reports whether a run that failed as attempt (0 for the original
+// run) with classes gets another one. Every class must be retryable: a run that
+// also hit an invalid cookie would fail the same way again.
+func (p RetryPolicy) ShouldRetry(attempt int, classes []string) bool {
+	if attempt+1 >= p.MaxAttempts || len(classes) == 0 {
+		return false
+	}
+	for _, class := range classes {
+		if !slices.Contains(p.Retryable, class) {
+			return false
+		}
+	}
+	return true
+}
+
+// Backoff returns the delay before retry number attempt, starting at 1. It grows
+// exponentially up to MaxDelay; random, e.g. rand.N, picks the upper half of it
+// so instances failing together don't retry in step.
+func (p RetryPolicy) Backoff(attempt int, random func(time.Duration) time.Duration) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay == 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay/2 + random(delay/2+1)
+}
+
+// FailedRun is a run that ended with StatusError.
+type FailedRun struct {
+	JobID string
+	Err   error
+	// Classes are the distinct error classes of the failed targets, or the class
+	// of Err when no target failed.
+	Classes []string
+}
+
+// Retrier schedules another attempt of a failed run. Retry is called before the
+// run's logs are closed, so logger still writes to its history; it reports
+// whether another attempt was scheduled.
+type Retrier interface {
+	Retry(run FailedRun, logger *zap.Logger) bool
+}
+
+var retrier struct {
+	sync.Mutex
+	r Retrier
+}
+
+// SetRetrier installs the Retrier every RunRecorder hands failed runs to. Without
+// one failed runs wait for the next schedule.
+func SetRetrier(r Retrier) {
+	retrier.Lock()
+	defer retrier.Unlock()
+	retrier.r = r
+}
+
+func currentRetrier() Retrier {
+	retrier.Lock()
+	defer retrier.Unlock()
+	return retrier.r
+}
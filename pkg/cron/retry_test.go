@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, Retryable: []string{subscriptionDB.ErrorClassNetwork, subscriptionDB.ErrorClassUpstream}}
+	network := []string{subscriptionDB.ErrorClassNetwork}
+
+	assert.True(t, p.ShouldRetry(0, network))
+	assert.True(t, p.ShouldRetry(1, []string{subscriptionDB.ErrorClassNetwork, subscriptionDB.ErrorClassUpstream}))
+	assert.False(t, p.ShouldRetry(2, network), "the third run is the last")
+	assert.False(t, p.ShouldRetry(0, []string{subscriptionDB.ErrorClassNetwork, subscriptionDB.ErrorClassAuth}))
+	assert.False(t, p.ShouldRetry(0, nil))
+	assert.False(t, RetryPolicy{}.ShouldRetry(0, network), "zero policy never retries")
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Minute, MaxDelay: 5 * time.Minute}
+	none := func(time.Duration) time.Duration { return 0 }
+	full := func(d time.Duration) time.Duration { return d - 1 }
+
+	for attempt, want := range map[int]time.Duration{1: time.Minute, 2: 2 * time.Minute, 3: 4 * time.Minute, 4: 5 * time.Minute, 10: 5 * time.Minute} {
+		assert.Equal(t, want/2, p.Backoff(attempt, none), "attempt %d", attempt)
+		assert.Equal(t, want/2+want/2, p.Backoff(attempt, full), "attempt %d", attempt)
+	}
+}
+
+type fakeRetrier struct {
+	runs  []FailedRun
+	retry bool
+}
+
+func (f *fakeRetrier) Retry(run FailedRun, logger *zap.Logger) bool {
+	f.runs = append(f.runs, run)
+	logger.Info("Schedule retry of failed job")
+	return f.retry
+}
+
+func TestRunRecorderHandsFailedRunToRetrier(t *testing.T) {
+	rt := &fakeRetrier{retry: true}
+	SetRetrier(rt)
+	t.Cleanup(func() { SetRetrier(nil) })
+
+	db := &fakeRunDB{}
+	recorder, logger := NewRunRecorder("job-1", db, zap.NewNop())
+	stat := recorder.StatDB(nopStatDB{})
+	subscriptionDB.Record(stat, subscriptionDB.PlatformZsxq, "g1", subscriptionDB.Run{Err: errors.New("timeout"), ErrorClass: subscriptionDB.ErrorClassNetwork}, logger)
+	subscriptionDB.Record(stat, subscriptionDB.PlatformZsxq, "g2", subscriptionDB.Run{Err: errors.New("timeout"), ErrorClass: subscriptionDB.ErrorClassNetwork}, logger)
+	subscriptionDB.Record(stat, subscriptionDB.PlatformZsxq, "g3", subscriptionDB.Run{ItemsFound: 1}, logger)
+
+	assert.True(t, recorder.Finish(cronDB.StatusError, errors.New("2 groups failed")))
+	require.Len(t, rt.runs, 1)
+	assert.Equal(t, "job-1", rt.runs[0].JobID)
+	assert.Equal(t, []string{subscriptionDB.ErrorClassNetwork}, rt.runs[0].Classes)
+	assert.Equal(t, cronDB.StatusError, db.status)
+
+	// 重试的决定写进这次运行的日志
+	var messages []string
+	for _, l := range db.lines() {
+		messages = append(messages, l.Message)
+	}
+	assert.Contains(t, messages, "Schedule retry of failed job")
+}
+
+func TestRunRecorderRetryClassesFallBackToRunError(t *testing.T) {
+	rt := &fakeRetrier{}
+	SetRetrier(rt)
+	t.Cleanup(func() { SetRetrier(nil) })
+
+	recorder, _ := NewRunRecorder("job-1", &fakeRunDB{}, zap.NewNop())
+	assert.False(t, recorder.Finish(cronDB.StatusError, errors.New("cookie missing")))
+	require.Len(t, rt.runs, 1)
+	assert.Equal(t, []string{subscriptionDB.ErrorClassUnknown}, rt.runs[0].Classes)
+
+	recorder, _ = NewRunRecorder("job-2", &fakeRunDB{}, zap.NewNop())
+	assert.False(t, recorder.Finish(cronDB.StatusFinished, nil))
+	assert.Len(t, rt.runs, 1, "only failed runs are retried")
+}
@@ -0,0 +1,261 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+const (
+	// logFlushSize is how many captured lines are buffered before writing them,
+	// so a crashed run still leaves most of its logs behind.
+	logFlushSize = 50
+	// maxRunLogs caps the lines kept per run; the rest are only counted.
+	maxRunLogs = 5000
+	// leaseRenewInterval keeps the job's lease well ahead of cronDB.LeaseTTL.
+	leaseRenewInterval = cronDB.LeaseTTL / 4
+)
+
+// RunRecorder keeps the history of one cron run: it captures the lines written
+// through the logger it returns, records the result of every crawled target and
+// writes the final status, error and warning count back to cron_jobs. While the
+// run lasts it also renews the job's owner lease.
+type RunRecorder struct {
+	jobID  string
+	db     cronDB.DB
+	logger *zap.Logger // the original logger, used to report capture failures
+	logs   *logCapture
+	// captured is the logger handed to the run, so the retry decision lands in its history
+	captured *zap.Logger
+
+	classesMu sync.Mutex
+	classes   []string // distinct error classes of the failed targets
+
+	stopLease     chan struct{}
+	stopLeaseOnce sync.Once
+}
+
+// NewRunRecorder returns the recorder and a logger whose Info and above lines
+// are captured for jobID. Use the returned logger for the rest of the run.
+func NewRunRecorder(jobID string, db cronDB.DB, logger *zap.Logger) (*RunRecorder, *zap.Logger) {
+	r := &RunRecorder{jobID: jobID, db: db, logger: logger, stopLease: make(chan struct{})}
+	r.logs = &logCapture{recorder: r}
+	go r.renewLease(leaseRenewInterval)
+	captured := logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, &captureCore{capture: r.logs})
+	}))
+	r.captured = captured
+	return r, captured
+}
+
+// StatDB wraps stat so every subscriptionDB.Record call of the run is also
+// saved as a target of this job.
+func (r *RunRecorder) StatDB(stat subscriptionDB.DB) subscriptionDB.DB {
+	return &targetRecorder{DB: stat, recorder: r}
+}
+
+// Finish flushes the captured logs and records the terminal status of the run.
+// A failed run is first handed to the Retrier; retrying reports whether another
+// attempt was scheduled, in which case the caller leaves the failure notification
+// to the last attempt.
+func (r *RunRecorder) Finish(status int, runErr error) (retrying bool) {
+	r.stopLeaseOnce.Do(func() { close(r.stopLease) })
+	if status == cronDB.StatusError {
+		if rt := currentRetrier(); rt != nil {
+			retrying = rt.Retry(FailedRun{JobID: r.jobID, Err: runErr, Classes: r.errorClasses(runErr)}, r.captured)
+		}
+	}
+	warnings := r.logs.close()
+	if err := r.db.FinishJob(r.jobID, status, runErr, warnings); err != nil {
+		r.logger.Error("Failed to update cron job status", zap.Error(err))
+	}
+	return retrying
+}
+
+// errorClasses returns the classes of the failed targets, falling back to the
+// class of runErr when the run failed before or between targets.
+func (r *RunRecorder) errorClasses(runErr error) []string {
+	r.classesMu.Lock()
+	defer r.classesMu.Unlock()
+	if len(r.classes) > 0 {
+		return slices.Clone(r.classes)
+	}
+	if runErr != nil {
+		return []string{subscriptionDB.ClassifyError(runErr)}
+	}
+	return nil
+}
+
+func (r *RunRecorder) addClass(class string) {
+	r.classesMu.Lock()
+	defer r.classesMu.Unlock()
+	if !slices.Contains(r.classes, class) {
+		r.classes = append(r.classes, class)
+	}
+}
+
+// Discard stops capturing without writing anything, for runs that never
+// registered a job, e.g. skipped because another job is running.
+func (r *RunRecorder) Discard() {
+	r.stopLeaseOnce.Do(func() { close(r.stopLease) })
+	r.logs.discard()
+}
+
+// renewLease is the run's heartbeat. Renewing before the job row exists, or
+// after another instance took it over, matches no row and is harmless.
+func (r *RunRecorder) renewLease(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopLease:
+			return
+		case <-ticker.C:
+			if err := r.db.RenewLease(r.jobID, cronDB.InstanceID); err != nil {
+				r.logger.Error("Failed to renew cron job lease", zap.Error(err))
+			}
+		}
+	}
+}
+
+type targetRecorder struct {
+	subscriptionDB.DB
+	recorder *RunRecorder
+}
+
+func (t *targetRecorder) RecordRun(platform, subID string, run subscriptionDB.Run) error {
+	target := &cronDB.CronJobTarget{
+		JobID:      t.recorder.jobID,
+		Target:     subID,
+		ItemsFound: run.ItemsFound,
+		ItemsNew:   run.ItemsNew,
+	}
+	if run.Err != nil {
+		target.Error = run.Err.Error()
+		target.ErrorClass = run.ErrorClass
+		if target.ErrorClass == "" {
+			target.ErrorClass = subscriptionDB.ClassifyError(run.Err)
+		}
+		t.recorder.addClass(target.ErrorClass)
+	}
+	if err := t.recorder.db.RecordTarget(target); err != nil {
+		t.recorder.logger.Error("Failed to record cron job target", zap.String("target", subID), zap.Error(err))
+	}
+	return t.DB.RecordRun(platform, subID, run)
+}
+
+// logCapture buffers the lines of one run and writes them in batches.
+type logCapture struct {
+	recorder *RunRecorder
+
+	mu       sync.Mutex
+	buf      []*cronDB.CronJobLog
+	kept     int
+	dropped  int
+	warnings int
+	closed   bool
+}
+
+func (c *logCapture) add(line *cronDB.CronJobLog, level zapcore.Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	if level == zapcore.WarnLevel {
+		c.warnings++
+	}
+	if c.kept >= maxRunLogs {
+		c.dropped++
+		return
+	}
+	c.kept++
+	c.buf = append(c.buf, line)
+	if len(c.buf) >= logFlushSize {
+		c.flushLocked()
+	}
+}
+
+func (c *logCapture) flushLocked() {
+	if err := c.recorder.db.SaveLogs(c.buf); err != nil {
+		c.recorder.logger.Error("Failed to save cron job logs", zap.Int("count", len(c.buf)), zap.Error(err))
+	}
+	c.buf = nil
+}
+
+// close flushes what is left and returns the warning count of the run.
+func (c *logCapture) close() (warnings int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return c.warnings
+	}
+	if c.dropped > 0 {
+		c.buf = append(c.buf, &cronDB.CronJobLog{
+			JobID:   c.recorder.jobID,
+			Level:   zapcore.WarnLevel.String(),
+			Message: fmt.Sprintf("%d more log lines were not kept", c.dropped),
+			Fields:  "{}",
+		})
+	}
+	c.flushLocked()
+	c.closed = true
+	return c.warnings
+}
+
+func (c *logCapture) discard() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buf, c.closed = nil, true
+}
+
+// captureCore is the zapcore.Core teed next to the run logger's own core.
+type captureCore struct {
+	capture *logCapture
+	fields  []zapcore.Field
+}
+
+func (c *captureCore) Enabled(level zapcore.Level) bool { return level >= zapcore.InfoLevel }
+
+func (c *captureCore) With(fields []zapcore.Field) zapcore.Core {
+	return &captureCore{capture: c.capture, fields: append(c.fields[:len(c.fields):len(c.fields)], fields...)}
+}
+
+func (c *captureCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *captureCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	encoded, err := json.Marshal(enc.Fields)
+	if err != nil {
+		encoded = []byte("{}")
+	}
+	c.capture.add(&cronDB.CronJobLog{
+		JobID:   c.capture.recorder.jobID,
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+		Fields:  string(encoded),
+	}, entry.Level)
+	return nil
+}
+
+func (c *captureCore) Sync() error { return nil }
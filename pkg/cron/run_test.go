@@ -0,0 +1,164 @@
+package cron
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// fakeRunDB 只实现 RunRecorder 用到的方法。
+type fakeRunDB struct {
+	cronDB.DB
+	mu       sync.Mutex
+	batches  [][]*cronDB.CronJobLog
+	targets  []*cronDB.CronJobTarget
+	status   int
+	runErr   error
+	warnings int
+	finished bool
+	renewed  []string
+}
+
+func (f *fakeRunDB) SaveLogs(logs []*cronDB.CronJobLog) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(logs) > 0 {
+		f.batches = append(f.batches, logs)
+	}
+	return nil
+}
+
+func (f *fakeRunDB) RecordTarget(t *cronDB.CronJobTarget) error {
+	f.targets = append(f.targets, t)
+	return nil
+}
+
+func (f *fakeRunDB) FinishJob(_ string, status int, runErr error, warnings int) error {
+	f.status, f.runErr, f.warnings, f.finished = status, runErr, warnings, true
+	return nil
+}
+
+func (f *fakeRunDB) RenewLease(jobID, owner string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.renewed = append(f.renewed, jobID+"@"+owner)
+	return nil
+}
+
+func (f *fakeRunDB) renewCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.renewed)
+}
+
+func (f *fakeRunDB) lines() []*cronDB.CronJobLog {
+	var out []*cronDB.CronJobLog
+	for _, b := range f.batches {
+		out = append(out, b...)
+	}
+	return out
+}
+
+type nopStatDB struct{ subscriptionDB.DB }
+
+func (nopStatDB) RecordRun(string, string, subscriptionDB.Run) error { return nil }
+
+func TestRunRecorderCapturesLogs(t *testing.T) {
+	db := &fakeRunDB{}
+	recorder, logger := NewRunRecorder("job-1", db, zap.NewNop())
+
+	logger = logger.With(zap.String("group", "g1"))
+	logger.Debug("not kept")
+	logger.Info("start", zap.Int("count", 2))
+	logger.Warn("slow")
+	for range logFlushSize {
+		logger.Info("tick")
+	}
+	require.Len(t, db.batches, 1, "满 logFlushSize 行即写一批")
+
+	recorder.Finish(cronDB.StatusError, errors.New("boom"))
+	logger.Info("after finish")
+
+	lines := db.lines()
+	require.Len(t, lines, logFlushSize+2)
+	assert.Equal(t, "job-1", lines[0].JobID)
+	assert.Equal(t, "info", lines[0].Level)
+	assert.Equal(t, "start", lines[0].Message)
+	assert.JSONEq(t, `{"group":"g1","count":2}`, lines[0].Fields)
+	assert.Equal(t, "warn", lines[1].Level)
+
+	assert.True(t, db.finished)
+	assert.Equal(t, cronDB.StatusError, db.status)
+	assert.EqualError(t, db.runErr, "boom")
+	assert.Equal(t, 1, db.warnings)
+}
+
+func TestRunRecorderCapsLogs(t *testing.T) {
+	db := &fakeRunDB{}
+	recorder, logger := NewRunRecorder("job-1", db, zap.NewNop())
+	for range maxRunLogs + 3 {
+		logger.Info("line")
+	}
+	recorder.Finish(cronDB.StatusFinished, nil)
+
+	lines := db.lines()
+	require.Len(t, lines, maxRunLogs+1)
+	last := lines[len(lines)-1]
+	assert.Equal(t, "warn", last.Level)
+	assert.Equal(t, "3 more log lines were not kept", last.Message)
+}
+
+func TestRunRecorderDiscard(t *testing.T) {
+	db := &fakeRunDB{}
+	recorder, logger := NewRunRecorder("job-1", db, zap.NewNop())
+	logger.Info("skipped")
+	recorder.Discard()
+	logger.Info("after discard")
+
+	assert.Empty(t, db.batches)
+	assert.False(t, db.finished)
+}
+
+func TestRunRecorderStatDB(t *testing.T) {
+	db := &fakeRunDB{}
+	recorder, _ := NewRunRecorder("job-1", db, zap.NewNop())
+	stat := recorder.StatDB(nopStatDB{})
+
+	require.NoError(t, stat.RecordRun(subscriptionDB.PlatformZsxq, "100", subscriptionDB.Run{ItemsFound: 3, ItemsNew: 1}))
+	require.NoError(t, stat.RecordRun(subscriptionDB.PlatformZsxq, "200", subscriptionDB.Run{Err: errors.New("x"), ErrorClass: subscriptionDB.ErrorClassGone}))
+
+	require.Len(t, db.targets, 2)
+	assert.Equal(t, cronDB.CronJobTarget{JobID: "job-1", Target: "100", ItemsFound: 3, ItemsNew: 1}, *db.targets[0])
+	assert.Equal(t, "x", db.targets[1].Error)
+	assert.Equal(t, subscriptionDB.ErrorClassGone, db.targets[1].ErrorClass)
+}
+
+func TestRunRecorderRenewsLeaseUntilFinished(t *testing.T) {
+	db := &fakeRunDB{}
+	recorder := &RunRecorder{jobID: "job-1", db: db, logger: zap.NewNop(), stopLease: make(chan struct{})}
+	recorder.logs = &logCapture{recorder: recorder}
+	done := make(chan struct{})
+	go func() {
+		recorder.renewLease(time.Millisecond)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return db.renewCount() >= 2 }, time.Second, time.Millisecond)
+
+	recorder.Finish(cronDB.StatusFinished, nil)
+	recorder.Discard() // stopping twice is fine
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("heartbeat still running after Finish")
+	}
+	assert.Equal(t, "job-1@"+cronDB.InstanceID, db.renewed[0])
+}
@@ -0,0 +1,26 @@
+// Package feedtoken 为私有 feed 签发 URL 令牌。RSS 阅读器无法携带登录头，私有 feed 只能靠 URL 中的令牌鉴权；
+// 令牌由服务端密钥对用户与 feed 路径签名，无需落库，更换密钥即可让所有旧链接失效。
+package feedtoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Sign 为 username 读取 feedPath 生成令牌。feedPath 是不含查询参数的 feed 路径，不同 feed 的令牌因此互不通用。
+func Sign(secret, username, feedPath string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(username))
+	mac.Write([]byte{0})
+	mac.Write([]byte(feedPath))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 以常量时间比较令牌，secret 为空时一律拒绝。
+func Verify(secret, username, feedPath, token string) bool {
+	if secret == "" || token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, username, feedPath)), []byte(token))
+}
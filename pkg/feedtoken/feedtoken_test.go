@@ -0,0 +1,19 @@
+package feedtoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	token := Sign("secret", "jason", "/rss/bookmark/jason/go")
+	assert.True(t, Verify("secret", "jason", "/rss/bookmark/jason/go", token))
+	assert.False(t, Verify("secret", "jason", "/rss/bookmark/jason/rust", token))
+	assert.False(t, Verify("secret", "bob", "/rss/bookmark/jason/go", token))
+	assert.False(t, Verify("other", "jason", "/rss/bookmark/jason/go", token))
+	assert.False(t, Verify("secret", "jason", "/rss/bookmark/jason/go", ""))
+	assert.False(t, Verify("", "jason", "/rss/bookmark/jason/go", Sign("", "jason", "/rss/bookmark/jason/go")), "empty secret disables the feed")
+	// username 与 feedPath 之间有分隔符，拼接后相同的两组参数不能共用令牌
+	assert.NotEqual(t, Sign("secret", "ab", "c"), Sign("secret", "a", "bc"))
+}
@@ -0,0 +1,108 @@
+// Package opml 读写 OPML 2.0 订阅列表，供 FreshRSS 等阅读器批量导入导出。
+package opml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type OPML struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    Head     `xml:"head"`
+	Body    Body     `xml:"body"`
+}
+
+type Head struct {
+	Title       string `xml:"title"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+type Body struct {
+	Outlines []Outline `xml:"outline"`
+}
+
+// Outline 既可以是分组（只有 Text 与子 Outline），也可以是一个 feed（带 XMLURL）。
+type Outline struct {
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr,omitempty"`
+	Type     string    `xml:"type,attr,omitempty"`
+	XMLURL   string    `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string    `xml:"htmlUrl,attr,omitempty"`
+	Outlines []Outline `xml:"outline"`
+}
+
+// Feed 是一条订阅。
+type Feed struct {
+	Title   string
+	XMLURL  string
+	HTMLURL string
+}
+
+// Group 是导出时的一个分组，FreshRSS 导入时会把它建成同名分类。
+type Group struct {
+	Name  string
+	Feeds []Feed
+}
+
+// Encode 按分组输出 OPML，没有 feed 的分组会被略去。
+func Encode(title string, created time.Time, groups []Group) ([]byte, error) {
+	doc := OPML{
+		Version: "2.0",
+		Head:    Head{Title: title, DateCreated: created.Format(time.RFC1123Z)},
+	}
+	for _, g := range groups {
+		if len(g.Feeds) == 0 {
+			continue
+		}
+		group := Outline{Text: g.Name, Title: g.Name}
+		for _, f := range g.Feeds {
+			group.Outlines = append(group.Outlines, Outline{
+				Text:    f.Title,
+				Title:   f.Title,
+				Type:    "rss",
+				XMLURL:  f.XMLURL,
+				HTMLURL: f.HTMLURL,
+			})
+		}
+		doc.Body.Outlines = append(doc.Body.Outlines, group)
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode opml: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// Decode 解析 OPML，把任意层级中带 xmlUrl 的 outline 展平为 Feed 列表，保持文档顺序。
+func Decode(data []byte) ([]Feed, error) {
+	var doc OPML
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	// 部分阅读器导出的 OPML 声明了非 UTF-8 编码，但内容实际是 UTF-8
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode opml: %w", err)
+	}
+
+	feeds := make([]Feed, 0)
+	var walk func(outlines []Outline)
+	walk = func(outlines []Outline) {
+		for _, o := range outlines {
+			if u := strings.TrimSpace(o.XMLURL); u != "" {
+				title := o.Title
+				if title == "" {
+					title = o.Text
+				}
+				feeds = append(feeds, Feed{Title: title, XMLURL: u, HTMLURL: o.HTMLURL})
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Body.Outlines)
+	return feeds, nil
+}
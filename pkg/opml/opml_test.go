@@ -0,0 +1,51 @@
+package opml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	groups := []Group{
+		{Name: "知乎", Feeds: []Feed{{Title: "墨苍离的知乎回答", XMLURL: "https://rss.example.com/rss/zhihu/answer/canglimo", HTMLURL: "https://www.zhihu.com/people/canglimo/answers"}}},
+		{Name: "空分组"},
+		{Name: "GitHub", Feeds: []Feed{{Title: "golang/go", XMLURL: "https://rss.example.com/rss/github/golang/go"}}},
+	}
+	data, err := Encode("rss-zero", time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), groups)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<opml version="2.0">`)
+	assert.Contains(t, string(data), "Thu, 01 Oct 2026 08:00:00 +0000")
+	assert.NotContains(t, string(data), "空分组")
+
+	feeds, err := Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, []Feed{groups[0].Feeds[0], groups[2].Feeds[0]}, feeds)
+}
+
+func TestDecodeNested(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="GBK"?>
+<opml version="1.0">
+  <head><title>FreshRSS</title></head>
+  <body>
+    <outline text="Top" xmlUrl=" https://a.example.com/feed "/>
+    <outline text="分类">
+      <outline text="子分类">
+        <outline text="B" title="B title" xmlUrl="https://b.example.com/feed" htmlUrl="https://b.example.com"/>
+      </outline>
+      <outline text="无地址"/>
+    </outline>
+  </body>
+</opml>`)
+	feeds, err := Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, []Feed{
+		{Title: "Top", XMLURL: "https://a.example.com/feed"},
+		{Title: "B title", XMLURL: "https://b.example.com/feed", HTMLURL: "https://b.example.com"},
+	}, feeds)
+
+	_, err = Decode([]byte("not xml"))
+	assert.Error(t, err)
+}
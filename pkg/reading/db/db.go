@@ -0,0 +1,99 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+)
+
+type DB interface {
+	// SaveProgress 记录滚动进度，进度达到 ReadThreshold 时自动标为已读。
+	SaveProgress(userID string, ref bookmarkDB.ContentRef, progress float64) (*ReadState, error)
+	// SetRead 显式标记已读或未读；标为未读会清空进度。
+	SetRead(userID string, ref bookmarkDB.ContentRef, read bool) (*ReadState, error)
+	// Touch 只刷新最近阅读时间，用于打开归档页面但尚未上报进度的情形。
+	Touch(userID string, ref bookmarkDB.ContentRef) error
+	// GetReadStates 返回 content id → 阅读状态，没有记录的内容不在结果中。
+	GetReadStates(userID, platform, kind string, contentIDs []string) (map[string]ReadState, error)
+}
+
+type ReadingDBImpl struct{ *gorm.DB }
+
+func NewReadingDBImpl(db *gorm.DB) DB { return &ReadingDBImpl{db} }
+
+func (db *ReadingDBImpl) SaveProgress(userID string, ref bookmarkDB.ContentRef, progress float64) (*ReadState, error) {
+	progress = ClampProgress(progress)
+	return db.upsert(userID, ref, func(s *ReadState, now time.Time) {
+		s.Progress = progress
+		if progress >= ReadThreshold {
+			markRead(s, now)
+		}
+	})
+}
+
+func (db *ReadingDBImpl) SetRead(userID string, ref bookmarkDB.ContentRef, read bool) (*ReadState, error) {
+	return db.upsert(userID, ref, func(s *ReadState, now time.Time) {
+		if read {
+			markRead(s, now)
+			return
+		}
+		s.Read, s.ReadAt, s.Progress = false, nil, 0
+	})
+}
+
+func (db *ReadingDBImpl) Touch(userID string, ref bookmarkDB.ContentRef) error {
+	_, err := db.upsert(userID, ref, func(*ReadState, time.Time) {})
+	return err
+}
+
+func (db *ReadingDBImpl) GetReadStates(userID, platform, kind string, contentIDs []string) (map[string]ReadState, error) {
+	result := make(map[string]ReadState, len(contentIDs))
+	if len(contentIDs) == 0 {
+		return result, nil
+	}
+	var states []ReadState
+	if err := db.Where("user_id = ? AND platform = ? AND kind = ? AND content_id IN ?", userID, platform, kind, contentIDs).
+		Find(&states).Error; err != nil {
+		return nil, fmt.Errorf("failed to get read states: %w", err)
+	}
+	for _, s := range states {
+		result[s.ContentID] = s
+	}
+	return result, nil
+}
+
+// upsert 在事务中读出（或新建）阅读状态，交给 apply 修改后写回，并总是刷新 LastReadAt。
+func (db *ReadingDBImpl) upsert(userID string, ref bookmarkDB.ContentRef, apply func(s *ReadState, now time.Time)) (*ReadState, error) {
+	state := &ReadState{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND platform = ? AND kind = ? AND content_id = ?", userID, ref.Platform, ref.Kind, ref.ID).
+			First(state).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get read state: %w", err)
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			state = &ReadState{UserID: userID, Platform: ref.Platform, Kind: ref.Kind, ContentID: ref.ID}
+		}
+		now := time.Now()
+		apply(state, now)
+		state.LastReadAt = now
+		if err := tx.Save(state).Error; err != nil {
+			return fmt.Errorf("failed to save read state: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func markRead(s *ReadState, now time.Time) {
+	if !s.Read {
+		s.Read, s.ReadAt = true, &now
+	}
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPickPreferred(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5}
+
+	for range 20 {
+		picked := PickPreferred(ids, []int{2, 4}, 3)
+		require.Len(t, picked, 3)
+		assert.ElementsMatch(t, []int{2, 4}, picked[:2])
+		assert.Contains(t, []int{1, 3, 5}, picked[2])
+	}
+
+	assert.ElementsMatch(t, []int{3}, PickPreferred(ids, []int{3}, 1))
+	assert.ElementsMatch(t, ids, PickPreferred(ids, nil, 10))
+	assert.Empty(t, PickPreferred([]int{}, nil, 1))
+}
+
+func TestClampProgress(t *testing.T) {
+	assert.Equal(t, 0.0, ClampProgress(-0.5))
+	assert.Equal(t, 0.5, ClampProgress(0.5))
+	assert.Equal(t, 1.0, ClampProgress(3))
+}
+
+func TestScopes(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	type answer struct{ ID int }
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("zhihu_answer").Order("create_at desc").
+			Scopes(Unread("jason", "zhihu", "answer", "zhihu_answer.id"),
+				OrderByLastRead("jason", "zhihu", "answer", "zhihu_answer.id", true, "create_at desc")).
+			Find(&[]answer{})
+	})
+	assert.Contains(t, sql, `NOT EXISTS (SELECT 1 FROM read_states rs WHERE rs.platform = 'zhihu' AND rs.kind = 'answer' AND rs.content_id = CAST(zhihu_answer.id AS TEXT) AND rs.user_id = 'jason' AND rs.read)`)
+	assert.Contains(t, sql, `ORDER BY (SELECT MAX(rs.last_read_at) FROM read_states rs WHERE rs.platform = 'zhihu' AND rs.kind = 'answer' AND rs.content_id = CAST(zhihu_answer.id AS TEXT) AND rs.user_id = 'jason') DESC NULLS LAST, create_at desc`)
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("zsxq_topic").Scopes(Unread("", "zsxq", "topic", "zsxq_topic.id")).Find(&[]answer{})
+	})
+	assert.NotContains(t, sql, "user_id")
+}
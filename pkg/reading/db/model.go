@@ -0,0 +1,30 @@
+package db
+
+import "time"
+
+// ReadThreshold 是自动标为已读的阅读进度：滚动到 95% 即视为读完，末尾的评论区、版权声明等不必读到底。
+const ReadThreshold = 0.95
+
+// ReadState 记录用户对一条归档内容的阅读状态，Platform + Kind + ContentID 与收藏的内容引用一致。
+// Progress 是最近一次上报的滚动进度（0..1），回看时可以下降；Read 一旦置位只能显式取消。
+type ReadState struct {
+	UserID    string `gorm:"type:text;primaryKey"`
+	Platform  string `gorm:"type:text;primaryKey"`
+	Kind      string `gorm:"type:text;primaryKey"`
+	ContentID string `gorm:"type:text;primaryKey"`
+
+	Progress float64 `gorm:"not null;default:0"`
+	Read     bool    `gorm:"not null;default:false;index"`
+	// ReadAt 是首次标为已读的时间，未读时为空
+	ReadAt *time.Time
+	// LastReadAt 是最近一次打开或上报进度的时间
+	LastReadAt time.Time `gorm:"index"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// ClampProgress 把上报的进度限定在 [0, 1]。
+func ClampProgress(progress float64) float64 {
+	return min(max(progress, 0), 1)
+}
@@ -0,0 +1,25 @@
+package db
+
+import "math/rand/v2"
+
+// PickPreferred 随机选出至多 n 个 id，用于随机推荐偏向未读内容：先从 preferred 中抽，不足 n 个再从 ids 其余部分补齐。
+// preferred 应是 ids 的子集。
+func PickPreferred[T comparable](ids, preferred []T, n int) []T {
+	preferredSet := make(map[T]struct{}, len(preferred))
+	for _, id := range preferred {
+		preferredSet[id] = struct{}{}
+	}
+	head := make([]T, 0, len(preferred))
+	tail := make([]T, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := preferredSet[id]; ok {
+			head = append(head, id)
+		} else {
+			tail = append(tail, id)
+		}
+	}
+	rand.Shuffle(len(head), func(i, j int) { head[i], head[j] = head[j], head[i] })
+	rand.Shuffle(len(tail), func(i, j int) { tail[i], tail[j] = tail[j], tail[i] })
+	picked := append(head, tail...)
+	return picked[:min(n, len(picked))]
+}
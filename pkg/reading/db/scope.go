@@ -0,0 +1,48 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 下面的 scope 供各平台内容查询按阅读状态过滤、排序，idColumn 是内容表中与 ContentID 对应的列，
+// 数值 id 会转成 text 比较。userID 为空表示不区分用户：任一用户读过即视为已读，
+// 用于没有登录上下文的场景（如定时生成的随机 RSS）。
+
+// readStateCond 返回与 idColumn 对应行匹配的 read_states 条件。
+func readStateCond(userID, platform, kind, idColumn string) (string, []any) {
+	cond := fmt.Sprintf("rs.platform = ? AND rs.kind = ? AND rs.content_id = CAST(%s AS TEXT)", idColumn)
+	args := []any{platform, kind}
+	if userID != "" {
+		cond += " AND rs.user_id = ?"
+		args = append(args, userID)
+	}
+	return cond, args
+}
+
+// Unread 只保留未读内容。
+func Unread(userID, platform, kind, idColumn string) func(*gorm.DB) *gorm.DB {
+	return func(stmt *gorm.DB) *gorm.DB {
+		cond, args := readStateCond(userID, platform, kind, idColumn)
+		return stmt.Where("NOT EXISTS (SELECT 1 FROM read_states rs WHERE "+cond+" AND rs.read)", args...)
+	}
+}
+
+// OrderByLastRead 按最近阅读时间排序，从未打开过的内容总排在最后，同一时间再按 then 排序。
+// 它整体替换查询已有的 ORDER BY：gorm 合并排序子句时会丢弃表达式形式的排序，无法与其他排序叠加。
+func OrderByLastRead(userID, platform, kind, idColumn string, desc bool, then string) func(*gorm.DB) *gorm.DB {
+	return func(stmt *gorm.DB) *gorm.DB {
+		cond, args := readStateCond(userID, platform, kind, idColumn)
+		direction := "ASC"
+		if desc {
+			direction = "DESC"
+		}
+		sql := "(SELECT MAX(rs.last_read_at) FROM read_states rs WHERE " + cond + ") " + direction + " NULLS LAST"
+		if then != "" {
+			sql += ", " + then
+		}
+		return stmt.Order(clause.OrderBy{Expression: clause.Expr{SQL: sql, Vars: args, WithoutParentheses: true}})
+	}
+}
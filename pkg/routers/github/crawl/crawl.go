@@ -1,6 +1,7 @@
 package crawl
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
@@ -11,7 +12,7 @@ import (
 	"go.uber.org/zap"
 )
 
-func CrawlRepo(user, repo, repoID, token string, parser parse.Parser, logger *zap.Logger) (err error) {
+func CrawlRepo(ctx context.Context, user, repo, repoID, token string, parser parse.Parser, logger *zap.Logger) (err error) {
 	crawlID := xid.New().String()
 	logger = logger.With(zap.String("crawl_id", crawlID))
 	logger.Info("Start to crawl github release", zap.String("user", user), zap.String("repo", repo))
@@ -23,7 +24,7 @@ func CrawlRepo(user, repo, repoID, token string, parser parse.Parser, logger *za
 		return nil
 	}
 
-	releases, err := request.GetRepoReleases(user, repo, token)
+	releases, err := request.GetRepoReleases(ctx, user, repo, token)
 	if err != nil {
 		if errors.Is(err, request.ErrNoRelease) {
 			logger.Warn("No release found for this repo")
@@ -1,6 +1,7 @@
 package crawl
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strings"
@@ -30,7 +31,7 @@ func TestCrawlRepoLeavesRequestErrorLoggingToCaller(t *testing.T) {
 	t.Cleanup(func() { http.DefaultClient = originalClient })
 
 	core, logs := observer.New(zap.DebugLevel)
-	err := CrawlRepo("owner", "repo", "repo-id", "token", nil, zap.New(core))
+	err := CrawlRepo(context.Background(), "owner", "repo", "repo-id", "token", nil, zap.New(core))
 	if err == nil {
 		t.Fatal("CrawlRepo() error = nil, want request error")
 	}
@@ -12,9 +12,11 @@ import (
 
 	"github.com/eli-yip/rss-zero/internal/ai"
 	"github.com/eli-yip/rss-zero/internal/log"
+	"github.com/eli-yip/rss-zero/internal/metrics"
 	"github.com/eli-yip/rss-zero/internal/notify"
 	"github.com/eli-yip/rss-zero/internal/redis"
 	"github.com/eli-yip/rss-zero/internal/rss"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	"github.com/eli-yip/rss-zero/pkg/cron"
 	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
@@ -22,25 +24,57 @@ import (
 	githubDB "github.com/eli-yip/rss-zero/pkg/routers/github/db"
 	githubParse "github.com/eli-yip/rss-zero/pkg/routers/github/parse"
 	githubRequest "github.com/eli-yip/rss-zero/pkg/routers/github/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
 )
 
-func Crawl(r redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, aiService ai.AI, notifier notify.Notifier) func(chan cron.CronJobInfo) {
-	return func(cronJobInfoChan chan cron.CronJobInfo) {
+func Crawl(taskID string, r redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, aiService ai.AI, notifier notify.Notifier) cron.CrawlFunc {
+	return func(trigger string, cronJobInfoChan chan cron.CronJobInfo) {
 		cronJobID := xid.New().String()
 		logger := log.DefaultLogger.With(zap.String("cron_job_id", cronJobID))
+		cronDBService := cronDB.NewDBService(db)
+		recorder, logger := cron.NewRunRecorder(cronJobID, cronDBService, logger)
 
-		cronJobInfoChan <- cron.CronJobInfo{Job: &cronDB.CronJob{ID: cronJobID}}
+		// Register the run for its history only; a failure here doesn't stop the crawl.
+		job, err := cronDBService.AddJob(cronJobID, taskID, trigger)
+		if err != nil {
+			logger.Error("Failed to add job", zap.Error(err))
+			job = &cronDB.CronJob{ID: cronJobID}
+		}
+		cronJobInfoChan <- cron.CronJobInfo{Job: job}
 
-		var err error
 		var errCount = 0
+		start := time.Now()
+		ctx, span := tracing.StartCronRun(subscriptionDB.PlatformGitHub, cronJobID)
+		ctx, release := cron.Stoppable(ctx, cronJobID)
 
 		defer func() {
-			if errCount > 0 {
-				notify.NoticeWithLogger(notifier, "Failed to crawl github content", cronJobID, logger)
+			outcome := metrics.CrawlSuccess
+			var spanErr error
+			stopCause := cron.StopCause(ctx)
+			release()
+			if stopCause != nil {
+				outcome, spanErr = metrics.CrawlStopped, stopCause
+			} else if errCount > 0 {
+				outcome = metrics.CrawlError
+				spanErr = fmt.Errorf("%d errors in github crawl", errCount)
 			}
 			if err := recover(); err != nil {
+				outcome = metrics.CrawlPanic
+				spanErr = fmt.Errorf("panic: %v", err)
 				logger.Error("github release crawl function panic", zap.Any("err", err))
 			}
+			status := cronDB.StatusFinished
+			if outcome == metrics.CrawlStopped {
+				status = cron.StoppedStatus(stopCause)
+			} else if spanErr != nil {
+				status = cronDB.StatusError
+			}
+			retrying := recorder.Finish(status, spanErr)
+			if stopCause == nil && errCount > 0 && !retrying {
+				notify.NoticeWithLogger(notifier, "Failed to crawl github content", cronJobID, logger)
+			}
+			metrics.ObserveCrawl(subscriptionDB.PlatformGitHub, outcome, time.Since(start))
+			tracing.End(span, spanErr)
 		}()
 
 		cookies, err := cookie.Bundle(cookieService, "github", notifier, logger)
@@ -51,6 +85,7 @@ func Crawl(r redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, aiServi
 		token := cookies["access_token"]
 
 		dbService := githubDB.NewDBService(db)
+		statDBService := recorder.StatDB(subscriptionDB.NewSubscriptionDBImpl(db))
 		parseService := githubParse.NewParseService(dbService, aiService)
 
 		var subs []githubDB.Sub
@@ -61,19 +96,36 @@ func Crawl(r redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, aiServi
 		}
 
 		for _, sub := range subs {
+			if cause := cron.StopCause(ctx); cause != nil {
+				logger.Info("Job stopped, leave the remaining subs", zap.Error(cause))
+				return
+			}
 			logger := logger.With(zap.String("sub_id", sub.ID))
 
 			repo, err := dbService.GetRepoByID(sub.RepoID)
 			if err != nil {
 				errCount++
 				logger.Error("Failed to get github repo", zap.Error(err))
+				subscriptionDB.Record(statDBService, subscriptionDB.PlatformGitHub, sub.ID, subscriptionDB.Run{Err: err}, logger)
 				continue
 			}
 			logger.Info("Get repo info successfully")
 
-			if err = crawl.CrawlRepo(repo.GithubUser, repo.Name, repo.ID, token, parseService, logger); err != nil {
+			counter := &countingParser{Parser: parseService}
+			countBefore, countErr := dbService.CountReleases(repo.ID)
+			err = crawl.CrawlRepo(ctx, repo.GithubUser, repo.Name, repo.ID, token, counter, logger)
+			if cause := cron.StopCause(ctx); err != nil && cause != nil {
+				logger.Info("Job stopped while crawling repo", zap.Error(cause))
+				return
+			}
+			run := subscriptionDB.Run{ItemsFound: counter.found, Err: err, ErrorClass: classifyCrawlErr(err)}
+			if countAfter, err := dbService.CountReleases(repo.ID); err == nil && countErr == nil {
+				run.ItemsNew = max(countAfter-countBefore, 0)
+			}
+			if err != nil {
 				errCount++
 				logger.Error("Failed to crawl github release", zap.Error(err))
+				subscriptionDB.Record(statDBService, subscriptionDB.PlatformGitHub, sub.ID, run, logger)
 				if errors.Is(err, githubRequest.ErrUnauthorized) && handleUnauthorizedToken(token, cookieService, notifier, logger, githubRequest.ValidateToken) {
 					return
 				}
@@ -81,13 +133,16 @@ func Crawl(r redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, aiServi
 			}
 			logger.Info("Crawl github release successfully")
 
-			if err = rss.WarmCache(r, fmt.Sprintf(redis.GitHubRSSPath, sub.ID), redis.RSSDefaultTTL,
+			if err = rss.WarmCache(ctx, r, fmt.Sprintf(redis.GitHubRSSPath, sub.ID), redis.RSSDefaultTTL,
 				func() (rss.FeedMeta, []rss.Item, error) { return rss.FetchGitHub(sub.ID, dbService, logger) }); err != nil {
 				errCount++
 				logger.Error("Failed to warm github rss cache", zap.Error(err))
+				run.Err = err
+				subscriptionDB.Record(statDBService, subscriptionDB.PlatformGitHub, sub.ID, run, logger)
 				continue
 			}
 			logger.Info("Warmed github rss cache successfully")
+			subscriptionDB.Record(statDBService, subscriptionDB.PlatformGitHub, sub.ID, run, logger)
 
 			// TODO: Use token pool to avoid rate limit
 			time.Sleep(5 * time.Second)
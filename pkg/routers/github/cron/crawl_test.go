@@ -2,6 +2,7 @@ package cron
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	githubRequest "github.com/eli-yip/rss-zero/pkg/routers/github/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
 )
 
 type fakeCookieStore struct {
@@ -110,3 +112,21 @@ func TestHandleUnauthorizedTokenPreservesTokenWhenValidationIsInconclusive(t *te
 		})
 	}
 }
+
+func TestClassifyCrawlErr(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&githubRequest.APIError{StatusCode: 401}, subscriptionDB.ErrorClassAuth},
+		{&githubRequest.APIError{StatusCode: 404}, subscriptionDB.ErrorClassGone},
+		{&githubRequest.APIError{StatusCode: 403, RateLimitRemaining: "0"}, subscriptionDB.ErrorClassRateLimit},
+		{&githubRequest.APIError{StatusCode: 502}, subscriptionDB.ErrorClassUpstream},
+		{errors.New("db down"), ""},
+	}
+	for _, tt := range tests {
+		if got := classifyCrawlErr(fmt.Errorf("failed to request github API: %w", tt.err)); got != tt.want {
+			t.Errorf("classifyCrawlErr(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package cron
+
+import (
+	"errors"
+	"net/http"
+
+	githubParse "github.com/eli-yip/rss-zero/pkg/routers/github/parse"
+	githubRequest "github.com/eli-yip/rss-zero/pkg/routers/github/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// countingParser 统计一次仓库抓取拿到的 release 数，写入订阅统计的 ItemsFound。
+type countingParser struct {
+	githubParse.Parser
+	found int
+}
+
+func (p *countingParser) ParseAndSaveRelease(repoID string, release githubRequest.Release) error {
+	p.found++
+	return p.Parser.ParseAndSaveRelease(repoID, release)
+}
+
+// classifyCrawlErr 按 GitHub API 的状态码归类抓取错误，返回空串时由 subscriptionDB.ClassifyError 推断。
+func classifyCrawlErr(err error) string {
+	if errors.Is(err, githubRequest.ErrUnauthorized) {
+		return subscriptionDB.ErrorClassAuth
+	}
+
+	var apiErr *githubRequest.APIError
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	switch {
+	case apiErr.StatusCode == http.StatusNotFound:
+		return subscriptionDB.ErrorClassGone
+	case apiErr.StatusCode == http.StatusTooManyRequests, apiErr.RateLimitRemaining == "0":
+		return subscriptionDB.ErrorClassRateLimit
+	default:
+		return subscriptionDB.ErrorClassUpstream
+	}
+}
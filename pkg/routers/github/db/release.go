@@ -23,6 +23,8 @@ type DBRelease interface {
 	GetRelease(id int) (*Release, error)
 	GetReleaseByTag(repoID, tag string) (*Release, error)
 	GetReleases(repoID string, preRelease bool, page, pageSize int) ([]Release, error)
+	// CountReleases 返回仓库已入库的 release 数（含 pre-release）
+	CountReleases(repoID string) (int, error)
 }
 
 func (s *DBService) SaveRelease(release *Release) error { return s.Save(release).Error }
@@ -55,3 +57,11 @@ func (s *DBService) GetReleases(repoID string, preRelease bool, page, pageSize i
 	}
 	return releases, nil
 }
+
+func (s *DBService) CountReleases(repoID string) (int, error) {
+	var count int64
+	if err := s.Model(&Release{}).Where("repo_id = ?", repoID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
@@ -21,6 +21,8 @@ type DBSub interface {
 	GetSubsIncludeDeleted() ([]Sub, error)
 	DeleteSub(id string) error
 	ActivateSub(id string) error
+	// PurgeSub 彻底删除订阅记录，repo 与已抓取的 release 保留
+	PurgeSub(id string) error
 }
 
 func (s *DBService) SaveSub(sub *Sub) error { return s.Save(sub).Error }
@@ -80,3 +82,7 @@ func (s *DBService) DeleteSub(id string) error {
 func (s *DBService) ActivateSub(id string) error {
 	return s.Unscoped().Model(&Sub{}).Where("id = ?", id).Update("deleted_at", nil).Error
 }
+
+func (s *DBService) PurgeSub(id string) error {
+	return s.Unscoped().Where("id = ?", id).Delete(&Sub{}).Error
+}
@@ -8,6 +8,10 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/eli-yip/rss-zero/internal/tracing"
 )
 
 const (
@@ -64,10 +68,19 @@ func (e *APIError) Unwrap() error {
 	return nil
 }
 
-func GetRepoReleases(user, repo, token string) (releases []Release, err error) {
+func GetRepoReleases(ctx context.Context, user, repo, token string) (releases []Release, err error) {
 	releases = make([]Release, 0)
 
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	ctx, span := tracing.StartChild(ctx, "github.request", attribute.String("github.repo", user+"/"+repo))
+	defer func() {
+		if errors.Is(err, ErrNoRelease) {
+			tracing.End(span, nil)
+			return
+		}
+		tracing.End(span, err)
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 	resp, err := get(ctx, fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", user, repo), token)
 	if err != nil {
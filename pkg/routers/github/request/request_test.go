@@ -55,7 +55,7 @@ func TestGetRepoReleasesIncludesTruncatedErrorResponseBody(t *testing.T) {
 	}
 	t.Cleanup(func() { http.DefaultClient = originalClient })
 
-	_, err := GetRepoReleases("owner", "repo", "token")
+	_, err := GetRepoReleases(context.Background(), "owner", "repo", "token")
 	if err == nil {
 		t.Fatal("GetRepoReleases() error = nil, want 503 error")
 	}
@@ -105,7 +105,7 @@ func TestGetRepoReleasesUnauthorizedIsClassifiedWithoutLeakingToken(t *testing.T
 	}
 	t.Cleanup(func() { http.DefaultClient = originalClient })
 
-	_, err := GetRepoReleases("owner", "repo", token)
+	_, err := GetRepoReleases(context.Background(), "owner", "repo", token)
 	if !errors.Is(err, ErrUnauthorized) {
 		t.Fatalf("GetRepoReleases() error = %v, want ErrUnauthorized", err)
 	}
@@ -1,6 +1,7 @@
 package macked
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strings"
@@ -112,7 +113,7 @@ func Crawl(redisService redis.Redis, db DB, logger *zap.Logger) (err error) {
 // preserving the prior "go empty when nothing is new" behaviour.
 func renderAndSaveRSS(redisService redis.Redis, posts []ParsedPost) error {
 	meta, items := feedFromPosts(posts)
-	if err := rss.WarmCache(redisService, redis.RssMackedPath, redis.RSSDefaultTTL,
+	if err := rss.WarmCache(context.Background(), redisService, redis.RssMackedPath, redis.RSSDefaultTTL,
 		func() (rss.FeedMeta, []rss.Item, error) { return meta, items, nil }); err != nil {
 		return fmt.Errorf("failed to warm macked rss cache: %w", err)
 	}
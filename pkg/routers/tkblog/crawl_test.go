@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 func testLogger() *zap.Logger { return zap.NewNop() }
@@ -45,6 +47,21 @@ func (d *fakeDB) GetPost(category, id string) (*Post, error) {
 	}
 	return nil, errors.New("record not found")
 }
+func (d *fakeDB) GetPosts(keys []PostKey) ([]Post, error) {
+	var posts []Post
+	for _, k := range keys {
+		if p, ok := d.posts[fakeKey(k.Category, k.ID)]; ok {
+			posts = append(posts, *p)
+		}
+	}
+	return posts, nil
+}
+func (d *fakeDB) FetchPostsWithDateRange(string, int, int, int, time.Time, time.Time, ...func(*gorm.DB) *gorm.DB) ([]Post, error) {
+	return nil, errors.New("not implemented")
+}
+func (d *fakeDB) CountPostsWithDateRange(string, time.Time, time.Time, ...func(*gorm.DB) *gorm.DB) (int, error) {
+	return 0, errors.New("not implemented")
+}
 
 // ---- stub requester ----
 
@@ -24,9 +24,17 @@ type Post struct {
 
 func (*Post) TableName() string { return "tombkeeper_blog_post" }
 
+// PostKey is a post's (category, id) primary key.
+type PostKey struct{ Category, ID string }
+
 type DB interface {
 	SavePost(p *Post) error
 	GetPost(category, id string) (*Post, error)
+	GetPosts(keys []PostKey) ([]Post, error)
+	// FetchPostsWithDateRange pages posts created in [startTime, endTime), newest
+	// first when order is 0. An empty category lists both blogs.
+	FetchPostsWithDateRange(category string, limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]Post, error)
+	CountPostsWithDateRange(category string, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error)
 }
 
 type DBService struct{ *gorm.DB }
@@ -43,3 +51,43 @@ func (d *DBService) GetPost(category, id string) (*Post, error) {
 	err := d.Where("category = ? AND id = ?", category, id).First(p).Error
 	return p, err
 }
+
+// GetPosts loads posts by primary key in one query; missing keys are skipped.
+func (d *DBService) GetPosts(keys []PostKey) ([]Post, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	pairs := make([][]any, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, []any{k.Category, k.ID})
+	}
+	var posts []Post
+	err := d.Where("(category, id) IN ?", pairs).Find(&posts).Error
+	return posts, err
+}
+
+func (d *DBService) postsWithDateRange(category string, startTime, endTime time.Time) *gorm.DB {
+	stmt := d.Model(&Post{}).Where("created_at >= ?", startTime).Where("created_at < ?", endTime)
+	if category != "" {
+		stmt = stmt.Where("category = ?", category)
+	}
+	return stmt
+}
+
+func (d *DBService) FetchPostsWithDateRange(category string, limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]Post, error) {
+	posts := make([]Post, 0, limit)
+	stmt := d.postsWithDateRange(category, startTime, endTime).Limit(limit).Offset(offset)
+	if order == 0 {
+		stmt = stmt.Order("created_at desc")
+	} else {
+		stmt = stmt.Order("created_at asc")
+	}
+	err := stmt.Scopes(scopes...).Find(&posts).Error
+	return posts, err
+}
+
+func (d *DBService) CountPostsWithDateRange(category string, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error) {
+	var count int64
+	err := d.postsWithDateRange(category, startTime, endTime).Scopes(scopes...).Count(&count).Error
+	return int(count), err
+}
@@ -2,6 +2,7 @@ package tombkeeper
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -127,7 +128,7 @@ func crawl(redisService redis.Redis, db DB, fileService file.File, logger *zap.L
 // old "render XML and Set" step), so a reader sees freshly crawled posts without
 // waiting for the cache to expire.
 func renderAndCacheRSS(redisService redis.Redis, db DB, logger *zap.Logger) error {
-	if err := rss.WarmCache(redisService, redis.RssTombkeeperTimelinePath, redis.RSSDefaultTTL,
+	if err := rss.WarmCache(context.Background(), redisService, redis.RssTombkeeperTimelinePath, redis.RSSDefaultTTL,
 		func() (rss.FeedMeta, []rss.Item, error) { return BuildFeed(db) }); err != nil {
 		return fmt.Errorf("warm tombkeeper rss cache: %w", err)
 	}
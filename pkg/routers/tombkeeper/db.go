@@ -73,6 +73,9 @@ type DB interface {
 	ImportStore
 	ContentReader
 	LatestTimelineEntries(n int) ([]Post, error)
+	// FetchTimelineWithDateRange 分页读取发布时间在 [startTime, endTime) 内的时间线博文，order 为 0 时倒序。
+	FetchTimelineWithDateRange(limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]Post, error)
+	CountTimelineWithDateRange(startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error)
 }
 
 type DBService struct{ *gorm.DB }
@@ -137,6 +140,28 @@ func (d *DBService) LatestTimelineEntries(n int) ([]Post, error) {
 	return posts, err
 }
 
+func (d *DBService) timelineWithDateRange(startTime, endTime time.Time) *gorm.DB {
+	return d.Model(&Post{}).Where("in_timeline = ?", true).Where("published_at >= ?", startTime).Where("published_at < ?", endTime)
+}
+
+func (d *DBService) FetchTimelineWithDateRange(limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]Post, error) {
+	posts := make([]Post, 0, limit)
+	stmt := d.timelineWithDateRange(startTime, endTime).Limit(limit).Offset(offset)
+	if order == 0 {
+		stmt = stmt.Order("published_at desc")
+	} else {
+		stmt = stmt.Order("published_at asc")
+	}
+	err := stmt.Scopes(scopes...).Find(&posts).Error
+	return posts, err
+}
+
+func (d *DBService) CountTimelineWithDateRange(startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error) {
+	var count int64
+	err := d.timelineWithDateRange(startTime, endTime).Scopes(scopes...).Count(&count).Error
+	return int(count), err
+}
+
 func (d *DBService) SaveImageAsset(asset *ImageAsset) error {
 	return d.Clauses(clause.OnConflict{
 		Columns: []clause.Column{{Name: "id"}},
@@ -50,14 +50,18 @@ func feedFromPosts(posts []Post, content ContentSnapshot, serverBaseURL string)
 		if err != nil {
 			return rss.FeedMeta{}, nil, fmt.Errorf("convert markdown to html: %w", err)
 		}
-		title := makeTitle(post.Text)
-		if title == "" {
-			title = id
-		}
 		items = append(items, rss.Item{
-			ID: id, Link: officialLink, Title: title, Author: post.ScreenName,
+			ID: id, Link: officialLink, Title: PostTitle(post), Author: post.ScreenName,
 			Time: post.PublishedAt, Summary: render.ExtractExcerpt(markdown), ContentHTML: contentHTML,
 		})
 	}
 	return meta, items, nil
 }
+
+// PostTitle 返回微博条目标题：正文前 10 个字，正文为空时回退为 id。
+func PostTitle(post Post) string {
+	if title := makeTitle(post.Text); title != "" {
+		return title
+	}
+	return strconv.FormatInt(post.ID, 10)
+}
@@ -62,7 +62,7 @@ func archiveImageAsset(req imageRequester, f file.File, store imageAssetStore, p
 	// SaveStream 负责关闭 resp.Body。
 	ext := extFromContentType(resp.Header.Get("Content-Type"))
 	objectKey := fmt.Sprintf("tombkeeper/%s.%s", picID, ext)
-	if err := f.SaveStream(objectKey, resp.Body, resp.ContentLength); err != nil {
+	if err := f.SaveStream(context.Background(), objectKey, resp.Body, resp.ContentLength); err != nil {
 		// 持久化失败结果，避免后续导入重复下载。
 		logger.Warn("oss save failed, abandoning image", zap.String("pic_id", picID), zap.Error(err))
 		if saveErr := store.SaveImageAsset(&ImageAsset{
@@ -96,7 +96,7 @@ func RedownloadObject(req Requester, f file.File, objectKey, picID string, logge
 		return "", fmt.Errorf("download pic %q: %w", picID, err)
 	}
 	// SaveStream 负责关闭 resp.Body。
-	if err = f.SaveStream(objectKey, resp.Body, resp.ContentLength); err != nil {
+	if err = f.SaveStream(context.Background(), objectKey, resp.Body, resp.ContentLength); err != nil {
 		return "", fmt.Errorf("save pic %q to %q: %w", picID, objectKey, err)
 	}
 	logger.Info("redownloaded image",
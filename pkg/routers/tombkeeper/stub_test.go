@@ -6,11 +6,14 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 func testLogger() *zap.Logger { return zap.NewNop() }
@@ -26,7 +29,7 @@ func newFakeFile() *fakeFile {
 	return &fakeFile{saved: map[string][]byte{}, domain: "https://oss.test/rss"}
 }
 
-func (f *fakeFile) SaveStream(path string, rc io.ReadCloser, _ int64) error {
+func (f *fakeFile) SaveStream(_ context.Context, path string, rc io.ReadCloser, _ int64) error {
 	defer rc.Close()
 	b, err := io.ReadAll(rc)
 	if err != nil {
@@ -35,11 +38,15 @@ func (f *fakeFile) SaveStream(path string, rc io.ReadCloser, _ int64) error {
 	f.saved[path] = b
 	return nil
 }
-func (f *fakeFile) GetStream(string) (io.ReadCloser, error) { return nil, errors.New("unsupported") }
-func (f *fakeFile) AssetsDomain() string                    { return f.domain }
-func (f *fakeFile) Delete(string) error                     { return nil }
-func (f *fakeFile) Exist(string) (bool, error)              { return false, nil }
-func (f *fakeFile) Size(path string) (int64, error)         { return int64(len(f.saved[path])), nil }
+func (f *fakeFile) GetStream(context.Context, string) (io.ReadCloser, error) {
+	return nil, errors.New("unsupported")
+}
+func (f *fakeFile) AssetsDomain() string                        { return f.domain }
+func (f *fakeFile) Delete(context.Context, string) error        { return nil }
+func (f *fakeFile) Exist(context.Context, string) (bool, error) { return false, nil }
+func (f *fakeFile) Size(_ context.Context, path string) (int64, error) {
+	return int64(len(f.saved[path])), nil
+}
 
 // ---- fake requester ----
 
@@ -155,6 +162,24 @@ func (d *fakeDB) LatestTimelineEntries(n int) ([]Post, error) {
 	}
 	return out, nil
 }
+func (d *fakeDB) FetchTimelineWithDateRange(limit, offset, order int, startTime, endTime time.Time, _ ...func(*gorm.DB) *gorm.DB) ([]Post, error) {
+	entries, _ := d.LatestTimelineEntries(len(d.posts))
+	out := make([]Post, 0, len(entries))
+	for _, p := range entries {
+		if !p.PublishedAt.Before(startTime) && p.PublishedAt.Before(endTime) {
+			out = append(out, p)
+		}
+	}
+	if order != 0 {
+		slices.Reverse(out)
+	}
+	out = out[min(offset, len(out)):]
+	return out[:min(limit, len(out))], nil
+}
+func (d *fakeDB) CountTimelineWithDateRange(startTime, endTime time.Time, _ ...func(*gorm.DB) *gorm.DB) (int, error) {
+	posts, _ := d.FetchTimelineWithDateRange(len(d.posts), 0, 0, startTime, endTime)
+	return len(posts), nil
+}
 func (d *fakeDB) SaveImageAsset(asset *ImageAsset) error {
 	if d.imageSaveErr {
 		return errors.New("save image failed")
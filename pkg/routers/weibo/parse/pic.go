@@ -2,6 +2,7 @@
 package parse
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -33,7 +34,7 @@ func (ps *ParseService) savePic(picURL, objectKey string) (err error) {
 		return fmt.Errorf("failed to get pic stream: %w", err)
 	}
 
-	if err = ps.fileService.SaveStream(objectKey, resp.Body, resp.ContentLength); err != nil {
+	if err = ps.fileService.SaveStream(context.Background(), objectKey, resp.Body, resp.ContentLength); err != nil {
 		return fmt.Errorf("failed to save image stream to file service: %w", err)
 	}
 
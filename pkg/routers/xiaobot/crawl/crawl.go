@@ -1,6 +1,7 @@
 package crawl
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -14,7 +15,7 @@ import (
 
 const limit = 20
 
-func Crawl(paperID string, request request.Requester, parser parse.Parser,
+func Crawl(ctx context.Context, paperID string, request request.Requester, parser parse.Parser,
 	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (err error) {
 	crawlID := xid.New().String()
 	logger = logger.With(zap.String("crawl_id", crawlID))
@@ -24,7 +25,7 @@ func Crawl(paperID string, request request.Requester, parser parse.Parser,
 	next := generateNextURL(paperID, offset)
 
 	for {
-		data, err := request.Limit(next)
+		data, err := request.Limit(ctx, next)
 		if err != nil {
 			logger.Error("Failed to request xiaobot api", zap.Error(err))
 			return err
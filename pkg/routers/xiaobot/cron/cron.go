@@ -1,6 +1,7 @@
 package crawl
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
@@ -11,11 +12,14 @@ import (
 
 	"github.com/rs/xid"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/eli-yip/rss-zero/internal/log"
+	"github.com/eli-yip/rss-zero/internal/metrics"
 	"github.com/eli-yip/rss-zero/internal/notify"
 	"github.com/eli-yip/rss-zero/internal/redis"
 	"github.com/eli-yip/rss-zero/internal/rss"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	"github.com/eli-yip/rss-zero/pkg/cron"
 	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
@@ -23,6 +27,7 @@ import (
 	xiaobotDB "github.com/eli-yip/rss-zero/pkg/routers/xiaobot/db"
 	"github.com/eli-yip/rss-zero/pkg/routers/xiaobot/parse"
 	"github.com/eli-yip/rss-zero/pkg/routers/xiaobot/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
 )
 
 type Filter struct {
@@ -30,16 +35,19 @@ type Filter struct {
 	Exclude []string
 }
 
-func BuildCronCrawlFunc(r redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, notifier notify.Notifier, fConfig *Filter) func(chan cron.CronJobInfo) {
-	return func(cronJobInfoChan chan cron.CronJobInfo) {
+func BuildCronCrawlFunc(taskID string, r redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, notifier notify.Notifier, fConfig *Filter) cron.CrawlFunc {
+	return func(trigger string, cronJobInfoChan chan cron.CronJobInfo) {
 		cronJobID := xid.New().String()
 		logger := log.DefaultLogger.With(zap.String("cron_job_id", cronJobID))
-		jobCtx := newXiaobotCrawlJobContext(cronJobID, notifier, logger)
-		jobCtx.start(cronJobInfoChan)
+		cronDBService := cronDB.NewDBService(db)
+		recorder, logger := cron.NewRunRecorder(cronJobID, cronDBService, logger)
+		jobCtx := newXiaobotCrawlJobContext(cronJobID, cronDBService, recorder, notifier, logger)
+		jobCtx.start(taskID, trigger, cronJobInfoChan)
 		defer jobCtx.finish()
 
 		cookies, err := cookie.Bundle(cookieService, "xiaobot", notifier, logger)
 		if err != nil {
+			jobCtx.err = err
 			return
 		}
 		token := cookies["token"]
@@ -47,19 +55,40 @@ func BuildCronCrawlFunc(r redis.Redis, cookieService cookie.CookieIface, db *gor
 		xiaobotDBService, xiaobotRequestService, xiaobotParser, err := initXiaobotServices(db, logger, cookieService, token)
 		if err != nil {
 			logger.Error("Failed to init xiaobot crawl services", zap.Error(err))
+			jobCtx.err = err
 			return
 		}
 		logger.Info("Init xiaobot crawl services successfully")
 
 		papers, err := loadPapersToCrawl(xiaobotDBService, fConfig, logger)
 		if err != nil {
+			jobCtx.err = err
 			return
 		}
 
+		statDBService := recorder.StatDB(subscriptionDB.NewSubscriptionDBImpl(db))
 		for _, paper := range papers {
-			if err := crawlPaper(paper, xiaobotDBService, xiaobotRequestService, xiaobotParser, r, logger); err != nil {
+			if cause := cron.StopCause(jobCtx.runCtx); cause != nil {
+				logger.Info("Job stopped, leave the remaining papers", zap.Error(cause))
+				return
+			}
+
+			counter := &countingParser{Parser: xiaobotParser}
+			countBefore, countErr := xiaobotDBService.CountPost(paper.ID)
+			err := crawlPaper(jobCtx.runCtx, paper, xiaobotDBService, xiaobotRequestService, counter, r, logger)
+			if cause := cron.StopCause(jobCtx.runCtx); err != nil && cause != nil {
+				logger.Info("Job stopped while crawling paper", zap.String("paper_id", paper.ID), zap.Error(cause))
+				return
+			}
+			run := subscriptionDB.Run{ItemsFound: counter.found, Err: err, ErrorClass: classifyCrawlErr(err)}
+			if countAfter, err := xiaobotDBService.CountPost(paper.ID); err == nil && countErr == nil {
+				run.ItemsNew = max(countAfter-countBefore, 0)
+			}
+			subscriptionDB.Record(statDBService, subscriptionDB.PlatformXiaobot, paper.ID, run, logger)
+			if err != nil {
 				if errors.Is(err, request.ErrNeedLogin) {
 					cookie.Invalidate(cookieService, cookie.CookieTypeXiaobotAccessToken, notifier, logger)
+					jobCtx.err = err
 					return
 				}
 				jobCtx.errCount++
@@ -73,29 +102,67 @@ func BuildCronCrawlFunc(r redis.Redis, cookieService cookie.CookieIface, db *gor
 // recovery and failure notification), keeping it separate from the per-paper
 // crawl business logic in BuildCronCrawlFunc.
 type xiaobotCrawlJobContext struct {
-	cronJobID string
-	notifier  notify.Notifier
-	logger    *zap.Logger
-	errCount  int
+	cronJobID     string
+	cronDBService cronDB.DB
+	recorder      *cron.RunRecorder
+	notifier      notify.Notifier
+	logger        *zap.Logger
+	errCount      int
+	// err 是中断整次抓取的错误，只计入抓取指标；需要通知的错误已在出错处通知过
+	err       error
+	startedAt time.Time
+	// runCtx 携带本次运行的根 span，向下传给抓取与请求服务；cancel 时被取消
+	runCtx  context.Context
+	span    trace.Span
+	release func()
 }
 
-func newXiaobotCrawlJobContext(cronJobID string, notifier notify.Notifier, logger *zap.Logger) *xiaobotCrawlJobContext {
-	return &xiaobotCrawlJobContext{cronJobID: cronJobID, notifier: notifier, logger: logger}
+func newXiaobotCrawlJobContext(cronJobID string, cronDBService cronDB.DB, recorder *cron.RunRecorder, notifier notify.Notifier, logger *zap.Logger) *xiaobotCrawlJobContext {
+	runCtx, span := tracing.StartCronRun(subscriptionDB.PlatformXiaobot, cronJobID)
+	runCtx, release := cron.Stoppable(runCtx, cronJobID)
+	return &xiaobotCrawlJobContext{cronJobID: cronJobID, cronDBService: cronDBService, recorder: recorder, notifier: notifier, logger: logger,
+		startedAt: time.Now(), runCtx: runCtx, span: span, release: release}
 }
 
-func (ctx *xiaobotCrawlJobContext) start(cronJobInfoChan chan cron.CronJobInfo) {
-	cronJobInfoChan <- cron.CronJobInfo{Job: &cronDB.CronJob{ID: ctx.cronJobID}}
+// start 登记本次运行以留下运行历史。xiaobot 不做续跑与互斥检查，登记失败也照常抓取。
+func (ctx *xiaobotCrawlJobContext) start(taskID, trigger string, cronJobInfoChan chan cron.CronJobInfo) {
+	job, err := ctx.cronDBService.AddJob(ctx.cronJobID, taskID, trigger)
+	if err != nil {
+		ctx.logger.Error("Failed to add job", zap.Error(err))
+		job = &cronDB.CronJob{ID: ctx.cronJobID}
+	}
+	cronJobInfoChan <- cron.CronJobInfo{Job: job}
 }
 
-// finish notifies on accumulated errors and recovers from a panic. It is meant
-// to be deferred; the crawl loop only bumps ctx.errCount.
+// finish recovers from a panic, records the status and notifies on accumulated
+// errors unless a retry was scheduled. It is meant to be deferred; the crawl
+// loop only bumps ctx.errCount.
 func (ctx *xiaobotCrawlJobContext) finish() {
-	if ctx.errCount > 0 {
-		notify.NoticeWithLogger(ctx.notifier, "Failed to crawl xiaobot content", ctx.cronJobID, ctx.logger)
+	outcome, spanErr := metrics.CrawlSuccess, ctx.err
+	if ctx.errCount > 0 && spanErr == nil {
+		spanErr = fmt.Errorf("%d papers failed", ctx.errCount)
+	}
+	if spanErr != nil {
+		outcome = metrics.CrawlError
 	}
 	if err := recover(); err != nil {
+		outcome, spanErr = metrics.CrawlPanic, fmt.Errorf("panic: %v", err)
 		ctx.logger.Error("Xiaobot crawl function panic", zap.Any("err", err))
 	}
+	status := cronDB.StatusFinished
+	if spanErr != nil {
+		status = cronDB.StatusError
+	}
+	if cause := cron.StopCause(ctx.runCtx); cause != nil && outcome != metrics.CrawlPanic {
+		outcome, status, spanErr = metrics.CrawlStopped, cron.StoppedStatus(cause), cause
+	}
+	ctx.release()
+	// 已安排重试时由最后一次尝试通知
+	if retrying := ctx.recorder.Finish(status, spanErr); ctx.errCount > 0 && !retrying {
+		notify.NoticeWithLogger(ctx.notifier, "Failed to crawl xiaobot content", ctx.cronJobID, ctx.logger)
+	}
+	metrics.ObserveCrawl(subscriptionDB.PlatformXiaobot, outcome, time.Since(ctx.startedAt))
+	tracing.End(ctx.span, spanErr)
 }
 
 // loadPapersToCrawl loads the paper subs from db and applies the exclude filter.
@@ -122,7 +189,7 @@ func loadPapersToCrawl(dbService xiaobotDB.DB, fConfig *Filter, logger *zap.Logg
 
 // crawlPaper crawls a single paper, renders its rss and caches it. Errors are
 // logged here so the caller only needs to count them.
-func crawlPaper(paper xiaobotDB.Paper, dbService xiaobotDB.DB, requestService request.Requester, parser parse.Parser, r redis.Redis, logger *zap.Logger) error {
+func crawlPaper(ctx context.Context, paper xiaobotDB.Paper, dbService xiaobotDB.DB, requestService request.Requester, parser parse.Parser, r redis.Redis, logger *zap.Logger) error {
 	logger = logger.With(zap.String("paper_id", paper.ID))
 	logger.Info("Start to crawl xiaobot paper")
 
@@ -131,13 +198,13 @@ func crawlPaper(paper xiaobotDB.Paper, dbService xiaobotDB.DB, requestService re
 		return err
 	}
 
-	if err = crawl.Crawl(paper.ID, requestService, parser, latestPostTimeInDB, 0, true, logger); err != nil {
+	if err = crawl.Crawl(ctx, paper.ID, requestService, parser, latestPostTimeInDB, 0, true, logger); err != nil {
 		logger.Error("Failed to crawl xiaobot paper", zap.Error(err))
 		return err
 	}
 	logger.Info("Crawl xiaobot paper successfully")
 
-	if err = rss.WarmCache(r, fmt.Sprintf(redis.XiaobotRSSPath, paper.ID), redis.RSSDefaultTTL,
+	if err = rss.WarmCache(ctx, r, fmt.Sprintf(redis.XiaobotRSSPath, paper.ID), redis.RSSDefaultTTL,
 		func() (rss.FeedMeta, []rss.Item, error) { return rss.FetchXiaobot(paper.ID, dbService, logger) }); err != nil {
 		logger.Error("Failed to warm xiaobot rss cache", zap.Error(err))
 		return err
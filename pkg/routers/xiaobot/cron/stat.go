@@ -0,0 +1,36 @@
+package crawl
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/routers/xiaobot/parse"
+	"github.com/eli-yip/rss-zero/pkg/routers/xiaobot/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// countingParser 统计一次专栏抓取解析到的文章数，写入订阅统计的 ItemsFound。
+type countingParser struct {
+	parse.Parser
+	found int
+}
+
+func (p *countingParser) ParsePaperPost(data []byte, paperID string, logger *zap.Logger) (string, error) {
+	p.found++
+	return p.Parser.ParsePaperPost(data, paperID, logger)
+}
+
+// classifyCrawlErr 归类小报童特有的抓取错误，返回空串时由 subscriptionDB.ClassifyError 推断。
+func classifyCrawlErr(err error) string {
+	switch {
+	case errors.Is(err, request.ErrNeedLogin):
+		return subscriptionDB.ErrorClassAuth
+	case errors.Is(err, request.ErrBadResponse), errors.Is(err, request.ErrInvalidSign):
+		return subscriptionDB.ErrorClassUpstream
+	case errors.Is(err, request.ErrMaxRetry):
+		return subscriptionDB.ErrorClassNetwork
+	default:
+		return ""
+	}
+}
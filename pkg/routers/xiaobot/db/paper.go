@@ -83,5 +83,5 @@ func (d *DBService) DeletePaper(id string) (err error) {
 }
 
 func (d *DBService) ActivatePaper(id string) (err error) {
-	return d.Model(&Paper{}).Where("id = ?", id).Update("deleted_at", nil).Error
+	return d.Unscoped().Model(&Paper{}).Where("id = ?", id).Update("deleted_at", nil).Error
 }
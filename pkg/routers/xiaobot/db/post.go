@@ -28,6 +28,10 @@ type DBPost interface {
 	FetchNPost(n int, opt Option) (ps []Post, err error)
 	// FetchNPostBefore get n post of a paper before a time
 	FetchNPostBefore(n int, paperID string, t time.Time) ([]Post, error)
+	// GetPostsByIDs get posts by ids, missing ids are skipped
+	GetPostsByIDs(ids []string) ([]Post, error)
+	// CountPost count posts of a paper
+	CountPost(paperID string) (int, error)
 }
 
 func (d *DBService) SavePost(post *Post) (err error) { return d.Save(post).Error }
@@ -75,3 +79,20 @@ func (d *DBService) FetchNPostBefore(n int, paperID string, t time.Time) ([]Post
 	err := d.Where("paper_id = ? AND create_at < ?", paperID, t).Order("create_at desc").Limit(n).Find(&posts).Error
 	return posts, err
 }
+
+func (d *DBService) GetPostsByIDs(ids []string) ([]Post, error) {
+	posts := make([]Post, 0, len(ids))
+	if len(ids) == 0 {
+		return posts, nil
+	}
+	err := d.Where("id IN ?", ids).Find(&posts).Error
+	return posts, err
+}
+
+func (d *DBService) CountPost(paperID string) (int, error) {
+	var count int64
+	if err := d.Model(&Post{}).Where("paper_id = ?", paperID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
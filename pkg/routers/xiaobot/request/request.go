@@ -1,6 +1,7 @@
 package request
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,8 +10,11 @@ import (
 	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"github.com/eli-yip/rss-zero/internal/tracing"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	"github.com/eli-yip/rss-zero/pkg/routers/xiaobot/encrypt"
 )
@@ -29,7 +33,7 @@ func init() {
 type Requester interface {
 	// Limit requests to the given url with limiter and returns data,
 	// and it will validate the response json data
-	Limit(string) ([]byte, error)
+	Limit(context.Context, string) ([]byte, error)
 }
 
 const userAgent = `Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36`
@@ -65,16 +69,27 @@ func NewRequestService(cookieService cookie.CookieIface, token string, logger *z
 	return s
 }
 
-func (r *RequestService) Limit(u string) (data []byte, err error) {
+func (r *RequestService) Limit(ctx context.Context, u string) (data []byte, err error) {
 	logger := r.logger.With(zap.String("url", u))
 	logger.Info("Start to request url with limiter")
 
+	ctx, span := tracing.StartChild(ctx, "xiaobot.request", attribute.String("url.full", u))
+	defer func() { tracing.End(span, err) }()
+
 	for i := 0; i < r.maxRetry; i++ {
+		if i > 0 {
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", i)))
+		}
 		logger := logger.With(zap.Int("index", i))
-		<-r.limiter
+		select {
+		case <-r.limiter:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		span.AddEvent("limiter acquired")
 
 		var req *http.Request
-		if req, err = r.setAPIReq(u); err != nil {
+		if req, err = r.setAPIReq(ctx, u); err != nil {
 			logger.Error("Failed to create a request", zap.Error(err))
 			continue
 		}
@@ -196,8 +211,8 @@ func (r *RequestService) getErrorMessage(bytes []byte) (message string, err erro
 //		Accept-Encoding:gzip, deflate, br (remove this because we do not want to handle gzip)
 //		Accept-Language:zh-CN,zh;q=0.9,en-US;q=0.8,en;q=0.7
 //	 ```
-func (r *RequestService) setAPIReq(u string) (req *http.Request, err error) {
-	req, err = http.NewRequest("GET", u, nil)
+func (r *RequestService) setAPIReq(ctx context.Context, u string) (req *http.Request, err error) {
+	req, err = http.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package crawl
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -30,12 +31,13 @@ func GenerateAnswerApiURL(user string, offset int) string {
 // offset: number of answers have been crawled
 // set it to 0 if you want to crawl answers from the beginning
 // oneTime: if true, only crawl one time
-func CrawlAnswer(user string, rs request.Requester, parser parse.Parser,
-	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (err error) {
+// latest: time of the newest item crawled, zero if the list is empty
+func CrawlAnswer(ctx context.Context, user string, rs request.Requester, parser parse.Parser,
+	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (latest time.Time, err error) {
 	crawlID := xid.New().String()
 	logger = logger.With(zap.String("crawl_id", crawlID))
 
-	return crawlListPages(user, rs, targetTime, offset, oneTime, logger, listCrawlOptions[apiModels.Answer]{
+	return crawlListPages(ctx, user, rs, targetTime, offset, oneTime, logger, listCrawlOptions[apiModels.Answer]{
 		contentType:        "answer",
 		startMessage:       "Start to crawl zhihu answers",
 		reachTargetMessage: "Reach target time, break",
@@ -58,3 +60,9 @@ func CrawlAnswer(user string, rs request.Requester, parser parse.Parser,
 		generateURL: GenerateAnswerApiURL,
 	})
 }
+
+// GenerateSingleAnswerApiURL 返回单条回答的 API 地址，响应与列表里的单条结构相同。
+func GenerateSingleAnswerApiURL(id int) string {
+	const params = `content,created_time,updated_time,question,author,answer_type`
+	return fmt.Sprintf("https://www.zhihu.com/api/v4/answers/%d?include=%s", id, url.QueryEscape(params))
+}
@@ -1,6 +1,7 @@
 package crawl
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -30,12 +31,13 @@ func GenerateArticleApiURL(user string, offset int) string {
 // offset: number of articles have been crawled
 // set it to 0 if you want to crawl articles from the beginning
 // oneTime: if true, only crawl one time
-func CrawlArticle(user string, request request.Requester, parser parse.Parser,
-	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (err error) {
+// latest: time of the newest item crawled, zero if the list is empty
+func CrawlArticle(ctx context.Context, user string, request request.Requester, parser parse.Parser,
+	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (latest time.Time, err error) {
 	crawlID := xid.New().String()
 	logger = logger.With(zap.String("crawl_id", crawlID))
 
-	return crawlListPages(user, request, targetTime, offset, oneTime, logger, listCrawlOptions[apiModels.Article]{
+	return crawlListPages(ctx, user, request, targetTime, offset, oneTime, logger, listCrawlOptions[apiModels.Article]{
 		contentType:        "article",
 		startMessage:       "Start to crawl zhihu articles",
 		reachTargetMessage: "Reach target time, break",
@@ -69,3 +71,8 @@ func CrawlArticle(user string, request request.Requester, parser parse.Parser,
 		generateURL: GenerateArticleApiURL,
 	})
 }
+
+// GenerateSingleArticleApiURL 返回单篇文章的 API 地址，响应带完整正文。
+func GenerateSingleArticleApiURL(id int) string {
+	return fmt.Sprintf("https://www.zhihu.com/api/v4/articles/%d", id)
+}
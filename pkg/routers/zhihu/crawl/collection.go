@@ -0,0 +1,38 @@
+package crawl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+)
+
+// GenerateCollectionApiURL 返回收藏夹详情的 API 地址，私密收藏夹对其他人不可达。
+func GenerateCollectionApiURL(collectionID string) string {
+	return fmt.Sprintf("https://www.zhihu.com/api/v4/collections/%s", collectionID)
+}
+
+// GenerateCollectionItemApiURL 返回收藏夹内容列表的 API 地址，按收藏时间从新到旧。
+func GenerateCollectionItemApiURL(collectionID string, offset int) string {
+	return fmt.Sprintf("https://www.zhihu.com/api/v4/collections/%s/items?offset=%d&limit=20", collectionID, offset)
+}
+
+// CrawlCollection crawl answers and articles collected into a public zhihu collection.
+// collectionID: collection id
+// targetTime: the time to stop crawling, compared with the collected time
+// offset: number of items have been crawled
+// oneTime: if true, only crawl one time
+// latest: time of the newest item crawled, zero if the list is empty
+func CrawlCollection(ctx context.Context, collectionID string, rs request.Requester, parser parse.Parser,
+	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (latest time.Time, err error) {
+	crawlID := xid.New().String()
+	logger = logger.With(zap.String("crawl_id", crawlID), zap.String("collection_id", collectionID))
+
+	return crawlListContents(ctx, common.ZhihuCollection, collectionID, rs, parser, targetTime, offset, oneTime, logger, GenerateCollectionItemApiURL)
+}
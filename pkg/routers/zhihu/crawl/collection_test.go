@@ -0,0 +1,50 @@
+package crawl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
+	apiModels "github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse/api_models"
+)
+
+type listParser struct {
+	parse.Parser
+	saved []string
+}
+
+func (p *listParser) ParseListItem(t common.ZhihuContentType, listID string, item apiModels.ListItem, _ []byte, _ *zap.Logger) error {
+	p.saved = append(p.saved, t.Slug()+"/"+listID+"/"+item.Type)
+	return nil
+}
+
+func TestCrawlCollection(t *testing.T) {
+	rs := &questionRequester{resp: []byte(`{
+		"paging": {"is_end": true, "totals": 3},
+		"data": [
+			{"created": 1750000300, "content": {"type": "pin", "id": "1"}},
+			{"created": 1750000200, "content": {"type": "article", "id": 3003}},
+			{"created": 1750000100, "content": {"type": "answer", "id": 2002}}
+		]
+	}`)}
+	// ParseListPage 不读库，占位的 DB 不会被调用
+	pageParser, err := parse.NewParseService(parse.WithDB(struct{ zhihuDB.DB }{}))
+	require.NoError(t, err)
+	parser := &listParser{Parser: pageParser}
+
+	latest, err := CrawlCollection(context.Background(), "9527", rs, parser, time.Unix(0, 0), 0, true, zap.NewNop())
+	require.NoError(t, err)
+	// 水位取整页最新的收藏时间，跳过的想法也算已处理
+	assert.Equal(t, time.Unix(1750000300, 0), latest)
+
+	assert.Equal(t, []string{GenerateCollectionItemApiURL("9527", 0)}, rs.urls)
+	// 从旧到新入库，想法跳过
+	assert.Equal(t, []string{"collection/9527/answer", "collection/9527/article"}, parser.saved)
+}
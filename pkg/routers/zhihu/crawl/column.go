@@ -0,0 +1,45 @@
+package crawl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+)
+
+// GenerateColumnApiURL 返回专栏详情的 API 地址，用于建订阅时确认专栏存在并取标题。
+func GenerateColumnApiURL(columnID string) string {
+	return fmt.Sprintf("https://www.zhihu.com/api/v4/columns/%s", columnID)
+}
+
+// GenerateColumnItemApiURL 返回专栏内容列表的 API 地址，按发布时间从新到旧，回答与文章都带正文。
+func GenerateColumnItemApiURL(columnID string, offset int) string {
+	const (
+		urlLayout = "https://www.zhihu.com/api/v4/columns/%s/items"
+		params    = `data[*].admin_closed_comment,comment_count,suggest_edit,can_comment,voteup_count,voting,review_info,is_labeled,label_info,created,updated,created_time,updated_time,question,content`
+	)
+	escaped := url.QueryEscape(params)
+	next := fmt.Sprintf(urlLayout, columnID)
+	return fmt.Sprintf("%s?include=%s&%s", next, escaped, fmt.Sprintf("offset=%d&limit=20", offset))
+}
+
+// CrawlColumn crawl answers and articles published into a zhihu column, whoever wrote them.
+// columnID: column url token, e.g. c_1234567890
+// targetTime: the time to stop crawling
+// offset: number of items have been crawled
+// oneTime: if true, only crawl one time
+// latest: time of the newest item crawled, zero if the list is empty
+func CrawlColumn(ctx context.Context, columnID string, rs request.Requester, parser parse.Parser,
+	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (latest time.Time, err error) {
+	crawlID := xid.New().String()
+	logger = logger.With(zap.String("crawl_id", crawlID), zap.String("column_id", columnID))
+
+	return crawlListContents(ctx, common.ZhihuColumn, columnID, rs, parser, targetTime, offset, oneTime, logger, GenerateColumnItemApiURL)
+}
@@ -7,6 +7,8 @@ import (
 	"slices"
 	"time"
 
+	"github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse/api_models"
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
 	"go.uber.org/zap"
@@ -33,7 +35,9 @@ type listCrawlOptions[T any] struct {
 	generateURL        func(string, int) string
 }
 
-func crawlListPages[T any](user string, rs request.Requester, targetTime time.Time, offset int, oneTime bool, logger *zap.Logger, opts listCrawlOptions[T]) error {
+// crawlListPages 从新到旧翻页抓取，返回本次处理过的条目里最新的时间，列表为空时返回零值。
+// 出错时返回的时间无意义，调用方不应据此推进水位。
+func crawlListPages[T any](ctx context.Context, user string, rs request.Requester, targetTime time.Time, offset int, oneTime bool, logger *zap.Logger, opts listCrawlOptions[T]) (latest time.Time, err error) {
 	logger.Info(opts.startMessage, zap.String("user_url_token", user))
 
 	next := opts.generateURL(user, offset)
@@ -41,17 +45,17 @@ func crawlListPages[T any](user string, rs request.Requester, targetTime time.Ti
 	index := 0
 	lastTotalCount := 0
 	for {
-		bytes, err := rs.LimitRaw(context.Background(), next, logger)
+		bytes, err := rs.LimitRaw(ctx, next, logger)
 		if err != nil {
 			logger.Error("Failed to request zhihu api", zap.Error(err), zap.String("url", next))
-			return fmt.Errorf("failed to request zhihu api: %w", err)
+			return time.Time{}, fmt.Errorf("failed to request zhihu api: %w", err)
 		}
 		logger.Info("Request zhihu api successfully", zap.String("url", next))
 
 		paging, excerpts, rawItems, err := opts.parseList(bytes, index, logger)
 		if err != nil {
 			logger.Error(fmt.Sprintf("Failed to parse %s list", opts.contentType), zap.Error(err), zap.Int("index", index), zap.String("url", next))
-			return fmt.Errorf("failed to parse %s list: %w", opts.contentType, err)
+			return time.Time{}, fmt.Errorf("failed to parse %s list: %w", opts.contentType, err)
 		}
 
 		parseListFields := []zap.Field{zap.Int("index", index)}
@@ -62,7 +66,7 @@ func crawlListPages[T any](user string, rs request.Requester, targetTime time.Ti
 
 		if index != 0 && paging.Totals != lastTotalCount {
 			logger.Error(opts.foundNewMessage, zap.Int(opts.foundNewCountField, paging.Totals-lastTotalCount))
-			return fmt.Errorf("%s", opts.foundNewError)
+			return time.Time{}, fmt.Errorf("%s", opts.foundNewError)
 		}
 		lastTotalCount = paging.Totals
 
@@ -77,14 +81,20 @@ func crawlListPages[T any](user string, rs request.Requester, targetTime time.Ti
 
 			if err = opts.parseItem(item, rawItems[i], itemLogger); err != nil {
 				itemLogger.Error(fmt.Sprintf("Failed to parse %s", opts.contentType), zap.Error(err))
-				return fmt.Errorf("failed to parse %s: %w", opts.contentType, err)
+				return time.Time{}, fmt.Errorf("failed to parse %s: %w", opts.contentType, err)
 			}
 			itemLogger.Info(fmt.Sprintf("Parse %s successfully", opts.contentType))
 		}
 
+		for _, item := range excerpts {
+			if t := time.Unix(opts.createdAt(item), 0); t.After(latest) {
+				latest = t
+			}
+		}
+
 		if len(excerpts) > 0 && !time.Unix(opts.createdAt(excerpts[len(excerpts)-1]), 0).After(targetTime) {
 			logger.Info(opts.reachTargetMessage)
-			return nil
+			return latest, nil
 		}
 
 		if paging.IsEnd {
@@ -100,5 +110,40 @@ func crawlListPages[T any](user string, rs request.Requester, targetTime time.Ti
 		}
 	}
 
-	return nil
+	return latest, nil
+}
+
+// crawlListContents 抓取专栏或收藏夹的内容列表，回答与文章各自入库后记入列表，其他类型跳过。
+func crawlListContents(ctx context.Context, t common.ZhihuContentType, listID string, rs request.Requester, parser parse.Parser,
+	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger, generateURL func(string, int) string) (time.Time, error) {
+	contentType := t.Slug() + " item"
+	return crawlListPages(ctx, listID, rs, targetTime, offset, oneTime, logger, listCrawlOptions[apiModels.ListItem]{
+		contentType:        contentType,
+		startMessage:       fmt.Sprintf("Start to crawl zhihu %s items", t.Slug()),
+		reachTargetMessage: "Reach target time, break",
+		reachEndMessage:    fmt.Sprintf("Reach the end of %s items, break", t.Slug()),
+		foundNewMessage:    fmt.Sprintf("Found new %s items, break now", t.Slug()),
+		foundNewCountField: "new_items_count",
+		foundNewError:      fmt.Sprintf("found new %s items", t.Slug()),
+		parseList: func(bytes []byte, index int, logger *zap.Logger) (apiModels.Paging, []apiModels.ListItem, []json.RawMessage, error) {
+			return parser.ParseListPage(t, bytes, index, logger)
+		},
+		parseItem: func(item apiModels.ListItem, raw json.RawMessage, logger *zap.Logger) error {
+			return parser.ParseListItem(t, listID, item, raw, logger)
+		},
+		skipItem: func(item apiModels.ListItem, logger *zap.Logger) bool {
+			if !parse.ListItemSupported(item) {
+				logger.Info("Found unsupported list item, skip", zap.String("item_type", item.Type))
+				return true
+			}
+			return false
+		},
+		createdAt: func(item apiModels.ListItem) int64 {
+			return item.AddedAt
+		},
+		itemLogFields: func(item apiModels.ListItem) []zap.Field {
+			return []zap.Field{zap.String("item_type", item.Type), zap.Int("item_id", item.ID)}
+		},
+		generateURL: generateURL,
+	})
 }
@@ -1,6 +1,7 @@
 package crawl
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"slices"
@@ -24,12 +25,13 @@ func GeneratePinApiURL(user string, offset int) string {
 // offset: number of pins have been crawled
 // set it to 0 if you want to crawl pins from the beginning
 // oneTime: if true, only crawl one time
-func CrawlPin(user string, request request.Requester, parser parse.Parser,
-	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (err error) {
+// latest: time of the newest item crawled, zero if the list is empty
+func CrawlPin(ctx context.Context, user string, request request.Requester, parser parse.Parser,
+	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (latest time.Time, err error) {
 	crawlID := xid.New().String()
 	logger = logger.With(zap.String("crawl_id", crawlID))
 
-	return crawlListPages(user, request, targetTime, offset, oneTime, logger, listCrawlOptions[apiModels.Pin]{
+	return crawlListPages(ctx, user, request, targetTime, offset, oneTime, logger, listCrawlOptions[apiModels.Pin]{
 		contentType:        "pin",
 		startMessage:       "Start to crawl zhihu pins",
 		reachTargetMessage: "Reached target time, break",
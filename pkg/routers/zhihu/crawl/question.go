@@ -0,0 +1,68 @@
+package crawl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
+	apiModels "github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse/api_models"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+)
+
+// GenerateQuestionApiURL 返回问题详情的 API 地址，用于建订阅时确认问题存在并取标题。
+func GenerateQuestionApiURL(questionID string) string {
+	return fmt.Sprintf("https://www.zhihu.com/api/v4/questions/%s?include=%s", questionID, url.QueryEscape("created,title"))
+}
+
+// GenerateQuestionAnswerApiURL 返回问题下回答列表的 API 地址，按发布时间从新到旧。
+func GenerateQuestionAnswerApiURL(questionID string, offset int) string {
+	const (
+		urlLayout = "https://www.zhihu.com/api/v4/questions/%s/answers"
+		params    = `data[*].is_normal,admin_closed_comment,reward_info,is_collapsed,annotation_action,annotation_detail,collapse_reason,collapsed_by,suggest_edit,comment_count,can_comment,content,voteup_count,reshipment_settings,comment_permission,mark_infos,created_time,updated_time,review_info,question,excerpt,is_labeled,label_info,relationship.is_authorized,voting,is_author,is_thanked,is_nothelp;data[*].author.badge[?(type=best_answerer)].topics`
+	)
+	escaped := url.QueryEscape(params)
+	next := fmt.Sprintf(urlLayout, questionID)
+	return fmt.Sprintf("%s?include=%s&%s", next, escaped, fmt.Sprintf("offset=%d&limit=20&sort_by=created", offset))
+}
+
+// CrawlQuestion crawl answers of a zhihu question, whoever wrote them.
+// Each answer is saved under its own author, anonymous ones under parse.AnonymousAuthorID.
+// questionID: question id
+// targetTime: the time to stop crawling
+// offset: number of answers have been crawled
+// oneTime: if true, only crawl one time
+// latest: time of the newest item crawled, zero if the list is empty
+func CrawlQuestion(ctx context.Context, questionID string, rs request.Requester, parser parse.Parser,
+	targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (latest time.Time, err error) {
+	crawlID := xid.New().String()
+	logger = logger.With(zap.String("crawl_id", crawlID), zap.String("question_id", questionID))
+
+	return crawlListPages(ctx, questionID, rs, targetTime, offset, oneTime, logger, listCrawlOptions[apiModels.Answer]{
+		contentType:        "question answer",
+		startMessage:       "Start to crawl zhihu question answers",
+		reachTargetMessage: "Reach target time, break",
+		reachEndMessage:    "Reach the end of question answers, break",
+		foundNewMessage:    "Found new question answers, break now",
+		foundNewCountField: "new_answers_count",
+		foundNewError:      "found new question answers",
+		parseList: func(bytes []byte, index int, logger *zap.Logger) (apiModels.Paging, []apiModels.Answer, []json.RawMessage, error) {
+			return parser.ParseAnswerList(bytes, index, logger)
+		},
+		parseItem: func(answer apiModels.Answer, raw json.RawMessage, logger *zap.Logger) error {
+			return parser.ParseAnswer(raw, parse.AnswerAuthorID(answer.Author), logger)
+		},
+		createdAt: func(answer apiModels.Answer) int64 {
+			return answer.CreateAt
+		},
+		itemLogFields: func(answer apiModels.Answer) []zap.Field {
+			return []zap.Field{zap.Int("ans_id", answer.ID), zap.String("author_id", answer.Author.ID)}
+		},
+		generateURL: GenerateQuestionAnswerApiURL,
+	})
+}
@@ -0,0 +1,64 @@
+package crawl
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+)
+
+type questionRequester struct {
+	request.Requester
+	urls []string
+	resp []byte
+}
+
+func (r *questionRequester) LimitRaw(_ context.Context, u string, _ *zap.Logger) ([]byte, error) {
+	r.urls = append(r.urls, u)
+	return r.resp, nil
+}
+
+func (r *questionRequester) NoLimitStream(context.Context, string, *zap.Logger) (*http.Response, error) {
+	return nil, nil
+}
+
+type questionParser struct {
+	parse.Parser
+	authors []string
+}
+
+func (p *questionParser) ParseAnswer(_ []byte, authorID string, _ *zap.Logger) error {
+	p.authors = append(p.authors, authorID)
+	return nil
+}
+
+func TestCrawlQuestion(t *testing.T) {
+	rs := &questionRequester{resp: []byte(`{
+		"paging": {"is_end": true, "totals": 2},
+		"data": [
+			{"id": 2002, "created_time": 1750000200, "question": {"id": 42000}, "author": {"url_token": "alice"}},
+			{"id": 2001, "created_time": 1750000100, "question": {"id": 42000}, "author": {"url_token": ""}}
+		]
+	}`)}
+	// ParseAnswerList 不读库，占位的 DB 不会被调用
+	listParser, err := parse.NewParseService(parse.WithDB(struct{ zhihuDB.DB }{}))
+	require.NoError(t, err)
+	parser := &questionParser{Parser: listParser}
+
+	latest, err := CrawlQuestion(context.Background(), "42000", rs, parser, time.Unix(0, 0), 0, true, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1750000200, 0), latest)
+
+	assert.Equal(t, []string{GenerateQuestionAnswerApiURL("42000", 0)}, rs.urls)
+	assert.Contains(t, rs.urls[0], "/questions/42000/answers")
+	// 列表从新到旧，入库从旧到新；匿名回答记在 parse.AnonymousAuthorID 名下
+	assert.Equal(t, []string{parse.AnonymousAuthorID, "alice"}, parser.authors)
+}
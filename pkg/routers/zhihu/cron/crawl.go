@@ -1,10 +1,14 @@
 package cron
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/rs/xid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
@@ -12,9 +16,11 @@ import (
 	"github.com/eli-yip/rss-zero/internal/ai"
 	"github.com/eli-yip/rss-zero/internal/file"
 	"github.com/eli-yip/rss-zero/internal/log"
+	"github.com/eli-yip/rss-zero/internal/metrics"
 	"github.com/eli-yip/rss-zero/internal/notify"
 	"github.com/eli-yip/rss-zero/internal/redis"
 	"github.com/eli-yip/rss-zero/internal/rss"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 	"github.com/eli-yip/rss-zero/pkg/common"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	"github.com/eli-yip/rss-zero/pkg/cron"
@@ -26,25 +32,29 @@ import (
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/render"
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
 )
 
 type ResumeJobInfo struct {
 	JobID, LastCrawled string
 }
 
-func BuildCrawlFunc(resumeJobInfo *ResumeJobInfo, taskID string, include, exclude []string, redisService redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, ai ai.AI, notifier notify.Notifier) func(chan cron.CronJobInfo) {
+func BuildCrawlFunc(resumeJobInfo *ResumeJobInfo, taskID string, include, exclude []string, redisService redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, ai ai.AI, notifier notify.Notifier) cron.CrawlFunc {
 	// If resumeJobID is not empty, then resume the crawl from the breakpoint based on lastCrawl.
-	return func(cronJobInfoChan chan cron.CronJobInfo) {
+	return func(trigger string, cronJobInfoChan chan cron.CronJobInfo) {
 		if config.C.Settings.DisableZhihu {
 			log.DefaultLogger.Info("Zhihu is disabled, skip this job")
+			metrics.ObserveCrawl(subscriptionDB.PlatformZhihu, metrics.CrawlSkipped, 0)
 			return
 		}
 
 		cronJobID := resolveCronJobID(resumeJobInfo)
 		logger := log.DefaultLogger.With(zap.String("cron_job_id", cronJobID))
 		cronDBService := cronDB.NewDBService(db)
-		jobCtx := newZhihuCrawlJobContext(cronJobID, taskID, resumeJobInfo, cronDBService, notifier, logger)
+		recorder, logger := cron.NewRunRecorder(cronJobID, cronDBService, logger)
+		jobCtx := newZhihuCrawlJobContext(cronJobID, taskID, trigger, resumeJobInfo, cronDBService, recorder, notifier, logger)
 		if !jobCtx.prepare(cronJobInfoChan) {
+			jobCtx.skip()
 			return
 		}
 		defer jobCtx.finish()
@@ -65,15 +75,39 @@ func BuildCrawlFunc(resumeJobInfo *ResumeJobInfo, taskID string, include, exclud
 			return
 		}
 
+		statDBService := recorder.StatDB(subscriptionDB.NewSubscriptionDBImpl(db))
 		destroyedAuthors := make(map[string]struct{})
-		for _, sub := range subs {
+		for i, sub := range subs {
+			if cause := cron.StopCause(jobCtx.runCtx); cause != nil {
+				logger.Info("Job stopped, leave the remaining subs", zap.Error(cause))
+				return
+			}
 			if _, ok := destroyedAuthors[sub.AuthorID]; ok {
 				logger.Info("Skip destroyed zhihu account sub", zap.String("author_id", sub.AuthorID), zap.String("sub_id", sub.ID))
+				subscriptionDB.Record(statDBService, subscriptionDB.PlatformZhihu, sub.ID,
+					subscriptionDB.Run{Err: request.ErrAccountDestroyed, ErrorClass: subscriptionDB.ErrorClassGone}, logger)
 				continue
 			}
 
-			skip, shouldReturn, err := crawlSub(sub, redisService, dbService, requestService, parser, destroyedAuthors, cookieService, notifier, logger)
+			counter := &countingParser{Parser: parser}
+			countBefore, countErr := countSubItems(sub, dbService)
+			skip, shouldReturn, err := crawlSub(jobCtx.runCtx, sub, redisService, dbService, requestService, counter, destroyedAuthors, cookieService, notifier, logger)
+			if cause := cron.StopCause(jobCtx.runCtx); err != nil && cause != nil {
+				// 不记 detail，续跑时从这个订阅重新抓
+				logger.Info("Job stopped while crawling sub", zap.String("sub_id", sub.ID), zap.Error(cause))
+				return
+			}
+			run := subscriptionDB.Run{ItemsFound: counter.found, Err: err, ErrorClass: classifyCrawlErr(err)}
+			if countAfter, err := countSubItems(sub, dbService); err == nil && countErr == nil {
+				run.ItemsNew = max(countAfter-countBefore, 0)
+			}
+			subscriptionDB.Record(statDBService, subscriptionDB.PlatformZhihu, sub.ID, run, logger)
 			if shouldReturn {
+				// 余下的订阅本轮不再抓取，也记下原因，免得看起来像作者停更
+				for _, rest := range subs[i+1:] {
+					subscriptionDB.Record(statDBService, subscriptionDB.PlatformZhihu, rest.ID,
+						subscriptionDB.Run{Err: fmt.Errorf("skipped: %w", err), ErrorClass: run.ErrorClass}, logger)
+				}
 				jobCtx.err = err
 				return
 			}
@@ -106,22 +140,37 @@ func resolveCronJobID(resumeJobInfo *ResumeJobInfo) string {
 type zhihuCrawlJobContext struct {
 	cronJobID     string
 	taskID        string
+	trigger       string
 	resumeJobInfo *ResumeJobInfo
 	cronDBService cronDB.DB
+	recorder      *cron.RunRecorder
 	notifier      notify.Notifier
 	logger        *zap.Logger
 	err           error
 	errCount      int
+	start         time.Time
+	// runCtx 携带本次运行的根 span，向下传给抓取与请求服务；cancel/pause 时被取消
+	runCtx  context.Context
+	span    trace.Span
+	release func()
 }
 
-func newZhihuCrawlJobContext(cronJobID, taskID string, resumeJobInfo *ResumeJobInfo, cronDBService cronDB.DB, notifier notify.Notifier, logger *zap.Logger) *zhihuCrawlJobContext {
+func newZhihuCrawlJobContext(cronJobID, taskID, trigger string, resumeJobInfo *ResumeJobInfo, cronDBService cronDB.DB, recorder *cron.RunRecorder, notifier notify.Notifier, logger *zap.Logger) *zhihuCrawlJobContext {
+	runCtx, span := tracing.StartCronRun(subscriptionDB.PlatformZhihu, cronJobID)
+	runCtx, release := cron.Stoppable(runCtx, cronJobID)
 	return &zhihuCrawlJobContext{
 		cronJobID:     cronJobID,
 		taskID:        taskID,
+		trigger:       trigger,
 		resumeJobInfo: resumeJobInfo,
 		cronDBService: cronDBService,
+		recorder:      recorder,
 		notifier:      notifier,
 		logger:        logger,
+		start:         time.Now(),
+		runCtx:        runCtx,
+		span:          span,
+		release:       release,
 	}
 }
 
@@ -159,7 +208,7 @@ func (ctx *zhihuCrawlJobContext) prepare(cronJobInfoChan chan cron.CronJobInfo)
 	// case 4
 	if runningJobID == "" && ctx.resumeJobInfo == nil {
 		ctx.logger.Info("New job, start to add it to db")
-		job, err := ctx.cronDBService.AddJob(ctx.cronJobID, ctx.taskID)
+		job, err := ctx.cronDBService.AddJob(ctx.cronJobID, ctx.taskID, ctx.trigger)
 		if err != nil {
 			ctx.logger.Error("Failed to add job", zap.Error(err))
 			cronJobInfo.Err = fmt.Errorf("failed to add job: %w", err)
@@ -172,29 +221,60 @@ func (ctx *zhihuCrawlJobContext) prepare(cronJobInfoChan chan cron.CronJobInfo)
 	}
 	// case 1, 3, 4
 
+	// case 1, 3
+	if ctx.resumeJobInfo != nil {
+		if err := ctx.cronDBService.ResumeJob(ctx.cronJobID); err != nil {
+			ctx.logger.Error("Failed to mark job as resumed", zap.Error(err))
+		}
+	}
+
 	return true
 }
 
+// skip 收尾一次未真正开始的运行，如已有同类任务在跑。
+func (ctx *zhihuCrawlJobContext) skip() {
+	metrics.ObserveCrawl(subscriptionDB.PlatformZhihu, metrics.CrawlSkipped, 0)
+	ctx.recorder.Discard()
+	ctx.release()
+	ctx.span.SetAttributes(attribute.Bool("cron.skipped", true))
+	ctx.span.End()
+}
+
 func (ctx *zhihuCrawlJobContext) finish() {
+	outcome, spanErr := metrics.CrawlSuccess, error(nil)
+	defer func() {
+		ctx.release()
+		metrics.ObserveCrawl(subscriptionDB.PlatformZhihu, outcome, time.Since(ctx.start))
+		tracing.End(ctx.span, spanErr)
+	}()
+
 	if err := recover(); err != nil {
+		outcome, spanErr = metrics.CrawlPanic, fmt.Errorf("panic: %v", err)
 		ctx.logger.Error("CrawlZhihu() panic", zap.Any("err", err))
-		if err = ctx.cronDBService.UpdateStatus(ctx.cronJobID, cronDB.StatusError); err != nil {
-			ctx.logger.Error("Failed to update cron job status", zap.Any("err", err))
-		}
+		ctx.recorder.Finish(cronDB.StatusError, spanErr)
+		return
+	}
+
+	if cause := cron.StopCause(ctx.runCtx); cause != nil {
+		outcome = metrics.CrawlStopped
+		ctx.span.SetAttributes(attribute.String("cron.stopped", cause.Error()))
+		ctx.recorder.Finish(cron.StoppedStatus(cause), cause)
 		return
 	}
 
 	if ctx.errCount > 0 || ctx.err != nil {
-		notify.NoticeWithLogger(ctx.notifier, "Failed to crawl zhihu content", ctx.cronJobID, ctx.logger)
-		if err := ctx.cronDBService.UpdateStatus(ctx.cronJobID, cronDB.StatusError); err != nil {
-			ctx.logger.Error("Failed to update cron job status", zap.Error(err))
+		outcome, spanErr = metrics.CrawlError, ctx.err
+		if spanErr == nil {
+			spanErr = fmt.Errorf("%d subs failed", ctx.errCount)
+		}
+		// Leave the notification to the last attempt when a retry is scheduled.
+		if !ctx.recorder.Finish(cronDB.StatusError, spanErr) {
+			notify.NoticeWithLogger(ctx.notifier, "Failed to crawl zhihu content", ctx.cronJobID, ctx.logger)
 		}
 		return
 	}
 
-	if err := ctx.cronDBService.UpdateStatus(ctx.cronJobID, cronDB.StatusFinished); err != nil {
-		ctx.logger.Error("Failed to update cron job status", zap.Error(err))
-	}
+	ctx.recorder.Finish(cronDB.StatusFinished, nil)
 }
 
 func loadSubsToCrawl(resumeJobInfo *ResumeJobInfo, include, exclude []string, dbService zhihuDB.DB, logger *zap.Logger) ([]zhihuDB.Sub, error) {
@@ -239,13 +319,13 @@ func resolveLastCrawledSub(resumeJobInfo *ResumeJobInfo, dbService zhihuDB.DB, l
 	return resumeJobInfo.LastCrawled, nil
 }
 
-func crawlSub(sub zhihuDB.Sub, redisService redis.Redis, dbService zhihuDB.DB, requestService request.Requester, parser parse.Parser, destroyedAuthors map[string]struct{}, cookieService cookie.CookieIface, notifier notify.Notifier, logger *zap.Logger) (skip, shouldReturn bool, err error) {
+func crawlSub(ctx context.Context, sub zhihuDB.Sub, redisService redis.Redis, dbService zhihuDB.DB, requestService request.Requester, parser parse.Parser, destroyedAuthors map[string]struct{}, cookieService cookie.CookieIface, notifier notify.Notifier, logger *zap.Logger) (skip, shouldReturn bool, err error) {
 	contentCrawler, ok := zhihuContentCrawlers[sub.Type]
 	if !ok {
 		return false, false, fmt.Errorf("unknown zhihu sub type: %q", sub.Type)
 	}
 
-	return crawlContentSub(sub, contentCrawler, redisService, dbService, requestService, parser, destroyedAuthors, cookieService, notifier, logger)
+	return crawlContentSub(ctx, sub, contentCrawler, redisService, dbService, requestService, parser, destroyedAuthors, cookieService, notifier, logger)
 }
 
 func handleSubCrawlErr(err error, authorID string, dbService zhihuDB.DB, destroyedAuthors map[string]struct{}, cookieService cookie.CookieIface, notifier notify.Notifier, logger *zap.Logger, contentType string) (skip, shouldReturn bool, retErr error) {
@@ -253,8 +333,9 @@ func handleSubCrawlErr(err error, authorID string, dbService zhihuDB.DB, destroy
 	if shouldReturn {
 		return false, true, err
 	}
+	// 已处理的错误也原样返回，调用方据此记录跳过原因
 	if handled {
-		return true, false, nil
+		return true, false, err
 	}
 
 	logger.Error(fmt.Sprintf("Failed to crawl %s", contentType), zap.Error(err))
@@ -264,14 +345,18 @@ func handleSubCrawlErr(err error, authorID string, dbService zhihuDB.DB, destroy
 type zhihuContentCrawler struct {
 	contentType common.ZhihuContentType
 	name        string
-	latestTime  func(authorID string, dbService zhihuDB.DB) (time.Time, bool, error)
-	crawl       func(authorID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) error
+	// latestTime 从内容表推算最新时间，只给还没有 Sub.LatestTime 水位的旧订阅补初值
+	latestTime func(authorID string, dbService zhihuDB.DB) (time.Time, bool, error)
+	count      func(authorID string, dbService zhihuDB.DB) (int, error)
+	// crawl 返回本次抓到的最新内容时间，成功后记作订阅的水位
+	crawl func(ctx context.Context, authorID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) (time.Time, error)
 }
 
 var zhihuContentCrawlers = map[common.ZhihuContentType]zhihuContentCrawler{
 	common.ZhihuAnswer: {
 		contentType: common.ZhihuAnswer,
 		name:        "answer",
+		count:       func(authorID string, dbService zhihuDB.DB) (int, error) { return dbService.CountAnswer(authorID) },
 		latestTime: func(authorID string, dbService zhihuDB.DB) (time.Time, bool, error) {
 			answers, err := dbService.GetLatestNAnswer(1, authorID)
 			if err != nil {
@@ -282,13 +367,14 @@ var zhihuContentCrawlers = map[common.ZhihuContentType]zhihuContentCrawler{
 			}
 			return answers[0].CreateAt, true, nil
 		},
-		crawl: func(authorID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) error {
-			return crawl.CrawlAnswer(authorID, requestService, parser, targetTime, 0, oneTime, logger)
+		crawl: func(ctx context.Context, authorID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) (time.Time, error) {
+			return crawl.CrawlAnswer(ctx, authorID, requestService, parser, targetTime, 0, oneTime, logger)
 		},
 	},
 	common.ZhihuArticle: {
 		contentType: common.ZhihuArticle,
 		name:        "article",
+		count:       func(authorID string, dbService zhihuDB.DB) (int, error) { return dbService.CountArticle(authorID) },
 		latestTime: func(authorID string, dbService zhihuDB.DB) (time.Time, bool, error) {
 			articles, err := dbService.GetLatestNArticle(1, authorID)
 			if err != nil {
@@ -299,13 +385,14 @@ var zhihuContentCrawlers = map[common.ZhihuContentType]zhihuContentCrawler{
 			}
 			return articles[0].CreateAt, true, nil
 		},
-		crawl: func(authorID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) error {
-			return crawl.CrawlArticle(authorID, requestService, parser, targetTime, 0, oneTime, logger)
+		crawl: func(ctx context.Context, authorID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) (time.Time, error) {
+			return crawl.CrawlArticle(ctx, authorID, requestService, parser, targetTime, 0, oneTime, logger)
 		},
 	},
 	common.ZhihuPin: {
 		contentType: common.ZhihuPin,
 		name:        "pin",
+		count:       func(authorID string, dbService zhihuDB.DB) (int, error) { return dbService.CountPin(authorID) },
 		latestTime: func(authorID string, dbService zhihuDB.DB) (time.Time, bool, error) {
 			pins, err := dbService.GetLatestNPin(1, authorID)
 			if err != nil {
@@ -316,16 +403,78 @@ var zhihuContentCrawlers = map[common.ZhihuContentType]zhihuContentCrawler{
 			}
 			return pins[0].CreateAt, true, nil
 		},
-		crawl: func(authorID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) error {
-			return crawl.CrawlPin(authorID, requestService, parser, targetTime, 0, oneTime, logger)
+		crawl: func(ctx context.Context, authorID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) (time.Time, error) {
+			return crawl.CrawlPin(ctx, authorID, requestService, parser, targetTime, 0, oneTime, logger)
+		},
+	},
+	// 问题订阅的 AuthorID 存的是问题 id
+	common.ZhihuQuestion: {
+		contentType: common.ZhihuQuestion,
+		name:        "question",
+		count: func(questionID string, dbService zhihuDB.DB) (int, error) {
+			id, err := strconv.Atoi(questionID)
+			if err != nil {
+				return 0, fmt.Errorf("invalid question id %q: %w", questionID, err)
+			}
+			return dbService.CountQuestionAnswer(id)
+		},
+		latestTime: func(questionID string, dbService zhihuDB.DB) (time.Time, bool, error) {
+			id, err := strconv.Atoi(questionID)
+			if err != nil {
+				return time.Time{}, false, fmt.Errorf("invalid question id %q: %w", questionID, err)
+			}
+			answers, err := dbService.GetLatestNQuestionAnswer(1, id)
+			if err != nil {
+				return time.Time{}, false, err
+			}
+			if len(answers) == 0 {
+				return time.Time{}, false, nil
+			}
+			return answers[0].CreateAt, true, nil
+		},
+		crawl: func(ctx context.Context, questionID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) (time.Time, error) {
+			return crawl.CrawlQuestion(ctx, questionID, requestService, parser, targetTime, 0, oneTime, logger)
 		},
 	},
+	// 专栏与收藏夹订阅的 AuthorID 存的是专栏或收藏夹 id
+	common.ZhihuColumn:     listContentCrawler(common.ZhihuColumn, crawl.CrawlColumn),
+	common.ZhihuCollection: listContentCrawler(common.ZhihuCollection, crawl.CrawlCollection),
 }
 
-func crawlContentSub(sub zhihuDB.Sub, contentCrawler zhihuContentCrawler, redisService redis.Redis, dbService zhihuDB.DB, requestService request.Requester, parser parse.Parser, destroyedAuthors map[string]struct{}, cookieService cookie.CookieIface, notifier notify.Notifier, logger *zap.Logger) (skip, shouldReturn bool, err error) {
+func listContentCrawler(t common.ZhihuContentType, crawlList func(ctx context.Context, listID string, rs request.Requester, parser parse.Parser, targetTime time.Time, offset int, oneTime bool, logger *zap.Logger) (time.Time, error)) zhihuContentCrawler {
+	return zhihuContentCrawler{
+		contentType: t,
+		name:        t.Slug(),
+		count:       func(listID string, dbService zhihuDB.DB) (int, error) { return dbService.CountListItem(t, listID) },
+		latestTime: func(listID string, dbService zhihuDB.DB) (time.Time, bool, error) {
+			items, err := dbService.GetListItems(t, listID, 1, 0)
+			if err != nil {
+				return time.Time{}, false, err
+			}
+			if len(items) == 0 {
+				return time.Time{}, false, nil
+			}
+			return items[0].AddedAt, true, nil
+		},
+		crawl: func(ctx context.Context, listID string, requestService request.Requester, parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger) (time.Time, error) {
+			return crawlList(ctx, listID, requestService, parser, targetTime, 0, oneTime, logger)
+		},
+	}
+}
+
+// subLatestTime 返回订阅的增量水位。作者订阅与问题、专栏、收藏夹订阅抓到的内容落在同一批表里，
+// 各订阅只认自己记下的水位；旧订阅还没有水位时才从内容表推算。
+func subLatestTime(sub zhihuDB.Sub, contentCrawler zhihuContentCrawler, dbService zhihuDB.DB) (time.Time, bool, error) {
+	if sub.LatestTime != nil {
+		return *sub.LatestTime, true, nil
+	}
+	return contentCrawler.latestTime(sub.AuthorID, dbService)
+}
+
+func crawlContentSub(ctx context.Context, sub zhihuDB.Sub, contentCrawler zhihuContentCrawler, redisService redis.Redis, dbService zhihuDB.DB, requestService request.Requester, parser parse.Parser, destroyedAuthors map[string]struct{}, cookieService cookie.CookieIface, notifier notify.Notifier, logger *zap.Logger) (skip, shouldReturn bool, err error) {
 	logger.Info("Start to crawl zhihu sub", zap.String("author_id", sub.AuthorID), zap.String("type", contentCrawler.name))
 
-	latestTimeInDB, hasLatest, err := contentCrawler.latestTime(sub.AuthorID, dbService)
+	latestTime, hasLatest, err := subLatestTime(sub, contentCrawler, dbService)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to get latest %s from database", contentCrawler.name), zap.Error(err))
 		return false, false, err
@@ -334,20 +483,32 @@ func crawlContentSub(sub zhihuDB.Sub, contentCrawler zhihuContentCrawler, redisS
 	targetTime := cron.LongLongAgo
 	oneTime := true
 	if hasLatest {
-		targetTime = latestTimeInDB
+		targetTime = latestTime
 		oneTime = false
 		logger.Info(fmt.Sprintf("Found %s in db, start to crawl %s in normal mode", contentCrawler.name, contentCrawler.name),
-			zap.Time(fmt.Sprintf("latest_%s's_create_time", contentCrawler.name), latestTimeInDB))
+			zap.Time(fmt.Sprintf("latest_%s's_create_time", contentCrawler.name), latestTime))
 	} else {
 		logger.Info(fmt.Sprintf("Found no %s in db, start to crawl %s in one time mode", contentCrawler.name, contentCrawler.name))
 	}
 
-	if err = contentCrawler.crawl(sub.AuthorID, requestService, parser, targetTime, oneTime, logger); err != nil {
+	crawledLatest, err := contentCrawler.crawl(ctx, sub.AuthorID, requestService, parser, targetTime, oneTime, logger)
+	if err != nil {
 		return handleSubCrawlErr(err, sub.AuthorID, dbService, destroyedAuthors, cookieService, notifier, logger, contentCrawler.name)
 	}
 	logger.Info(fmt.Sprintf("Crawl %s successfully", contentCrawler.name))
 
-	if err = rss.WarmCache(redisService, contentCrawler.contentType.RedisKey(sub.AuthorID), redis.RSSDefaultTTL,
+	// 没抓到新内容时也写下补出的初值，之后不再从内容表推算
+	if hasLatest && latestTime.After(crawledLatest) {
+		crawledLatest = latestTime
+	}
+	if !crawledLatest.IsZero() {
+		if err = dbService.SetLatestTime(sub.AuthorID, sub.Type, crawledLatest); err != nil {
+			logger.Error("Failed to save sub latest time", zap.Error(err))
+			return false, false, err
+		}
+	}
+
+	if err = rss.WarmCache(ctx, redisService, contentCrawler.contentType.RedisKey(sub.AuthorID), redis.RSSDefaultTTL,
 		func() (rss.FeedMeta, []rss.Item, error) {
 			return rss.FetchZhihu(contentCrawler.contentType, sub.AuthorID, dbService, logger)
 		}); err != nil {
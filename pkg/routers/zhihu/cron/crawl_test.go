@@ -1,10 +1,19 @@
 package cron
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/eli-yip/rss-zero/pkg/common"
 	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
+	apiModels "github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse/api_models"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestCutSubs(t *testing.T) {
@@ -29,3 +38,65 @@ func TestCutSubs(t *testing.T) {
 		assert.Equal(c.want, got)
 	}
 }
+
+func TestClassifyCrawlErr(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(subscriptionDB.ErrorClassAuth, classifyCrawlErr(fmt.Errorf("failed to crawl: %w", request.ErrInvalidZSECK)))
+	assert.Equal(subscriptionDB.ErrorClassGone, classifyCrawlErr(request.ErrAccountDestroyed))
+	assert.Equal(subscriptionDB.ErrorClassUpstream, classifyCrawlErr(zhihuDB.ErrNoAvailableService))
+	assert.Equal("", classifyCrawlErr(errors.New("db down")))
+	assert.Equal("", classifyCrawlErr(nil))
+}
+
+// answerDB 的最新回答可能来自问题或收藏夹抓取
+type answerDB struct {
+	zhihuDB.DB
+	answers []zhihuDB.Answer
+}
+
+func (d *answerDB) GetLatestNAnswer(n int, _ string) ([]zhihuDB.Answer, error) {
+	return d.answers[:min(n, len(d.answers))], nil
+}
+
+func TestSubLatestTime(t *testing.T) {
+	assert := assert.New(t)
+	crawler := zhihuContentCrawlers[common.ZhihuAnswer]
+	fromQuestion := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	own := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	dbService := &answerDB{answers: []zhihuDB.Answer{{CreateAt: fromQuestion}}}
+
+	got, ok, err := subLatestTime(zhihuDB.Sub{AuthorID: "alice", Type: common.ZhihuAnswer, LatestTime: &own}, crawler, dbService)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(own, got, "the sub's own watermark wins over newer answers saved by other crawls")
+
+	got, ok, err = subLatestTime(zhihuDB.Sub{AuthorID: "alice", Type: common.ZhihuAnswer}, crawler, dbService)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(fromQuestion, got, "legacy subs fall back to the content table")
+
+	_, ok, err = subLatestTime(zhihuDB.Sub{AuthorID: "bob", Type: common.ZhihuAnswer}, crawler, &answerDB{})
+	assert.NoError(err)
+	assert.False(ok)
+}
+
+type listItemParser struct {
+	parse.Parser
+	saved []string
+}
+
+func (p *listItemParser) ParseListItem(t common.ZhihuContentType, listID string, item apiModels.ListItem, _ []byte, _ *zap.Logger) error {
+	p.saved = append(p.saved, fmt.Sprintf("%s/%s/%d", t.Slug(), listID, item.ID))
+	return nil
+}
+
+func TestCountingParserListItem(t *testing.T) {
+	inner := &listItemParser{}
+	counter := &countingParser{Parser: inner}
+	for _, id := range []int{1, 2} {
+		assert.NoError(t, counter.ParseListItem(common.ZhihuColumn, "c_1", apiModels.ListItem{ID: id, Type: "answer"}, nil, zap.NewNop()))
+	}
+	assert.Equal(t, 2, counter.found)
+	assert.Equal(t, []string{"column/c_1/1", "column/c_1/2"}, inner.saved)
+}
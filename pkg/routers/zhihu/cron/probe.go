@@ -0,0 +1,149 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/log"
+	"github.com/eli-yip/rss-zero/internal/notify"
+	"github.com/eli-yip/rss-zero/pkg/cookie"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+)
+
+// ProbeFailThreshold 是连续探测失败多少次后自动下线加密服务，避免一次网络抖动就摘掉服务。
+const ProbeFailThreshold = 2
+
+// ProbeSummary 汇总一轮探测：Healthy 为探测成功的服务，Down 与 Recovered 为本轮自动下线与恢复的服务 slug，
+// CookieRejected 为知乎拒绝 cookie 的次数。
+type ProbeSummary struct {
+	Healthy        int
+	Down           []string
+	Recovered      []string
+	CookieRejected int
+}
+
+// ProbeFunc 探测单个加密服务。
+type ProbeFunc func(ctx context.Context, es zhihuDB.EncryptionService) request.ProbeResult
+
+// BuildProbeFunc 构建加密服务健康探测任务。
+func BuildProbeFunc(db *gorm.DB, cs cookie.CookieIface, notifier notify.Notifier) func() {
+	return func() {
+		if config.C.Settings.DisableZhihu {
+			log.DefaultLogger.Info("Zhihu is disabled, skip this job")
+			return
+		}
+
+		logger := log.DefaultLogger.With(zap.String("cron_job_id", xid.New().String()))
+
+		cookies, err := cookie.GetZhihuCookies(cs, logger)
+		if err != nil {
+			if otherErr := cookie.HandleZhihuCookiesErr(err, notifier, logger); otherErr != nil {
+				logger.Error("Failed to get zhihu cookies", zap.Error(otherErr))
+			}
+			return
+		}
+
+		client := &http.Client{}
+		probe := func(ctx context.Context, es zhihuDB.EncryptionService) request.ProbeResult {
+			return request.Probe(ctx, client, es, cookies)
+		}
+
+		summary, err := ProbeServices(context.Background(), zhihuDB.NewDBService(db), probe, notifier, logger)
+		if err != nil {
+			logger.Error("Failed to probe zhihu encryption services", zap.Error(err))
+			return
+		}
+		logger.Info("Probe zhihu encryption services done", zap.Int("healthy", summary.Healthy),
+			zap.Strings("down", summary.Down), zap.Strings("recovered", summary.Recovered))
+	}
+}
+
+// ProbeServices 探测全部已注册的加密服务并记录结果：探测成功的服务自动上线，连续失败 ProbeFailThreshold
+// 次的服务自动下线。管理员停用的服务照常记录探测结果，但不会自动上线，也不算作健康服务。知乎拒绝 cookie 时不改变服务状态。上下线变化与没有健康服务时发送通知，
+// 后者只在上一轮仍有健康服务或从未探测过时发送，避免每轮重复提醒。
+func ProbeServices(ctx context.Context, dbService zhihuDB.EncryptionServiceIface, probe ProbeFunc, notifier notify.Notifier, logger *zap.Logger) (summary ProbeSummary, err error) {
+	services, err := dbService.GetServices()
+	if err != nil {
+		return summary, fmt.Errorf("failed to get encryption services: %w", err)
+	}
+	if len(services) == 0 {
+		logger.Warn("No zhihu encryption service registered, skip probing")
+		return summary, nil
+	}
+
+	wasHealthy := false
+	for _, es := range services {
+		if !es.AdminDisabled && (es.LastProbeAt == nil || es.ProbeFailures == 0) {
+			wasHealthy = true
+		}
+	}
+
+	var lastErrs []string
+	for _, es := range services {
+		logger := logger.With(zap.String("service_id", es.ID), zap.String("slug", es.Slug))
+
+		result := probe(ctx, es)
+		if result.CookieRejected {
+			logger.Warn("Zhihu rejected cookies when probing, keep service status", zap.Error(result.Err))
+			summary.CookieRejected++
+			lastErrs = append(lastErrs, fmt.Sprintf("%s: %v", es.Slug, result.Err))
+			continue
+		}
+
+		record := zhihuDB.ProbeRecord{At: time.Now(), Latency: result.Latency}
+		if result.Err != nil {
+			record.Err = result.Err.Error()
+		}
+		if err = dbService.RecordProbe(es.ID, record); err != nil {
+			return summary, fmt.Errorf("failed to record probe of %s: %w", es.Slug, err)
+		}
+
+		if result.Err == nil && es.AdminDisabled {
+			logger.Info("Probe zhihu encryption service successfully, keep it disabled by admin", zap.Duration("latency", result.Latency))
+			continue
+		}
+		if result.Err == nil {
+			logger.Info("Probe zhihu encryption service successfully", zap.Duration("latency", result.Latency))
+			summary.Healthy++
+			if !es.IsAvailable {
+				if err = dbService.MarkAvailable(es.ID); err != nil {
+					return summary, fmt.Errorf("failed to mark %s available: %w", es.Slug, err)
+				}
+				summary.Recovered = append(summary.Recovered, es.Slug)
+			}
+			continue
+		}
+
+		logger.Error("Failed to probe zhihu encryption service", zap.Error(result.Err), zap.Int("failures", es.ProbeFailures+1))
+		lastErrs = append(lastErrs, fmt.Sprintf("%s: %v", es.Slug, result.Err))
+		if es.IsAvailable && es.ProbeFailures+1 >= ProbeFailThreshold {
+			if err = dbService.MarkUnavailable(es.ID); err != nil {
+				return summary, fmt.Errorf("failed to mark %s unavailable: %w", es.Slug, err)
+			}
+			summary.Down = append(summary.Down, es.Slug)
+		}
+	}
+
+	if len(summary.Down) > 0 || len(summary.Recovered) > 0 {
+		notify.NoticeWithLogger(notifier, "Zhihu encryption services changed",
+			fmt.Sprintf("down: %s\nrecovered: %s", strings.Join(summary.Down, ", "), strings.Join(summary.Recovered, ", ")), logger)
+	}
+	if summary.Healthy == 0 && wasHealthy {
+		content := strings.Join(lastErrs, "\n")
+		if summary.CookieRejected > 0 {
+			content = "zhihu rejected cookies, check zhihu cookies first\n" + content
+		}
+		notify.NoticeWithLogger(notifier, "No healthy zhihu encryption service", content, logger)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,140 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+)
+
+type probeDB struct {
+	zhihuDB.EncryptionServiceIface
+	services    []zhihuDB.EncryptionService
+	records     map[string]zhihuDB.ProbeRecord
+	available   []string
+	unavailable []string
+}
+
+func (d *probeDB) GetServices() ([]zhihuDB.EncryptionService, error) { return d.services, nil }
+
+func (d *probeDB) RecordProbe(id string, probe zhihuDB.ProbeRecord) error {
+	d.records[id] = probe
+	return nil
+}
+
+func (d *probeDB) MarkAvailable(id string) error {
+	d.available = append(d.available, id)
+	return nil
+}
+
+func (d *probeDB) MarkUnavailable(id string) error {
+	d.unavailable = append(d.unavailable, id)
+	return nil
+}
+
+type probeNotifier struct{ titles []string }
+
+func (n *probeNotifier) Notify(title, _ string) error {
+	n.titles = append(n.titles, title)
+	return nil
+}
+
+func TestProbeServices(t *testing.T) {
+	probed := time.Now()
+	db := &probeDB{
+		services: []zhihuDB.EncryptionService{
+			{ID: "ok", Slug: "ok", IsAvailable: true},
+			{ID: "back", Slug: "back", IsAvailable: false, ProbeFailures: 3, LastProbeAt: &probed},
+			{ID: "flaky", Slug: "flaky", IsAvailable: true},
+			{ID: "broken", Slug: "broken", IsAvailable: true, ProbeFailures: 1, LastProbeAt: &probed},
+		},
+		records: make(map[string]zhihuDB.ProbeRecord),
+	}
+	probe := func(_ context.Context, es zhihuDB.EncryptionService) request.ProbeResult {
+		switch es.ID {
+		case "ok", "back":
+			return request.ProbeResult{Latency: time.Second}
+		default:
+			return request.ProbeResult{Latency: time.Second, Err: errors.New("bad status code 500")}
+		}
+	}
+	notifier := &probeNotifier{}
+
+	summary, err := ProbeServices(context.Background(), db, probe, notifier, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, summary.Healthy)
+	assert.Equal(t, []string{"broken"}, summary.Down)
+	assert.Equal(t, []string{"back"}, summary.Recovered)
+	assert.Equal(t, []string{"back"}, db.available)
+	assert.Equal(t, []string{"broken"}, db.unavailable, "flaky fails for the first time and stays available")
+	assert.Len(t, db.records, 4)
+	assert.Equal(t, int64(1000), db.records["ok"].Latency.Milliseconds())
+	assert.NotEmpty(t, db.records["flaky"].Err)
+	assert.Equal(t, []string{"Zhihu encryption services changed"}, notifier.titles)
+}
+
+func TestProbeServicesNoneHealthy(t *testing.T) {
+	probed := time.Now()
+	db := &probeDB{
+		services: []zhihuDB.EncryptionService{
+			{ID: "a", Slug: "a", IsAvailable: true, LastProbeAt: &probed},
+			{ID: "b", Slug: "b", IsAvailable: false, ProbeFailures: 5, LastProbeAt: &probed},
+		},
+		records: make(map[string]zhihuDB.ProbeRecord),
+	}
+	rejected := func(context.Context, zhihuDB.EncryptionService) request.ProbeResult {
+		return request.ProbeResult{Err: errors.New("zhihu rejected cookies with status 403"), CookieRejected: true}
+	}
+	notifier := &probeNotifier{}
+
+	summary, err := ProbeServices(context.Background(), db, rejected, notifier, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.Zero(t, summary.Healthy)
+	assert.Equal(t, 2, summary.CookieRejected)
+	assert.Empty(t, db.records, "cookie rejection says nothing about the services")
+	assert.Empty(t, db.unavailable)
+	assert.Equal(t, []string{"No healthy zhihu encryption service"}, notifier.titles)
+
+	// 上一轮已经全部探测失败时不再重复提醒
+	db.services[0].IsAvailable, db.services[0].ProbeFailures = false, 2
+	notifier.titles = nil
+	failing := func(context.Context, zhihuDB.EncryptionService) request.ProbeResult {
+		return request.ProbeResult{Err: errors.New("bad status code 500")}
+	}
+	_, err = ProbeServices(context.Background(), db, failing, notifier, zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, notifier.titles)
+}
+
+func TestProbeServicesKeepsAdminDisabled(t *testing.T) {
+	probed := time.Now()
+	db := &probeDB{
+		services: []zhihuDB.EncryptionService{
+			{ID: "ok", Slug: "ok", IsAvailable: true},
+			{ID: "parked", Slug: "parked", IsAvailable: false, AdminDisabled: true, LastProbeAt: &probed},
+		},
+		records: make(map[string]zhihuDB.ProbeRecord),
+	}
+	healthy := func(context.Context, zhihuDB.EncryptionService) request.ProbeResult {
+		return request.ProbeResult{Latency: time.Second}
+	}
+	notifier := &probeNotifier{}
+
+	summary, err := ProbeServices(context.Background(), db, healthy, notifier, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, summary.Healthy)
+	assert.Empty(t, summary.Recovered)
+	assert.Empty(t, db.available, "a service disabled by an admin stays disabled")
+	assert.Contains(t, db.records, "parked", "its probe result is still recorded")
+	assert.Empty(t, notifier.titles)
+}
@@ -11,13 +11,14 @@ import (
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/random"
 )
 
-func BuildRandomSelectCanglimoAnswerCronFunc(gormDB *gorm.DB, redisService redis.Redis) func() {
+// BuildRandomSelectCanglimoAnswerCronFunc picks count answers into the cached random feed.
+func BuildRandomSelectCanglimoAnswerCronFunc(gormDB *gorm.DB, redisService redis.Redis, count int) func() {
 	return func() {
 		logger := log.DefaultLogger.With(zap.String("cron_job_id", xid.New().String()))
 
 		zhihuDBService := zhihuDB.NewDBService(gormDB)
 
-		rssContent, err := random.GenerateRandomCanglimoAnswerRSS(zhihuDBService, logger)
+		rssContent, err := random.GenerateRandomCanglimoAnswerRSS(zhihuDBService, count, logger)
 		if err != nil {
 			logger.Error("Failed to generate random canglimo answer rss", zap.Error(err))
 			return
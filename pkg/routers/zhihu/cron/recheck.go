@@ -0,0 +1,179 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/ai"
+	"github.com/eli-yip/rss-zero/internal/log"
+	"github.com/eli-yip/rss-zero/internal/notify"
+	"github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/cookie"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/crawl"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// DefaultRecheckDays 是复查任务默认回看的天数。
+const DefaultRecheckDays = 7
+
+// RecheckResult 汇总一次复查：Edited 为正文有变化的条数，Deleted 为新发现删除的条数，Restored 为删除后又能访问的回答与文章数。
+type RecheckResult struct {
+	Checked, Edited, Deleted, Restored int
+}
+
+// BuildRecheckFunc 构建复查任务：逐条重新请求订阅作者近 days 天的回答与文章，发现修改与删除。
+func BuildRecheckFunc(days int, db *gorm.DB, cs cookie.CookieIface, aiService ai.AI, notifier notify.Notifier) func() {
+	return func() {
+		if config.C.Settings.DisableZhihu {
+			log.DefaultLogger.Info("Zhihu is disabled, skip this job")
+			return
+		}
+
+		logger := log.DefaultLogger.With(zap.String("cron_job_id", xid.New().String()))
+
+		dbService, requestService, parser, err := initZhihuServices(db, aiService, cs, logger)
+		if err != nil {
+			if otherErr := cookie.HandleZhihuCookiesErr(err, notifier, logger); otherErr != nil {
+				logger.Error("Failed to init zhihu services", zap.Error(otherErr))
+			}
+			return
+		}
+
+		result, err := Recheck(context.Background(), time.Now().AddDate(0, 0, -days), dbService, requestService, parser, logger)
+		if err != nil {
+			logger.Error("Failed to recheck zhihu content", zap.Error(err))
+			notify.NoticeWithLogger(notifier, "Failed to recheck zhihu content", err.Error(), logger)
+		}
+		logger.Info("Recheck zhihu content done", zap.Int("checked", result.Checked), zap.Int("edited", result.Edited),
+			zap.Int("deleted", result.Deleted), zap.Int("restored", result.Restored))
+	}
+}
+
+// Recheck 重新请求订阅作者 since 之后发布的回答与文章。正文有变化的交给 parser 重新入库，旧版本由
+// SaveAnswerTx/SaveArticleTx 存为修订；返回 404 的记为删除。cookie 失效时中止，其余单条错误只记日志。
+func Recheck(ctx context.Context, since time.Time, dbService zhihuDB.DB, rs request.Requester, parser parse.Parser, logger *zap.Logger) (result RecheckResult, err error) {
+	subs, err := dbService.GetSubs()
+	if err != nil {
+		return result, fmt.Errorf("failed to get zhihu subs: %w", err)
+	}
+
+	for _, sub := range subs {
+		logger := logger.With(zap.String("author_id", sub.AuthorID), zap.String("type", string(sub.Type)))
+		switch sub.Type {
+		case common.ZhihuAnswer:
+			answers, err := dbService.GetAnswerAfter(sub.AuthorID, since)
+			if err != nil {
+				return result, fmt.Errorf("failed to get answers of %s: %w", sub.AuthorID, err)
+			}
+			for _, answer := range answers {
+				if err = recheckAnswer(ctx, answer, dbService, rs, parser, &result, logger.With(zap.Int("answer_id", answer.ID))); err != nil {
+					return result, err
+				}
+			}
+		case common.ZhihuArticle:
+			articles, err := dbService.GetArticleAfter(sub.AuthorID, since)
+			if err != nil {
+				return result, fmt.Errorf("failed to get articles of %s: %w", sub.AuthorID, err)
+			}
+			for _, article := range articles {
+				if err = recheckArticle(ctx, article, dbService, rs, parser, &result, logger.With(zap.Int("article_id", article.ID))); err != nil {
+					return result, err
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func recheckAnswer(ctx context.Context, answer zhihuDB.Answer, dbService zhihuDB.DB, rs request.Requester, parser parse.Parser, result *RecheckResult, logger *zap.Logger) error {
+	result.Checked++
+	raw, err := rs.LimitRaw(ctx, crawl.GenerateSingleAnswerApiURL(answer.ID), logger)
+	if errors.Is(err, request.ErrUnreachable) {
+		marked, err := dbService.MarkAnswerDeleted(answer.ID)
+		if err != nil {
+			return fmt.Errorf("failed to mark answer %d deleted: %w", answer.ID, err)
+		}
+		if marked {
+			logger.Info("Found deleted answer")
+			result.Deleted++
+		}
+		return nil
+	}
+	if err != nil {
+		return recheckRequestErr(err, logger)
+	}
+
+	if answer.Status == zhihuDB.AnswerStatusUnreachable {
+		if err = dbService.UpdateAnswerStatus(answer.ID, zhihuDB.AnswerStatusCompleted); err != nil {
+			return fmt.Errorf("failed to restore answer %d status: %w", answer.ID, err)
+		}
+		logger.Info("Found restored answer")
+		result.Restored++
+	}
+	if !zhihuDB.ContentChanged(answer.Raw, raw) {
+		return nil
+	}
+	if err = parser.ParseAnswer(raw, answer.AuthorID, logger); err != nil {
+		logger.Error("Failed to parse edited answer", zap.Error(err))
+		return nil
+	}
+	logger.Info("Found edited answer")
+	result.Edited++
+	return nil
+}
+
+func recheckArticle(ctx context.Context, article zhihuDB.Article, dbService zhihuDB.DB, rs request.Requester, parser parse.Parser, result *RecheckResult, logger *zap.Logger) error {
+	result.Checked++
+	raw, err := rs.LimitRaw(ctx, crawl.GenerateSingleArticleApiURL(article.ID), logger)
+	if errors.Is(err, request.ErrUnreachable) {
+		marked, err := dbService.MarkArticleDeleted(article.ID)
+		if err != nil {
+			return fmt.Errorf("failed to mark article %d deleted: %w", article.ID, err)
+		}
+		if marked {
+			logger.Info("Found deleted article")
+			result.Deleted++
+		}
+		return nil
+	}
+	if err != nil {
+		return recheckRequestErr(err, logger)
+	}
+
+	if article.Deleted {
+		if err = dbService.RestoreArticle(article.ID); err != nil {
+			return fmt.Errorf("failed to restore article %d: %w", article.ID, err)
+		}
+		logger.Info("Found restored article")
+		result.Restored++
+	}
+	if !zhihuDB.ContentChanged(article.Raw, raw) {
+		return nil
+	}
+	if err = parser.ParseArticle(raw, logger); err != nil {
+		logger.Error("Failed to parse edited article", zap.Error(err))
+		return nil
+	}
+	logger.Info("Found edited article")
+	result.Edited++
+	return nil
+}
+
+// recheckRequestErr 在 cookie 失效或加密服务不可用时中止复查，其余请求错误跳过该条。
+func recheckRequestErr(err error, logger *zap.Logger) error {
+	if classifyCrawlErr(err) == subscriptionDB.ErrorClassAuth || errors.Is(err, zhihuDB.ErrNoAvailableService) {
+		return fmt.Errorf("failed to request zhihu: %w", err)
+	}
+	logger.Error("Failed to request zhihu content, skip", zap.Error(err))
+	return nil
+}
@@ -0,0 +1,147 @@
+package cron
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/crawl"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+)
+
+type recheckDB struct {
+	zhihuDB.DB
+	answers          []zhihuDB.Answer
+	articles         []zhihuDB.Article
+	deletedAnswers   []int
+	deletedArticles  []int
+	restored         []int
+	restoredArticles []int
+}
+
+func (d *recheckDB) GetSubs() ([]zhihuDB.Sub, error) {
+	return []zhihuDB.Sub{{AuthorID: "a", Type: common.ZhihuAnswer}, {AuthorID: "a", Type: common.ZhihuArticle}}, nil
+}
+
+func (d *recheckDB) GetAnswerAfter(string, time.Time) ([]zhihuDB.Answer, error) {
+	return d.answers, nil
+}
+
+func (d *recheckDB) GetArticleAfter(string, time.Time) ([]zhihuDB.Article, error) {
+	return d.articles, nil
+}
+
+func (d *recheckDB) MarkAnswerDeleted(id int) (bool, error) {
+	d.deletedAnswers = append(d.deletedAnswers, id)
+	return true, nil
+}
+
+func (d *recheckDB) MarkArticleDeleted(id int) (bool, error) {
+	d.deletedArticles = append(d.deletedArticles, id)
+	return true, nil
+}
+
+func (d *recheckDB) RestoreArticle(id int) error {
+	d.restoredArticles = append(d.restoredArticles, id)
+	return nil
+}
+
+func (d *recheckDB) UpdateAnswerStatus(id int, status int) error {
+	if status == zhihuDB.AnswerStatusCompleted {
+		d.restored = append(d.restored, id)
+	}
+	return nil
+}
+
+type recheckRequester struct {
+	request.Requester
+	resp map[string][]byte
+	errs map[string]error
+}
+
+func (r *recheckRequester) LimitRaw(_ context.Context, u string, _ *zap.Logger) ([]byte, error) {
+	if err, ok := r.errs[u]; ok {
+		return nil, err
+	}
+	return r.resp[u], nil
+}
+
+func (r *recheckRequester) NoLimitStream(context.Context, string, *zap.Logger) (*http.Response, error) {
+	return nil, nil
+}
+
+type recheckParser struct {
+	parse.Parser
+	answers  [][]byte
+	articles [][]byte
+}
+
+func (p *recheckParser) ParseAnswer(content []byte, _ string, _ *zap.Logger) error {
+	p.answers = append(p.answers, content)
+	return nil
+}
+
+func (p *recheckParser) ParseArticle(content []byte, _ *zap.Logger) error {
+	p.articles = append(p.articles, content)
+	return nil
+}
+
+func TestRecheck(t *testing.T) {
+	db := &recheckDB{
+		answers: []zhihuDB.Answer{
+			{ID: 1, AuthorID: "a", Raw: []byte(`{"content":"v1","voteup_count":1}`)},
+			{ID: 2, AuthorID: "a", Raw: []byte(`{"content":"same","voteup_count":1}`)},
+			{ID: 3, AuthorID: "a", Raw: []byte(`{"content":"gone"}`)},
+			{ID: 4, AuthorID: "a", Raw: []byte(`{"content":"back"}`), Status: zhihuDB.AnswerStatusUnreachable},
+		},
+		articles: []zhihuDB.Article{
+			{ID: 10, AuthorID: "a", Raw: []byte(`{"title":"t","content":"c"}`)},
+			{ID: 11, AuthorID: "a", Raw: []byte(`{"title":"t","content":"c"}`)},
+			{ID: 12, AuthorID: "a", Raw: []byte(`{"title":"t","content":"back"}`), Deleted: true},
+		},
+	}
+	rs := &recheckRequester{
+		resp: map[string][]byte{
+			crawl.GenerateSingleAnswerApiURL(1):   []byte(`{"content":"v2","voteup_count":1}`),
+			crawl.GenerateSingleAnswerApiURL(2):   []byte(`{"content":"same","voteup_count":9}`),
+			crawl.GenerateSingleAnswerApiURL(4):   []byte(`{"content":"back"}`),
+			crawl.GenerateSingleArticleApiURL(10): []byte(`{"title":"t2","content":"c"}`),
+			crawl.GenerateSingleArticleApiURL(12): []byte(`{"title":"t","content":"back"}`),
+		},
+		errs: map[string]error{
+			crawl.GenerateSingleAnswerApiURL(3):   request.ErrUnreachable,
+			crawl.GenerateSingleArticleApiURL(11): request.ErrUnreachable,
+		},
+	}
+	parser := &recheckParser{}
+
+	result, err := Recheck(context.Background(), time.Now(), db, rs, parser, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.Equal(t, RecheckResult{Checked: 7, Edited: 2, Deleted: 2, Restored: 2}, result)
+	assert.Equal(t, [][]byte{[]byte(`{"content":"v2","voteup_count":1}`)}, parser.answers, "赞数变化不算修改")
+	assert.Equal(t, [][]byte{[]byte(`{"title":"t2","content":"c"}`)}, parser.articles)
+	assert.Equal(t, []int{3}, db.deletedAnswers)
+	assert.Equal(t, []int{11}, db.deletedArticles)
+	assert.Equal(t, []int{4}, db.restored)
+	assert.Equal(t, []int{12}, db.restoredArticles, "正文未变的文章也要恢复")
+}
+
+func TestRecheckAbortsOnAuthError(t *testing.T) {
+	db := &recheckDB{answers: []zhihuDB.Answer{{ID: 1}, {ID: 2}}}
+	rs := &recheckRequester{errs: map[string]error{
+		crawl.GenerateSingleAnswerApiURL(1): request.ErrInvalidZSECK,
+	}}
+
+	result, err := Recheck(context.Background(), time.Now(), db, rs, &recheckParser{}, zap.NewNop())
+	assert.ErrorIs(t, err, request.ErrInvalidZSECK)
+	assert.Equal(t, 1, result.Checked)
+}
@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse"
+	apiModels "github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse/api_models"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// countingParser 统计一次订阅抓取解析到的条目数，写入订阅统计的 ItemsFound。
+type countingParser struct {
+	parse.Parser
+	found int
+}
+
+func (p *countingParser) ParseAnswer(content []byte, authorID string, logger *zap.Logger) error {
+	p.found++
+	return p.Parser.ParseAnswer(content, authorID, logger)
+}
+
+func (p *countingParser) ParseArticle(content []byte, logger *zap.Logger) error {
+	p.found++
+	return p.Parser.ParseArticle(content, logger)
+}
+
+func (p *countingParser) ParsePin(content []byte, logger *zap.Logger) error {
+	p.found++
+	return p.Parser.ParsePin(content, logger)
+}
+
+// ParseListItem 按列表项计数，内部的回答、文章入库不经过这层包装，不会重复计入。
+func (p *countingParser) ParseListItem(t common.ZhihuContentType, listID string, item apiModels.ListItem, content []byte, logger *zap.Logger) error {
+	p.found++
+	return p.Parser.ParseListItem(t, listID, item, content, logger)
+}
+
+// countSubItems 返回订阅对应内容在库中的条数，抓取前后相减即新入库条数。
+func countSubItems(sub zhihuDB.Sub, dbService zhihuDB.DB) (int, error) {
+	contentCrawler, ok := zhihuContentCrawlers[sub.Type]
+	if !ok {
+		return 0, fmt.Errorf("unknown zhihu sub type: %q", sub.Type)
+	}
+	return contentCrawler.count(sub.AuthorID, dbService)
+}
+
+// classifyCrawlErr 归类知乎特有的抓取错误，返回空串时由 subscriptionDB.ClassifyError 推断。
+func classifyCrawlErr(err error) string {
+	switch {
+	case errors.Is(err, request.ErrNeedZC0), errors.Is(err, request.ErrInvalidZSECK), errors.Is(err, request.ErrInvalidZC0):
+		return subscriptionDB.ErrorClassAuth
+	case errors.Is(err, request.ErrAccountDestroyed):
+		return subscriptionDB.ErrorClassGone
+	case errors.Is(err, zhihuDB.ErrNoAvailableService), errors.Is(err, request.ErrBadResponse),
+		errors.Is(err, request.ErrForbidden), errors.Is(err, request.ErrUnreachable):
+		return subscriptionDB.ErrorClassUpstream
+	case errors.Is(err, request.ErrMaxRetry):
+		return subscriptionDB.ErrorClassNetwork
+	default:
+		return ""
+	}
+}
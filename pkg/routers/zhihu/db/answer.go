@@ -2,13 +2,14 @@ package db
 
 import (
 	"fmt"
-	"math/rand/v2"
 	"slices"
 	"time"
 
 	"gorm.io/gorm"
 
 	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/pkg/common"
+	readingDB "github.com/eli-yip/rss-zero/pkg/reading/db"
 )
 
 type DBAnswer interface {
@@ -16,7 +17,7 @@ type DBAnswer interface {
 	SaveAnswer(a *Answer) error
 	// SaveAnswerTx 在单事务内提交一条 answer 产生的全部行：问题、图片对象、answer 根行。
 	// 原子性来自事务本身（一起提交或一起回滚），故不留可见半态；写入顺序（根行最后写）
-	// 只是可读性约定，无 FK 强制，不改变回滚语义。
+	// 只是可读性约定，无 FK 强制，不改变回滚语义。库里已有的 answer 正文变化时，旧版本存入 zhihu_revision。
 	SaveAnswerTx(answer *Answer, question *Question, objects []Object) error
 	GetLatestNAnswer(n int, userID string) ([]Answer, error)
 	// GetLatestNVisibleAnswer is GetLatestNAnswer excluding answers hidden by
@@ -30,7 +31,8 @@ type DBAnswer interface {
 	FetchNAnswer(int, FetchAnswerOption) ([]Answer, error)
 	FetchAnswer(author string, limit, offset int) ([]Answer, error)
 	FetchAnswerByIDs(ids []int) (map[int]Answer, error)
-	FetchAnswerWithDateRange(author string, limit, offset, order int, startTime, endTime time.Time) ([]Answer, error)
+	// FetchAnswerWithDateRange 的 scopes 在默认条件与排序之后应用，可追加过滤或替换排序。
+	FetchAnswerWithDateRange(author string, limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]Answer, error)
 	// UpdateAnswerStatus update answer status in zhihu_answer table
 	UpdateAnswerStatus(id int, status int) error
 	// GetLatestAnswerTime get the latest answer time from zhihu_answer table
@@ -40,12 +42,19 @@ type DBAnswer interface {
 	// GetAnswer get answer info from zhihu_answer table
 	GetAnswer(id int) (*Answer, error)
 	CountAnswer(userID string) (int, error)
-	CountAnswerWithDateRange(userID string, startTime, endTime time.Time) (int, error)
+	// GetLatestNQuestionAnswer returns the latest n answers of a question, whoever wrote them.
+	GetLatestNQuestionAnswer(n int, questionID int) ([]Answer, error)
+	// GetLatestNVisibleQuestionAnswer is GetLatestNQuestionAnswer excluding answers hidden
+	// by content detection. Used by RSS generation.
+	GetLatestNVisibleQuestionAnswer(n int, questionID int) ([]Answer, error)
+	CountQuestionAnswer(questionID int) (int, error)
+	CountAnswerWithDateRange(userID string, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error)
 	FetchNAnswersBeforeTime(n int, t time.Time, userID string) ([]Answer, error)
 	// RandomSelect select n random answers from zhihu_answer table
 	//
 	// Answers are created after 2023-01-01, and the word count is between 300 and 1200.
-	RandomSelect(n int, userID string) ([]Answer, error)
+	// Answers matching all prefer scopes are picked first; the rest only fill up when there are fewer than n.
+	RandomSelect(n int, userID string, prefer ...func(*gorm.DB) *gorm.DB) ([]Answer, error)
 	SelectByID(ids []int) ([]Answer, error)
 	SelectAnswerIDsWithAuthorID(authorID string) ([]int, error)
 }
@@ -131,6 +140,9 @@ func (d *DBService) SaveAnswerTx(answer *Answer, question *Question, objects []O
 				return fmt.Errorf("failed to save object %d: %w", objects[i].ID, err)
 			}
 		}
+		if err := archiveRevision(tx, &Answer{}, common.ZhihuAnswer, answer.ID, answer.AuthorID, answer.Raw); err != nil {
+			return err
+		}
 		if err := tx.Save(answer).Error; err != nil {
 			return fmt.Errorf("failed to save answer root %d: %w", answer.ID, err)
 		}
@@ -162,6 +174,22 @@ func (d *DBService) GetVisibleAnswerAfter(userID string, t time.Time) ([]Answer,
 	return as, nil
 }
 
+func (d *DBService) GetLatestNQuestionAnswer(n int, questionID int) ([]Answer, error) {
+	as := make([]Answer, 0, n)
+	if err := d.Where("question_id = ?", questionID).Order("create_at desc").Limit(n).Find(&as).Error; err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
+func (d *DBService) GetLatestNVisibleQuestionAnswer(n int, questionID int) ([]Answer, error) {
+	as := make([]Answer, 0, n)
+	if err := d.Where("question_id = ?", questionID).Where("detect_status <> ?", DetectStatusSkipped).Order("create_at desc").Limit(n).Find(&as).Error; err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
 func (d *DBService) FetchNAnswersBeforeTime(n int, t time.Time, userID string) (as []Answer, err error) {
 	err = d.Where("author_id = ? and create_at < ?", userID, t).Order("create_at desc").Limit(n).Find(&as).Error
 	return as, err
@@ -189,9 +217,17 @@ func (d *DBService) CountAnswer(userID string) (int, error) {
 	return int(count), nil
 }
 
-func (d *DBService) CountAnswerWithDateRange(userID string, startTime, endTime time.Time) (int, error) {
+func (d *DBService) CountQuestionAnswer(questionID int) (int, error) {
 	var count int64
-	if err := d.Model(&Answer{}).Where("author_id = ?", userID).Where("create_at >= ?", startTime).Where("create_at <= ?", endTime).Count(&count).Error; err != nil {
+	if err := d.Model(&Answer{}).Where("question_id = ?", questionID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (d *DBService) CountAnswerWithDateRange(userID string, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error) {
+	var count int64
+	if err := d.Model(&Answer{}).Where("author_id = ?", userID).Where("create_at >= ?", startTime).Where("create_at <= ?", endTime).Scopes(scopes...).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return int(count), nil
@@ -233,7 +269,7 @@ func (d *DBService) FetchAnswer(author string, limit, offset int) ([]Answer, err
 	return as, nil
 }
 
-func (d *DBService) FetchAnswerWithDateRange(author string, limit, offset, order int, startTime, endTime time.Time) ([]Answer, error) {
+func (d *DBService) FetchAnswerWithDateRange(author string, limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]Answer, error) {
 	as := make([]Answer, 0, limit)
 	stmt := d.Where("author_id = ?", author).Where("create_at >= ?", startTime).Where("create_at < ?", endTime).Limit(limit).Offset(offset)
 	if order == 0 {
@@ -241,7 +277,7 @@ func (d *DBService) FetchAnswerWithDateRange(author string, limit, offset, order
 	} else {
 		stmt = stmt.Order("create_at asc")
 	}
-	if err := stmt.Find(&as).Error; err != nil {
+	if err := stmt.Scopes(scopes...).Find(&as).Error; err != nil {
 		return nil, err
 	}
 	return as, nil
@@ -278,33 +314,33 @@ func (d *DBService) UpdateAnswerStatus(id int, status int) error {
 	return d.Model(&Answer{}).Where("id = ?", id).Update("status", status).Error
 }
 
-func (d *DBService) RandomSelect(n int, userID string) (answers []Answer, err error) {
+func (d *DBService) RandomSelect(n int, userID string, prefer ...func(*gorm.DB) *gorm.DB) (answers []Answer, err error) {
 	/**
 	 * Note: The following code is not efficient, but it is simple:
-	 * 1. Get all answer ids of the user.
-	 * 2. Shuffle the answer ids.
+	 * 1. Get all answer ids of the user, and those matching prefer scopes.
+	 * 2. Shuffle the answer ids, preferred ones first.
 	 * 3. Select the first n answer ids.
 	 */
 
 	answers = make([]Answer, 0, n)
 
-	answerIDs := make([]int, 0, n)
 	date := time.Date(2023, 1, 1, 0, 0, 0, 0, config.C.BJT)
-	d.Model(&Answer{}).Where("author_id = ?", userID).Where("create_at >= ?", date).Where("word_count between ? and ?", 300, 1200).Pluck("id", &answerIDs)
+	candidates := func() *gorm.DB {
+		return d.Model(&Answer{}).Where("author_id = ?", userID).Where("create_at >= ?", date).Where("word_count between ? and ?", 300, 1200)
+	}
 
-	if len(answerIDs) <= n {
-		if err = d.Where("id in ?", answerIDs).Find(&answers).Error; err != nil {
-			return nil, fmt.Errorf("failed to get answers: %w", err)
+	answerIDs := make([]int, 0, n)
+	if err = candidates().Pluck("id", &answerIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get answer ids: %w", err)
+	}
+	var preferredIDs []int
+	if len(prefer) > 0 {
+		if err = candidates().Scopes(prefer...).Pluck("id", &preferredIDs).Error; err != nil {
+			return nil, fmt.Errorf("failed to get preferred answer ids: %w", err)
 		}
-		return answers, nil
 	}
 
-	// shuffle answerIDs
-	rand.Shuffle(len(answerIDs), func(i, j int) {
-		answerIDs[i], answerIDs[j] = answerIDs[j], answerIDs[i]
-	})
-
-	answerIDs = answerIDs[:n]
+	answerIDs = readingDB.PickPreferred(answerIDs, preferredIDs, n)
 
 	if err := d.Where("id in ?", answerIDs).Find(&answers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get answers: %w", err)
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
 )
 
 type Article struct {
@@ -14,6 +16,8 @@ type Article struct {
 	UpdateAt time.Time `gorm:"column:update_at;type:timestamptz"`
 	Title    string    `gorm:"column:title;type:text"`
 	Raw      []byte    `gorm:"column:raw;type:bytea"`
+	// Deleted 表示复查发现文章在知乎已被删除，由 MarkArticleDeleted / RestoreArticle 维护，保存根行时不覆盖
+	Deleted bool `gorm:"column:deleted;type:bool;not null;default:false"`
 }
 
 func (p *Article) TableName() string { return "zhihu_article" }
@@ -23,12 +27,13 @@ type DBArticle interface {
 	SaveArticle(p *Article) error
 	// SaveArticleTx 在单事务内提交一条 article 产生的全部行：作者、图片对象、article 根行。
 	// 原子性来自事务本身（任一步失败整体回滚，见 plan 决策 4）；根行最后写只是可读性约定，
-	// 无 FK 强制、不改变回滚语义。
+	// 无 FK 强制、不改变回滚语义。库里已有的 article 正文或标题变化时，旧版本存入 zhihu_revision。
 	SaveArticleTx(article *Article, author *Author, objects []Object) error
 	GetLatestNArticle(n int, authorID string) ([]Article, error)
 	GetLatestArticleTime(authorID string) (time.Time, error)
 	FetchNArticle(n int, opt FetchArticleOption) (as []Article, err error)
 	GetArticleAfter(authorID string, t time.Time) ([]Article, error)
+	FetchArticleByIDs(ids []int) (map[int]Article, error)
 	CountArticle(authorID string) (int, error)
 	FetchNArticlesBeforeTime(n int, t time.Time, authorID string) (as []Article, err error)
 }
@@ -41,7 +46,7 @@ func (d *DBService) GetArticle(id int) (*Article, error) {
 	return &a, nil
 }
 
-func (d *DBService) SaveArticle(p *Article) error { return d.Save(p).Error }
+func (d *DBService) SaveArticle(p *Article) error { return d.Omit("deleted").Save(p).Error }
 
 // SaveArticleTx 把一条 article 解析出的全部事实行放进同一个事务提交：作者、各图片对象、
 // article 根行。原子性来自事务本身（任一步失败整体回滚）；根行最后写只是可读性约定，无 FK
@@ -58,7 +63,10 @@ func (d *DBService) SaveArticleTx(article *Article, author *Author, objects []Ob
 				return fmt.Errorf("failed to save object %d: %w", objects[i].ID, err)
 			}
 		}
-		if err := tx.Save(article).Error; err != nil {
+		if err := archiveRevision(tx, &Article{}, common.ZhihuArticle, article.ID, article.AuthorID, article.Raw); err != nil {
+			return err
+		}
+		if err := tx.Omit("deleted").Save(article).Error; err != nil {
 			return fmt.Errorf("failed to save article root %d: %w", article.ID, err)
 		}
 		return nil
@@ -130,3 +138,17 @@ func (d *DBService) GetArticleAfter(authorID string, t time.Time) ([]Article, er
 	}
 	return as, nil
 }
+
+func (d *DBService) FetchArticleByIDs(ids []int) (map[int]Article, error) {
+	articles := make([]Article, 0, len(ids))
+	if err := d.Where("id in ?", ids).Find(&articles).Error; err != nil {
+		return nil, fmt.Errorf("failed to get articles by ids: %w", err)
+	}
+
+	result := make(map[int]Article, len(articles))
+	for _, article := range articles {
+		result[article.ID] = article
+	}
+
+	return result, nil
+}
@@ -10,11 +10,13 @@ type DB interface {
 	DBAnswer
 	DBQuestion
 	DBArticle
+	DBRevision
 	DBPin
 	DBAuthor
 	DBObject
 	DBSub
 	DBZvideo
+	DBList
 	EncryptionServiceIface
 }
 
@@ -15,25 +15,52 @@ type EncryptionServiceIface interface {
 	DeleteService(string) error
 	GetService(id string) (*EncryptionService, error)
 	GetServiceBySlug(slug string) (*EncryptionService, error)
+	// MarkAvailable 与 MarkUnavailable 是健康探测与请求失败时的自动上下线，不会上线管理员停用的服务
 	MarkAvailable(id string) error
 	MarkUnavailable(id string) error
+	// EnableService 与 DisableService 是管理员手动上下线，停用后只能手动重新启用
+	EnableService(id string) error
+	DisableService(id string) error
 	IncreaseUsedCount(id string) error
 	IncreaseFailedCount(id string) error
+	// RecordProbe 记录一次健康探测的结果，并衰减近期计数。
+	RecordProbe(id string, probe ProbeRecord) error
 	SelectService() (*EncryptionService, error)
 }
 
 type EncryptionService struct {
-	ID          string         `gorm:"column:id;type:string;primary_key" json:"id"`
-	Slug        string         `gorm:"column:slug;type:string;unique" json:"slug"`
-	URL         string         `gorm:"column:url;type:string" json:"url"`
-	IsAvailable bool           `gorm:"column:is_available;type:bool" json:"is_available"`
-	UsedCount   int            `gorm:"column:used_count;type:int" json:"used_count"`
-	FailedCount int            `gorm:"column:failed_count;type:int" json:"failed_count"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeleteAt    gorm.DeletedAt `json:"delete_at"`
+	ID          string `gorm:"column:id;type:string;primary_key" json:"id"`
+	Slug        string `gorm:"column:slug;type:string;unique" json:"slug"`
+	URL         string `gorm:"column:url;type:string" json:"url"`
+	IsAvailable bool   `gorm:"column:is_available;type:bool" json:"is_available"`
+	UsedCount   int    `gorm:"column:used_count;type:int" json:"used_count"`
+	FailedCount int    `gorm:"column:failed_count;type:int" json:"failed_count"`
+	// RecentUsed 与 RecentFailed 是近期的请求与失败次数，每次健康探测按 RecentDecay 衰减，SelectService 据此加权
+	RecentUsed   float64 `gorm:"column:recent_used;type:double precision;not null;default:0" json:"recent_used"`
+	RecentFailed float64 `gorm:"column:recent_failed;type:double precision;not null;default:0" json:"recent_failed"`
+	// ProbeFailures 是连续探测失败的次数，探测成功后清零
+	ProbeFailures  int            `gorm:"column:probe_failures;type:int;not null;default:0" json:"probe_failures"`
+	LastProbeAt    *time.Time     `gorm:"column:last_probe_at" json:"last_probe_at"`
+	LastLatencyMs  int64          `gorm:"column:last_latency_ms;type:bigint" json:"last_latency_ms"`
+	LastProbeError string         `gorm:"column:last_probe_error;type:text" json:"last_probe_error"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeleteAt       gorm.DeletedAt `json:"delete_at"`
+
+	// AdminDisabled 表示管理员手动停用，此时 IsAvailable 为 false，探测成功也不会自动上线
+	AdminDisabled bool `gorm:"column:admin_disabled;type:bool;not null;default:false" json:"admin_disabled"`
 }
 
+// ProbeRecord 是一次健康探测的结果，Err 为空表示签名正确。
+type ProbeRecord struct {
+	At      time.Time
+	Latency time.Duration
+	Err     string
+}
+
+// RecentDecay 是每次健康探测时近期计数的衰减系数，即每轮探测后旧记录的权重减半。
+const RecentDecay = 0.5
+
 var ErrSlugExists = errors.New("slug should be unique")
 
 func (d *EncryptionService) TableName() string { return "zhihu_encryption_service" }
@@ -87,19 +114,56 @@ func (d *DBService) GetServiceBySlug(slug string) (*EncryptionService, error) {
 }
 
 func (d *DBService) MarkAvailable(id string) error {
-	return d.Model(&EncryptionService{}).Where("id = ?", id).Update("is_available", true).Error
+	return d.Model(&EncryptionService{}).Where("id = ? AND NOT admin_disabled", id).Update("is_available", true).Error
 }
 
 func (d *DBService) MarkUnavailable(id string) error {
 	return d.Model(&EncryptionService{}).Where("id = ?", id).Update("is_available", false).Error
 }
 
+func (d *DBService) EnableService(id string) error {
+	return d.Model(&EncryptionService{}).Where("id = ?", id).UpdateColumns(map[string]any{
+		"is_available":   true,
+		"admin_disabled": false,
+	}).Error
+}
+
+func (d *DBService) DisableService(id string) error {
+	return d.Model(&EncryptionService{}).Where("id = ?", id).UpdateColumns(map[string]any{
+		"is_available":   false,
+		"admin_disabled": true,
+	}).Error
+}
+
 func (d *DBService) IncreaseUsedCount(id string) error {
-	return d.Model(&EncryptionService{}).Where("id = ?", id).UpdateColumn("used_count", gorm.Expr("used_count + ?", 1)).Error
+	return d.Model(&EncryptionService{}).Where("id = ?", id).UpdateColumns(map[string]any{
+		"used_count":  gorm.Expr("used_count + ?", 1),
+		"recent_used": gorm.Expr("recent_used + ?", 1),
+	}).Error
 }
 
 func (d *DBService) IncreaseFailedCount(id string) error {
-	return d.Model(&EncryptionService{}).Where("id = ?", id).UpdateColumn("failed_count", gorm.Expr("failed_count + ?", 1)).Error
+	return d.Model(&EncryptionService{}).Where("id = ?", id).UpdateColumns(map[string]any{
+		"failed_count":  gorm.Expr("failed_count + ?", 1),
+		"recent_failed": gorm.Expr("recent_failed + ?", 1),
+	}).Error
+}
+
+// RecordProbe 先按 RecentDecay 衰减近期计数，再把这次探测计为一次请求（失败时同时计为一次失败），
+// 并更新连续失败次数与最近一次探测的耗时和错误。探测不计入 used_count 与 failed_count。
+func (d *DBService) RecordProbe(id string, probe ProbeRecord) error {
+	failed, probeFailures := 0, gorm.Expr("0")
+	if probe.Err != "" {
+		failed, probeFailures = 1, gorm.Expr("probe_failures + 1")
+	}
+	return d.Model(&EncryptionService{}).Where("id = ?", id).UpdateColumns(map[string]any{
+		"recent_used":      gorm.Expr("recent_used * ? + 1", RecentDecay),
+		"recent_failed":    gorm.Expr("recent_failed * ? + ?", RecentDecay, failed),
+		"probe_failures":   probeFailures,
+		"last_probe_at":    probe.At,
+		"last_latency_ms":  probe.Latency.Milliseconds(),
+		"last_probe_error": probe.Err,
+	}).Error
 }
 
 var ErrNoAvailableService = errors.New("no available services")
@@ -114,33 +178,34 @@ func (d *DBService) SelectService() (*EncryptionService, error) {
 		return nil, ErrNoAvailableService
 	}
 
+	if service := pickWeighted(services, rand.Float64()); service != nil {
+		return service, nil
+	}
+	return nil, errors.New("failed to select a service")
+}
+
+// Weight 是服务被 SelectService 选中的权重：近期成功率，分子分母各加 1 和 2 做平滑，
+// 因此刚注册或近期没有请求的服务权重为 0.5，仍有机会被选中。
+func (s *EncryptionService) Weight() float64 {
+	return (s.RecentUsed - s.RecentFailed + 1) / (s.RecentUsed + 2)
+}
+
+// pickWeighted 按 Weight 加权选择一个服务，r 为 [0, 1) 之间的随机数。
+func pickWeighted(services []EncryptionService, r float64) *EncryptionService {
 	weights := make([]float64, len(services))
 	totalWeight := 0.0
-
-	for i, service := range services {
-		if service.UsedCount == 0 {
-			weights[i] = 0
-		} else {
-			successCount := float64(service.UsedCount - service.FailedCount)
-			totalCount := float64(service.UsedCount)
-			weights[i] = successCount / totalCount
-		}
+	for i := range services {
+		weights[i] = max(services[i].Weight(), 0)
 		totalWeight += weights[i]
 	}
 
-	if totalWeight == 0 {
-		return nil, errors.New("all available services have zero weight")
-	}
-
-	randomWeight := rand.Float64() * totalWeight
+	randomWeight := r * totalWeight
 	cumulativeWeight := 0.0
-
 	for i, weight := range weights {
 		cumulativeWeight += weight
 		if randomWeight < cumulativeWeight {
-			return &services[i], nil
+			return &services[i]
 		}
 	}
-
-	return nil, errors.New("failed to select a service")
+	return nil
 }
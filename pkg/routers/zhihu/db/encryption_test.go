@@ -0,0 +1,30 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptionServiceWeight(t *testing.T) {
+	fresh := EncryptionService{}
+	healthy := EncryptionService{RecentUsed: 8, RecentFailed: 0}
+	failing := EncryptionService{RecentUsed: 8, RecentFailed: 8}
+
+	assert.InDelta(t, 0.5, fresh.Weight(), 1e-9)
+	assert.InDelta(t, 0.9, healthy.Weight(), 1e-9)
+	assert.InDelta(t, 0.1, failing.Weight(), 1e-9)
+}
+
+func TestPickWeighted(t *testing.T) {
+	services := []EncryptionService{
+		{ID: "failing", RecentUsed: 8, RecentFailed: 8},
+		{ID: "healthy", RecentUsed: 8},
+	}
+
+	// 总权重为 1.0，failing 占 [0, 0.1)，healthy 占 [0.1, 1.0)
+	assert.Equal(t, "failing", pickWeighted(services, 0.05).ID)
+	assert.Equal(t, "healthy", pickWeighted(services, 0.1).ID)
+	assert.Equal(t, "healthy", pickWeighted(services, 0.99).ID)
+	assert.Nil(t, pickWeighted(nil, 0.5))
+}
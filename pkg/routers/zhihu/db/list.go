@@ -0,0 +1,67 @@
+package db
+
+import (
+	"time"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+)
+
+// List 是一个专栏或公开收藏夹，Type 为 common.ZhihuColumn 或 common.ZhihuCollection。
+// 专栏 id 是专栏的 url token，收藏夹 id 是数字。
+type List struct {
+	ID    string                  `gorm:"column:id;type:text;primaryKey"`
+	Type  common.ZhihuContentType `gorm:"column:type;type:int;primaryKey"`
+	Title string                  `gorm:"column:title;type:text"`
+}
+
+func (*List) TableName() string { return "zhihu_list" }
+
+// ListItem 记录一条回答或文章出现在某个专栏或收藏夹里，内容本身仍存在 zhihu_answer 与 zhihu_article。
+type ListItem struct {
+	ListType    common.ZhihuContentType `gorm:"column:list_type;type:int;primaryKey"`
+	ListID      string                  `gorm:"column:list_id;type:text;primaryKey"`
+	ContentType common.ZhihuContentType `gorm:"column:content_type;type:int;primaryKey"`
+	ContentID   int                     `gorm:"column:content_id;type:bigint;primaryKey"`
+	// AddedAt 是内容进入列表的时间：专栏取发布时间，收藏夹取收藏时间
+	AddedAt time.Time `gorm:"column:added_at;type:timestamptz;index"`
+}
+
+func (*ListItem) TableName() string { return "zhihu_list_item" }
+
+type DBList interface {
+	SaveList(l *List) error
+	// GetList 返回专栏或收藏夹，不存在时返回 gorm.ErrRecordNotFound。
+	GetList(t common.ZhihuContentType, id string) (*List, error)
+	SaveListItem(item *ListItem) error
+	// GetListItems 按加入时间倒序返回列表里的内容，跳过前 offset 条。
+	GetListItems(t common.ZhihuContentType, id string, n, offset int) ([]ListItem, error)
+	CountListItem(t common.ZhihuContentType, id string) (int, error)
+}
+
+func (d *DBService) SaveList(l *List) error { return d.Save(l).Error }
+
+func (d *DBService) GetList(t common.ZhihuContentType, id string) (*List, error) {
+	var l List
+	if err := d.Where("type = ? and id = ?", t, id).First(&l).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (d *DBService) SaveListItem(item *ListItem) error { return d.Save(item).Error }
+
+func (d *DBService) GetListItems(t common.ZhihuContentType, id string, n, offset int) ([]ListItem, error) {
+	items := make([]ListItem, 0, n)
+	if err := d.Where("list_type = ? and list_id = ?", t, id).Order("added_at desc").Offset(offset).Limit(n).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (d *DBService) CountListItem(t common.ZhihuContentType, id string) (int, error) {
+	var count int64
+	if err := d.Model(&ListItem{}).Where("list_type = ? and list_id = ?", t, id).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
@@ -31,10 +31,10 @@ type DBPin interface {
 	GetLatestPinTime(userID string) (time.Time, error)
 	FetchNPin(n int, opt FetchPinOption) (ps []Pin, err error)
 	FetchPinByIDs(ids []int) (map[int]Pin, error)
-	FetchPinWithDateRange(authorID string, limit, offset, order int, startTime, endTime time.Time) (ps []Pin, err error)
+	FetchPinWithDateRange(authorID string, limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (ps []Pin, err error)
 	GetPinAfter(authorID string, t time.Time) ([]Pin, error)
 	CountPin(authorID string) (int, error)
-	CountPinWithDateRange(authorID string, startTime, endTime time.Time) (int, error)
+	CountPinWithDateRange(authorID string, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error)
 	FetchNPinsBeforeTime(n int, t time.Time, authorID string) (ps []Pin, err error)
 }
 
@@ -149,7 +149,7 @@ func (d *DBService) FetchNPin(n int, opt FetchPinOption) (ps []Pin, err error) {
 	return ps, nil
 }
 
-func (d *DBService) FetchPinWithDateRange(authorID string, limit, offset, order int, startTime, endTime time.Time) (ps []Pin, err error) {
+func (d *DBService) FetchPinWithDateRange(authorID string, limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (ps []Pin, err error) {
 	ps = make([]Pin, 0, limit)
 	stmt := d.Where("author_id = ? and create_at >= ? and create_at < ?", authorID, startTime, endTime).Order("create_at desc").Limit(limit).Offset(offset)
 	if order == 0 {
@@ -157,7 +157,7 @@ func (d *DBService) FetchPinWithDateRange(authorID string, limit, offset, order
 	} else {
 		stmt = stmt.Order("create_at asc")
 	}
-	if err := stmt.Find(&ps).Error; err != nil {
+	if err := stmt.Scopes(scopes...).Find(&ps).Error; err != nil {
 		return nil, err
 	}
 	return ps, nil
@@ -171,9 +171,9 @@ func (d *DBService) GetPinAfter(authorID string, t time.Time) ([]Pin, error) {
 	return ps, nil
 }
 
-func (d *DBService) CountPinWithDateRange(authorID string, startTime, endTime time.Time) (int, error) {
+func (d *DBService) CountPinWithDateRange(authorID string, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error) {
 	var count int64
-	if err := d.Model(&Pin{}).Where("author_id = ? and create_at >= ? and create_at < ?", authorID, startTime, endTime).Count(&count).Error; err != nil {
+	if err := d.Model(&Pin{}).Where("author_id = ? and create_at >= ? and create_at < ?", authorID, startTime, endTime).Scopes(scopes...).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return int(count), nil
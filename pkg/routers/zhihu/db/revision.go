@@ -0,0 +1,140 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+)
+
+// Revision 保存回答或文章被覆盖前的一个版本。当前版本仍只在根行，每次重新抓取到不同正文时，
+// 被覆盖的旧 raw 存为一行；Deleted 为 true 的行记录内容在知乎被删除，raw 是删除前最后抓到的版本。
+type Revision struct {
+	ID        int    `gorm:"column:id;primaryKey;autoIncrement"`
+	Type      string `gorm:"column:type;type:text;index:idx_zhihu_revision_content,priority:1"` // common.ZhihuContentType
+	ContentID int    `gorm:"column:content_id;type:bigint;index:idx_zhihu_revision_content,priority:2"`
+	AuthorID  string `gorm:"column:author_id;type:text;index"`
+	// UpdateAt 是该版本在知乎的更新时间
+	UpdateAt   time.Time `gorm:"column:update_at;type:timestamptz"`
+	Raw        []byte    `gorm:"column:raw;type:bytea"`
+	Deleted    bool      `gorm:"column:deleted;type:bool;not null;default:false"`
+	DetectedAt time.Time `gorm:"column:detected_at;type:timestamptz;autoCreateTime;index"`
+}
+
+func (*Revision) TableName() string { return "zhihu_revision" }
+
+type DBRevision interface {
+	// ListRevisions 按发现先后返回一条内容的历史版本，不含根行里的当前版本。
+	ListRevisions(t common.ZhihuContentType, contentID int) ([]Revision, error)
+	// GetLatestNRevision 按发现时间倒序返回作者最近的 n 条修改与删除记录。
+	GetLatestNRevision(n int, authorID string) ([]Revision, error)
+	// MarkAnswerDeleted 把回答标记为不可达并记一条删除记录，已标记过时返回 false。
+	MarkAnswerDeleted(id int) (bool, error)
+	// MarkArticleDeleted 把文章标记为已删除并记一条删除记录，已标记过时返回 false。
+	MarkArticleDeleted(id int) (bool, error)
+	// RestoreArticle 清除文章的删除标记，用于删除后又能访问的文章。
+	RestoreArticle(id int) error
+}
+
+// ContentChanged 比较两份 raw 的标题与正文 HTML。raw 里的赞数、评论数等每次抓取都可能不同，
+// 不算作修改；任一份解析失败时退回逐字节比较。
+func ContentChanged(oldRaw, newRaw []byte) bool {
+	type content struct {
+		Title   string `json:"title"`
+		Content string `json:"content"`
+	}
+	var o, n content
+	if json.Unmarshal(oldRaw, &o) != nil || json.Unmarshal(newRaw, &n) != nil {
+		return !bytes.Equal(oldRaw, newRaw)
+	}
+	return o != n
+}
+
+// archiveRevision 在事务内、根行被覆盖前调用：库里已有该内容且正文不同时，把旧版本存为修订。
+func archiveRevision(tx *gorm.DB, model any, t common.ZhihuContentType, id int, authorID string, raw []byte) error {
+	var old struct {
+		Raw      []byte
+		UpdateAt time.Time
+	}
+	result := tx.Model(model).Select("raw", "update_at").Where("id = ?", id).Limit(1).Scan(&old)
+	if result.Error != nil {
+		return fmt.Errorf("failed to load previous %s %d: %w", t, id, result.Error)
+	}
+	if result.RowsAffected == 0 || !ContentChanged(old.Raw, raw) {
+		return nil
+	}
+	if err := tx.Create(&Revision{Type: string(t), ContentID: id, AuthorID: authorID, UpdateAt: old.UpdateAt, Raw: old.Raw}).Error; err != nil {
+		return fmt.Errorf("failed to save revision of %s %d: %w", t, id, err)
+	}
+	return nil
+}
+
+func (d *DBService) ListRevisions(t common.ZhihuContentType, contentID int) (revisions []Revision, err error) {
+	if err = d.Where("type = ? AND content_id = ?", string(t), contentID).Order("id").Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (d *DBService) GetLatestNRevision(n int, authorID string) (revisions []Revision, err error) {
+	if err = d.Where("author_id = ?", authorID).Order("detected_at desc, id desc").Limit(n).Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get latest revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (d *DBService) MarkAnswerDeleted(id int) (marked bool, err error) {
+	err = d.Transaction(func(tx *gorm.DB) error {
+		var answer Answer
+		if err := tx.First(&answer, id).Error; err != nil {
+			return fmt.Errorf("failed to get answer %d: %w", id, err)
+		}
+		if answer.Status == AnswerStatusUnreachable {
+			return nil
+		}
+		if err := tx.Create(&Revision{
+			Type: string(common.ZhihuAnswer), ContentID: id, AuthorID: answer.AuthorID,
+			UpdateAt: answer.UpdateAt, Raw: answer.Raw, Deleted: true,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to save deletion of answer %d: %w", id, err)
+		}
+		if err := tx.Model(&Answer{}).Where("id = ?", id).Update("status", AnswerStatusUnreachable).Error; err != nil {
+			return fmt.Errorf("failed to update answer %d status: %w", id, err)
+		}
+		marked = true
+		return nil
+	})
+	return marked, err
+}
+
+func (d *DBService) MarkArticleDeleted(id int) (marked bool, err error) {
+	err = d.Transaction(func(tx *gorm.DB) error {
+		var article Article
+		if err := tx.First(&article, id).Error; err != nil {
+			return fmt.Errorf("failed to get article %d: %w", id, err)
+		}
+		if article.Deleted {
+			return nil
+		}
+		if err := tx.Create(&Revision{
+			Type: string(common.ZhihuArticle), ContentID: id, AuthorID: article.AuthorID,
+			UpdateAt: article.UpdateAt, Raw: article.Raw, Deleted: true,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to save deletion of article %d: %w", id, err)
+		}
+		if err := tx.Model(&Article{}).Where("id = ?", id).Update("deleted", true).Error; err != nil {
+			return fmt.Errorf("failed to update article %d deleted: %w", id, err)
+		}
+		marked = true
+		return nil
+	})
+	return marked, err
+}
+
+func (d *DBService) RestoreArticle(id int) error {
+	return d.Model(&Article{}).Where("id = ?", id).Update("deleted", false).Error
+}
@@ -16,6 +16,10 @@ type Sub struct {
 	Type      common.ZhihuContentType `gorm:"column:type;type:int;primary_key"`
 	Finished  bool                    `gorm:"column:finished;type:boolean"`
 	DeletedAt gorm.DeletedAt
+
+	// LatestTime 是这个订阅自己抓到的最新内容时间，作增量抓取的水位。问题、专栏、收藏夹抓到的内容
+	// 会记在原作者名下，所以水位不能从内容表里按作者或问题推算。旧订阅为空，首次抓取时按库里内容补上。
+	LatestTime *time.Time `gorm:"column:latest_time"`
 }
 
 func (s *Sub) TableName() string { return "zhihu_sub" }
@@ -30,9 +34,13 @@ type DBSub interface {
 	GetSubsWithNoID() ([]Sub, error)
 	SetSubID(authorID string, subType common.ZhihuContentType, id string) error
 	SetStatus(authorID string, subType common.ZhihuContentType, finished bool) error
+	// SetLatestTime 推进订阅的增量水位，只会往后移
+	SetLatestTime(authorID string, subType common.ZhihuContentType, t time.Time) error
 	DeleteSub(id string) error
 	DeleteSubsByAuthor(authorID string) error
 	ActivateSub(id string) error
+	// PurgeSub 彻底删除订阅记录，已抓取的内容保留
+	PurgeSub(id string) error
 }
 
 func (d *DBService) AddSub(authorID string, subType common.ZhihuContentType) error {
@@ -106,6 +114,11 @@ func (d *DBService) SetStatus(authorID string, subType common.ZhihuContentType,
 	return d.Model(&Sub{}).Where("author_id = ? and type = ?", authorID, subType).Update("finished", finished).Error
 }
 
+func (d *DBService) SetLatestTime(authorID string, subType common.ZhihuContentType, t time.Time) error {
+	return d.Model(&Sub{}).Where("author_id = ? and type = ?", authorID, subType).
+		Where("latest_time IS NULL OR latest_time < ?", t).Update("latest_time", t).Error
+}
+
 func SetEmptySubID(db *gorm.DB) (n int, err error) {
 	zhihuDBService := NewDBService(db)
 	subs, err := zhihuDBService.GetSubsWithNoID()
@@ -135,3 +148,7 @@ func (d *DBService) DeleteSubsByAuthor(authorID string) (err error) {
 func (d *DBService) ActivateSub(id string) (err error) {
 	return d.Unscoped().Model(&Sub{}).Where("id = ?", id).Update("deleted_at", nil).Error
 }
+
+func (d *DBService) PurgeSub(id string) (err error) {
+	return d.Unscoped().Where("id = ?", id).Delete(&Sub{}).Error
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
 )
 
 // openTestDB 连接由 ZHIHU_TEST_DATABASE_URL 指定的临时 Postgres；未设置则跳过（对齐 zsxq /
@@ -25,7 +27,7 @@ func openTestDB(t *testing.T) *gorm.DB {
 
 func dropZhihuTables(t *testing.T, gdb *gorm.DB) {
 	t.Helper()
-	for _, table := range []string{"zhihu_answer", "zhihu_question", "zhihu_article", "zhihu_author", "zhihu_object", "zhihu_pin"} {
+	for _, table := range []string{"zhihu_answer", "zhihu_question", "zhihu_article", "zhihu_author", "zhihu_object", "zhihu_pin", "zhihu_revision"} {
 		require.NoError(t, gdb.Exec("DROP TABLE IF EXISTS "+table).Error)
 	}
 }
@@ -128,3 +130,70 @@ func TestSavePinTxAtomic(t *testing.T) {
 	assert.Zero(t, count(t, gdb, &Author{}), "作者应随事务回滚")
 	assert.Zero(t, count(t, gdb, &Object{}), "对象应随事务回滚")
 }
+
+// TestSaveAnswerTxArchivesRevision 验证重新抓取到不同正文时旧版本存为修订，只有赞数变化时不存。
+func TestSaveAnswerTxArchivesRevision(t *testing.T) {
+	gdb := openTestDB(t)
+	dropZhihuTables(t, gdb)
+	t.Cleanup(func() { dropZhihuTables(t, gdb) })
+	require.NoError(t, gdb.AutoMigrate(&Answer{}, &Question{}, &Object{}, &Revision{}))
+	store := NewDBService(gdb)
+
+	save := func(raw string) {
+		t.Helper()
+		require.NoError(t, store.SaveAnswerTx(
+			&Answer{ID: 100, QuestionID: 1, AuthorID: "canglimo", Raw: []byte(raw), Status: AnswerStatusCompleted},
+			&Question{ID: 1, Title: "标题"}, nil,
+		))
+	}
+	save(`{"content":"v1","voteup_count":1}`)
+	save(`{"content":"v1","voteup_count":2}`)
+	assert.Zero(t, count(t, gdb, &Revision{}), "赞数变化不应产生修订")
+
+	save(`{"content":"v2","voteup_count":2}`)
+	revisions, err := store.ListRevisions(common.ZhihuAnswer, 100)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.JSONEq(t, `{"content":"v1","voteup_count":2}`, string(revisions[0].Raw))
+	assert.False(t, revisions[0].Deleted)
+
+	marked, err := store.MarkAnswerDeleted(100)
+	require.NoError(t, err)
+	assert.True(t, marked)
+	marked, err = store.MarkAnswerDeleted(100)
+	require.NoError(t, err)
+	assert.False(t, marked, "重复标记删除应幂等")
+	assert.EqualValues(t, 2, count(t, gdb, &Revision{}))
+}
+
+// TestMarkArticleDeleted 验证文章删除标记幂等、重新保存根行不清除标记，恢复后可再次记删除。
+func TestMarkArticleDeleted(t *testing.T) {
+	gdb := openTestDB(t)
+	dropZhihuTables(t, gdb)
+	t.Cleanup(func() { dropZhihuTables(t, gdb) })
+	require.NoError(t, gdb.AutoMigrate(&Article{}, &Revision{}))
+	store := NewDBService(gdb)
+	save := func() {
+		t.Helper()
+		require.NoError(t, store.SaveArticleTx(&Article{ID: 300, AuthorID: "canglimo", Raw: []byte(`{"content":"v1"}`)}, nil, nil))
+	}
+	save()
+
+	marked, err := store.MarkArticleDeleted(300)
+	require.NoError(t, err)
+	assert.True(t, marked)
+	marked, err = store.MarkArticleDeleted(300)
+	require.NoError(t, err)
+	assert.False(t, marked, "重复标记删除应幂等")
+
+	save()
+	article, err := store.GetArticle(300)
+	require.NoError(t, err)
+	assert.True(t, article.Deleted, "保存根行不应清除删除标记")
+
+	require.NoError(t, store.RestoreArticle(300))
+	marked, err = store.MarkArticleDeleted(300)
+	require.NoError(t, err)
+	assert.True(t, marked, "恢复后再删除应重新记录")
+	assert.EqualValues(t, 2, count(t, gdb, &Revision{}))
+}
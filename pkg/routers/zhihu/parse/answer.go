@@ -15,6 +15,18 @@ import (
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/render"
 )
 
+// AnonymousAuthorID 是匿名回答入库时的作者 id，知乎对匿名用户不返回 url_token。
+const AnonymousAuthorID = "0"
+
+// AnswerAuthorID 返回回答入库时用的作者 id，匿名回答记在 AnonymousAuthorID 名下。
+// 作者订阅之外的来源（问题、专栏、收藏夹）里的回答用它。
+func AnswerAuthorID(author apiModels.Author) string {
+	if author.ID == "" {
+		return AnonymousAuthorID
+	}
+	return author.ID
+}
+
 type AnswerParser interface {
 	// answerExcerpt: answer list for answer id etc. why: some may need some info before parse raw message
 	ParseAnswerList(content []byte, index int, logger *zap.Logger) (paging apiModels.Paging, answersExcerpt []apiModels.Answer, answers []json.RawMessage, err error)
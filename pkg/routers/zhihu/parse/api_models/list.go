@@ -0,0 +1,38 @@
+package apiModels
+
+import "encoding/json"
+
+// ListPage 是专栏或收藏夹内容列表的一页。专栏的每一项就是内容本身；收藏夹的每一项是
+// CollectionItem，内容包在 content 里。
+type ListPage struct {
+	Paging Paging            `json:"paging"`
+	Data   []json.RawMessage `json:"data"`
+}
+
+type CollectionItem struct {
+	// Created 是收藏时间，知乎有时返回秒级时间戳，有时返回 RFC 3339 字符串
+	Created json.RawMessage `json:"created"`
+	Content json.RawMessage `json:"content"`
+}
+
+// ListItem 是列表项的摘要，Type 为 answer、article 或知乎的其他内容类型。
+type ListItem struct {
+	Type       string `json:"type"`
+	ID         int    `json:"-"`
+	RawID      any    `json:"id"`           // zhihu now returns both string and int
+	CreateAt   int64  `json:"created"`      // article
+	CreateTime int64  `json:"created_time"` // answer
+	Author     Author `json:"author"`
+	Title      string `json:"title"`
+	AddedAt    int64  `json:"-"` // 进入列表的时间，专栏取发布时间，收藏夹取收藏时间
+}
+
+// ListInfo 是专栏详情；收藏夹详情包在 CollectionInfo.Collection 里。
+type ListInfo struct {
+	RawID any    `json:"id"`
+	Title string `json:"title"`
+}
+
+type CollectionInfo struct {
+	Collection ListInfo `json:"collection"`
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"io"
 	"testing"
+
+	"github.com/eli-yip/rss-zero/internal/ai"
 )
 
 // fakeAI implements ai.AI for detector tests; only Classify is exercised.
@@ -13,11 +15,12 @@ type fakeAI struct {
 	calls int
 }
 
-func (f *fakeAI) Polish(text string) (string, error)        { return text, nil }
-func (f *fakeAI) Text(io.Reader) (string, error)            { return "", nil }
-func (f *fakeAI) Conclude(text string) (string, error)      { return text, nil }
-func (f *fakeAI) TranslateToZh(text string) (string, error) { return text, nil }
-func (f *fakeAI) Embed(text string) ([]float32, error)      { return nil, nil }
+func (f *fakeAI) Polish(text string) (string, error)            { return text, nil }
+func (f *fakeAI) Text(io.Reader) (string, error)                { return "", nil }
+func (f *fakeAI) Conclude(text string) (string, error)          { return text, nil }
+func (f *fakeAI) TranslateToZh(text string) (string, error)     { return text, nil }
+func (f *fakeAI) Embed(text string) ([]float32, error)          { return nil, nil }
+func (f *fakeAI) SummarizeMany([]ai.DigestItem) (string, error) { return "", nil }
 func (f *fakeAI) Classify(prompt string) (string, error) {
 	f.calls++
 	return f.reply, f.err
@@ -0,0 +1,155 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	apiModels "github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse/api_models"
+)
+
+// 列表项里会被抓取的内容类型，其余（想法、视频等）只跳过
+const (
+	listItemTypeAnswer  = "answer"
+	listItemTypeArticle = "article"
+)
+
+type ListParser interface {
+	// ParseListInfo parse result from api/v4/columns/{id} or api/v4/collections/{id},
+	// save the column or collection and return its title
+	ParseListInfo(t common.ZhihuContentType, listID string, apiResp []byte, logger *zap.Logger) (title string, err error)
+	// ParseListPage parse a page of api/v4/columns/{id}/items or api/v4/collections/{id}/items,
+	// contents are the raw answers and articles, unwrapped from collection items
+	ParseListPage(t common.ZhihuContentType, apiResp []byte, index int, logger *zap.Logger) (paging apiModels.Paging, itemsExcerpt []apiModels.ListItem, contents []json.RawMessage, err error)
+	// ParseListItem save the answer or article through ParseAnswer / ParseArticle, then record it in the list
+	ParseListItem(t common.ZhihuContentType, listID string, item apiModels.ListItem, content []byte, logger *zap.Logger) error
+}
+
+// ListItemSupported reports whether a list item is an answer or article that ParseListItem saves.
+func ListItemSupported(item apiModels.ListItem) bool {
+	return item.Type == listItemTypeAnswer || item.Type == listItemTypeArticle
+}
+
+func (p *ParseService) ParseListInfo(t common.ZhihuContentType, listID string, apiResp []byte, logger *zap.Logger) (title string, err error) {
+	var info apiModels.ListInfo
+	switch t {
+	case common.ZhihuColumn:
+		err = json.Unmarshal(apiResp, &info)
+	case common.ZhihuCollection:
+		var collection apiModels.CollectionInfo
+		err = json.Unmarshal(apiResp, &collection)
+		info = collection.Collection
+	default:
+		return emptyString, fmt.Errorf("unsupported zhihu list type: %s", t)
+	}
+	if err != nil {
+		return emptyString, fmt.Errorf("failed to unmarshal %s info: %w", t, err)
+	}
+	logger.Info("Unmarshal list info successfully", zap.String("list_type", t.Slug()), zap.String("list_id", listID))
+
+	if err = p.db.SaveList(&db.List{ID: listID, Type: t, Title: info.Title}); err != nil {
+		return emptyString, fmt.Errorf("failed to save %s %s to db: %w", t, listID, err)
+	}
+
+	return info.Title, nil
+}
+
+func (p *ParseService) ParseListPage(t common.ZhihuContentType, apiResp []byte, index int, logger *zap.Logger) (paging apiModels.Paging, itemsExcerpt []apiModels.ListItem, contents []json.RawMessage, err error) {
+	logger.Info("Start to parse list page", zap.String("list_type", t.Slug()), zap.Int("list_page_index", index))
+
+	page := apiModels.ListPage{}
+	if err = json.Unmarshal(apiResp, &page); err != nil {
+		logListPayloadDiagnostics(logger, t.Slug(), index, apiResp, err)
+		return apiModels.Paging{}, nil, nil, fmt.Errorf("failed to unmarshal %s page: %w", t, err)
+	}
+	logger.Info("Unmarshal list page successfully",
+		zap.Int("data_count", len(page.Data)),
+		zap.Int("paging_total", page.Paging.Totals),
+		zap.Bool("is_end", page.Paging.IsEnd))
+
+	for _, rawMessage := range page.Data {
+		content, addedAt := rawMessage, int64(0)
+		if t == common.ZhihuCollection {
+			collectionItem := apiModels.CollectionItem{}
+			if err = json.Unmarshal(rawMessage, &collectionItem); err != nil {
+				return apiModels.Paging{}, nil, nil, fmt.Errorf("failed to unmarshal collection item: %w, data: %s", err, string(rawMessage))
+			}
+			if addedAt, err = parseCollectedTime(collectionItem.Created); err != nil {
+				return apiModels.Paging{}, nil, nil, fmt.Errorf("failed to parse collected time: %w, data: %s", err, string(rawMessage))
+			}
+			content = collectionItem.Content
+		}
+
+		item := apiModels.ListItem{}
+		if err = json.Unmarshal(content, &item); err != nil {
+			return apiModels.Paging{}, nil, nil, fmt.Errorf("failed to unmarshal list item: %w, data: %s", err, string(rawMessage))
+		}
+		if ListItemSupported(item) {
+			if item.ID, err = anyToID(item.RawID); err != nil {
+				return apiModels.Paging{}, nil, nil, fmt.Errorf("failed to convert %s id from any to int: %w, data: %s", item.Type, err, string(rawMessage))
+			}
+		}
+		item.AddedAt = addedAt
+		if item.AddedAt == 0 {
+			item.AddedAt = item.CreateAt
+			if item.Type == listItemTypeAnswer {
+				item.AddedAt = item.CreateTime
+			}
+		}
+
+		itemsExcerpt = append(itemsExcerpt, item)
+		contents = append(contents, content)
+	}
+
+	return page.Paging, itemsExcerpt, contents, nil
+}
+
+func (p *ParseService) ParseListItem(t common.ZhihuContentType, listID string, item apiModels.ListItem, content []byte, logger *zap.Logger) (err error) {
+	contentType := common.ZhihuAnswer
+	switch item.Type {
+	case listItemTypeAnswer:
+		err = p.ParseAnswer(content, AnswerAuthorID(item.Author), logger)
+	case listItemTypeArticle:
+		contentType = common.ZhihuArticle
+		err = p.ParseArticle(content, logger)
+	default:
+		return fmt.Errorf("unsupported list item type: %s", item.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = p.db.SaveListItem(&db.ListItem{
+		ListType:    t,
+		ListID:      listID,
+		ContentType: contentType,
+		ContentID:   item.ID,
+		AddedAt:     time.Unix(item.AddedAt, 0),
+	}); err != nil {
+		return fmt.Errorf("failed to save %s item %d to db: %w", t, item.ID, err)
+	}
+	logger.Info("Save list item to db successfully")
+
+	return nil
+}
+
+// parseCollectedTime 解析收藏夹项的收藏时间，兼容秒级时间戳与 RFC 3339 字符串。
+func parseCollectedTime(raw json.RawMessage) (int64, error) {
+	if len(raw) == 0 {
+		return 0, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return strconv.ParseInt(string(raw), 10, 64)
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
@@ -0,0 +1,80 @@
+package parse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+)
+
+type listDB struct {
+	db.DB
+	lists []db.List
+}
+
+func (d *listDB) SaveList(l *db.List) error {
+	d.lists = append(d.lists, *l)
+	return nil
+}
+
+func TestParseListPage(t *testing.T) {
+	parser := &ParseService{}
+	logger := zap.NewNop()
+
+	// 收藏夹项把内容包在 content 里，收藏时间可能是字符串或时间戳
+	paging, items, contents, err := parser.ParseListPage(common.ZhihuCollection, []byte(`{
+		"paging": {"is_end": true, "totals": 3},
+		"data": [
+			{"created": "2026-07-01T08:00:00+08:00", "content": {"type": "answer", "id": 2002, "created_time": 1750000200, "author": {"url_token": ""}}},
+			{"created": 1750000000, "content": {"type": "article", "id": "3003", "created": 1740000000, "author": {"url_token": "carol"}}},
+			{"created": 1740000000, "content": {"type": "pin", "id": "pin-1"}}
+		]
+	}`), 0, logger)
+	require.NoError(t, err)
+	assert.True(t, paging.IsEnd)
+	require.Len(t, items, 3)
+	require.Len(t, contents, 3)
+
+	assert.Equal(t, 2002, items[0].ID)
+	assert.Equal(t, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC).Unix(), items[0].AddedAt)
+	assert.Equal(t, AnonymousAuthorID, AnswerAuthorID(items[0].Author))
+	assert.JSONEq(t, `{"type": "answer", "id": 2002, "created_time": 1750000200, "author": {"url_token": ""}}`, string(contents[0]))
+	assert.Equal(t, 3003, items[1].ID)
+	assert.Equal(t, int64(1750000000), items[1].AddedAt)
+	assert.False(t, ListItemSupported(items[2]))
+
+	// 专栏项就是内容本身，加入时间取发布时间
+	_, items, _, err = parser.ParseListPage(common.ZhihuColumn, []byte(`{
+		"paging": {"is_end": true, "totals": 2},
+		"data": [
+			{"type": "article", "id": 3003, "created": 1740000000},
+			{"type": "answer", "id": 2002, "created_time": 1750000200}
+		]
+	}`), 0, logger)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1740000000), items[0].AddedAt)
+	assert.Equal(t, int64(1750000200), items[1].AddedAt)
+}
+
+func TestParseListInfo(t *testing.T) {
+	d := &listDB{}
+	parser := &ParseService{db: d}
+
+	title, err := parser.ParseListInfo(common.ZhihuCollection, "9527", []byte(`{"collection": {"id": 9527, "title": "好文收藏"}}`), zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "好文收藏", title)
+
+	title, err = parser.ParseListInfo(common.ZhihuColumn, "c_1", []byte(`{"id": "c_1", "title": "专栏标题"}`), zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "专栏标题", title)
+
+	assert.Equal(t, []db.List{
+		{ID: "9527", Type: common.ZhihuCollection, Title: "好文收藏"},
+		{ID: "c_1", Type: common.ZhihuColumn, Title: "专栏标题"},
+	}, d.lists)
+}
@@ -1,6 +1,7 @@
 package parse
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -21,6 +22,8 @@ type Parser interface {
 	ArticleParser
 	PinParser
 	AuthorParser
+	QuestionParser
+	ListParser
 }
 
 type ParseService struct {
@@ -122,7 +125,7 @@ func (p *ParseService) downloadImageObjects(convertedBody string, contentID int,
 
 		const zhihuImageObjectKeyLayout = "zhihu/%d.jpg"
 		objectKey := fmt.Sprintf(zhihuImageObjectKeyLayout, picID)
-		if err = p.file.SaveStream(objectKey, resp.Body, resp.ContentLength); err != nil {
+		if err = p.file.SaveStream(context.Background(), objectKey, resp.Body, resp.ContentLength); err != nil {
 			return nil, fmt.Errorf("failed to save image stream %s to file service: %w", link, err)
 		}
 
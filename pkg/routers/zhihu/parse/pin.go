@@ -1,6 +1,7 @@
 package parse
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -216,7 +217,7 @@ func (p *ParseService) parsePinContent(content []json.RawMessage, id int, logger
 
 			const zhihuImageObjectKeyLayout = "zhihu/%d.jpg"
 			objectKey := fmt.Sprintf(zhihuImageObjectKeyLayout, picID)
-			if err = p.file.SaveStream(objectKey, resp.Body, resp.ContentLength); err != nil {
+			if err = p.file.SaveStream(context.Background(), objectKey, resp.Body, resp.ContentLength); err != nil {
 				return emptyString, emptyString, nil, fmt.Errorf("failed to save image stream %s to file service: %w", imageContent.OriginalURL, err)
 			}
 			logger.Info("Save image stream to file service successfully", zap.String("object_key", objectKey))
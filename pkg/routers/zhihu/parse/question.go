@@ -0,0 +1,38 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	apiModels "github.com/eli-yip/rss-zero/pkg/routers/zhihu/parse/api_models"
+)
+
+type QuestionParser interface {
+	// Parse result from api/v4/questions/{id}, save the question and return its title
+	ParseQuestion(apiResp []byte, logger *zap.Logger) (title string, err error)
+}
+
+func (p *ParseService) ParseQuestion(apiResp []byte, logger *zap.Logger) (title string, err error) {
+	question := apiModels.Question{}
+	if err = json.Unmarshal(apiResp, &question); err != nil {
+		return emptyString, fmt.Errorf("failed to unmarshal question: %w", err)
+	}
+	if question.ID, err = anyToID(question.RawID); err != nil {
+		return emptyString, fmt.Errorf("failed to convert question id from any to int: %w", err)
+	}
+	logger.Info("Unmarshal question successfully", zap.Int("question_id", question.ID))
+
+	if err = p.db.SaveQuestion(&db.Question{
+		ID:       question.ID,
+		CreateAt: time.Unix(question.CreateAt, 0),
+		Title:    question.Title,
+	}); err != nil {
+		return emptyString, fmt.Errorf("failed to save question %d to db: %w", question.ID, err)
+	}
+
+	return question.Title, nil
+}
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/eli-yip/rss-zero/internal/ai"
 	"github.com/eli-yip/rss-zero/internal/md"
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/render"
@@ -22,10 +23,11 @@ type recordingAI struct {
 	concludeOut  string
 }
 
-func (a *recordingAI) Polish(text string) (string, error)        { return text, nil }
-func (a *recordingAI) Text(io.Reader) (string, error)            { return "", nil }
-func (a *recordingAI) TranslateToZh(text string) (string, error) { return text, nil }
-func (a *recordingAI) Embed(text string) ([]float32, error)      { return nil, nil }
+func (a *recordingAI) Polish(text string) (string, error)            { return text, nil }
+func (a *recordingAI) Text(io.Reader) (string, error)                { return "", nil }
+func (a *recordingAI) TranslateToZh(text string) (string, error)     { return text, nil }
+func (a *recordingAI) Embed(text string) ([]float32, error)          { return nil, nil }
+func (a *recordingAI) SummarizeMany([]ai.DigestItem) (string, error) { return "", nil }
 func (a *recordingAI) Conclude(text string) (string, error) {
 	a.lastConclude = text
 	return a.concludeOut, nil
@@ -7,23 +7,30 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/eli-yip/rss-zero/internal/rss"
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
 	"github.com/eli-yip/rss-zero/pkg/common"
+	readingDB "github.com/eli-yip/rss-zero/pkg/reading/db"
 	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
 	zhihuRender "github.com/eli-yip/rss-zero/pkg/routers/zhihu/render"
 )
 
+// DefaultPickCount is how many answers a random feed holds unless the
+// canglimo_random_select job is configured otherwise.
+const DefaultPickCount = 1
+
 // GenerateRandomCanglimoAnswerRSS renders an Atom feed of randomly selected
 // canglimo answers through the shared BuildZhihuFeed/RenderAtom path. The random id
 // and time.Now() are intentional: a fresh random selection rendered once and cached
-// per RSSRandomTTL.
-func GenerateRandomCanglimoAnswerRSS(zhihuDBService db.DB, logger *zap.Logger) (string, error) {
+// per RSSRandomTTL. The feed has no reader identity, so answers nobody has read
+// yet are preferred. count is the number of answers to pick.
+func GenerateRandomCanglimoAnswerRSS(zhihuDBService db.DB, count int, logger *zap.Logger) (string, error) {
 	const (
-		answerCountToSelect = 1
-		authorID            = `canglimo`
-		authorName          = `墨苍离`
+		authorID   = `canglimo`
+		authorName = `墨苍离`
 	)
 
-	answers, err := zhihuDBService.RandomSelect(answerCountToSelect, authorID)
+	unread := readingDB.Unread("", bookmarkDB.PlatformZhihu, common.ZhihuAnswer.Slug(), "zhihu_answer.id")
+	answers, err := zhihuDBService.RandomSelect(count, authorID, unread)
 	if err != nil {
 		logger.Error("Failed to random select answers", zap.Error(err))
 		return "", err
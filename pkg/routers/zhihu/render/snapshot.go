@@ -68,6 +68,18 @@ func AnswerTitle(content ContentSnapshot, questionID int) string {
 	return strconv.Itoa(questionID)
 }
 
+// RawAuthorName 从回答或文章的 raw 里取知乎返回的作者昵称，取不到时返回 fallback。作者表只存
+// 订阅过的作者，问题、专栏与收藏夹里的内容靠它显示作者。
+func RawAuthorName(raw []byte, fallback string) string {
+	var content struct {
+		Author apiModels.Author `json:"author"`
+	}
+	if err := json.Unmarshal(raw, &content); err != nil || content.Author.Name == "" {
+		return fallback
+	}
+	return content.Author.Name
+}
+
 // renderAnswer 复现抓取期 answer 正文：HTML→Markdown、读取期换链、（付费则前置付费提示）、
 // 再对「提示 + 正文」整体格式化一次。转换后的正文优先复用快照缓存（装配/抓取期已转换），缺省则现转。
 func renderAnswer(answer zhihuDB.Answer, content ContentSnapshot) (string, error) {
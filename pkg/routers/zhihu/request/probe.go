@@ -0,0 +1,90 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rs/xid"
+
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+)
+
+const (
+	// ProbeURL 是健康探测时交给加密服务签名的知乎接口，返回知乎小管家的公开资料。
+	ProbeURL = "https://www.zhihu.com/api/v4/members/" + probeURLToken
+	// ProbeTimeout 是单次探测的超时时间。
+	ProbeTimeout  = 30 * time.Second
+	probeURLToken = "zhihuadmin"
+)
+
+// ErrProbeMismatch 表示加密服务返回了 200，但内容不是 ProbeURL 对应的资料。
+var ErrProbeMismatch = errors.New("unexpected probe response")
+
+// ProbeResult 是一次探测的结果。Err 为 nil 表示签名正确；CookieRejected 表示知乎以 401 或 403
+// 拒绝了 cookie，这种失败不能归咎于加密服务。
+type ProbeResult struct {
+	Latency        time.Duration
+	Err            error
+	CookieRejected bool
+}
+
+// Probe 通过加密服务 es 请求 ProbeURL，校验返回的 JSON 是否为预期的用户资料，并记录耗时。
+// 探测不经过限流器，也不计入服务的使用与失败次数。
+func Probe(ctx context.Context, client *http.Client, es zhihuDB.EncryptionService, cookie *Cookie) (result ProbeResult) {
+	reqBody, err := json.Marshal(EncryptReq{RequestID: "probe_" + xid.New().String(), DC0: cookie.DC0, ZC0: cookie.ZC0, ZSE_CK: cookie.ZseCk, URL: ProbeURL})
+	if err != nil {
+		result.Err = fmt.Errorf("failed to marshal request body: %w", err)
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, es.URL+"/data", bytes.NewReader(reqBody))
+	if err != nil {
+		result.Err = fmt.Errorf("failed to new a request: %w", err)
+		return result
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Latency = time.Since(start)
+		result.Err = fmt.Errorf("failed to request: %w", err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to read response: %w", err)
+		return result
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		result.CookieRejected = true
+		result.Err = fmt.Errorf("zhihu rejected cookies with status %d: %s", resp.StatusCode, truncateBody(string(body)))
+		return result
+	default:
+		result.Err = fmt.Errorf("bad status code %d: %s", resp.StatusCode, truncateBody(string(body)))
+		return result
+	}
+
+	var member struct {
+		URLToken string `json:"url_token"`
+	}
+	if err = json.Unmarshal(body, &member); err != nil || member.URLToken != probeURLToken {
+		result.Err = fmt.Errorf("%w: %s", ErrProbeMismatch, truncateBody(string(body)))
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+)
+
+func TestProbe(t *testing.T) {
+	cases := []struct {
+		name           string
+		status         int
+		body           string
+		wantErr        bool
+		cookieRejected bool
+	}{
+		{name: "ok", status: http.StatusOK, body: `{"url_token":"zhihuadmin","name":"知乎小管家"}`},
+		{name: "wrong member", status: http.StatusOK, body: `{"url_token":"someone"}`, wantErr: true},
+		{name: "not json", status: http.StatusOK, body: `<html></html>`, wantErr: true},
+		{name: "bad gateway", status: http.StatusBadGateway, body: `{"zhihu_status":500}`, wantErr: true},
+		{name: "cookie rejected", status: http.StatusForbidden, body: `{"error":{"need_login":true}}`, wantErr: true, cookieRejected: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got EncryptReq
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/data", r.URL.Path)
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+				w.WriteHeader(c.status)
+				_, _ = w.Write([]byte(c.body))
+			}))
+			defer server.Close()
+
+			result := Probe(context.Background(), server.Client(), zhihuDB.EncryptionService{URL: server.URL},
+				&Cookie{DC0: "d", ZC0: "z", ZseCk: "ck"})
+
+			assert.Equal(t, ProbeURL, got.URL)
+			assert.Equal(t, "z", got.ZC0)
+			assert.Equal(t, c.wantErr, result.Err != nil, "err: %v", result.Err)
+			assert.Equal(t, c.cookieRejected, result.CookieRejected)
+			assert.Positive(t, result.Latency)
+		})
+	}
+}
+
+func TestProbeUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	result := Probe(context.Background(), http.DefaultClient, zhihuDB.EncryptionService{URL: url}, &Cookie{})
+	require.Error(t, result.Err)
+	assert.False(t, result.CookieRejected)
+}
@@ -13,9 +13,13 @@ import (
 	"time"
 
 	"github.com/rs/xid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"github.com/eli-yip/rss-zero/internal/metrics"
 	"github.com/eli-yip/rss-zero/internal/notify"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
 )
 
@@ -166,7 +170,15 @@ func (r *RequestService) LimitRaw(ctx context.Context, u string, logger *zap.Log
 	logger = logger.With(zap.String("url", u))
 	logger.Info("Start to get zhihu raw data with limit, waiting for limiter", zap.String("request_task_id", requestTaskID))
 
+	ctx, span := tracing.StartChild(ctx, "zhihu.request",
+		attribute.String("url.full", u), attribute.String("request_task_id", requestTaskID))
+	defer func() { tracing.End(span, err) }()
+
 	for i := range r.maxRetry {
+		if i > 0 {
+			metrics.IncRequestRetry("zhihu")
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", i)))
+		}
 		currentRequestTaskID := fmt.Sprintf("%s_%d", requestTaskID, i)
 		logger := logger.With(zap.String("request_task_id", currentRequestTaskID))
 
@@ -180,7 +192,12 @@ func (r *RequestService) LimitRaw(ctx context.Context, u string, logger *zap.Log
 		}
 		logger.Info("Select zhihu encryption service successfully", zap.Any("service", es))
 
-		<-r.limiter
+		select {
+		case <-r.limiter:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		span.AddEvent("limiter acquired", trace.WithAttributes(attribute.String("encryption_service", es.ID)))
 		logger.Info("Get limiter successfully, start to request url")
 
 		reqBodyByte, err := json.Marshal(EncryptReq{RequestID: currentRequestTaskID, DC0: r.d_c0, ZC0: r.z_c0, ZSE_CK: r.zse_ck, URL: u})
@@ -348,7 +365,14 @@ func NoLimitStream(ctx context.Context, client *http.Client, u string, maxRetry
 	logger = logger.With(zap.String("url", u))
 	logger.Info("start to request without limit for stream")
 
+	ctx, span := tracing.StartChild(ctx, "zhihu.request_stream", attribute.String("url.full", u))
+	defer func() { tracing.End(span, err) }()
+
 	for i := range maxRetry {
+		if i > 0 {
+			metrics.IncRequestRetry("zhihu")
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", i)))
+		}
 		logger := logger.With(zap.Int("index", i))
 
 		var req *http.Request
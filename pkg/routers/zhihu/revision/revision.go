@@ -0,0 +1,246 @@
+// Package revision 把 zhihu_revision 里保存的旧版本与根行里的当前版本拼成一条内容的版本历史，
+// 并按版本号生成正文 diff，供归档接口与修改/删除 feed 使用。
+package revision
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zhihu/render"
+)
+
+var (
+	ErrUnsupportedType = errors.New("only answers and articles keep revisions")
+	ErrInvalidVersion  = errors.New("invalid version")
+)
+
+// Version 是内容的一个版本。Number 从 1 起按时间递增，最后一个是当前版本。
+type Version struct {
+	Number int `json:"number"`
+	// UpdateAt 是该版本在知乎的更新时间
+	UpdateAt time.Time `json:"update_at"`
+	// ReplacedAt 是发现该版本被改写的时间，当前版本为空
+	ReplacedAt *time.Time `json:"replaced_at,omitempty"`
+}
+
+type History struct {
+	Type     string    `json:"type"`
+	ID       int       `json:"id"`
+	Versions []Version `json:"versions"`
+	// DeletedAt 是发现内容在知乎被删除的时间，未删除时为空
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type Diff struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Text string `json:"text"` // unified diff，两版相同时为空
+}
+
+type Service struct {
+	db            zhihuDB.DB
+	loader        render.ContentLoader
+	serverBaseURL string
+}
+
+func NewService(db zhihuDB.DB, serverBaseURL string) *Service {
+	return &Service{db: db, loader: render.NewContentLoader(db), serverBaseURL: serverBaseURL}
+}
+
+// content 是一条内容的根行与全部修订，raws 按版本号排列（下标 0 为版本 1）。
+type content struct {
+	t         common.ZhihuContentType
+	answer    zhihuDB.Answer
+	article   zhihuDB.Article
+	raws      [][]byte
+	versions  []Version
+	deletedAt *time.Time
+	// numbers 把修订 id 映射到它保存的版本号
+	numbers map[int]int
+}
+
+func (s *Service) load(t common.ZhihuContentType, id int) (*content, error) {
+	c := &content{t: t, numbers: make(map[int]int)}
+	var (
+		currentRaw []byte
+		updateAt   time.Time
+	)
+	switch t {
+	case common.ZhihuAnswer:
+		answer, err := s.db.GetAnswer(id)
+		if err != nil {
+			return nil, err
+		}
+		c.answer, currentRaw, updateAt = *answer, answer.Raw, answer.UpdateAt
+	case common.ZhihuArticle:
+		article, err := s.db.GetArticle(id)
+		if err != nil {
+			return nil, err
+		}
+		c.article, currentRaw, updateAt = *article, article.Raw, article.UpdateAt
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	revisions, err := s.db.ListRevisions(t, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range revisions {
+		if r.Deleted {
+			c.deletedAt = &r.DetectedAt
+			continue
+		}
+		c.raws = append(c.raws, r.Raw)
+		c.numbers[r.ID] = len(c.raws)
+		c.versions = append(c.versions, Version{Number: len(c.raws), UpdateAt: r.UpdateAt, ReplacedAt: &r.DetectedAt})
+	}
+	c.raws = append(c.raws, currentRaw)
+	c.versions = append(c.versions, Version{Number: len(c.raws), UpdateAt: updateAt})
+	return c, nil
+}
+
+// History 返回内容的版本列表。内容不存在时返回 gorm.ErrRecordNotFound。
+func (s *Service) History(t common.ZhihuContentType, id int) (History, error) {
+	c, err := s.load(t, id)
+	if err != nil {
+		return History{}, err
+	}
+	return History{Type: string(t), ID: id, Versions: c.versions, DeletedAt: c.deletedAt}, nil
+}
+
+// Diff 比较两个版本渲染后的 Markdown。to 为 0 时取当前版本，from 为 0 时取 to 的前一版。
+func (s *Service) Diff(t common.ZhihuContentType, id, from, to int) (Diff, error) {
+	c, err := s.load(t, id)
+	if err != nil {
+		return Diff{}, err
+	}
+	if to == 0 {
+		to = len(c.raws)
+	}
+	if from == 0 {
+		from = to - 1
+	}
+	if from < 1 || to > len(c.raws) || from >= to {
+		return Diff{}, fmt.Errorf("%w: %d..%d of %d versions", ErrInvalidVersion, from, to, len(c.raws))
+	}
+
+	text, err := s.diff(c, from, to)
+	if err != nil {
+		return Diff{}, err
+	}
+	return Diff{From: from, To: to, Text: text}, nil
+}
+
+func (s *Service) diff(c *content, from, to int) (string, error) {
+	a, err := s.markdown(c, c.raws[from-1])
+	if err != nil {
+		return "", err
+	}
+	b, err := s.markdown(c, c.raws[to-1])
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fmt.Sprintf("v%d", from),
+		ToFile:   fmt.Sprintf("v%d", to),
+		Context:  3,
+	})
+}
+
+// markdown 用根行的外键与某个版本的 raw 渲染正文，文章在正文前加上该版本的标题。
+func (s *Service) markdown(c *content, raw []byte) (string, error) {
+	switch c.t {
+	case common.ZhihuAnswer:
+		answer := c.answer
+		answer.Raw = raw
+		snap, err := s.loader.LoadAnswers([]zhihuDB.Answer{answer})
+		if err != nil {
+			return "", err
+		}
+		return render.RenderMarkdown(answer.ID, snap, s.serverBaseURL)
+	default:
+		article := c.article
+		article.Raw = raw
+		snap, err := s.loader.LoadArticles([]zhihuDB.Article{article})
+		if err != nil {
+			return "", err
+		}
+		body, err := render.RenderMarkdown(article.ID, snap, s.serverBaseURL)
+		if err != nil {
+			return "", err
+		}
+		var title struct {
+			Title string `json:"title"`
+		}
+		_ = json.Unmarshal(raw, &title)
+		return "# " + title.Title + "\n\n" + body, nil
+	}
+}
+
+// Change 是一条修改或删除记录。Text 对修改是该版本到下一版本的 diff，对删除是删除前的正文。
+type Change struct {
+	Type       string
+	ID         int
+	Title      string
+	Link       string // 知乎原文链接
+	DetectedAt time.Time
+	Deleted    bool
+	Text       string
+}
+
+// Changes 返回作者最近 n 条修改与删除记录，按发现时间倒序。
+func (s *Service) Changes(authorID string, n int) ([]Change, error) {
+	revisions, err := s.db.GetLatestNRevision(n, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make(map[string]*content)
+	changes := make([]Change, 0, len(revisions))
+	for _, r := range revisions {
+		t := common.ZhihuContentType(r.Type)
+		key := fmt.Sprintf("%s/%d", r.Type, r.ContentID)
+		c, ok := loaded[key]
+		if !ok {
+			if c, err = s.load(t, r.ContentID); err != nil {
+				return nil, fmt.Errorf("failed to load %s: %w", key, err)
+			}
+			loaded[key] = c
+		}
+
+		change := Change{Type: r.Type, ID: r.ContentID, DetectedAt: r.DetectedAt, Deleted: r.Deleted}
+		change.Title, change.Link = s.titleAndLink(c)
+		if r.Deleted {
+			change.Text, err = s.markdown(c, r.Raw)
+		} else {
+			number := c.numbers[r.ID]
+			change.Text, err = s.diff(c, number, number+1)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to render change of %s: %w", key, err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func (s *Service) titleAndLink(c *content) (title, link string) {
+	if c.t == common.ZhihuArticle {
+		return c.article.Title, render.GenerateArticleLink(c.article.ID)
+	}
+	link = render.GenerateAnswerLink(c.answer.QuestionID, c.answer.ID)
+	question, err := s.db.GetQuestion(c.answer.QuestionID)
+	if err != nil || question.Title == "" {
+		return fmt.Sprintf("%d", c.answer.QuestionID), link
+	}
+	return question.Title, link
+}
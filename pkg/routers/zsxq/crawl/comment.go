@@ -0,0 +1,76 @@
+package crawl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/parse"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/parse/models"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/request"
+)
+
+// CommentStore 是评论刷新判断是否需要重抓所需的端口，zsxqDB.DB 已实现。
+type CommentStore interface {
+	GetTopicByID(id int) (zsxqDB.Topic, error)
+	CountComments(topicID int) (int, error)
+	SetTopicCommentsCount(id int, count int) error
+}
+
+// RefreshComments 重新翻 group 在 since 之后发布的 topic 列表，列表里的评论数与上次抓评论时记下的不同时
+// 重抓该 topic 的评论，返回重抓的 topic 数。未入库的 topic（如被跳过的）不抓评论。
+func RefreshComments(ctx context.Context, groupID int, request request.Requester, parser parse.Parser,
+	store CommentStore, since time.Time, logger *zap.Logger) (refreshed int, err error) {
+	logger = logger.With(zap.String("crawl_id", xid.New().String()), zap.Int("group_id", groupID))
+	logger.Info("Start to refresh zsxq comments", zap.Time("since", since))
+
+	err = walkTopics(ctx, groupID, request, parser, since, false, logger,
+		func(result *models.TopicParseResult, logger *zap.Logger) error {
+			topic, err := store.GetTopicByID(result.TopicID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				return fmt.Errorf("failed to get topic %d: %w", result.TopicID, err)
+			}
+
+			refresh, err := commentsChanged(topic, result.CommentsCount, store)
+			if err != nil {
+				return err
+			}
+			if refresh {
+				logger.Info("Found comments changed, refresh them", zap.Intp("last_seen", topic.CommentsCount), zap.Int("current", result.CommentsCount))
+				if _, err = parser.ParseComments(ctx, result.TopicID, logger); err != nil {
+					return fmt.Errorf("failed to parse comments of topic %d: %w", result.TopicID, err)
+				}
+				refreshed++
+			}
+			if refresh || topic.CommentsCount == nil {
+				if err = store.SetTopicCommentsCount(result.TopicID, result.CommentsCount); err != nil {
+					return fmt.Errorf("failed to save comments count of topic %d: %w", result.TopicID, err)
+				}
+			}
+			return nil
+		})
+
+	return refreshed, err
+}
+
+// commentsChanged 比较接口当前的 comments_count 与上次抓评论时记下的值。还没记过的旧 topic 退回比较库里
+// 的评论行数，只在接口更多时重抓，之后都按记下的值比较。
+func commentsChanged(topic zsxqDB.Topic, current int, store CommentStore) (bool, error) {
+	if topic.CommentsCount != nil {
+		return current != *topic.CommentsCount, nil
+	}
+	stored, err := store.CountComments(topic.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to count comments of topic %d: %w", topic.ID, err)
+	}
+	return current > stored, nil
+}
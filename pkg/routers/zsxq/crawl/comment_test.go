@@ -0,0 +1,41 @@
+package crawl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+)
+
+type commentRows map[int]int
+
+func (c commentRows) GetTopicByID(int) (zsxqDB.Topic, error) { return zsxqDB.Topic{}, nil }
+func (c commentRows) CountComments(topicID int) (int, error) { return c[topicID], nil }
+func (c commentRows) SetTopicCommentsCount(int, int) error   { return nil }
+
+func TestCommentsChanged(t *testing.T) {
+	lastSeen := func(n int) *int { return &n }
+	// 接口计数含已删除的评论，库里只有 3 行
+	store := commentRows{1: 3}
+
+	tests := []struct {
+		name    string
+		topic   zsxqDB.Topic
+		current int
+		want    bool
+	}{
+		{name: "unchanged count", topic: zsxqDB.Topic{ID: 1, CommentsCount: lastSeen(5)}, current: 5, want: false},
+		{name: "new comment", topic: zsxqDB.Topic{ID: 1, CommentsCount: lastSeen(5)}, current: 6, want: true},
+		{name: "deleted comment", topic: zsxqDB.Topic{ID: 1, CommentsCount: lastSeen(5)}, current: 4, want: true},
+		{name: "legacy topic with all rows", topic: zsxqDB.Topic{ID: 1}, current: 3, want: false},
+		{name: "legacy topic missing rows", topic: zsxqDB.Topic{ID: 1}, current: 5, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := commentsChanged(tt.topic, tt.current, store)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
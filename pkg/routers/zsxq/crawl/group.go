@@ -20,13 +20,27 @@ const (
 	apiFetchURL = "%s&end_time=%s"
 )
 
-func CrawlGroup(groupID int, request request.Requester,
+func CrawlGroup(ctx context.Context, groupID int, request request.Requester,
 	parser parse.Parser, targetTime time.Time,
 	oneTime bool, logger *zap.Logger) (err error) {
 	logger = logger.With(zap.String("crawl_id", xid.New().String()))
 
 	logger.Info("Start to crawl zsxq group", zap.Int("group_id", groupID))
 
+	return walkTopics(ctx, groupID, request, parser, targetTime, oneTime, logger,
+		func(result *models.TopicParseResult, logger *zap.Logger) error {
+			if err := parser.ParseTopic(ctx, result, logger); err != nil {
+				logger.Error("Failed to parse topic", zap.Error(err))
+				return fmt.Errorf("failed to parse topic: %w", err)
+			}
+			return nil
+		})
+}
+
+// walkTopics 按时间倒序翻 group 的 topic 列表，对晚于 targetTime 的每条 topic 调用 handle。
+func walkTopics(ctx context.Context, groupID int, request request.Requester,
+	parser parse.Parser, targetTime time.Time, oneTime bool, logger *zap.Logger,
+	handle func(result *models.TopicParseResult, logger *zap.Logger) error) (err error) {
 	var (
 		finished  = false
 		firstTime = true
@@ -41,7 +55,7 @@ func CrawlGroup(groupID int, request request.Requester,
 		}
 		firstTime = false
 
-		respByte, err := request.Limit(context.Background(), url, logger)
+		respByte, err := request.Limit(ctx, url, logger)
 		if err != nil {
 			logger.Error("Failed to request zsxq api", zap.String("url", url), zap.Error(err))
 			return fmt.Errorf("failed to request zsxq api: %w", err)
@@ -76,12 +90,16 @@ func CrawlGroup(groupID int, request request.Requester,
 				break
 			}
 
-			if err := parser.ParseTopic(&result, logger); err != nil {
-				logger.Error("Failed to parse topic", zap.Error(err))
-				return fmt.Errorf("failed to parse topic: %w", err)
+			if err := handle(&result, logger); err != nil {
+				return err
 			}
 		}
 
+		// 空页说明已翻到底，避免 createTime 不变时重复请求同一页
+		if len(rawTopics) == 0 {
+			break
+		}
+
 		if oneTime {
 			logger.Info("One time mode, break")
 			break
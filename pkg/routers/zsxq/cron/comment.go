@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/ai"
+	"github.com/eli-yip/rss-zero/internal/log"
+	"github.com/eli-yip/rss-zero/internal/notify"
+	"github.com/eli-yip/rss-zero/pkg/cookie"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/crawl"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/request"
+)
+
+// DefaultCommentRefreshDays 是评论刷新任务默认回看的天数。
+const DefaultCommentRefreshDays = 3
+
+// BuildCommentRefreshFunc 构建评论刷新任务：逐个 group 重翻近 days 天的 topic，补抓发布后才出现的评论。
+func BuildCommentRefreshFunc(days int, db *gorm.DB, cookieService cookie.CookieIface, aiService ai.AI, notifier notify.Notifier) func() {
+	return func() {
+		if config.C.Zsxq.SkipComments {
+			log.DefaultLogger.Info("Zsxq comments are skipped, skip this job")
+			return
+		}
+
+		cronJobID := xid.New().String()
+		logger := log.DefaultLogger.With(zap.String("cron_job_id", cronJobID))
+
+		cookies, err := cookie.Bundle(cookieService, "zsxq", notifier, logger)
+		if err != nil {
+			return
+		}
+
+		dbService, requestService, parseService, err := prepareZsxqServices(cookies["zsxq_access_token"], db, aiService, logger)
+		if err != nil {
+			logger.Error("Failed to init zsxq services", zap.Error(err))
+			return
+		}
+
+		groupIDs, err := dbService.GetZsxqGroupIDs()
+		if err != nil {
+			logger.Error("Failed to get group IDs from database", zap.Error(err))
+			return
+		}
+
+		since := time.Now().AddDate(0, 0, -days)
+		var errCount, refreshed int
+		for _, groupID := range groupIDs {
+			count, err := crawl.RefreshComments(context.Background(), groupID, requestService, parseService, dbService, since, logger)
+			refreshed += count
+			if err != nil {
+				logger.Error("Failed to refresh zsxq comments", zap.Int("group_id", groupID), zap.Error(err))
+				if errors.Is(err, request.ErrInvalidCookie) {
+					cookie.Invalidate(cookieService, cookie.CookieTypeZsxqAccessToken, notifier, logger)
+					return
+				}
+				errCount++
+			}
+		}
+
+		if errCount > 0 {
+			notify.NoticeWithLogger(notifier, "Failed to refresh zsxq comments", cronJobID, logger)
+		}
+		logger.Info("Refresh zsxq comments done", zap.Int("groups", len(groupIDs)), zap.Int("refreshed_topics", refreshed), zap.Int("failed_groups", errCount))
+	}
+}
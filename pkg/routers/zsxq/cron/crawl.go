@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
@@ -8,6 +9,8 @@ import (
 	"time"
 
 	"github.com/rs/xid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
@@ -15,9 +18,11 @@ import (
 	"github.com/eli-yip/rss-zero/internal/ai"
 	"github.com/eli-yip/rss-zero/internal/file"
 	"github.com/eli-yip/rss-zero/internal/log"
+	"github.com/eli-yip/rss-zero/internal/metrics"
 	"github.com/eli-yip/rss-zero/internal/notify"
 	"github.com/eli-yip/rss-zero/internal/redis"
 	"github.com/eli-yip/rss-zero/internal/rss"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 	"github.com/eli-yip/rss-zero/pkg/cookie"
 	"github.com/eli-yip/rss-zero/pkg/cron"
 	cronDB "github.com/eli-yip/rss-zero/pkg/cron/db"
@@ -26,19 +31,22 @@ import (
 	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/parse"
 	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/render"
 	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
 )
 
 type ResumeJobInfo struct {
 	JobID, LastCrawled string
 }
 
-func BuildCrawlFunc(resumeJobInfo *ResumeJobInfo, taskID string, include []string, exclude []string, redisService redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, ai ai.AI, notifier notify.Notifier) func(chan cron.CronJobInfo) {
-	return func(cronJobInfoChan chan cron.CronJobInfo) {
+func BuildCrawlFunc(resumeJobInfo *ResumeJobInfo, taskID string, include []string, exclude []string, redisService redis.Redis, cookieService cookie.CookieIface, db *gorm.DB, ai ai.AI, notifier notify.Notifier) cron.CrawlFunc {
+	return func(trigger string, cronJobInfoChan chan cron.CronJobInfo) {
 		cronJobID := resolveCronJobID(resumeJobInfo)
 		logger := log.DefaultLogger.With(zap.String("cron_job_id", cronJobID))
 		cronDBService := cronDB.NewDBService(db)
-		jobCtx := newZsxqCrawlJobContext(cronJobID, taskID, resumeJobInfo, cronDBService, notifier, logger)
+		recorder, logger := cron.NewRunRecorder(cronJobID, cronDBService, logger)
+		jobCtx := newZsxqCrawlJobContext(cronJobID, taskID, trigger, resumeJobInfo, cronDBService, recorder, notifier, logger)
 		if !jobCtx.prepare(cronJobInfoChan) {
+			jobCtx.skip()
 			return
 		}
 		defer jobCtx.finish()
@@ -67,8 +75,27 @@ func BuildCrawlFunc(resumeJobInfo *ResumeJobInfo, taskID string, include []strin
 			return
 		}
 
+		statDBService := recorder.StatDB(subscriptionDB.NewSubscriptionDBImpl(db))
 		for groupID := range slices.Values(groupIDs) {
-			if err = crawlGroup(groupID, requestService, parseService, redisService, dbService, logger); err != nil {
+			if cause := cron.StopCause(jobCtx.runCtx); cause != nil {
+				logger.Info("Job stopped, leave the remaining groups", zap.Error(cause))
+				return
+			}
+
+			counter := &countingParser{Parser: parseService}
+			countBefore, countErr := dbService.CountTopic(groupID)
+			err = crawlGroup(jobCtx.runCtx, groupID, requestService, counter, redisService, dbService, logger)
+			if cause := cron.StopCause(jobCtx.runCtx); err != nil && cause != nil {
+				// The group is left unrecorded in detail, so a resume crawls it again.
+				logger.Info("Job stopped while crawling group", zap.Int("group_id", groupID), zap.Error(cause))
+				return
+			}
+			run := subscriptionDB.Run{ItemsFound: counter.found, Err: err, ErrorClass: classifyCrawlErr(err)}
+			if countAfter, err := dbService.CountTopic(groupID); err == nil && countErr == nil {
+				run.ItemsNew = max(countAfter-countBefore, 0)
+			}
+			subscriptionDB.Record(statDBService, subscriptionDB.PlatformZsxq, strconv.Itoa(groupID), run, logger)
+			if err != nil {
 				jobCtx.errCount++
 				logger.Error("Failed to do cron job on group", zap.Error(err))
 				if errors.Is(err, request.ErrInvalidCookie) {
@@ -105,22 +132,37 @@ func resolveCronJobID(resumeJobInfo *ResumeJobInfo) string {
 type zsxqCrawlJobContext struct {
 	cronJobID     string
 	taskID        string
+	trigger       string
 	resumeJobInfo *ResumeJobInfo
 	cronDBService cronDB.DB
+	recorder      *cron.RunRecorder
 	notifier      notify.Notifier
 	logger        *zap.Logger
 	err           error
 	errCount      int
+	start         time.Time
+	// runCtx 携带本次运行的根 span，向下传给抓取、解析、存储；cancel/pause 时被取消
+	runCtx  context.Context
+	span    trace.Span
+	release func()
 }
 
-func newZsxqCrawlJobContext(cronJobID, taskID string, resumeJobInfo *ResumeJobInfo, cronDBService cronDB.DB, notifier notify.Notifier, logger *zap.Logger) *zsxqCrawlJobContext {
+func newZsxqCrawlJobContext(cronJobID, taskID, trigger string, resumeJobInfo *ResumeJobInfo, cronDBService cronDB.DB, recorder *cron.RunRecorder, notifier notify.Notifier, logger *zap.Logger) *zsxqCrawlJobContext {
+	runCtx, span := tracing.StartCronRun(subscriptionDB.PlatformZsxq, cronJobID)
+	runCtx, release := cron.Stoppable(runCtx, cronJobID)
 	return &zsxqCrawlJobContext{
 		cronJobID:     cronJobID,
 		taskID:        taskID,
+		trigger:       trigger,
 		resumeJobInfo: resumeJobInfo,
 		cronDBService: cronDBService,
+		recorder:      recorder,
 		notifier:      notifier,
 		logger:        logger,
+		start:         time.Now(),
+		runCtx:        runCtx,
+		span:          span,
+		release:       release,
 	}
 }
 
@@ -149,7 +191,7 @@ func (ctx *zsxqCrawlJobContext) prepare(cronJobInfoChan chan cron.CronJobInfo) b
 
 	if runningJobID == "" && ctx.resumeJobInfo == nil {
 		ctx.logger.Info("New job, start to add it to db")
-		job, err := ctx.cronDBService.AddJob(ctx.cronJobID, ctx.taskID)
+		job, err := ctx.cronDBService.AddJob(ctx.cronJobID, ctx.taskID, ctx.trigger)
 		if err != nil {
 			ctx.logger.Error("Failed to add job", zap.Error(err), zap.String("task_id", ctx.taskID))
 			cronJobInfo.Err = fmt.Errorf("failed to add job: %w", err)
@@ -161,32 +203,62 @@ func (ctx *zsxqCrawlJobContext) prepare(cronJobInfoChan chan cron.CronJobInfo) b
 		cronJobInfoChan <- cronJobInfo
 	}
 
+	if ctx.resumeJobInfo != nil {
+		if err := ctx.cronDBService.ResumeJob(ctx.cronJobID); err != nil {
+			ctx.logger.Error("Failed to mark job as resumed", zap.Error(err))
+		}
+	}
+
 	return true
 }
 
+// skip closes out a run that never started, e.g. another job is running.
+func (ctx *zsxqCrawlJobContext) skip() {
+	metrics.ObserveCrawl(subscriptionDB.PlatformZsxq, metrics.CrawlSkipped, 0)
+	ctx.recorder.Discard()
+	ctx.release()
+	ctx.span.SetAttributes(attribute.Bool("cron.skipped", true))
+	ctx.span.End()
+}
+
 // finish recovers from a panic and records the terminal job status. It is meant
 // to be deferred; the crawl loop only sets ctx.err / ctx.errCount.
 func (ctx *zsxqCrawlJobContext) finish() {
+	outcome, spanErr := metrics.CrawlSuccess, error(nil)
+	defer func() {
+		ctx.release()
+		metrics.ObserveCrawl(subscriptionDB.PlatformZsxq, outcome, time.Since(ctx.start))
+		tracing.End(ctx.span, spanErr)
+	}()
+
 	if err := recover(); err != nil {
+		outcome, spanErr = metrics.CrawlPanic, fmt.Errorf("panic: %v", err)
 		ctx.logger.Error("CrawlZsxq() panic", zap.Any("err", err))
-		if err = ctx.cronDBService.UpdateStatus(ctx.cronJobID, cronDB.StatusError); err != nil {
-			ctx.logger.Error("Failed to update cron job status", zap.Any("err", err))
-		}
+		ctx.recorder.Finish(cronDB.StatusError, spanErr)
+		return
+	}
+
+	if cause := cron.StopCause(ctx.runCtx); cause != nil {
+		outcome = metrics.CrawlStopped
+		ctx.span.SetAttributes(attribute.String("cron.stopped", cause.Error()))
+		ctx.recorder.Finish(cron.StoppedStatus(cause), cause)
 		return
 	}
 
 	if ctx.errCount > 0 || ctx.err != nil {
-		notify.NoticeWithLogger(ctx.notifier, "Failed to crawl zsxq content", ctx.cronJobID, ctx.logger)
-		if err := ctx.cronDBService.UpdateStatus(ctx.cronJobID, cronDB.StatusError); err != nil {
-			ctx.logger.Error("Failed to update cron job status", zap.Error(err))
+		outcome, spanErr = metrics.CrawlError, ctx.err
+		if spanErr == nil {
+			spanErr = fmt.Errorf("%d groups failed", ctx.errCount)
+		}
+		// Leave the notification to the last attempt when a retry is scheduled.
+		if !ctx.recorder.Finish(cronDB.StatusError, spanErr) {
+			notify.NoticeWithLogger(ctx.notifier, "Failed to crawl zsxq content", ctx.cronJobID, ctx.logger)
 		}
 		return
 	}
 
 	ctx.logger.Info("There is no error during zsxq crawl, set status to finished")
-	if err := ctx.cronDBService.UpdateStatus(ctx.cronJobID, cronDB.StatusFinished); err != nil {
-		ctx.logger.Error("Failed to update cron job status", zap.Error(err))
-	}
+	ctx.recorder.Finish(cronDB.StatusFinished, nil)
 }
 
 // loadGroupIDsToCrawl loads the group ids from db and reduces them to the set
@@ -263,8 +335,10 @@ func prepareZsxqServices(cookie string, db *gorm.DB, ai ai.AI, logger *zap.Logge
 	return dbService, requestService, parseService, nil
 }
 
+func crawlGroup(ctx context.Context, groupID int, requestService request.Requester, parseService parse.Parser, redisService redis.Redis, dbService zsxqDB.DB, logger *zap.Logger) (err error) {
+	ctx, span := tracing.StartChild(ctx, "zsxq.crawl_group", attribute.Int("zsxq.group_id", groupID))
+	defer func() { tracing.End(span, err) }()
 
-func crawlGroup(groupID int, requestService request.Requester, parseService parse.Parser, redisService redis.Redis, dbService zsxqDB.DB, logger *zap.Logger) (err error) {
 	// Get latest topic time from database
 	var latestTopicTimeInDB time.Time
 	if latestTopicTimeInDB, err = getTargetTime(groupID, dbService); err != nil {
@@ -273,7 +347,7 @@ func crawlGroup(groupID int, requestService request.Requester, parseService pars
 	logger.Info("Get latest topic time from db successfully", zap.Time("latest_topic_time", latestTopicTimeInDB))
 
 	// Get latest topics from zsxq
-	if err = crawl.CrawlGroup(groupID, requestService, parseService,
+	if err = crawl.CrawlGroup(ctx, groupID, requestService, parseService,
 		latestTopicTimeInDB, false, logger); err != nil {
 		return fmt.Errorf("failed to crawl group: %w", err)
 	}
@@ -284,7 +358,7 @@ func crawlGroup(groupID int, requestService request.Requester, parseService pars
 	}
 	logger.Info("Update crawl time successfully")
 
-	if err = rss.WarmCache(redisService, fmt.Sprintf(redis.ZsxqRSSPath, strconv.Itoa(groupID)), redis.RSSDefaultTTL,
+	if err = rss.WarmCache(ctx, redisService, fmt.Sprintf(redis.ZsxqRSSPath, strconv.Itoa(groupID)), redis.RSSDefaultTTL,
 		func() (rss.FeedMeta, []rss.Item, error) { return rss.FetchZSXQ(groupID, dbService, logger) }); err != nil {
 		return fmt.Errorf("failed to warm zsxq rss cache: %w", err)
 	}
@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/internal/ai"
+	"github.com/eli-yip/rss-zero/internal/log"
+	"github.com/eli-yip/rss-zero/internal/notify"
+	"github.com/eli-yip/rss-zero/pkg/cookie"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/request"
+)
+
+// DefaultFileMirrorCount 是附件补转存任务每轮最多处理的附件数。
+const DefaultFileMirrorCount = 50
+
+// BuildFileMirrorFunc 构建附件补转存任务：重新下载还没有 sha256 的旧附件，写入 OSS 并记录大小与校验和。
+func BuildFileMirrorFunc(count int, db *gorm.DB, cookieService cookie.CookieIface, aiService ai.AI, notifier notify.Notifier) func() {
+	return func() {
+		cronJobID := xid.New().String()
+		logger := log.DefaultLogger.With(zap.String("cron_job_id", cronJobID))
+
+		cookies, err := cookie.Bundle(cookieService, "zsxq", notifier, logger)
+		if err != nil {
+			return
+		}
+
+		dbService, _, parseService, err := prepareZsxqServices(cookies["zsxq_access_token"], db, aiService, logger)
+		if err != nil {
+			logger.Error("Failed to init zsxq services", zap.Error(err))
+			return
+		}
+
+		files, err := dbService.GetUnmirroredFiles(count)
+		if err != nil {
+			logger.Error("Failed to get unmirrored zsxq files", zap.Error(err))
+			return
+		}
+
+		var errCount int
+		for i := range files {
+			if err = parseService.MirrorFile(context.Background(), &files[i], logger); err != nil {
+				logger.Error("Failed to mirror zsxq file", zap.Int("file_id", files[i].ID), zap.Error(err))
+				if errors.Is(err, request.ErrInvalidCookie) {
+					cookie.Invalidate(cookieService, cookie.CookieTypeZsxqAccessToken, notifier, logger)
+					return
+				}
+				errCount++
+			}
+		}
+
+		if errCount > 0 {
+			notify.NoticeWithLogger(notifier, "Failed to mirror zsxq files", cronJobID, logger)
+		}
+		logger.Info("Mirror zsxq files done", zap.Int("files", len(files)), zap.Int("failed", errCount))
+	}
+}
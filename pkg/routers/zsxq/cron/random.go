@@ -10,11 +10,12 @@ import (
 	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/random"
 )
 
-func BuildRandomSelectCanglimoDigestTopicFunc(gormDB *gorm.DB, redisService redis.Redis) func() {
+// BuildRandomSelectCanglimoDigestTopicFunc picks count digests into the cached random feed.
+func BuildRandomSelectCanglimoDigestTopicFunc(gormDB *gorm.DB, redisService redis.Redis, count int) func() {
 	return func() {
 		logger := log.DefaultLogger.With(zap.String("cron_job_id", xid.New().String()))
 
-		rssContent, err := random.GenerateRandomCanglimoDigestRss(gormDB, logger)
+		rssContent, err := random.GenerateRandomCanglimoDigestRss(gormDB, count, logger)
 		if err != nil {
 			logger.Error("Failed to generate random canglimo digest rss", zap.Error(err))
 			return
@@ -0,0 +1,38 @@
+package cron
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/parse"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/parse/models"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/request"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// countingParser 统计一次星球抓取解析到的 topic 数，写入订阅统计的 ItemsFound。
+type countingParser struct {
+	parse.Parser
+	found int
+}
+
+func (p *countingParser) ParseTopic(ctx context.Context, topic *models.TopicParseResult, logger *zap.Logger) error {
+	p.found++
+	return p.Parser.ParseTopic(ctx, topic, logger)
+}
+
+// classifyCrawlErr 归类星球特有的抓取错误，返回空串时由 subscriptionDB.ClassifyError 推断。
+func classifyCrawlErr(err error) string {
+	switch {
+	case errors.Is(err, request.ErrInvalidCookie):
+		return subscriptionDB.ErrorClassAuth
+	case errors.Is(err, request.ErrBadResponse):
+		return subscriptionDB.ErrorClassUpstream
+	case errors.Is(err, request.ErrMaxRetry):
+		return subscriptionDB.ErrorClassNetwork
+	default:
+		return ""
+	}
+}
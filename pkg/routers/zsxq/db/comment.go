@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/internal/tracing"
+)
+
+// Comment 是 topic 下的一条评论。楼中楼回复的 ParentID 为所属评论 id，顶层评论为 0；
+// ReplieeID 为被回复者，未指明时为 0。正文与图片在读取期从 Raw 重放。
+type Comment struct {
+	ID        int       `gorm:"column:id;primary_key"`
+	TopicID   int       `gorm:"column:topic_id;index"`
+	ParentID  int       `gorm:"column:parent_id"`
+	AuthorID  int       `gorm:"column:author_id"`
+	ReplieeID int       `gorm:"column:repliee_id"`
+	Time      time.Time `gorm:"column:time"`
+	Raw       []byte    `gorm:"column:raw;type:bytea"`
+}
+
+func (c *Comment) TableName() string { return "zsxq_comment" }
+
+type DBComment interface {
+	// SaveCommentsTx 在单事务内提交一条 topic 的评论及其作者、图片，已存在的按 id 覆盖
+	SaveCommentsTx(ctx context.Context, authors []Author, comments []Comment, objects []Object) error
+	// Batch get comments of topics from zsxq_comment table, ordered by time asc
+	GetCommentsByTopicIDs(topicIDs []int) (cs []Comment, err error)
+	// Count comments (including replies) of a topic from zsxq_comment table
+	CountComments(topicID int) (count int, err error)
+}
+
+// SaveCommentsTx 与 SaveTopicTx 相同：图片已在事务外上传 OSS，这里只做纯 DB 写，任一步失败整体回滚。
+// 评论接口不再返回的旧评论保留不删，归档以抓到过的为准。
+func (s *ZsxqDBService) SaveCommentsTx(ctx context.Context, authors []Author, comments []Comment, objects []Object) error {
+	return tracing.Transaction(ctx, s.db, "zsxq.SaveCommentsTx", func(tx *gorm.DB) error {
+		for i := range authors {
+			if err := tx.Save(&authors[i]).Error; err != nil {
+				return fmt.Errorf("failed to save author %d: %w", authors[i].ID, err)
+			}
+		}
+		for i := range objects {
+			if err := tx.Save(&objects[i]).Error; err != nil {
+				return fmt.Errorf("failed to save object %d: %w", objects[i].ID, err)
+			}
+		}
+		for i := range comments {
+			if err := tx.Save(&comments[i]).Error; err != nil {
+				return fmt.Errorf("failed to save comment %d: %w", comments[i].ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *ZsxqDBService) GetCommentsByTopicIDs(topicIDs []int) (comments []Comment, err error) {
+	comments = make([]Comment, 0)
+	if len(topicIDs) == 0 {
+		return comments, nil
+	}
+	err = s.db.Where("topic_id IN ?", topicIDs).Order("time asc, id asc").Find(&comments).Error
+	return comments, err
+}
+
+func (s *ZsxqDBService) CountComments(topicID int) (int, error) {
+	var count int64
+	err := s.db.Model(&Comment{}).Where("topic_id = ?", topicID).Count(&count).Error
+	return int(count), err
+}
@@ -10,6 +10,7 @@ type DB interface {
 	DBObject
 	DBAuthor
 	DBGroup
+	DBComment
 }
 
 type ZsxqDBService struct{ db *gorm.DB }
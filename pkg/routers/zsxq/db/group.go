@@ -1,7 +1,10 @@
 package db
 
 import (
+	"errors"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Group struct {
@@ -10,6 +13,8 @@ type Group struct {
 	UpdateAt   time.Time `gorm:"column:update_at"`
 	ErrorTimes int       `gorm:"column:error_times;type:int"`
 	Finished   bool      `gorm:"column:finished;type:bool"`
+	// DeletedAt 非空表示订阅已暂停，cron 不再抓取，但 feed 仍可访问
+	DeletedAt gorm.DeletedAt
 }
 
 func (g *Group) TableName() string { return "zsxq_group" }
@@ -25,6 +30,16 @@ type DBGroup interface {
 	GetCrawlStatus(groupID int) (finished bool, err error)
 	// Save crawl status to zsxq_group table
 	SaveCrawlStatus(groupID int, finished bool) (err error)
+	// Get all groups including paused ones
+	GetGroupsIncludeDeleted() (groups []Group, err error)
+	// Check whether a group exists, paused ones included
+	CheckGroupIncludeDeleted(groupID int) (exist bool, err error)
+	// Save a group to zsxq_group table
+	SaveGroup(group *Group) (err error)
+	// Pause a group by soft deleting it
+	DeleteGroup(groupID int) (err error)
+	// Resume a paused group
+	ActivateGroup(groupID int) (err error)
 }
 
 func (s *ZsxqDBService) GetZsxqGroupIDs() ([]int, error) {
@@ -42,7 +57,7 @@ func (s *ZsxqDBService) GetZsxqGroupIDs() ([]int, error) {
 
 func (s *ZsxqDBService) GetGroupName(groupID int) (groupName string, err error) {
 	var group Group
-	if err := s.db.Where("id = ?", groupID).First(&group).Error; err != nil {
+	if err := s.db.Unscoped().Where("id = ?", groupID).First(&group).Error; err != nil {
 		return "", err
 	}
 	return group.Name, nil
@@ -73,3 +88,31 @@ func (s *ZsxqDBService) SaveCrawlStatus(groupID int, finished bool) (err error)
 	group.Finished = finished
 	return s.db.Save(&group).Error
 }
+
+func (s *ZsxqDBService) GetGroupsIncludeDeleted() (groups []Group, err error) {
+	if err = s.db.Unscoped().Order("id ASC").Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (s *ZsxqDBService) CheckGroupIncludeDeleted(groupID int) (exist bool, err error) {
+	var group Group
+	if err = s.db.Unscoped().Where("id = ?", groupID).First(&group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *ZsxqDBService) SaveGroup(group *Group) (err error) { return s.db.Save(group).Error }
+
+func (s *ZsxqDBService) DeleteGroup(groupID int) (err error) {
+	return s.db.Where("id = ?", groupID).Delete(&Group{}).Error
+}
+
+func (s *ZsxqDBService) ActivateGroup(groupID int) (err error) {
+	return s.db.Unscoped().Model(&Group{}).Where("id = ?", groupID).Update("deleted_at", nil).Error
+}
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -21,6 +23,11 @@ type Object struct {
 	ObjectKey       string         `gorm:"column:object_key;type:text"`
 	StorageProvider pq.StringArray `gorm:"column:storage_provider;type:text[]"`
 	Transcript      string         `gorm:"column:transcript;type:text"`
+	// Name, Size and SHA256 are only recorded for files; older file rows have
+	// an empty SHA256 until the mirror job downloads them again.
+	Name   string `gorm:"column:name;type:text"`
+	Size   int64  `gorm:"column:size"`
+	SHA256 string `gorm:"column:sha256;type:text"`
 	// Note: for zsxq files, download link maybe expired, not testes yet.
 	// If it's expired, we can get another download link by requesting api with file id
 	Url string `gorm:"column:url;type:text"`
@@ -42,6 +49,15 @@ func (o *Object) URI() (string, error) {
 	return file.ObjectURI(o.StorageProvider, o.ObjectKey)
 }
 
+// FileName returns the recorded file name, falling back to the name embedded
+// in the object key "zsxq/<id>-<name>" for file rows saved before Name existed.
+func (o *Object) FileName() string {
+	if o.Name != "" {
+		return o.Name
+	}
+	return strings.TrimPrefix(o.ObjectKey, fmt.Sprintf("zsxq/%d-", o.ID))
+}
+
 type DBObject interface {
 	// Save object info to zsxq_object table
 	SaveObjectInfo(o *Object) error
@@ -49,6 +65,19 @@ type DBObject interface {
 	GetObjectInfo(oid int) (o *Object, err error)
 	// Batch get object info by ids from zsxq_object table
 	GetObjectsByIDs(ids []int) (os []Object, err error)
+	// Fetch n files of a group with their topic titles, ordered by time desc
+	FetchFiles(gid, n, offset int) (fs []TopicFile, err error)
+	// Count files of a group
+	CountFiles(gid int) (count int, err error)
+	// Get n files that have not been mirrored with checksum yet, ordered by time desc
+	GetUnmirroredFiles(n int) (os []Object, err error)
+}
+
+// TopicFile 是附件对象及其所属 topic 的标题与 group，供附件列表和附件 feed 使用。
+type TopicFile struct {
+	Object
+	TopicTitle *string `gorm:"column:topic_title"`
+	GroupID    int     `gorm:"column:group_id"`
 }
 
 func (s *ZsxqDBService) SaveObjectInfo(o *Object) error { return s.db.Save(o).Error }
@@ -66,3 +95,32 @@ func (s *ZsxqDBService) GetObjectsByIDs(ids []int) (objects []Object, err error)
 	err = s.db.Where("id IN ?", ids).Find(&objects).Error
 	return objects, err
 }
+
+// groupFiles 选出 gid 下所有附件对象，join topic 取标题与 group。
+func (s *ZsxqDBService) groupFiles(gid int) *gorm.DB {
+	return s.db.Model(&Object{}).
+		Joins("JOIN zsxq_topic ON zsxq_topic.id = zsxq_object.topic_id").
+		Where("zsxq_object.type = ? AND zsxq_topic.group_id = ?", "file", gid)
+}
+
+func (s *ZsxqDBService) FetchFiles(gid, n, offset int) (files []TopicFile, err error) {
+	files = make([]TopicFile, 0, n)
+	err = s.groupFiles(gid).
+		Select("zsxq_object.*, zsxq_topic.title AS topic_title, zsxq_topic.group_id").
+		Order("zsxq_object.time desc, zsxq_object.id desc").
+		Limit(n).Offset(offset).
+		Scan(&files).Error
+	return files, err
+}
+
+func (s *ZsxqDBService) CountFiles(gid int) (int, error) {
+	var count int64
+	err := s.groupFiles(gid).Count(&count).Error
+	return int(count), err
+}
+
+func (s *ZsxqDBService) GetUnmirroredFiles(n int) (objects []Object, err error) {
+	err = s.db.Where("type = ? AND (sha256 IS NULL OR sha256 = '')", "file").
+		Order("time desc").Limit(n).Find(&objects).Error
+	return objects, err
+}
@@ -57,3 +57,12 @@ func TestObjectURI(t *testing.T) {
 		}
 	})
 }
+
+func TestObjectFileName(t *testing.T) {
+	if got := (&Object{ID: 7, Name: "研报.pdf", ObjectKey: "zsxq/7-old.pdf"}).FileName(); got != "研报.pdf" {
+		t.Fatalf("recorded name not preferred: %q", got)
+	}
+	if got := (&Object{ID: 7, ObjectKey: "zsxq/7-东昌：基因.docx"}).FileName(); got != "东昌：基因.docx" {
+		t.Fatalf("name not recovered from object key: %q", got)
+	}
+}
@@ -1,11 +1,14 @@
 package db
 
 import (
+	"context"
 	"fmt"
-	"math/rand"
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/internal/tracing"
+	readingDB "github.com/eli-yip/rss-zero/pkg/reading/db"
 )
 
 type Topic struct {
@@ -17,6 +20,10 @@ type Topic struct {
 	AuthorID int       `gorm:"column:author_id"`
 	Title    *string   `gorm:"column:title;type:text"` // Although title is not null in q&a and talk, it is null in some topics
 	Raw      []byte    `gorm:"column:raw;type:bytea"`
+
+	// CommentsCount 是上次完整抓取评论时接口给出的 comments_count，评论刷新据此判断是否有变化。
+	// 接口计数可能含已删除或列表不返回的评论，不能拿库里评论行数比较；为空表示还没抓过评论。
+	CommentsCount *int `gorm:"column:comments_count"`
 }
 
 func (t *Topic) TableName() string { return "zsxq_topic" }
@@ -24,11 +31,13 @@ func (t *Topic) TableName() string { return "zsxq_topic" }
 type DBTopic interface {
 	// SaveTopicTx 在单事务内提交一条 topic 产生的全部行；原子性来自事务本身（一起提交或
 	// 一起回滚），根行最后写只是可读性约定，无 FK 强制、不改变回滚语义。
-	SaveTopicTx(root *Topic, author *Author, article *Article, objects []Object) error
+	SaveTopicTx(ctx context.Context, root *Topic, author *Author, article *Article, objects []Object) error
 	// Get latest topic time from zsxq_topic table
 	GetLatestTopicTime(gid int) (t time.Time, err error)
 	// Get latest n topics from zsxq_topic table
 	GetLatestNTopics(gid int, n int) (ts []Topic, err error)
+	// Count topics of a group from zsxq_topic table
+	CountTopic(gid int) (count int, err error)
 	// Get All ids from zsxq_topic table
 	GetAllTopicIDs(gid int) (ids []int, err error)
 	// Fetch n topics before time from zsxq_topic table
@@ -37,8 +46,17 @@ type DBTopic interface {
 	FetchNTopics(n int, opt Options) (ts []Topic, err error)
 	// Get topic by id from zsxq_topic table
 	GetTopicByID(id int) (t Topic, err error)
+	// SetTopicCommentsCount 记下评论抓取完成时接口给出的 comments_count
+	SetTopicCommentsCount(id int, count int) error
+	// Get topics by ids from zsxq_topic table, missing ids are skipped
+	GetTopicsByIDs(ids []int) (ts []Topic, err error)
+	// FetchTopicsWithDateRange 分页读取小组在 [startTime, endTime) 内的主题，order 为 0 时按时间倒序
+	FetchTopicsWithDateRange(gid, limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]Topic, error)
+	// CountTopicsWithDateRange 统计小组在 [startTime, endTime) 内的主题数
+	CountTopicsWithDateRange(gid int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error)
 	// Random select n topics from zsxq_topic table
-	RandomSelect(userID, n int, digest bool) (topics []Topic, err error)
+	// RandomSelect 随机选出 n 条主题，满足全部 prefer scope 的主题优先，不足 n 条时再从其余主题补齐。
+	RandomSelect(userID, n int, digest bool, prefer ...func(*gorm.DB) *gorm.DB) (topics []Topic, err error)
 }
 
 // SaveTopicTx 把一条 topic 解析出的全部事实行放进同一个事务提交：作者、外部文章、各对象、
@@ -47,8 +65,8 @@ type DBTopic interface {
 //
 // 对象二进制已在事务外上传 OSS（见 parse.collect*），只有上传成功的对象元数据才会进入
 // objects，故这里只做纯 DB 写。author/article 为 nil（如未知类型）时相应步骤跳过。
-func (s *ZsxqDBService) SaveTopicTx(root *Topic, author *Author, article *Article, objects []Object) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+func (s *ZsxqDBService) SaveTopicTx(ctx context.Context, root *Topic, author *Author, article *Article, objects []Object) error {
+	return tracing.Transaction(ctx, s.db, "zsxq.SaveTopicTx", func(tx *gorm.DB) error {
 		if author != nil {
 			if err := tx.Save(author).Error; err != nil {
 				return fmt.Errorf("failed to save author %d: %w", author.ID, err)
@@ -64,7 +82,8 @@ func (s *ZsxqDBService) SaveTopicTx(root *Topic, author *Author, article *Articl
 				return fmt.Errorf("failed to save object %d: %w", objects[i].ID, err)
 			}
 		}
-		if err := tx.Save(root).Error; err != nil {
+		// comments_count 只由评论抓取写入，重抓 topic 不覆盖
+		if err := tx.Omit("comments_count").Save(root).Error; err != nil {
 			return fmt.Errorf("failed to save topic root %d: %w", root.ID, err)
 		}
 		return nil
@@ -87,11 +106,30 @@ func (s *ZsxqDBService) GetTopicByID(id int) (t Topic, err error) {
 	return t, err
 }
 
+func (s *ZsxqDBService) SetTopicCommentsCount(id int, count int) error {
+	return s.db.Model(&Topic{}).Where("id = ?", id).Update("comments_count", count).Error
+}
+
+func (s *ZsxqDBService) GetTopicsByIDs(ids []int) (ts []Topic, err error) {
+	ts = make([]Topic, 0, len(ids))
+	if len(ids) == 0 {
+		return ts, nil
+	}
+	err = s.db.Where("id IN ?", ids).Find(&ts).Error
+	return ts, err
+}
+
 func (s *ZsxqDBService) GetLatestNTopics(gid, n int) (ts []Topic, err error) {
 	err = s.db.Where("group_id = ?", gid).Order("time desc").Limit(n).Find(&ts).Error
 	return ts, err
 }
 
+func (s *ZsxqDBService) CountTopic(gid int) (int, error) {
+	var count int64
+	err := s.db.Model(&Topic{}).Where("group_id = ?", gid).Count(&count).Error
+	return int(count), err
+}
+
 func (s *ZsxqDBService) FetchNTopicsBefore(gid, n int, t time.Time) (ts []Topic, err error) {
 	err = s.db.Where("group_id = ? and time < ?", gid, t).Order("time desc").Limit(n).Find(&ts).Error
 	return ts, err
@@ -108,6 +146,28 @@ func (s *ZsxqDBService) GetAllTopicIDs(gid int) (ids []int, err error) {
 	return ids, nil
 }
 
+func (s *ZsxqDBService) FetchTopicsWithDateRange(gid, limit, offset, order int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]Topic, error) {
+	ts := make([]Topic, 0, limit)
+	stmt := s.db.Where("group_id = ?", gid).Where("time >= ?", startTime).Where("time < ?", endTime).Limit(limit).Offset(offset)
+	if order == 0 {
+		stmt = stmt.Order("time desc")
+	} else {
+		stmt = stmt.Order("time asc")
+	}
+	if err := stmt.Scopes(scopes...).Find(&ts).Error; err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+func (s *ZsxqDBService) CountTopicsWithDateRange(gid int, startTime, endTime time.Time, scopes ...func(*gorm.DB) *gorm.DB) (int, error) {
+	var count int64
+	if err := s.db.Model(&Topic{}).Where("group_id = ?", gid).Where("time >= ?", startTime).Where("time < ?", endTime).Scopes(scopes...).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
 type Options struct {
 	GroupID   int
 	Type      *string
@@ -149,24 +209,25 @@ func (s *ZsxqDBService) FetchNTopics(n int, opt Options) (ts []Topic, err error)
 	return ts, nil
 }
 
-func (s *ZsxqDBService) RandomSelect(userID, n int, digest bool) (topics []Topic, err error) {
+func (s *ZsxqDBService) RandomSelect(userID, n int, digest bool, prefer ...func(*gorm.DB) *gorm.DB) (topics []Topic, err error) {
 	topics = make([]Topic, 0, n)
 
-	topicIDs := make([]int, 0, n)
-	s.db.Model(&Topic{}).Where("author_id = ? AND digested = ?", userID, digest).Pluck("id", &topicIDs)
+	candidates := func() *gorm.DB {
+		return s.db.Model(&Topic{}).Where("author_id = ? AND digested = ?", userID, digest)
+	}
 
-	if len(topicIDs) <= n {
-		if err = s.db.Where("id in ?", topicIDs).Find(&topics).Error; err != nil {
-			return nil, fmt.Errorf("failed to get topics: %w", err)
+	topicIDs := make([]int, 0, n)
+	if err = candidates().Pluck("id", &topicIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get topic ids: %w", err)
+	}
+	var preferredIDs []int
+	if len(prefer) > 0 {
+		if err = candidates().Scopes(prefer...).Pluck("id", &preferredIDs).Error; err != nil {
+			return nil, fmt.Errorf("failed to get preferred topic ids: %w", err)
 		}
-		return topics, nil
 	}
 
-	rand.Shuffle(len(topicIDs), func(i, j int) {
-		topicIDs[i], topicIDs[j] = topicIDs[j], topicIDs[i]
-	})
-
-	topicIDs = topicIDs[:n]
+	topicIDs = readingDB.PickPreferred(topicIDs, preferredIDs, n)
 
 	if err := s.db.Where("id in ?", topicIDs).Find(&topics).Error; err != nil {
 		return nil, fmt.Errorf("failed to get topics: %w", err)
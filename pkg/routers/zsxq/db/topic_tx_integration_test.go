@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
@@ -53,7 +54,7 @@ func TestSaveTopicTxAtomic(t *testing.T) {
 		article := &Article{ID: "art-1", Title: "外部文章", Raw: []byte("<p>x</p>")}
 		objects := []Object{{ID: 21, TopicID: 100, Type: "image", ObjectKey: "zsxq/21.jpg"}}
 
-		err := store.SaveTopicTx(root, author, article, objects)
+		err := store.SaveTopicTx(context.Background(), root, author, article, objects)
 		require.Error(t, err, "root 表缺失应导致事务失败")
 
 		assert.Zero(t, count(t, gdb, &Author{}), "作者应随事务回滚")
@@ -69,7 +70,7 @@ func TestSaveTopicTxAtomic(t *testing.T) {
 		store := NewDBService(gdb)
 
 		// 预置一次成功抓取的旧行。
-		require.NoError(t, store.SaveTopicTx(
+		require.NoError(t, store.SaveTopicTx(context.Background(),
 			&Topic{ID: 100, Time: time.Now(), GroupID: 5, Type: "talk", AuthorID: 1, Raw: []byte("{}")},
 			&Author{ID: 1, Name: "旧名"},
 			nil,
@@ -79,7 +80,7 @@ func TestSaveTopicTxAtomic(t *testing.T) {
 		// 破坏 root 写入：删掉 raw 列，后续 SaveTopicTx 的根行 INSERT 必失败。
 		require.NoError(t, gdb.Exec("ALTER TABLE zsxq_topic DROP COLUMN raw").Error)
 
-		err := store.SaveTopicTx(
+		err := store.SaveTopicTx(context.Background(),
 			&Topic{ID: 200, Time: time.Now(), GroupID: 5, Type: "talk", AuthorID: 1, Raw: []byte("{}")},
 			&Author{ID: 1, Name: "新名"}, // upsert 同一作者，若提交会覆盖旧名
 			nil,
@@ -66,6 +66,7 @@ func (m *mockZsxqDBService) FetchNTopics(groupID int, opt zsxqDB.Options) ([]zsx
 // ContentLoader 装配快照所需的批量只读：本 mock 无对象/文章，只回作者。
 func (m *mockZsxqDBService) GetObjectsByIDs([]int) ([]zsxqDB.Object, error)     { return nil, nil }
 func (m *mockZsxqDBService) GetArticlesByIDs([]string) ([]zsxqDB.Article, error) { return nil, nil }
+func (m *mockZsxqDBService) GetCommentsByTopicIDs([]int) ([]zsxqDB.Comment, error) { return nil, nil }
 func (m *mockZsxqDBService) GetAuthorsByIDs([]int) ([]zsxqDB.Author, error) {
 	m.authorCalls++
 	return []zsxqDB.Author{{ID: 11111, Name: "作者"}}, nil
@@ -0,0 +1,120 @@
+package parse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/parse/models"
+	zsxqTime "github.com/eli-yip/rss-zero/pkg/routers/zsxq/time"
+)
+
+const (
+	commentsPageSize = 30
+	commentsURL      = "https://api.zsxq.com/v2/topics/%d/comments?sort=desc&count=30"
+	commentsFetchURL = "%s&end_time=%s"
+)
+
+// CommentParser 抓取并保存 topic 的评论。
+type CommentParser interface {
+	// ParseComments 按时间倒序翻完一条 topic 的评论，连同楼中楼、评论者与图片在单事务内落库，返回评论条数（含楼中楼）。
+	ParseComments(ctx context.Context, topicID int, logger *zap.Logger) (count int, err error)
+}
+
+// commentFacts 汇集一条 topic 全部评论解析后的待提交行，作者按 id 去重。
+type commentFacts struct {
+	authors  map[int]db.Author
+	comments []db.Comment
+	objects  []db.Object
+}
+
+func (s *ParseService) ParseComments(ctx context.Context, topicID int, logger *zap.Logger) (count int, err error) {
+	logger = logger.With(zap.Int("topic_id", topicID))
+	facts := commentFacts{authors: make(map[int]db.Author)}
+
+	var endTime time.Time
+	for {
+		url := fmt.Sprintf(commentsURL, topicID)
+		if !endTime.IsZero() {
+			url = fmt.Sprintf(commentsFetchURL, url, zsxqTime.EncodeTimeForQuery(endTime))
+		}
+
+		respBytes, err := s.request.Limit(ctx, url, logger)
+		if err != nil {
+			return 0, fmt.Errorf("failed to request zsxq comments api: %w", err)
+		}
+
+		var resp models.CommentsResponse
+		if err = json.Unmarshal(respBytes, &resp); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal comments response: %w", err)
+		}
+
+		for _, raw := range resp.RespData.Comments {
+			mc, comment, err := s.collectComment(ctx, topicID, 0, raw, &facts, logger)
+			if err != nil {
+				return 0, err
+			}
+			endTime = comment.Time
+
+			for _, replyRaw := range mc.RepliedComments {
+				if _, _, err = s.collectComment(ctx, topicID, comment.ID, replyRaw, &facts, logger); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+		if len(resp.RespData.Comments) < commentsPageSize {
+			break
+		}
+	}
+
+	authors := make([]db.Author, 0, len(facts.authors))
+	for _, a := range facts.authors {
+		authors = append(authors, a)
+	}
+	if err = s.db.SaveCommentsTx(ctx, authors, facts.comments, facts.objects); err != nil {
+		return 0, fmt.Errorf("failed to save comments: %w", err)
+	}
+	logger.Info("Save zsxq comments successfully", zap.Int("count", len(facts.comments)))
+
+	return len(facts.comments), nil
+}
+
+// collectComment 解析一条评论（或楼中楼回复），图片转存 OSS，把评论、评论者与被回复者、图片行记入 facts。
+func (s *ParseService) collectComment(ctx context.Context, topicID, parentID int, raw json.RawMessage, facts *commentFacts, logger *zap.Logger) (mc models.Comment, comment db.Comment, err error) {
+	if err = json.Unmarshal(raw, &mc); err != nil {
+		return mc, comment, fmt.Errorf("failed to unmarshal comment: %w", err)
+	}
+
+	createTime, err := zsxqTime.DecodeZsxqAPITime(mc.CreateTime)
+	if err != nil {
+		return mc, comment, fmt.Errorf("failed to decode create time of comment %d: %w", mc.CommentID, err)
+	}
+
+	objects, err := s.collectImages(ctx, mc.Images, topicID, mc.CreateTime, logger)
+	if err != nil {
+		return mc, comment, fmt.Errorf("failed to save images of comment %d: %w", mc.CommentID, err)
+	}
+	facts.objects = append(facts.objects, objects...)
+
+	facts.authors[mc.Owner.UserID] = *buildAuthor(&mc.Owner)
+	comment = db.Comment{
+		ID:       mc.CommentID,
+		TopicID:  topicID,
+		ParentID: parentID,
+		AuthorID: mc.Owner.UserID,
+		Time:     createTime,
+		Raw:      raw,
+	}
+	if mc.Repliee != nil {
+		facts.authors[mc.Repliee.UserID] = *buildAuthor(mc.Repliee)
+		comment.ReplieeID = mc.Repliee.UserID
+	}
+	facts.comments = append(facts.comments, comment)
+
+	return mc, comment, nil
+}
@@ -0,0 +1,116 @@
+package parse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/parse/models"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/request"
+)
+
+// pagedRequester 按请求顺序依次返回 pages，并记录请求过的 url。
+type pagedRequester struct {
+	request.Requester
+	pages [][]byte
+	urls  []string
+}
+
+func (r *pagedRequester) Limit(_ context.Context, u string, _ *zap.Logger) ([]byte, error) {
+	r.urls = append(r.urls, u)
+	if len(r.urls) > len(r.pages) {
+		return nil, fmt.Errorf("unexpected request %s", u)
+	}
+	return r.pages[len(r.urls)-1], nil
+}
+
+func (r *pagedRequester) LimitStream(context.Context, string, *zap.Logger) (*http.Response, error) {
+	return nil, fmt.Errorf("unexpected download")
+}
+
+type commentDB struct {
+	db.DB
+	authors  []db.Author
+	comments []db.Comment
+}
+
+func (d *commentDB) SaveCommentsTx(_ context.Context, authors []db.Author, comments []db.Comment, _ []db.Object) error {
+	d.authors, d.comments = authors, comments
+	return nil
+}
+
+func commentsPage(t *testing.T, comments ...models.Comment) []byte {
+	var resp models.CommentsResponse
+	for _, c := range comments {
+		raw, err := json.Marshal(c)
+		require.NoError(t, err)
+		resp.RespData.Comments = append(resp.RespData.Comments, raw)
+	}
+	body, err := json.Marshal(resp)
+	require.NoError(t, err)
+	return body
+}
+
+// TestParseComments 验证评论按 end_time 翻页直到不满一页，楼中楼记在所属评论下并带被回复者，评论者按 id 去重。
+func TestParseComments(t *testing.T) {
+	base := time.Date(2024, 1, 22, 12, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	comment := func(id int, owner models.User, replies ...models.Comment) models.Comment {
+		c := models.Comment{
+			CommentID:  id,
+			CreateTime: base.Add(-time.Duration(id) * time.Minute).Format("2006-01-02T15:04:05.000-0700"),
+			Owner:      owner,
+			Text:       fmt.Sprintf("评论 %d", id),
+		}
+		for _, r := range replies {
+			raw, err := json.Marshal(r)
+			require.NoError(t, err)
+			c.RepliedComments = append(c.RepliedComments, raw)
+		}
+		return c
+	}
+	alice, bob := models.User{UserID: 1, Name: "alice"}, models.User{UserID: 2, Name: "bob"}
+
+	reply := comment(1000, bob)
+	reply.Repliee = &alice
+	firstPage := []models.Comment{comment(1, alice, reply)}
+	for id := 2; id <= commentsPageSize; id++ {
+		firstPage = append(firstPage, comment(id, alice))
+	}
+
+	rs := &pagedRequester{pages: [][]byte{
+		commentsPage(t, firstPage...),
+		commentsPage(t, comment(commentsPageSize+1, bob)),
+	}}
+	dbSvc := &commentDB{}
+	s := &ParseService{request: rs, db: dbSvc}
+
+	count, err := s.ParseComments(context.Background(), 42, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.Equal(t, commentsPageSize+2, count)
+	require.Len(t, rs.urls, 2)
+	assert.NotContains(t, rs.urls[0], "end_time")
+	assert.True(t, strings.Contains(rs.urls[1], "end_time="), "second page should continue from the last comment")
+
+	require.Len(t, dbSvc.comments, commentsPageSize+2)
+	assert.Equal(t, db.Comment{ID: 1000, TopicID: 42, ParentID: 1, AuthorID: 2, ReplieeID: 1}, withoutTimeAndRaw(dbSvc.comments[1]))
+	for _, c := range dbSvc.comments {
+		assert.Equal(t, 42, c.TopicID)
+		assert.NotEmpty(t, c.Raw)
+	}
+	assert.Len(t, dbSvc.authors, 2)
+}
+
+func withoutTimeAndRaw(c db.Comment) db.Comment {
+	c.Time, c.Raw = time.Time{}, nil
+	return c
+}
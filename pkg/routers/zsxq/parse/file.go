@@ -0,0 +1,87 @@
+package parse
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+)
+
+// ErrIncompleteFile 表示下载到的字节数与 Content-Length 不一致，附件不会被记为已转存。
+var ErrIncompleteFile = errors.New("incomplete file download")
+
+// FileMirror 把附件转存到 OSS。
+type FileMirror interface {
+	// MirrorFile 重新申请下载链接转存附件 o，回填名称、大小与 sha256 后写回 zsxq_object。
+	MirrorFile(ctx context.Context, o *db.Object, logger *zap.Logger) (err error)
+}
+
+// mirrorFile 申请新的下载链接，把附件流式写入 OSS 并同时计算大小与 sha256；不落库。
+// 返回的对象只填了与 topic 无关的字段，TopicID 与 Time 由调用方补齐。
+func (s *ParseService) mirrorFile(ctx context.Context, fileID int, name string, logger *zap.Logger) (object db.Object, err error) {
+	downloadLink, err := s.downloadLink(ctx, fileID, logger)
+	if err != nil {
+		return object, fmt.Errorf("failed to get download link for file %d: %w", fileID, err)
+	}
+
+	objectKey := fmt.Sprintf("zsxq/%d-%s", fileID, name)
+	resp, err := s.request.LimitStream(ctx, downloadLink, logger)
+	if err != nil {
+		return object, fmt.Errorf("failed to download file %d: %w", fileID, err)
+	}
+
+	body := &hashingReader{ReadCloser: resp.Body, hash: sha256.New()}
+	if err = s.file.SaveStream(ctx, objectKey, body, resp.ContentLength); err != nil {
+		return object, fmt.Errorf("failed to save file %d: %w", fileID, err)
+	}
+	if resp.ContentLength >= 0 && body.size != resp.ContentLength {
+		return object, fmt.Errorf("%w: file %d got %d of %d bytes", ErrIncompleteFile, fileID, body.size, resp.ContentLength)
+	}
+	logger.Info("Mirror file successfully", zap.Int("file_id", fileID), zap.Int64("size", body.size))
+
+	return db.Object{
+		ID:              fileID,
+		Type:            "file",
+		Name:            name,
+		ObjectKey:       objectKey,
+		StorageProvider: []string{s.file.AssetsDomain()},
+		Url:             downloadLink,
+		Size:            body.size,
+		SHA256:          hex.EncodeToString(body.hash.Sum(nil)),
+	}, nil
+}
+
+func (s *ParseService) MirrorFile(ctx context.Context, o *db.Object, logger *zap.Logger) (err error) {
+	mirrored, err := s.mirrorFile(ctx, o.ID, o.FileName(), logger)
+	if err != nil {
+		return err
+	}
+
+	o.Name, o.ObjectKey, o.StorageProvider = mirrored.Name, mirrored.ObjectKey, mirrored.StorageProvider
+	o.Url, o.Size, o.SHA256 = mirrored.Url, mirrored.Size, mirrored.SHA256
+	if err = s.db.SaveObjectInfo(o); err != nil {
+		return fmt.Errorf("failed to save file %d: %w", o.ID, err)
+	}
+	return nil
+}
+
+// hashingReader 在读出数据的同时累计字节数与摘要。
+type hashingReader struct {
+	io.ReadCloser
+	hash hash.Hash
+	size int64
+}
+
+func (r *hashingReader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	r.size += int64(n)
+	r.hash.Write(p[:n])
+	return n, err
+}
@@ -0,0 +1,97 @@
+package parse
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/internal/file"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/request"
+)
+
+// downloadRequester 返回固定的下载链接，下载时给出 body 与声明的 Content-Length。
+type downloadRequester struct {
+	request.Requester
+	body          string
+	contentLength int64
+}
+
+func (r *downloadRequester) Limit(context.Context, string, *zap.Logger) ([]byte, error) {
+	return []byte(`{"resp_data":{"download_url":"https://files.zsxq.com/fresh"}}`), nil
+}
+
+func (r *downloadRequester) LimitStream(context.Context, string, *zap.Logger) (*http.Response, error) {
+	return &http.Response{Body: io.NopCloser(strings.NewReader(r.body)), ContentLength: r.contentLength}, nil
+}
+
+type memoryFile struct {
+	file.File
+	saved map[string]string
+}
+
+func (f *memoryFile) SaveStream(_ context.Context, path string, rc io.ReadCloser, _ int64) error {
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	f.saved[path] = string(b)
+	return nil
+}
+
+func (f *memoryFile) AssetsDomain() string { return "https://oss.example.com" }
+
+type objectDB struct {
+	db.DB
+	saved []db.Object
+}
+
+func (d *objectDB) SaveObjectInfo(o *db.Object) error {
+	d.saved = append(d.saved, *o)
+	return nil
+}
+
+// TestMirrorFile 验证旧附件重新转存时从 object key 还原文件名并回填大小与 sha256。
+func TestMirrorFile(t *testing.T) {
+	const body = "%PDF-1.7 research"
+	sum := sha256.Sum256([]byte(body))
+
+	files := &memoryFile{saved: map[string]string{}}
+	dbSvc := &objectDB{}
+	s := &ParseService{request: &downloadRequester{body: body, contentLength: int64(len(body))}, file: files, db: dbSvc}
+
+	o := &db.Object{ID: 7, TopicID: 42, Type: "file", ObjectKey: "zsxq/7-研报.pdf", Url: "https://files.zsxq.com/expired"}
+	require.NoError(t, s.MirrorFile(context.Background(), o, zap.NewNop()))
+
+	assert.Equal(t, body, files.saved["zsxq/7-研报.pdf"])
+	require.Len(t, dbSvc.saved, 1)
+	got := dbSvc.saved[0]
+	assert.Equal(t, "研报.pdf", got.Name)
+	assert.Equal(t, int64(len(body)), got.Size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), got.SHA256)
+	assert.Equal(t, "https://files.zsxq.com/fresh", got.Url)
+	assert.Equal(t, 42, got.TopicID)
+}
+
+func TestMirrorFileIncomplete(t *testing.T) {
+	dbSvc := &objectDB{}
+	s := &ParseService{
+		request: &downloadRequester{body: "partial", contentLength: 100},
+		file:    &memoryFile{saved: map[string]string{}},
+		db:      dbSvc,
+	}
+
+	o := &db.Object{ID: 7, Type: "file", Name: "a.pdf"}
+	err := s.MirrorFile(context.Background(), o, zap.NewNop())
+	assert.ErrorIs(t, err, ErrIncompleteFile)
+	assert.Empty(t, dbSvc.saved)
+	assert.Empty(t, o.SHA256)
+}
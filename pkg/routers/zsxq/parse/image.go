@@ -13,7 +13,7 @@ import (
 
 // collectImages 下载图片转存 OSS（事务外网络副作用），返回待提交的对象事实行；不落库。
 // 保留旧 saveImages 的 url 优选与错误路径：无有效 url 报错、下载/转存失败即中止整条 topic。
-func (s *ParseService) collectImages(images []models.Image, topicID int, createTimeStr string, logger *zap.Logger) (objects []db.Object, err error) {
+func (s *ParseService) collectImages(ctx context.Context, images []models.Image, topicID int, createTimeStr string, logger *zap.Logger) (objects []db.Object, err error) {
 	if images == nil {
 		return nil, nil
 	}
@@ -38,11 +38,11 @@ func (s *ParseService) collectImages(images []models.Image, topicID int, createT
 		}
 
 		objectKey := fmt.Sprintf("zsxq/%d.%s", image.ImageID, image.Type)
-		resp, err := s.request.LimitStream(context.Background(), url, logger)
+		resp, err := s.request.LimitStream(ctx, url, logger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to download image %d: %w", image.ImageID, err)
 		}
-		if err = s.file.SaveStream(objectKey, resp.Body, resp.ContentLength); err != nil {
+		if err = s.file.SaveStream(ctx, objectKey, resp.Body, resp.ContentLength); err != nil {
 			return nil, fmt.Errorf("failed to save image %d: %w", image.ImageID, err)
 		}
 
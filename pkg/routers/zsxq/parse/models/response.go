@@ -7,3 +7,9 @@ type APIResponse struct {
 		RawTopics []json.RawMessage `json:"topics"`
 	} `json:"resp_data"`
 }
+
+type CommentsResponse struct {
+	RespData struct {
+		Comments []json.RawMessage `json:"comments"`
+	} `json:"resp_data"`
+}
@@ -22,6 +22,8 @@ type Topic struct {
 	Answer     *Answer   `json:"answer"`
 	Title      *string   `json:"title"`
 	Digested   bool      `json:"digested"`
+	// CommentsCount 是 topic 下的评论数（含楼中楼），为 0 时不请求评论接口
+	CommentsCount int `json:"comments_count"`
 }
 
 type Talk struct {
@@ -80,3 +82,16 @@ type Voice struct {
 	VoiceID int    `json:"voice_id"`
 	URL     string `json:"url"`
 }
+
+// Comment 是评论接口返回的一条评论。楼中楼回复保留原始 JSON 在 RepliedComments 里，逐条入库；
+// Repliee 为被回复的人。
+type Comment struct {
+	CommentID       int               `json:"comment_id"`
+	CreateTime      string            `json:"create_time"`
+	Owner           User              `json:"owner"`
+	Text            string            `json:"text"`
+	Images          []Image           `json:"images"`
+	Repliee         *User             `json:"repliee"`
+	LikesCount      int               `json:"likes_count"`
+	RepliedComments []json.RawMessage `json:"replied_comments"`
+}
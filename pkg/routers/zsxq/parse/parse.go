@@ -1,6 +1,7 @@
 package parse
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/eli-yip/rss-zero/internal/ai"
@@ -14,7 +15,9 @@ import (
 
 type Parser interface {
 	SplitTopics(respBytes []byte, logger *zap.Logger) (rawTopics []json.RawMessage, err error)
-	ParseTopic(topic *models.TopicParseResult, logger *zap.Logger) (err error)
+	ParseTopic(ctx context.Context, topic *models.TopicParseResult, logger *zap.Logger) (err error)
+	CommentParser
+	FileMirror
 }
 
 type ParseService struct {
@@ -12,7 +12,7 @@ import (
 
 // parseQA 抽取一条 q&a 的全部待提交事实（作者 / 提问 + 回答图片 + 语音对象），不落库。
 // 沿用旧行为：缺 question 或 answer 报错（ParseTopic 据此 error，不存 root）。
-func (s *ParseService) parseQA(logger *zap.Logger, topic *models.Topic) (author *db.Author, objects []db.Object, err error) {
+func (s *ParseService) parseQA(ctx context.Context, logger *zap.Logger, topic *models.Topic) (author *db.Author, objects []db.Object, err error) {
 	question := topic.Question
 	answer := topic.Answer
 	if question == nil || answer == nil {
@@ -21,19 +21,19 @@ func (s *ParseService) parseQA(logger *zap.Logger, topic *models.Topic) (author
 
 	author = buildAuthor(&answer.Answerer)
 
-	questionImages, err := s.collectImages(question.Images, topic.TopicID, topic.CreateTime, logger)
+	questionImages, err := s.collectImages(ctx, question.Images, topic.TopicID, topic.CreateTime, logger)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to save question images: %w", err)
 	}
 	objects = append(objects, questionImages...)
 
-	answerImages, err := s.collectImages(answer.Images, topic.TopicID, topic.CreateTime, logger)
+	answerImages, err := s.collectImages(ctx, answer.Images, topic.TopicID, topic.CreateTime, logger)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to save answer images: %w", err)
 	}
 	objects = append(objects, answerImages...)
 
-	voiceObject, err := s.collectVoice(logger, answer.Voice, topic.TopicID, topic.CreateTime)
+	voiceObject, err := s.collectVoice(ctx, logger, answer.Voice, topic.TopicID, topic.CreateTime)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to save voice: %w", err)
 	}
@@ -46,23 +46,23 @@ func (s *ParseService) parseQA(logger *zap.Logger, topic *models.Topic) (author
 
 // collectVoice 下载语音转存 OSS 并转写（事务外网络 + AI 副作用），返回待提交的对象事实行；
 // 不落库。voice 为 nil 时返回 nil。
-func (s *ParseService) collectVoice(logger *zap.Logger, voice *models.Voice, topicID int, createTimeStr string) (*db.Object, error) {
+func (s *ParseService) collectVoice(ctx context.Context, logger *zap.Logger, voice *models.Voice, topicID int, createTimeStr string) (*db.Object, error) {
 	if voice == nil {
 		return nil, nil
 	}
 
 	objectKey := fmt.Sprintf("zsxq/%d.%s", voice.VoiceID, "wav")
-	resp, err := s.request.LimitStream(context.Background(), voice.URL, logger)
+	resp, err := s.request.LimitStream(ctx, voice.URL, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download voice %d: %w", voice.VoiceID, err)
 	}
-	if err = s.file.SaveStream(objectKey, resp.Body, resp.ContentLength); err != nil {
+	if err = s.file.SaveStream(ctx, objectKey, resp.Body, resp.ContentLength); err != nil {
 		return nil, fmt.Errorf("failed to save voice %d: %w", voice.VoiceID, err)
 	}
 
 	// Get voice stream from file service,
 	// then send it to ai service to get transcript
-	voiceStream, err := s.file.GetStream(objectKey)
+	voiceStream, err := s.file.GetStream(ctx, objectKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get voice stream: %w", err)
 	}
@@ -7,12 +7,16 @@ import (
 	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
+	"github.com/eli-yip/rss-zero/config"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 	commonRender "github.com/eli-yip/rss-zero/pkg/render"
 	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
 	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/parse/models"
 	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/render"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/request"
 	zsxqTime "github.com/eli-yip/rss-zero/pkg/routers/zsxq/time"
 )
 
@@ -55,7 +59,11 @@ func (s *ParseService) SplitTopics(respBytes []byte, logger *zap.Logger) (rawTop
 // ParseTopic 抽取一条 topic 的事实、推导标题，再在单事务内原子落库（根行最后写）。
 // 抓取期不再持久化正文 Markdown：标题由 transient 纯渲染喂入（不落库），正文读取期从
 // raw + 侧表重放。skip / error 语义见 plan 决策 6 行为矩阵，逐条保持不变。
-func (s *ParseService) ParseTopic(topic *models.TopicParseResult, logger *zap.Logger) (err error) {
+func (s *ParseService) ParseTopic(ctx context.Context, topic *models.TopicParseResult, logger *zap.Logger) (err error) {
+	ctx, span := tracing.StartChild(ctx, "zsxq.parse_topic",
+		attribute.Int("zsxq.topic_id", topic.TopicID), attribute.String("zsxq.topic_type", topic.Type))
+	defer func() { tracing.End(span, err) }()
+
 	if _, skip := topicIDSkip[topic.TopicID]; skip {
 		logger.Info("Skip crawling topic, as it will cause markdown parser timeout", zap.Int("topic_id", topic.TopicID))
 		return nil
@@ -66,7 +74,7 @@ func (s *ParseService) ParseTopic(topic *models.TopicParseResult, logger *zap.Lo
 	var result TopicParseResult
 	switch topic.Type {
 	case "talk":
-		author, objects, article, err := s.parseTalk(logger, &topic.Topic)
+		author, objects, article, err := s.parseTalk(ctx, logger, &topic.Topic)
 		if err != nil {
 			switch {
 			case errors.Is(err, ErrNoText):
@@ -81,7 +89,7 @@ func (s *ParseService) ParseTopic(topic *models.TopicParseResult, logger *zap.Lo
 		}
 		result.Author, result.Objects, result.Article = author, objects, article
 	case "q&a":
-		author, objects, err := s.parseQA(logger, &topic.Topic)
+		author, objects, err := s.parseQA(ctx, logger, &topic.Topic)
 		if err != nil {
 			return fmt.Errorf("failed to parse q&a: %w", err)
 		}
@@ -138,11 +146,28 @@ func (s *ParseService) ParseTopic(topic *models.TopicParseResult, logger *zap.Lo
 		Raw:      topic.Raw,
 	}
 
-	if err = s.db.SaveTopicTx(&result.Topic, result.Author, result.Article, result.Objects); err != nil {
+	if err = s.db.SaveTopicTx(ctx, &result.Topic, result.Author, result.Article, result.Objects); err != nil {
 		return fmt.Errorf("failed to save topic info to database: %w", err)
 	}
 	logger.Info("Save topic info to database successfully")
 
+	// 评论请求失败不影响 topic 本身，留给评论刷新任务补抓；cookie 失效仍中止整轮抓取。
+	if config.C.Zsxq.SkipComments {
+		return nil
+	}
+	if topic.CommentsCount > 0 {
+		if _, err = s.ParseComments(ctx, topic.TopicID, logger); err != nil {
+			if errors.Is(err, request.ErrInvalidCookie) {
+				return fmt.Errorf("failed to parse comments: %w", err)
+			}
+			logger.Error("Failed to parse comments, leave them to comment refresh", zap.Error(err))
+			return nil
+		}
+	}
+	if err = s.db.SetTopicCommentsCount(topic.TopicID, topic.CommentsCount); err != nil {
+		return fmt.Errorf("failed to save comments count: %w", err)
+	}
+
 	return nil
 }
 
@@ -176,10 +201,10 @@ type FileDownload struct {
 	} `json:"resp_data"`
 }
 
-func (s *ParseService) downloadLink(fileID int, logger *zap.Logger) (link string, err error) {
+func (s *ParseService) downloadLink(ctx context.Context, fileID int, logger *zap.Logger) (link string, err error) {
 	url := fmt.Sprintf(ZsxqFileBaseURL, fileID)
 
-	resp, err := s.request.Limit(context.Background(), url, logger)
+	resp, err := s.request.Limit(ctx, url, logger)
 	if err != nil {
 		return "", fmt.Errorf("failed to request zsxq api: %w", err)
 	}
@@ -1,6 +1,7 @@
 package parse
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -27,17 +28,27 @@ func (c *captureAI) Conclude(text string) (string, error) {
 	return "结论标题", nil
 }
 
-// captureDB 只覆盖 SaveTopicTx，捕获根行以断言标题落到 Topic.Title；其余 db.DB 方法不被调用。
+// captureDB 只覆盖 SaveTopicTx 与 SetTopicCommentsCount，捕获根行以断言标题落到 Topic.Title；
+// 其余 db.DB 方法不被调用。
 type captureDB struct {
 	db.DB
-	savedRoot *db.Topic
+	savedRoot     *db.Topic
+	commentsCount map[int]int
 }
 
-func (d *captureDB) SaveTopicTx(root *db.Topic, _ *db.Author, _ *db.Article, _ []db.Object) error {
+func (d *captureDB) SaveTopicTx(_ context.Context, root *db.Topic, _ *db.Author, _ *db.Article, _ []db.Object) error {
 	d.savedRoot = root
 	return nil
 }
 
+func (d *captureDB) SetTopicCommentsCount(id int, count int) error {
+	if d.commentsCount == nil {
+		d.commentsCount = make(map[int]int)
+	}
+	d.commentsCount[id] = count
+	return nil
+}
+
 // TestParseTopicTransientTitle 证明抓取期标题由 transient 纯渲染喂入：ParseTopic 用内存事实
 // 装配快照跑 render.RenderMarkdown，把结果交给 ai.Conclude，且与读取期同一份 RenderMarkdown
 // 逐字节一致。作者带别名时正文取真实姓名（.Name），与读取期一致——这是本次声明的语义等价点。
@@ -62,7 +73,7 @@ func TestParseTopicTransientTitle(t *testing.T) {
 	dbSvc := &captureDB{}
 	s := &ParseService{ai: aiSvc, db: dbSvc}
 
-	require.NoError(t, s.ParseTopic(result, zap.NewNop()))
+	require.NoError(t, s.ParseTopic(context.Background(), result, zap.NewNop()))
 
 	// 独立按读取期（loader.go）的方式装配快照：作者行含 Name+Alias，RenderMarkdown 取 .Name。
 	want := render.ContentSnapshot{
@@ -81,6 +92,7 @@ func TestParseTopicTransientTitle(t *testing.T) {
 	require.NotNil(t, dbSvc.savedRoot)
 	require.NotNil(t, dbSvc.savedRoot.Title)
 	assert.Equal(t, "结论标题", *dbSvc.savedRoot.Title, "结论标题应落到 Topic.Title")
+	assert.Equal(t, map[int]int{100: 0}, dbSvc.commentsCount, "没有评论也记下接口计数，刷新时不再重抓")
 }
 
 // autocorrect-enable
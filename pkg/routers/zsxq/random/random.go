@@ -8,25 +8,32 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/eli-yip/rss-zero/internal/rss"
+	bookmarkDB "github.com/eli-yip/rss-zero/pkg/bookmark/db"
+	readingDB "github.com/eli-yip/rss-zero/pkg/reading/db"
 	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
 	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/render"
 )
 
+// DefaultPickCount is how many digests a random feed holds unless the
+// canglimo_digest_random_select job is configured otherwise.
+const DefaultPickCount = 1
+
 // GenerateRandomCanglimoDigestRss renders an Atom feed of randomly selected
 // canglimo digests through the shared BuildZSXQFeed/RenderAtom path. The xid entry
 // id (via ZSXQRow.FakeID) and time.Now() are intentional: a fresh random selection
-// rendered once and cached per RSSRandomTTL.
-func GenerateRandomCanglimoDigestRss(gormDB *gorm.DB, logger *zap.Logger) (string, error) {
+// rendered once and cached per RSSRandomTTL. The feed has no reader identity,
+// so digests nobody has read yet are preferred. count is the number of digests to pick.
+func GenerateRandomCanglimoDigestRss(gormDB *gorm.DB, count int, logger *zap.Logger) (string, error) {
 	const (
-		topicCountToSelect = 1
-		authorID           = 48512854525288
-		authorName         = `墨苍离`
-		groupName          = `苍离的博弈与成长`
+		authorID   = 48512854525288
+		authorName = `墨苍离`
+		groupName  = `苍离的博弈与成长`
 	)
 
 	zsxqDB := db.NewDBService(gormDB)
 
-	topics, err := zsxqDB.RandomSelect(authorID, topicCountToSelect, true)
+	unread := readingDB.Unread("", bookmarkDB.PlatformZsxq, bookmarkDB.KindZsxqTopic, "zsxq_topic.id")
+	topics, err := zsxqDB.RandomSelect(authorID, count, true, unread)
 	if err != nil {
 		logger.Error("Failed to random select topics", zap.Error(err))
 		return "", err
@@ -0,0 +1,82 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/eli-yip/rss-zero/internal/md"
+	"github.com/eli-yip/rss-zero/pkg/render"
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	"github.com/eli-yip/rss-zero/pkg/routers/zsxq/parse/models"
+	zsxqTime "github.com/eli-yip/rss-zero/pkg/routers/zsxq/time"
+)
+
+// RenderComments 渲染一条 topic 的评论区：顶层评论依次排列，楼中楼回复以引用块跟在所属评论后。
+// 与 RenderMarkdown 一样是纯函数；快照里没有评论时返回空串。
+func RenderComments(topicID int, content ContentSnapshot) (string, error) {
+	comments := content.Comments[topicID]
+	if len(comments) == 0 {
+		return "", nil
+	}
+
+	topLevel := make(map[int]struct{}, len(comments))
+	for _, c := range comments {
+		if c.ParentID == 0 {
+			topLevel[c.ID] = struct{}{}
+		}
+	}
+	replies := make(map[int][]zsxqDB.Comment)
+	for _, c := range comments {
+		if _, ok := topLevel[c.ParentID]; ok {
+			replies[c.ParentID] = append(replies[c.ParentID], c)
+		}
+	}
+
+	parts := []string{md.H2("评论")}
+	for _, c := range comments {
+		// 所属评论缺失的回复按顶层评论展示
+		if _, ok := topLevel[c.ParentID]; ok {
+			continue
+		}
+		text, err := renderComment(content, c)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, text)
+		for _, r := range replies[c.ID] {
+			text, err := renderComment(content, r)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, md.Quote(text))
+		}
+	}
+
+	return formatTopicText(md.Join(parts...))
+}
+
+// renderComment 渲染单条评论：作者（回复时带被回复者）、日期、正文与图片。
+func renderComment(content ContentSnapshot, c zsxqDB.Comment) (string, error) {
+	var mc models.Comment
+	if err := json.Unmarshal(c.Raw, &mc); err != nil {
+		return "", fmt.Errorf("failed to decode comment %d raw: %w", c.ID, err)
+	}
+
+	author := md.Bold(content.Authors[c.AuthorID].Name)
+	if c.ReplieeID != 0 {
+		author = fmt.Sprintf("%s 回复 %s", author, md.Bold(content.Authors[c.ReplieeID].Name))
+	}
+	header := fmt.Sprintf("%s（%s）：", author, zsxqTime.FmtForRead(c.Time))
+
+	text, err := removeSpaces(mc.Text)
+	if err != nil {
+		return "", err
+	}
+
+	imagePart, err := renderImageParts(content, mc.Images, "这条评论的图片如下：")
+	if err != nil {
+		return "", err
+	}
+
+	return render.TrimRightSpace(md.Join(header+render.TrimRightSpace(text), imagePart)), nil
+}
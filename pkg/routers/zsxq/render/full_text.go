@@ -10,7 +10,7 @@ import (
 	zsxqTime "github.com/eli-yip/rss-zero/pkg/routers/zsxq/time"
 )
 
-// FullTextRenderer 把一条 topic 根行渲染成归档/导出用的完整文本（标题壳 + 正文 + 时间 + 原文链接）。
+// FullTextRenderer 把一条 topic 根行渲染成归档/导出用的完整文本（标题壳 + 正文 + 时间 + 原文链接 + 评论区）。
 type FullTextRenderer interface {
 	FullText(topic zsxqDB.Topic) (text string, err error)
 	// LoadSnapshot 为一页 topic 批量装配一次快照，供 FullTextFromSnapshot 逐条渲染，
@@ -23,28 +23,37 @@ type FullTextRenderer interface {
 // FullTextRenderService 读取期从 raw 渲染正文：装配快照走的是与 feed 同一条纯渲染路径，
 // 不再吃冻结的 topic.Text。
 type FullTextRenderService struct {
+	reader CommentReader
 	loader ContentLoader
 	mdFmt  *md.MarkdownFormatter
 }
 
-// NewFullTextRenderService 注入批量只读 reader（读取期传 db 服务），供 loader 装配快照。
-func NewFullTextRenderService(reader ContentReader) FullTextRenderer {
-	return &FullTextRenderService{loader: NewContentLoader(reader), mdFmt: md.NewMarkdownFormatter()}
+// NewFullTextRenderService 注入批量只读 reader（读取期传 db 服务），供 loader 装配快照与评论。
+func NewFullTextRenderService(reader CommentReader) FullTextRenderer {
+	return &FullTextRenderService{reader: reader, loader: NewContentLoader(reader), mdFmt: md.NewMarkdownFormatter()}
 }
 
+// LoadSnapshot 装配正文快照后再补上评论，完整文本带评论区。
 func (m *FullTextRenderService) LoadSnapshot(topics []zsxqDB.Topic) (ContentSnapshot, error) {
-	return m.loader.Load(topics)
+	snapshot, err := m.loader.Load(topics)
+	if err != nil {
+		return ContentSnapshot{}, err
+	}
+	if err = LoadComments(m.reader, &snapshot); err != nil {
+		return ContentSnapshot{}, err
+	}
+	return snapshot, nil
 }
 
 func (m *FullTextRenderService) FullText(t zsxqDB.Topic) (text string, err error) {
-	snapshot, err := m.loader.Load([]zsxqDB.Topic{t})
+	snapshot, err := m.LoadSnapshot([]zsxqDB.Topic{t})
 	if err != nil {
 		return "", err
 	}
 	return m.FullTextFromSnapshot(t, snapshot)
 }
 
-// FullTextFromSnapshot 渲染单条 topic 的信封（标题 + 正文 + 时间 + 链接）。ParseTopic 会持久化
+// FullTextFromSnapshot 渲染单条 topic 的信封（标题 + 正文 + 时间 + 链接），快照里有评论时在末尾附评论区。ParseTopic 会持久化
 // 非 talk/q&a 的未知类型 topic（只存元数据+raw，不存侧表事实），RenderMarkdown 对这类
 // topic 返回 ErrUnknownType；旧实现（topic.Text 列时代）对这类 topic 落库的是空字符串
 // 正文，导出/web 仍照常输出信封（feed 路径另有 render.Support 前置过滤，跳过整条，
@@ -59,10 +68,15 @@ func (m *FullTextRenderService) FullTextFromSnapshot(t zsxqDB.Topic, snapshot Co
 		body = ""
 	}
 
+	comments, err := RenderComments(t.ID, snapshot)
+	if err != nil {
+		return "", fmt.Errorf("failed to render comments: %w", err)
+	}
+
 	title := render.TrimRightSpace(md.H1(BuildTitle(t)))
 	timePart := zsxqTime.FmtForRead(t.Time)
 	link := BuildLink(t.GroupID, t.ID)
 	linkText := render.TrimRightSpace(fmt.Sprintf("[%s](%s)", link, link))
-	text = md.Join(title, body, timePart, linkText)
+	text = md.Join(title, body, timePart, linkText, comments)
 	return m.mdFmt.FormatStr(text)
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/eli-yip/rss-zero/config"
 	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
@@ -14,12 +15,17 @@ import (
 
 // fakeContentReader 是 ContentReader 的最简实现，供 FullText/FullTextFromSnapshot 单测装配快照。
 type fakeContentReader struct {
-	authors []zsxqDB.Author
+	authors  []zsxqDB.Author
+	comments []zsxqDB.Comment
+	objects  []zsxqDB.Object
 }
 
-func (f *fakeContentReader) GetObjectsByIDs([]int) ([]zsxqDB.Object, error)     { return nil, nil }
+func (f *fakeContentReader) GetObjectsByIDs([]int) ([]zsxqDB.Object, error)      { return f.objects, nil }
 func (f *fakeContentReader) GetArticlesByIDs([]string) ([]zsxqDB.Article, error) { return nil, nil }
-func (f *fakeContentReader) GetAuthorsByIDs([]int) ([]zsxqDB.Author, error)     { return f.authors, nil }
+func (f *fakeContentReader) GetAuthorsByIDs([]int) ([]zsxqDB.Author, error)      { return f.authors, nil }
+func (f *fakeContentReader) GetCommentsByTopicIDs([]int) ([]zsxqDB.Comment, error) {
+	return f.comments, nil
+}
 
 // autocorrect-disable -- expected render output, must match FullText verbatim
 
@@ -54,4 +60,72 @@ func TestFullTextUnknownType(t *testing.T) {
 	assert.Equal(expect, text)
 }
 
+// TestFullTextComments 钉住评论区：顶层评论按时间排列，楼中楼以引用块跟在所属评论后，回复带被回复者，
+// 评论图片走与正文同一套对象链接。
+func TestFullTextComments(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	raw, err := json.Marshal(models.Topic{
+		Type:     "q&a",
+		Question: &models.Question{Text: "问题"},
+		Answer:   &models.Answer{Text: new("回答")},
+	})
+	require.NoError(err)
+	topic := zsxqDB.Topic{
+		ID:       44444,
+		Time:     time.Date(2022, 11, 20, 0, 0, 0, 0, config.C.BJT),
+		GroupID:  28855218411241,
+		Type:     "q&a",
+		AuthorID: 1,
+		Title:    new("标题"),
+		Raw:      raw,
+	}
+
+	commentRaw := func(text string, images ...models.Image) []byte {
+		raw, err := json.Marshal(models.Comment{Text: text, Images: images})
+		require.NoError(err)
+		return raw
+	}
+	commentTime := time.Date(2022, 11, 21, 8, 0, 0, 0, config.C.BJT)
+	reader := &fakeContentReader{
+		authors: []zsxqDB.Author{{ID: 1, Name: "作者"}, {ID: 2, Name: "张三"}, {ID: 3, Name: "李四"}},
+		comments: []zsxqDB.Comment{
+			{ID: 10, TopicID: 44444, AuthorID: 2, Time: commentTime, Raw: commentRaw("第一条评论", models.Image{ImageID: 99})},
+			{ID: 11, TopicID: 44444, ParentID: 10, AuthorID: 1, ReplieeID: 2, Time: commentTime, Raw: commentRaw("作者的回复")},
+			{ID: 12, TopicID: 44444, AuthorID: 3, Time: commentTime, Raw: commentRaw("第二条评论")},
+		},
+		objects: []zsxqDB.Object{{ID: 99, ObjectKey: "zsxq/99.jpg", StorageProvider: []string{"https://oss.example.com"}}},
+	}
+
+	text, err := NewFullTextRenderService(reader).FullText(topic)
+	require.NoError(err)
+
+	const expect = `# 标题
+
+> 问题
+
+***作者**回答如下：*
+
+回答
+
+2022年11月20日
+
+[https://wx.zsxq.com/group/28855218411241/topic/44444](https://wx.zsxq.com/group/28855218411241/topic/44444)
+
+## 评论
+
+**张三**（2022年11月21日）：第一条评论
+
+这条评论的图片如下：
+
+第1张图片：![99](https://oss.example.com/zsxq/99.jpg)
+
+> **作者** 回复 **张三**（2022年11月21日）：作者的回复
+
+**李四**（2022年11月21日）：第二条评论
+`
+	assert.Equal(expect, text)
+}
+
 // autocorrect-enable
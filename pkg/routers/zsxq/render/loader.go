@@ -19,6 +19,12 @@ type ContentReader interface {
 	GetAuthorsByIDs(ids []int) ([]zsxqDB.Author, error)
 }
 
+// CommentReader 在 ContentReader 之上多一个评论批量查询，zsxqDB.DB 已实现。
+type CommentReader interface {
+	ContentReader
+	GetCommentsByTopicIDs(topicIDs []int) ([]zsxqDB.Comment, error)
+}
+
 // ContentLoader 把一批 topic 根行两阶段批量装配成自包含 ContentSnapshot，
 // 供纯函数 RenderMarkdown 消费，避免 per-topic N+1。
 type ContentLoader struct{ reader ContentReader }
@@ -114,3 +120,59 @@ func collectRefs(mt models.Topic, objectIDs map[int]struct{}, articleIDs map[str
 		}
 	}
 }
+
+// LoadComments 为已装配的快照补上其中 topic 的评论，再补查评论引用而快照里还没有的图片与作者。
+// 查询次数同样是常数（最多 3 次）。feed 正文不含评论，只有完整文本（归档、导出）需要它。
+func LoadComments(reader CommentReader, snap *ContentSnapshot) error {
+	snap.Comments = map[int][]zsxqDB.Comment{}
+	if len(snap.Topics) == 0 {
+		return nil
+	}
+
+	comments, err := reader.GetCommentsByTopicIDs(slices.Collect(maps.Keys(snap.Topics)))
+	if err != nil {
+		return fmt.Errorf("failed to batch load comments: %w", err)
+	}
+
+	objectIDs := map[int]struct{}{}
+	authorIDs := map[int]struct{}{}
+	for _, c := range comments {
+		snap.Comments[c.TopicID] = append(snap.Comments[c.TopicID], c)
+
+		var mc models.Comment
+		if err := json.Unmarshal(c.Raw, &mc); err != nil {
+			return fmt.Errorf("failed to decode comment %d raw: %w", c.ID, err)
+		}
+		for _, img := range mc.Images {
+			if _, ok := snap.Objects[img.ImageID]; !ok {
+				objectIDs[img.ImageID] = struct{}{}
+			}
+		}
+		for _, id := range []int{c.AuthorID, c.ReplieeID} {
+			if _, ok := snap.Authors[id]; !ok && id != 0 {
+				authorIDs[id] = struct{}{}
+			}
+		}
+	}
+
+	if len(objectIDs) > 0 {
+		objects, err := reader.GetObjectsByIDs(slices.Collect(maps.Keys(objectIDs)))
+		if err != nil {
+			return fmt.Errorf("failed to batch load comment objects: %w", err)
+		}
+		for _, o := range objects {
+			snap.Objects[o.ID] = o
+		}
+	}
+	if len(authorIDs) > 0 {
+		authors, err := reader.GetAuthorsByIDs(slices.Collect(maps.Keys(authorIDs)))
+		if err != nil {
+			return fmt.Errorf("failed to batch load comment authors: %w", err)
+		}
+		for _, a := range authors {
+			snap.Authors[a.ID] = a
+		}
+	}
+
+	return nil
+}
@@ -23,6 +23,7 @@ type ContentSnapshot struct {
 	Objects  map[int]zsxqDB.Object     // objectID -> 资源事实（key/provider/transcript）
 	Articles map[string]zsxqDB.Article // articleID -> 外部文章事实（.Text 已是转换后 markdown）
 	Authors  map[int]zsxqDB.Author     // authorID -> 作者事实
+	Comments map[int][]zsxqDB.Comment  // topicID -> 评论（按时间升序），只有 LoadComments 装配过才有
 }
 
 // RenderMarkdown 是渲染 zsxq topic 正文的纯函数：仅依赖 content，不查库、不联网、
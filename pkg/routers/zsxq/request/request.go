@@ -13,8 +13,13 @@ import (
 	"time"
 
 	"github.com/rs/xid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/net/publicsuffix"
+
+	"github.com/eli-yip/rss-zero/internal/metrics"
+	"github.com/eli-yip/rss-zero/internal/tracing"
 )
 
 var TokenPool chan struct{} = make(chan struct{})
@@ -129,15 +134,28 @@ const noSignBackoff = 60 * time.Second
 // last underlying error for diagnostics.
 func (r *RequestService) doWithRetry(ctx context.Context, u string,
 	validate func(resp *http.Response) (done bool, err error),
-	logger *zap.Logger) (*http.Response, error) {
+	logger *zap.Logger) (_ *http.Response, err error) {
 	requestTaskID := xid.New().String()
 	logger.Info("Start to request zsxq api, waiting for limiter", zap.String("url", u), zap.String("request_task_id", requestTaskID))
 
-	var err error
+	ctx, span := tracing.StartChild(ctx, "zsxq.request",
+		attribute.String("url.full", u), attribute.String("request_task_id", requestTaskID))
+	defer func() { tracing.End(span, err) }()
+
 	for i := 0; i < r.maxRetry; i++ {
+		if i > 0 {
+			metrics.IncRequestRetry("zsxq")
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", i), attribute.String("error", fmt.Sprint(err))))
+		}
 		currentRequestTaskID := fmt.Sprintf("%s_%d", requestTaskID, i)
 		logger := logger.With(zap.String("request_task_id", currentRequestTaskID))
-		<-r.limiter // block until get a token
+		// block until get a token, or the crawl is canceled
+		select {
+		case <-r.limiter:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		span.AddEvent("limiter acquired")
 		logger.Info("Get limiter successfully, start to request url")
 
 		var req *http.Request
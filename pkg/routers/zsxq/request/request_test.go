@@ -157,3 +157,20 @@ func TestLimitStream_Non200RetriesToMaxRetry(t *testing.T) {
 		t.Fatalf("expected %d requests, got %d", maxRetry, count)
 	}
 }
+
+func TestLimit_CanceledWhileWaitingForLimiter(t *testing.T) {
+	var count int32
+	svc, srv := newTestService(countingHandler(&count, http.StatusOK, `{"succeeded":true}`), 3)
+	defer srv.Close()
+	svc.limiter = make(chan struct{}) // never yields a token
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := svc.Limit(ctx, srv.URL, zap.NewNop())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected no request, got %d", count)
+	}
+}
@@ -0,0 +1,94 @@
+package statistics
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/internal/md"
+)
+
+// DB 按 Query 取参与统计的内容，返回的 bool 表示该来源是否有字数。
+type DB interface {
+	Entries(q Query) ([]Entry, bool, error)
+}
+
+type DBService struct{ *gorm.DB }
+
+func NewDBService(db *gorm.DB) DB { return &DBService{db} }
+
+// row 是各平台查询共用的扫描目标，只选统计需要的列，不读 raw。
+type row struct {
+	Time  time.Time
+	Words int
+	Text  string
+	Key   string
+	Title string
+}
+
+func (d *DBService) Entries(q Query) ([]Entry, bool, error) {
+	var (
+		rows           []row
+		wordsAvailable bool
+		countText      bool
+	)
+	query := d.DB
+	switch q.Platform {
+	case PlatformZhihu:
+		switch q.Type {
+		case "answer":
+			query = query.Table("zhihu_answer a").
+				Select("a.create_at AS time, COALESCE(a.word_count, 0) AS words, CAST(a.question_id AS text) AS key, COALESCE(q.title, '') AS title").
+				Joins("LEFT JOIN zhihu_question q ON q.id = a.question_id").
+				Where("a.author_id = ? AND a.create_at >= ? AND a.create_at < ?", q.Author, q.From, q.To)
+			wordsAvailable = true
+		case "article":
+			query = query.Table("zhihu_article").Select("create_at AS time").
+				Where("author_id = ? AND create_at >= ? AND create_at < ?", q.Author, q.From, q.To)
+		case "pin":
+			query = query.Table("zhihu_pin").Select("create_at AS time").
+				Where("author_id = ? AND create_at >= ? AND create_at < ?", q.Author, q.From, q.To)
+		}
+	case PlatformZsxq:
+		groupID, err := strconv.Atoi(q.Author)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: zsxq author must be a group id", ErrInvalidQuery)
+		}
+		query = query.Table("zsxq_topic t").
+			Select("t.time AS time, CAST(t.author_id AS text) AS key, COALESCE(au.name, '') AS title").
+			Joins("LEFT JOIN zsxq_author au ON au.id = t.author_id").
+			Where("t.group_id = ? AND t.time >= ? AND t.time < ?", groupID, q.From, q.To)
+		if q.Type != "" {
+			query = query.Where("t.type = ?", q.Type)
+		}
+	case PlatformXiaobot:
+		query = query.Table("xiaobot_post").Select("create_at AS time, COALESCE(text, '') AS text").
+			Where("paper_id = ? AND create_at >= ? AND create_at < ?", q.Author, q.From, q.To)
+		wordsAvailable, countText = true, true
+	case PlatformTombkeeper:
+		query = query.Table("tombkeeper_post").Select("published_at AS time, COALESCE(text, '') AS text").
+			Where("in_timeline AND deleted_at IS NULL AND published_at >= ? AND published_at < ?", q.From, q.To)
+		if q.Author != "" {
+			query = query.Where("author_id = ?", q.Author)
+		}
+		wordsAvailable, countText = true, true
+	default:
+		return nil, false, fmt.Errorf("%w: unknown platform %q", ErrInvalidQuery, q.Platform)
+	}
+
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to load %s statistics entries: %w", q.Platform, err)
+	}
+
+	entries := make([]Entry, 0, len(rows))
+	for _, r := range rows {
+		words := r.Words
+		if countText {
+			words = md.Count(r.Text)
+		}
+		entries = append(entries, Entry{Time: r.Time, Words: words, Key: r.Key, Title: r.Title})
+	}
+	return entries, wordsAvailable, nil
+}
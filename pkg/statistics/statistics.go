@@ -0,0 +1,211 @@
+// Package statistics 汇总归档内容的发布统计：按日/周/月的数量、字数合计、发布时段热力图与热门问题等，
+// 覆盖知乎、星球、小报童与 tombkeeper。DB 只取时间、字数与归组键等少数列，聚合在 Compute 里完成。
+package statistics
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	PlatformZhihu      = "zhihu"
+	PlatformZsxq       = "zsxq"
+	PlatformXiaobot    = "xiaobot"
+	PlatformTombkeeper = "tombkeeper"
+)
+
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// DefaultTop 是热门列表的默认长度。
+const DefaultTop = 10
+
+// MaxRange 限制一次统计的时间跨度，避免一次读出多年的全部内容。
+const MaxRange = 5 * 366 * 24 * time.Hour
+
+var (
+	ErrInvalidQuery = errors.New("invalid statistics query")
+)
+
+// Query 描述一次统计。Author 按平台含义不同：知乎为作者 id，星球为 group id，小报童为 paper id，
+// tombkeeper 为可选的微博作者 id。Type 为内容类型：知乎 answer/article/pin（缺省 answer），
+// 星球 talk/q&a 等（缺省全部），其余平台忽略。时间范围为 [From, To)。
+type Query struct {
+	Platform string
+	Author   string
+	Type     string
+	From     time.Time
+	To       time.Time
+	Interval string
+	Top      int
+}
+
+// Validate 检查参数并补齐缺省值。
+func (q *Query) Validate() error {
+	switch q.Platform {
+	case PlatformZhihu:
+		if q.Type == "" {
+			q.Type = "answer"
+		}
+		if !slices.Contains([]string{"answer", "article", "pin"}, q.Type) {
+			return fmt.Errorf("%w: unknown zhihu type %q", ErrInvalidQuery, q.Type)
+		}
+	case PlatformZsxq, PlatformXiaobot:
+	case PlatformTombkeeper:
+		return q.validateRange()
+	default:
+		return fmt.Errorf("%w: unknown platform %q", ErrInvalidQuery, q.Platform)
+	}
+	if q.Author == "" {
+		return fmt.Errorf("%w: author is required for %s", ErrInvalidQuery, q.Platform)
+	}
+	return q.validateRange()
+}
+
+func (q *Query) validateRange() error {
+	if q.Interval == "" {
+		q.Interval = IntervalDay
+	}
+	if !slices.Contains([]string{IntervalDay, IntervalWeek, IntervalMonth}, q.Interval) {
+		return fmt.Errorf("%w: unknown interval %q", ErrInvalidQuery, q.Interval)
+	}
+	if q.Top <= 0 {
+		q.Top = DefaultTop
+	}
+	if !q.From.Before(q.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+	if q.To.Sub(q.From) > MaxRange {
+		return fmt.Errorf("%w: range is longer than 5 years", ErrInvalidQuery)
+	}
+	return nil
+}
+
+// Entry 是一条内容参与统计的部分。Key/Title 是热门列表的归组键：知乎回答为问题，星球为发帖人，其余平台为空。
+type Entry struct {
+	Time  time.Time
+	Words int
+	Key   string
+	Title string
+}
+
+type Bucket struct {
+	// Start 是时段起点：日为 2006-01-02，周为该周周一的日期，月为 2006-01
+	Start string `json:"start"`
+	Count int    `json:"count"`
+	Words int    `json:"words"`
+}
+
+type TopItem struct {
+	Key   string `json:"key"`
+	Title string `json:"title"`
+	Count int    `json:"count"`
+	Words int    `json:"words"`
+}
+
+type Result struct {
+	Platform string    `json:"platform"`
+	Author   string    `json:"author"`
+	Type     string    `json:"type"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Interval string    `json:"interval"`
+
+	Total int `json:"total"`
+	Words int `json:"words"`
+	// WordsAvailable 为 false 时该来源没有可统计的正文（知乎文章/想法、星球），字数均为 0
+	WordsAvailable bool `json:"words_available"`
+
+	// Buckets 按时间升序，没有内容的时段也列出
+	Buckets []Bucket `json:"buckets"`
+	// Heatmap[weekday][hour] 是各时段的发布数，weekday 0 为周日
+	Heatmap [7][24]int `json:"heatmap"`
+	Top     []TopItem  `json:"top"`
+}
+
+// Compute 汇总 entries。时间按 loc 分日与分时，entries 不要求有序。
+func Compute(q Query, entries []Entry, wordsAvailable bool, loc *time.Location) Result {
+	result := Result{
+		Platform: q.Platform, Author: q.Author, Type: q.Type, From: q.From, To: q.To, Interval: q.Interval,
+		WordsAvailable: wordsAvailable,
+		Buckets:        emptyBuckets(q, loc),
+		Top:            []TopItem{},
+	}
+	index := make(map[string]int, len(result.Buckets))
+	for i, b := range result.Buckets {
+		index[b.Start] = i
+	}
+
+	top := make(map[string]*TopItem)
+	for _, e := range entries {
+		t := e.Time.In(loc)
+		result.Total++
+		result.Words += e.Words
+		result.Heatmap[t.Weekday()][t.Hour()]++
+		if i, ok := index[bucketStart(t, q.Interval)]; ok {
+			result.Buckets[i].Count++
+			result.Buckets[i].Words += e.Words
+		}
+		if e.Key == "" {
+			continue
+		}
+		item, ok := top[e.Key]
+		if !ok {
+			item = &TopItem{Key: e.Key, Title: e.Title}
+			top[e.Key] = item
+		}
+		item.Count++
+		item.Words += e.Words
+	}
+
+	for _, item := range top {
+		result.Top = append(result.Top, *item)
+	}
+	slices.SortFunc(result.Top, func(a, b TopItem) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(b.Words, a.Words), cmp.Compare(a.Key, b.Key))
+	})
+	result.Top = result.Top[:min(len(result.Top), q.Top)]
+	return result
+}
+
+// Daily 把按日统计的结果转为日期到数量的映射，只含有内容的日期，供旧版日历使用。
+func (r Result) Daily() map[string]int {
+	daily := make(map[string]int)
+	for _, b := range r.Buckets {
+		if b.Count > 0 {
+			daily[b.Start] = b.Count
+		}
+	}
+	return daily
+}
+
+func emptyBuckets(q Query, loc *time.Location) []Bucket {
+	buckets := make([]Bucket, 0)
+	last := ""
+	for t := q.From.In(loc); t.Before(q.To); t = t.AddDate(0, 0, 1) {
+		if start := bucketStart(t, q.Interval); start != last {
+			buckets = append(buckets, Bucket{Start: start})
+			last = start
+		}
+	}
+	return buckets
+}
+
+func bucketStart(t time.Time, interval string) string {
+	switch interval {
+	case IntervalWeek:
+		// 周一为一周的第一天
+		offset := (int(t.Weekday()) + 6) % 7
+		return t.AddDate(0, 0, -offset).Format(time.DateOnly)
+	case IntervalMonth:
+		return t.Format("2006-01")
+	default:
+		return t.Format(time.DateOnly)
+	}
+}
@@ -0,0 +1,78 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var bjt = time.FixedZone("BJT", 8*3600)
+
+func date(day, hour int) time.Time { return time.Date(2024, time.March, day, hour, 0, 0, 0, bjt) }
+
+func TestValidate(t *testing.T) {
+	q := Query{Platform: PlatformZhihu, Author: "canglimo", From: date(1, 0), To: date(8, 0)}
+	require.NoError(t, q.Validate())
+	assert.Equal(t, "answer", q.Type)
+	assert.Equal(t, IntervalDay, q.Interval)
+	assert.Equal(t, DefaultTop, q.Top)
+
+	q = Query{Platform: PlatformTombkeeper, From: date(1, 0), To: date(8, 0)}
+	assert.NoError(t, q.Validate(), "tombkeeper author is optional")
+
+	for name, bad := range map[string]Query{
+		"platform": {Platform: "weibo", Author: "a", From: date(1, 0), To: date(8, 0)},
+		"author":   {Platform: PlatformXiaobot, From: date(1, 0), To: date(8, 0)},
+		"type":     {Platform: PlatformZhihu, Author: "a", Type: "zvideo", From: date(1, 0), To: date(8, 0)},
+		"interval": {Platform: PlatformZsxq, Author: "1", Interval: "year", From: date(1, 0), To: date(8, 0)},
+		"range":    {Platform: PlatformZsxq, Author: "1", From: date(8, 0), To: date(1, 0)},
+		"too long": {Platform: PlatformZsxq, Author: "1", From: date(1, 0).AddDate(-6, 0, 0), To: date(1, 0)},
+	} {
+		assert.ErrorIs(t, bad.Validate(), ErrInvalidQuery, name)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	// 2024-03-04 是周一
+	q := Query{Platform: PlatformZhihu, Author: "canglimo", Type: "answer", From: date(1, 0), To: date(15, 0), Interval: IntervalWeek, Top: 1}
+	entries := []Entry{
+		{Time: date(4, 9), Words: 100, Key: "1", Title: "q1"},
+		{Time: date(5, 9), Words: 50, Key: "2", Title: "q2"},
+		{Time: date(5, 23), Words: 30, Key: "2", Title: "q2"},
+		// UTC 时间落在 BJT 的 3 月 11 日 01 点
+		{Time: time.Date(2024, time.March, 10, 17, 0, 0, 0, time.UTC), Words: 20, Key: "1", Title: "q1"},
+	}
+	result := Compute(q, entries, true, bjt)
+
+	assert.Equal(t, 4, result.Total)
+	assert.Equal(t, 200, result.Words)
+	assert.Equal(t, []Bucket{
+		{Start: "2024-02-26", Count: 0, Words: 0},
+		{Start: "2024-03-04", Count: 3, Words: 180},
+		{Start: "2024-03-11", Count: 1, Words: 20},
+	}, result.Buckets)
+	assert.Equal(t, 1, result.Heatmap[time.Monday][1])
+	assert.Equal(t, 1, result.Heatmap[time.Tuesday][23])
+	assert.Equal(t, 2, result.Heatmap[time.Tuesday][9]+result.Heatmap[time.Monday][9])
+	// 数量相同时字数多的在前
+	assert.Equal(t, []TopItem{{Key: "1", Title: "q1", Count: 2, Words: 120}}, result.Top)
+
+	q.Interval = IntervalMonth
+	result = Compute(q, entries, true, bjt)
+	assert.Equal(t, []Bucket{{Start: "2024-03", Count: 4, Words: 200}}, result.Buckets)
+
+	q.Interval = IntervalDay
+	result = Compute(q, entries, true, bjt)
+	assert.Len(t, result.Buckets, 14)
+	assert.Equal(t, map[string]int{"2024-03-04": 1, "2024-03-05": 2, "2024-03-11": 1}, result.Daily())
+}
+
+func TestComputeEmpty(t *testing.T) {
+	q := Query{Platform: PlatformZsxq, Author: "1", From: date(1, 0), To: date(2, 0), Interval: IntervalDay, Top: DefaultTop}
+	result := Compute(q, nil, false, bjt)
+	assert.Equal(t, []Bucket{{Start: "2024-03-01"}}, result.Buckets)
+	assert.NotNil(t, result.Top)
+	assert.Empty(t, result.Daily())
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+)
+
+// 抓取错误分类，写入 Stat.ErrorClass。平台特有的错误（cookie 失效、账号注销等）由各平台 cron 自行归类，
+// 其余交给 ClassifyError 按通用错误类型推断。
+const (
+	ErrorClassAuth      = "auth" // cookie / token 失效，需要人工更新
+	ErrorClassGone      = "gone" // 作者注销、仓库不存在等，订阅本身已失效
+	ErrorClassRateLimit = "rate_limit"
+	ErrorClassUpstream  = "upstream" // 上游返回异常或依赖服务（如知乎加密服务）不可用
+	ErrorClassNetwork   = "network"  // 超时、连接失败
+	ErrorClassParse     = "parse"    // 响应结构变化导致解析失败
+	ErrorClassUnknown   = "unknown"
+)
+
+// ClassifyError 按通用错误类型推断分类，err 为空时返回空串。
+func ClassifyError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var (
+		netErr       net.Error
+		syntaxErr    *json.SyntaxError
+		unmarshalErr *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return ErrorClassNetwork
+	case errors.As(err, &syntaxErr), errors.As(err, &unmarshalErr):
+		return ErrorClassParse
+	default:
+		return ErrorClassUnknown
+	}
+}
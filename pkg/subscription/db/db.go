@@ -0,0 +1,104 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type DB interface {
+	// RecordRun 记录一次抓取结果。
+	RecordRun(platform, subID string, run Run) error
+	// GetStats 返回某平台 sub id → 抓取统计，从未抓取过的订阅不在结果中。
+	GetStats(platform string) (map[string]Stat, error)
+	// DeleteStat 删除订阅时一并清掉统计。
+	DeleteStat(platform, subID string) error
+}
+
+type SubscriptionDBImpl struct{ *gorm.DB }
+
+func NewSubscriptionDBImpl(db *gorm.DB) DB { return &SubscriptionDBImpl{db} }
+
+// Run 是一次订阅抓取的结果，Err 为空即成功。
+type Run struct {
+	ItemsFound int
+	ItemsNew   int
+	Err        error
+	// ErrorClass 为空时由 ClassifyError 推断
+	ErrorClass string
+}
+
+func (db *SubscriptionDBImpl) RecordRun(platform, subID string, run Run) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		stat := Stat{Platform: platform, SubID: subID}
+		if err := tx.Where("platform = ? AND sub_id = ?", platform, subID).First(&stat).Error; err != nil &&
+			!errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get subscription stat: %w", err)
+		}
+		applyRun(&stat, run, time.Now())
+		if err := tx.Save(&stat).Error; err != nil {
+			return fmt.Errorf("failed to save subscription stat: %w", err)
+		}
+		return nil
+	})
+}
+
+func applyRun(stat *Stat, run Run, now time.Time) {
+	stat.CrawlCount++
+	stat.LastAttemptAt = &now
+	stat.ItemsFound, stat.ItemsNew = run.ItemsFound, run.ItemsNew
+
+	if run.Err == nil {
+		stat.ConsecutiveFailures = 0
+		stat.LastSuccessAt = &now
+		return
+	}
+
+	stat.FailureCount++
+	stat.ConsecutiveFailures++
+	stat.LastFailureAt = &now
+	stat.LastError = truncateError(run.Err.Error())
+	stat.ErrorClass = run.ErrorClass
+	if stat.ErrorClass == "" {
+		stat.ErrorClass = ClassifyError(run.Err)
+	}
+}
+
+func (db *SubscriptionDBImpl) GetStats(platform string) (map[string]Stat, error) {
+	var stats []Stat
+	if err := db.Where("platform = ?", platform).Find(&stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get subscription stats: %w", err)
+	}
+	result := make(map[string]Stat, len(stats))
+	for _, s := range stats {
+		result[s.SubID] = s
+	}
+	return result, nil
+}
+
+func (db *SubscriptionDBImpl) DeleteStat(platform, subID string) error {
+	return db.Where("platform = ? AND sub_id = ?", platform, subID).Delete(&Stat{}).Error
+}
+
+func truncateError(msg string) string {
+	if len(msg) <= maxErrorLength {
+		return msg
+	}
+	// 退到 rune 起点再截断，避免切开中文
+	cut := maxErrorLength
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+	return msg[:cut] + "…"
+}
+
+// Record 记录一次抓取结果。统计写入失败只记日志，不影响抓取本身。
+func Record(db DB, platform, subID string, run Run, logger *zap.Logger) {
+	if err := db.RecordRun(platform, subID, run); err != nil {
+		logger.Error("Failed to record subscription stat", zap.String("platform", platform), zap.String("sub_id", subID), zap.Error(err))
+	}
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateError(t *testing.T) {
+	assert.Equal(t, "timeout", truncateError("timeout"))
+
+	long := strings.Repeat("a", maxErrorLength-1) + "超时了"
+	got := truncateError(long)
+	assert.True(t, utf8.ValidString(got))
+	assert.Equal(t, strings.Repeat("a", maxErrorLength-1)+"…", got)
+}
+
+func TestApplyRun(t *testing.T) {
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	var stat Stat
+
+	applyRun(&stat, Run{Err: context.DeadlineExceeded}, now)
+	applyRun(&stat, Run{Err: errors.New("cookie expired"), ErrorClass: ErrorClassAuth}, now)
+	assert.Equal(t, 2, stat.CrawlCount)
+	assert.Equal(t, 2, stat.ConsecutiveFailures)
+	assert.Equal(t, ErrorClassAuth, stat.ErrorClass)
+	assert.Nil(t, stat.LastSuccessAt)
+
+	applyRun(&stat, Run{ItemsFound: 20, ItemsNew: 2}, now)
+	assert.Equal(t, 0, stat.ConsecutiveFailures)
+	assert.Equal(t, 2, stat.FailureCount)
+	assert.Equal(t, &now, stat.LastSuccessAt)
+	assert.Equal(t, &now, stat.LastAttemptAt)
+	assert.Equal(t, 20, stat.ItemsFound)
+	assert.Equal(t, 2, stat.ItemsNew)
+	// 最近一次失败的信息保留下来
+	assert.Equal(t, "cookie expired", stat.LastError)
+}
+
+func TestClassifyError(t *testing.T) {
+	assert.Equal(t, "", ClassifyError(nil))
+	assert.Equal(t, ErrorClassNetwork, ClassifyError(fmt.Errorf("request: %w", context.DeadlineExceeded)))
+	assert.Equal(t, ErrorClassNetwork, ClassifyError(&net.OpError{Op: "dial", Err: errors.New("refused")}))
+	assert.Equal(t, ErrorClassParse, ClassifyError(json.Unmarshal([]byte("{"), &struct{}{})))
+	assert.Equal(t, ErrorClassUnknown, ClassifyError(errors.New("boom")))
+}
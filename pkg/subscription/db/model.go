@@ -0,0 +1,40 @@
+package db
+
+import "time"
+
+// 订阅所属平台，同时是 /api/v1/subscriptions 路径中的 platform 段，与动态 cron 来源的 Kind 一致。
+const (
+	PlatformZhihu   = "zhihu"
+	PlatformZsxq    = "zsxq"
+	PlatformXiaobot = "xiaobot"
+	PlatformGitHub  = "github"
+)
+
+// maxErrorLength 限制 LastError 的长度，爬虫错误偶尔会带上整段响应体。
+const maxErrorLength = 1024
+
+// Stat 是一条订阅的抓取统计，由各平台 cron 在每个订阅抓取结束时写入（含被跳过的订阅）。
+// SubID 是平台订阅表的主键：zhihu/github 为 sub id，xiaobot 为 paper id，zsxq 为 group id。
+type Stat struct {
+	Platform string `gorm:"type:text;primaryKey"`
+	SubID    string `gorm:"type:text;primaryKey"`
+
+	CrawlCount   int `gorm:"not null;default:0"`
+	FailureCount int `gorm:"not null;default:0"`
+	// ConsecutiveFailures 成功一次即清零
+	ConsecutiveFailures int `gorm:"not null;default:0"`
+	// LastAttemptAt / LastSuccessAt / LastFailureAt 为空表示从未抓取 / 成功 / 失败过
+	LastAttemptAt *time.Time
+	LastSuccessAt *time.Time
+	LastFailureAt *time.Time
+	// LastError / ErrorClass 是最近一次失败的错误与分类，成功后保留以便回溯
+	LastError  string `gorm:"type:text"`
+	ErrorClass string `gorm:"type:text"`
+	// ItemsFound / ItemsNew 是最近一次抓取解析到的条目数与新入库的条目数
+	ItemsFound int `gorm:"not null;default:0"`
+	ItemsNew   int `gorm:"not null;default:0"`
+
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (s *Stat) TableName() string { return "subscription_stats" }
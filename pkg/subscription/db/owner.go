@@ -0,0 +1,121 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Owner 记录某个用户订阅了一条平台订阅。平台订阅仍只有一条、只抓取一次，多个用户各持一条 Owner；
+// 没有 Owner 的订阅（多用户之前建的、管理员经 /subscriptions 建的）只出现在管理员的全局列表里。
+type Owner struct {
+	Platform  string    `gorm:"type:text;primaryKey" json:"platform"`
+	SubID     string    `gorm:"type:text;primaryKey" json:"sub_id"`
+	Username  string    `gorm:"type:text;primaryKey;index" json:"username"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (o *Owner) TableName() string { return "subscription_owners" }
+
+// Grant 授权用户访问一个付费来源：星球为 group id，小报童为 paper id。
+type Grant struct {
+	Platform  string    `gorm:"type:text;primaryKey" json:"platform"`
+	TargetID  string    `gorm:"type:text;primaryKey" json:"target_id"`
+	Username  string    `gorm:"type:text;primaryKey;index" json:"username"`
+	CreatedBy string    `gorm:"type:text" json:"created_by"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (g *Grant) TableName() string { return "subscription_grants" }
+
+var (
+	ErrOwnerNotFound = errors.New("subscription owner not found")
+	ErrGrantNotFound = errors.New("subscription grant not found")
+)
+
+type OwnerDB interface {
+	// AddOwner 已是 owner 时不报错。
+	AddOwner(platform, subID, username string) error
+	// RemoveOwner 不是 owner 时返回 ErrOwnerNotFound。
+	RemoveOwner(platform, subID, username string) error
+	ListOwned(username string) ([]Owner, error)
+
+	// AddGrant 已有授权时不报错。
+	AddGrant(grant *Grant) error
+	// DeleteGrant 没有授权时返回 ErrGrantNotFound。
+	DeleteGrant(platform, targetID, username string) error
+	// ListGrants 返回用户的授权，username 为空时返回全部。
+	ListGrants(username string) ([]Grant, error)
+	HasGrant(platform, targetID, username string) (bool, error)
+}
+
+type OwnerDBImpl struct{ *gorm.DB }
+
+func NewOwnerDBImpl(db *gorm.DB) OwnerDB { return &OwnerDBImpl{db} }
+
+func (db *OwnerDBImpl) AddOwner(platform, subID, username string) error {
+	owner := Owner{Platform: platform, SubID: subID, Username: username}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&owner).Error; err != nil {
+		return fmt.Errorf("failed to add subscription owner: %w", err)
+	}
+	return nil
+}
+
+func (db *OwnerDBImpl) RemoveOwner(platform, subID, username string) error {
+	result := db.Where("platform = ? AND sub_id = ? AND username = ?", platform, subID, username).Delete(&Owner{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove subscription owner: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrOwnerNotFound
+	}
+	return nil
+}
+
+func (db *OwnerDBImpl) ListOwned(username string) (owners []Owner, err error) {
+	if err = db.Where("username = ?", username).Order("created_at").Find(&owners).Error; err != nil {
+		return nil, fmt.Errorf("failed to list owned subscriptions: %w", err)
+	}
+	return owners, nil
+}
+
+func (db *OwnerDBImpl) AddGrant(grant *Grant) error {
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(grant).Error; err != nil {
+		return fmt.Errorf("failed to add subscription grant: %w", err)
+	}
+	return nil
+}
+
+func (db *OwnerDBImpl) DeleteGrant(platform, targetID, username string) error {
+	result := db.Where("platform = ? AND target_id = ? AND username = ?", platform, targetID, username).Delete(&Grant{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete subscription grant: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrGrantNotFound
+	}
+	return nil
+}
+
+func (db *OwnerDBImpl) ListGrants(username string) (grants []Grant, err error) {
+	query := db.Order("platform, target_id, username")
+	if username != "" {
+		query = query.Where("username = ?", username)
+	}
+	if err = query.Find(&grants).Error; err != nil {
+		return nil, fmt.Errorf("failed to list subscription grants: %w", err)
+	}
+	return grants, nil
+}
+
+func (db *OwnerDBImpl) HasGrant(platform, targetID, username string) (bool, error) {
+	var count int64
+	err := db.Model(&Grant{}).Where("platform = ? AND target_id = ? AND username = ?", platform, targetID, username).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check subscription grant: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,105 @@
+package subscription
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	githubDB "github.com/eli-yip/rss-zero/pkg/routers/github/db"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// GitHub 订阅的内容种类：prerelease 订阅的 feed 同时包含正式版与预发布版。
+const (
+	GitHubKindRelease    = "release"
+	GitHubKindPrerelease = "prerelease"
+)
+
+// GitHubSubscribeFunc 是 github RSS 路由首次访问时的建订阅逻辑（会请求 GitHub 确认 repo 存在）。
+type GitHubSubscribeFunc func(user, repoName string, pre bool) (subID string, err error)
+
+type githubSource struct {
+	db        githubDB.DB
+	subscribe GitHubSubscribeFunc
+}
+
+// NewGitHubSource 每个 repo 至多两条订阅：仅正式版、含预发布版。
+func NewGitHubSource(db githubDB.DB, subscribe GitHubSubscribeFunc) Source {
+	return &githubSource{db: db, subscribe: subscribe}
+}
+
+func (s *githubSource) Platform() string { return subscriptionDB.PlatformGitHub }
+
+func (s *githubSource) List() ([]Subscription, error) {
+	subs, err := s.db.GetSubsIncludeDeleted()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Subscription, 0, len(subs))
+	for _, sub := range subs {
+		repo, err := s.db.GetRepoByID(sub.RepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repo %s: %w", sub.RepoID, err)
+		}
+		target := repo.GithubUser + "/" + repo.Name
+		name, kinds, prefix := target, []string{GitHubKindRelease}, "/rss/github/"
+		if sub.PreRelease {
+			name, kinds, prefix = target+" (pre-release)", []string{GitHubKindRelease, GitHubKindPrerelease}, "/rss/github/pre/"
+		}
+		result = append(result, Subscription{
+			Platform: subscriptionDB.PlatformGitHub,
+			ID:       sub.ID,
+			TargetID: target,
+			Name:     name,
+			Kinds:    kinds,
+			Active:   !sub.DeletedAt.Valid,
+			FeedPath: prefix + target,
+			URL:      "https://github.com/" + target + "/releases",
+		})
+	}
+	return result, nil
+}
+
+func (s *githubSource) Create(req CreateRequest, _ *zap.Logger) (Subscription, error) {
+	user, repo, ok := strings.Cut(req.TargetID, "/")
+	if !ok || user == "" || repo == "" || strings.Contains(repo, "/") {
+		return Subscription{}, fmt.Errorf("%w: github target must be user/repo", ErrInvalidTarget)
+	}
+	var pre bool
+	switch req.Kind {
+	case "", GitHubKindRelease:
+	case GitHubKindPrerelease:
+		pre = true
+	default:
+		return Subscription{}, fmt.Errorf("%w: unknown github kind %q", ErrInvalidTarget, req.Kind)
+	}
+
+	subID, err := s.subscribe(user, repo, pre)
+	if err != nil {
+		return Subscription{}, err
+	}
+	return Subscription{Platform: subscriptionDB.PlatformGitHub, ID: subID}, nil
+}
+
+func (s *githubSource) Pause(id string) error  { return s.db.DeleteSub(id) }
+func (s *githubSource) Resume(id string) error { return s.db.ActivateSub(id) }
+func (s *githubSource) Delete(id string) error { return s.db.PurgeSub(id) }
+
+func (s *githubSource) RecentItemTimes(sub Subscription, n int) ([]time.Time, error) {
+	githubSub, err := s.db.GetSubByIDIncludeDeleted(sub.ID)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := s.db.GetReleases(githubSub.RepoID, githubSub.PreRelease, 1, n)
+	if err != nil {
+		return nil, err
+	}
+	times := make([]time.Time, 0, len(releases))
+	for _, r := range releases {
+		times = append(times, r.PublishedAt)
+	}
+	return times, nil
+}
@@ -0,0 +1,128 @@
+package subscription
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// 订阅健康状态，按严重程度排列：先看抓取是否失败，再看是否久未抓取，最后才看作者是否停更。
+const (
+	HealthOK = "ok"
+	// HealthFailing 连续失败达到 failingThreshold，ErrorClass 指明是 cookie 失效、账号注销还是上游异常
+	HealthFailing = "failing"
+	// HealthNotCrawled 从未抓取，或最近一次成功抓取已超过 crawlStaleAfter
+	HealthNotCrawled = "not_crawled"
+	// HealthStale 抓取正常，但距最新一条内容已超出该订阅惯常更新间隔的 staleFactor 倍
+	HealthStale = "stale"
+)
+
+const (
+	failingThreshold = 3
+	crawlStaleAfter  = 48 * time.Hour
+
+	// cadenceSamples 取最近多少条内容估算更新间隔，不足 minCadenceSamples 条时退回平台默认间隔
+	cadenceSamples    = 20
+	minCadenceSamples = 3
+	staleFactor       = 3
+	minStaleAfter     = 2 * 24 * time.Hour
+	maxStaleAfter     = 180 * 24 * time.Hour
+)
+
+// defaultCadence 是内容太少、无法估算时各平台的惯常更新间隔。
+var defaultCadence = map[string]time.Duration{
+	subscriptionDB.PlatformZhihu:   14 * 24 * time.Hour,
+	subscriptionDB.PlatformZsxq:    3 * 24 * time.Hour,
+	subscriptionDB.PlatformXiaobot: 7 * 24 * time.Hour,
+	subscriptionDB.PlatformGitHub:  60 * 24 * time.Hour,
+}
+
+// Health 是一条启用中订阅的健康评估。
+type Health struct {
+	Subscription
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+	// LastItemAt 是最新一条已入库内容的发布时间，没有内容时为空
+	LastItemAt *time.Time `json:"last_item_at"`
+	// CadenceHours 是最近内容发布间隔的中位数，StaleAfterHours 是判定停更的阈值
+	CadenceHours    float64 `json:"cadence_hours"`
+	StaleAfterHours float64 `json:"stale_after_hours"`
+}
+
+// Health 评估启用中订阅的健康状态，platform 为空表示全部平台。已暂停的订阅 cron 不抓取，不参与评估。
+func (r *Registry) Health(platform string, now time.Time) ([]Health, error) {
+	active := true
+	subs, err := r.List(Filter{Platform: platform, Active: &active})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Health, 0, len(subs))
+	for _, sub := range subs {
+		s, err := r.source(sub.Platform)
+		if err != nil {
+			return nil, err
+		}
+		itemTimes, err := s.RecentItemTimes(sub, cadenceSamples)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recent items of %s/%s: %w", sub.Platform, sub.ID, err)
+		}
+		result = append(result, evaluate(sub, itemTimes, now))
+	}
+	return result, nil
+}
+
+// evaluate 判定一条订阅的健康状态，itemTimes 从新到旧。
+func evaluate(sub Subscription, itemTimes []time.Time, now time.Time) Health {
+	cadence := typicalInterval(itemTimes)
+	if cadence == 0 {
+		cadence = defaultCadence[sub.Platform]
+	}
+	staleAfter := min(max(cadence*staleFactor, minStaleAfter), maxStaleAfter)
+
+	h := Health{
+		Subscription:    sub,
+		Status:          HealthOK,
+		CadenceHours:    cadence.Hours(),
+		StaleAfterHours: staleAfter.Hours(),
+	}
+	if len(itemTimes) > 0 {
+		h.LastItemAt = &itemTimes[0]
+	}
+
+	stats := sub.Stats
+	switch {
+	case stats != nil && stats.ConsecutiveFailures >= failingThreshold:
+		h.Status = HealthFailing
+		h.Reason = fmt.Sprintf("%d consecutive failures (%s): %s", stats.ConsecutiveFailures, stats.ErrorClass, stats.LastError)
+	case stats == nil || stats.LastSuccessAt == nil:
+		h.Status = HealthNotCrawled
+		h.Reason = "never crawled successfully"
+	case now.Sub(*stats.LastSuccessAt) > crawlStaleAfter:
+		h.Status = HealthNotCrawled
+		h.Reason = fmt.Sprintf("last successful crawl at %s", stats.LastSuccessAt.Format(time.RFC3339))
+	case h.LastItemAt == nil:
+		h.Status = HealthStale
+		h.Reason = "no items archived"
+	case now.Sub(*h.LastItemAt) > staleAfter:
+		h.Status = HealthStale
+		h.Reason = fmt.Sprintf("no new items for %.0f days, typically every %.1f days",
+			now.Sub(*h.LastItemAt).Hours()/24, cadence.Hours()/24)
+	}
+	return h
+}
+
+// typicalInterval 返回相邻内容发布间隔的中位数，样本不足时返回 0。
+func typicalInterval(itemTimes []time.Time) time.Duration {
+	if len(itemTimes) < minCadenceSamples {
+		return 0
+	}
+	gaps := make([]time.Duration, 0, len(itemTimes)-1)
+	for i := 1; i < len(itemTimes); i++ {
+		gaps = append(gaps, itemTimes[i-1].Sub(itemTimes[i]).Abs())
+	}
+	slices.Sort(gaps)
+	return gaps[len(gaps)/2]
+}
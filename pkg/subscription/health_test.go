@@ -0,0 +1,128 @@
+package subscription
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// daily 返回从 latest 起每隔 gap 一条、共 n 条的发布时间，从新到旧。
+func daily(latest time.Time, gap time.Duration, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	for i := range n {
+		times = append(times, latest.Add(-time.Duration(i)*gap))
+	}
+	return times
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	crawled := now.Add(-time.Hour)
+	healthy := &Stats{LastSuccessAt: &crawled}
+	longAgo := now.Add(-3 * day)
+
+	tests := []struct {
+		name        string
+		sub         Subscription
+		itemTimes   []time.Time
+		wantStatus  string
+		wantCadence time.Duration
+	}{
+		{
+			name:        "按惯常间隔仍在更新",
+			sub:         Subscription{Platform: subscriptionDB.PlatformZhihu, Stats: healthy},
+			itemTimes:   daily(now.Add(-2*day), day, 10),
+			wantStatus:  HealthOK,
+			wantCadence: day,
+		},
+		{
+			name:        "日更作者一周没有新内容",
+			sub:         Subscription{Platform: subscriptionDB.PlatformZhihu, Stats: healthy},
+			itemTimes:   daily(now.Add(-7*day), day, 10),
+			wantStatus:  HealthStale,
+			wantCadence: day,
+		},
+		{
+			name:        "内容太少时退回平台默认间隔",
+			sub:         Subscription{Platform: subscriptionDB.PlatformGitHub, Stats: healthy},
+			itemTimes:   []time.Time{now.Add(-100 * day)},
+			wantStatus:  HealthOK,
+			wantCadence: defaultCadence[subscriptionDB.PlatformGitHub],
+		},
+		{
+			name: "cookie 失效连续失败",
+			sub: Subscription{Platform: subscriptionDB.PlatformZhihu, Stats: &Stats{
+				LastSuccessAt: &crawled, ConsecutiveFailures: 3, ErrorClass: subscriptionDB.ErrorClassAuth,
+			}},
+			itemTimes:   daily(now, day, 10),
+			wantStatus:  HealthFailing,
+			wantCadence: day,
+		},
+		{
+			name:        "从未抓取",
+			sub:         Subscription{Platform: subscriptionDB.PlatformXiaobot},
+			wantStatus:  HealthNotCrawled,
+			wantCadence: defaultCadence[subscriptionDB.PlatformXiaobot],
+		},
+		{
+			name: "久未成功抓取",
+			sub: Subscription{Platform: subscriptionDB.PlatformZsxq, Stats: &Stats{
+				LastSuccessAt: &longAgo, ConsecutiveFailures: 1,
+			}},
+			itemTimes:   daily(now, day, 10),
+			wantStatus:  HealthNotCrawled,
+			wantCadence: day,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := evaluate(tt.sub, tt.itemTimes, now)
+			assert.Equal(t, tt.wantStatus, h.Status, h.Reason)
+			assert.Equal(t, tt.wantCadence.Hours(), h.CadenceHours)
+			if tt.wantStatus != HealthOK {
+				assert.NotEmpty(t, h.Reason)
+			}
+		})
+	}
+}
+
+func TestTypicalInterval(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	assert.Zero(t, typicalInterval([]time.Time{now, now.Add(-time.Hour)}))
+
+	// 中位数不受偶发的长间隔影响
+	times := []time.Time{now, now.Add(-time.Hour), now.Add(-2 * time.Hour), now.Add(-100 * time.Hour)}
+	assert.Equal(t, time.Hour, typicalInterval(times))
+}
+
+func TestRegistryHealth(t *testing.T) {
+	now := time.Now()
+	crawled := now.Add(-time.Hour)
+	zhihu := &fakeSource{
+		platform: subscriptionDB.PlatformZhihu,
+		subs: []Subscription{
+			{Platform: subscriptionDB.PlatformZhihu, ID: "a", Active: true},
+			{Platform: subscriptionDB.PlatformZhihu, ID: "paused", Active: false},
+		},
+		itemTimes: map[string][]time.Time{"a": daily(now.Add(-30*24*time.Hour), 24*time.Hour, 30)},
+	}
+	stats := &fakeStatDB{stats: map[string]map[string]subscriptionDB.Stat{
+		subscriptionDB.PlatformZhihu: {"a": {LastSuccessAt: &crawled}},
+	}}
+	r := NewRegistry(stats, zhihu)
+
+	result, err := r.Health("", now)
+	require.NoError(t, err)
+	require.Len(t, result, 1, "已暂停的订阅不参与评估")
+	assert.Equal(t, "a", result[0].ID)
+	assert.Equal(t, HealthStale, result[0].Status)
+	assert.Equal(t, float64(24), result[0].CadenceHours, "只取最近 cadenceSamples 条估算")
+
+	_, err = r.Health("weibo", now)
+	assert.ErrorIs(t, err, ErrUnknownPlatform)
+}
@@ -0,0 +1,207 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/feedtoken"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+var (
+	// ErrForbidden 表示用户无权订阅该平台或该付费来源。
+	ErrForbidden = errors.New("no access to this subscription")
+	// ErrQuotaExceeded 表示用户的订阅数已达上限。
+	ErrQuotaExceeded = errors.New("subscription quota exceeded")
+)
+
+// DefaultQuota 是未配置 settings.subscription_quota 时每个非管理员用户可持有的订阅数。
+const DefaultQuota = 50
+
+// selfServicePlatforms 是非管理员可自行订阅的平台。星球建订阅需要管理员的星球 cookie 能访问该 group，只由管理员建。
+var selfServicePlatforms = []string{subscriptionDB.PlatformZhihu, subscriptionDB.PlatformGitHub, subscriptionDB.PlatformXiaobot}
+
+// IsPaid 报告平台内容是否付费：星球 group 与小报童 paper 只对获得授权的用户可见。
+func IsPaid(platform string) bool {
+	return platform == subscriptionDB.PlatformZsxq || platform == subscriptionDB.PlatformXiaobot
+}
+
+// User 是发起请求的用户。管理员不受平台、配额与付费授权的限制。
+type User struct {
+	Name  string
+	Admin bool
+}
+
+// Ownership 在 Registry 之上维护用户与订阅的关系：每个用户的“我的订阅”、配额，以及付费来源的授权。
+type Ownership struct {
+	registry *Registry
+	db       subscriptionDB.OwnerDB
+	quota    int
+	// feedSecret 为付费 feed 签发访问令牌，为空时付费 feed 不做限制
+	feedSecret string
+}
+
+// NewOwnership 的 quota 不大于 0 时使用 DefaultQuota。
+func NewOwnership(registry *Registry, db subscriptionDB.OwnerDB, quota int, feedSecret string) *Ownership {
+	if quota <= 0 {
+		quota = DefaultQuota
+	}
+	return &Ownership{registry: registry, db: db, quota: quota, feedSecret: feedSecret}
+}
+
+// List 返回用户订阅的订阅，付费 feed 的 FeedPath 带上该用户的访问令牌。授权被收回的付费订阅不再列出。
+func (o *Ownership) List(user User, filter Filter) ([]Subscription, error) {
+	owned, err := o.db.ListOwned(user.Name)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(owned))
+	for _, owner := range owned {
+		keys[owner.Platform+"/"+owner.SubID] = true
+	}
+
+	subs, err := o.registry.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Subscription, 0, len(owned))
+	for _, sub := range subs {
+		if !keys[sub.Platform+"/"+sub.ID] {
+			continue
+		}
+		if err = o.CheckAccess(user, sub.Platform, sub.TargetID); errors.Is(err, ErrForbidden) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		sub.FeedPath = o.FeedPath(user, sub)
+		result = append(result, sub)
+	}
+	return result, nil
+}
+
+// Subscribe 建立（或复用已有的）订阅并记到用户名下。非管理员只能订阅 selfServicePlatforms，付费来源需先获得授权，
+// 且持有的订阅数不能超过配额。
+func (o *Ownership) Subscribe(user User, req CreateRequest, logger *zap.Logger) (Subscription, error) {
+	if !user.Admin && !slices.Contains(selfServicePlatforms, req.Platform) {
+		return Subscription{}, fmt.Errorf("%w: %s subscriptions are managed by admins", ErrForbidden, req.Platform)
+	}
+	if err := o.CheckAccess(user, req.Platform, req.TargetID); err != nil {
+		return Subscription{}, err
+	}
+	if !user.Admin {
+		owned, err := o.db.ListOwned(user.Name)
+		if err != nil {
+			return Subscription{}, err
+		}
+		if len(owned) >= o.quota {
+			return Subscription{}, fmt.Errorf("%w: %d of %d used", ErrQuotaExceeded, len(owned), o.quota)
+		}
+	}
+
+	sub, err := o.registry.Create(req, logger)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if err = o.db.AddOwner(sub.Platform, sub.ID, user.Name); err != nil {
+		return Subscription{}, err
+	}
+	sub.FeedPath = o.FeedPath(user, sub)
+	return sub, nil
+}
+
+// Unsubscribe 只把订阅从用户名下移除，平台订阅本身与其他用户的订阅不受影响。
+func (o *Ownership) Unsubscribe(user User, platform, id string) error {
+	err := o.db.RemoveOwner(platform, id, user.Name)
+	if errors.Is(err, subscriptionDB.ErrOwnerNotFound) {
+		return fmt.Errorf("%w: %s/%s", ErrNotFound, platform, id)
+	}
+	return err
+}
+
+// CheckAccess 检查用户能否访问付费来源，非付费平台与管理员总是可以。
+func (o *Ownership) CheckAccess(user User, platform, targetID string) error {
+	if user.Admin || !IsPaid(platform) {
+		return nil
+	}
+	ok, err := o.db.HasGrant(platform, targetID, user.Name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s/%s is not granted to %s", ErrForbidden, platform, targetID, user.Name)
+	}
+	return nil
+}
+
+// Grant 授权用户访问付费来源，by 是操作的管理员。
+func (o *Ownership) Grant(platform, targetID, username, by string) error {
+	if !IsPaid(platform) {
+		return fmt.Errorf("%w: %s is not a paid platform", ErrInvalidTarget, platform)
+	}
+	if targetID == "" || username == "" {
+		return fmt.Errorf("%w: target id and username are required", ErrInvalidTarget)
+	}
+	return o.db.AddGrant(&subscriptionDB.Grant{Platform: platform, TargetID: targetID, Username: username, CreatedBy: by})
+}
+
+// Revoke 收回授权。用户名下的订阅保留，重新授权后即恢复；期间其 feed 令牌失效。
+func (o *Ownership) Revoke(platform, targetID, username string) error {
+	err := o.db.DeleteGrant(platform, targetID, username)
+	if errors.Is(err, subscriptionDB.ErrGrantNotFound) {
+		return fmt.Errorf("%w: grant %s/%s for %s", ErrNotFound, platform, targetID, username)
+	}
+	return err
+}
+
+func (o *Ownership) Grants(username string) ([]subscriptionDB.Grant, error) {
+	return o.db.ListGrants(username)
+}
+
+// FeedPath 返回用户可用的 feed 路径。付费 feed 在配置了 feed 密钥时带上 user 与 token 查询参数；
+// 管理员的令牌不绑定用户（user 为空），只能靠更换密钥作废。
+func (o *Ownership) FeedPath(user User, sub Subscription) string {
+	if o.feedSecret == "" || !IsPaid(sub.Platform) {
+		return sub.FeedPath
+	}
+	return SignFeedPath(o.feedSecret, feedUser(user), sub.FeedPath)
+}
+
+// CanReadFeed 校验付费 feed 请求带来的令牌：令牌须由 feed 密钥为该用户与路径签发，且用户仍有授权。
+// targetID 为空的 feed（如星球随机精华）不对应单个来源，只接受管理员令牌。未配置密钥时不限制。
+func (o *Ownership) CanReadFeed(platform, targetID, feedPath, username, token string) (bool, error) {
+	if o.feedSecret == "" {
+		return true, nil
+	}
+	if !feedtoken.Verify(o.feedSecret, username, feedPath, token) {
+		return false, nil
+	}
+	if username == "" {
+		return true, nil
+	}
+	if targetID == "" {
+		return false, nil
+	}
+	return o.db.HasGrant(platform, targetID, username)
+}
+
+func feedUser(user User) string {
+	if user.Admin {
+		return ""
+	}
+	return user.Name
+}
+
+// SignFeedPath 在 feed 路径后附上 user 与 token 查询参数，username 为空时省略 user。
+func SignFeedPath(secret, username, feedPath string) string {
+	query := url.Values{}
+	if username != "" {
+		query.Set("user", username)
+	}
+	query.Set("token", feedtoken.Sign(secret, username, feedPath))
+	return feedPath + "?" + query.Encode()
+}
@@ -0,0 +1,175 @@
+package subscription
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/eli-yip/rss-zero/pkg/feedtoken"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+type fakeOwnerDB struct {
+	owners []subscriptionDB.Owner
+	grants []subscriptionDB.Grant
+}
+
+func (f *fakeOwnerDB) AddOwner(platform, subID, username string) error {
+	for _, o := range f.owners {
+		if o.Platform == platform && o.SubID == subID && o.Username == username {
+			return nil
+		}
+	}
+	f.owners = append(f.owners, subscriptionDB.Owner{Platform: platform, SubID: subID, Username: username})
+	return nil
+}
+
+func (f *fakeOwnerDB) RemoveOwner(platform, subID, username string) error {
+	for i, o := range f.owners {
+		if o.Platform == platform && o.SubID == subID && o.Username == username {
+			f.owners = append(f.owners[:i], f.owners[i+1:]...)
+			return nil
+		}
+	}
+	return subscriptionDB.ErrOwnerNotFound
+}
+
+func (f *fakeOwnerDB) ListOwned(username string) (owned []subscriptionDB.Owner, err error) {
+	for _, o := range f.owners {
+		if o.Username == username {
+			owned = append(owned, o)
+		}
+	}
+	return owned, nil
+}
+
+func (f *fakeOwnerDB) AddGrant(grant *subscriptionDB.Grant) error {
+	f.grants = append(f.grants, *grant)
+	return nil
+}
+
+func (f *fakeOwnerDB) DeleteGrant(platform, targetID, username string) error {
+	for i, g := range f.grants {
+		if g.Platform == platform && g.TargetID == targetID && g.Username == username {
+			f.grants = append(f.grants[:i], f.grants[i+1:]...)
+			return nil
+		}
+	}
+	return subscriptionDB.ErrGrantNotFound
+}
+
+func (f *fakeOwnerDB) ListGrants(string) ([]subscriptionDB.Grant, error) { return f.grants, nil }
+
+func (f *fakeOwnerDB) HasGrant(platform, targetID, username string) (bool, error) {
+	for _, g := range f.grants {
+		if g.Platform == platform && g.TargetID == targetID && g.Username == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newTestOwnership(quota int, secret string) (*Ownership, *fakeOwnerDB) {
+	zhihu := &fakeSource{platform: subscriptionDB.PlatformZhihu, subs: []Subscription{
+		{Platform: subscriptionDB.PlatformZhihu, ID: "z1", TargetID: "canglimo", Active: true, FeedPath: "/rss/zhihu/answer/canglimo"},
+	}}
+	zsxq := &fakeSource{platform: subscriptionDB.PlatformZsxq, subs: []Subscription{
+		{Platform: subscriptionDB.PlatformZsxq, ID: "42", TargetID: "42", Active: true, FeedPath: "/rss/zsxq/42"},
+	}}
+	xiaobot := &fakeSource{platform: subscriptionDB.PlatformXiaobot, subs: []Subscription{
+		{Platform: subscriptionDB.PlatformXiaobot, ID: "p1", TargetID: "p1", Active: true, FeedPath: "/rss/xiaobot/p1"},
+	}}
+	db := &fakeOwnerDB{}
+	registry := NewRegistry(&fakeStatDB{}, zhihu, zsxq, xiaobot)
+	return NewOwnership(registry, db, quota, secret), db
+}
+
+func TestOwnershipSubscribe(t *testing.T) {
+	o, db := newTestOwnership(2, "")
+	alice := User{Name: "alice"}
+
+	sub, err := o.Subscribe(alice, CreateRequest{Platform: subscriptionDB.PlatformZhihu, TargetID: "x"}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "new-x", sub.ID)
+
+	// 星球只由管理员订阅，小报童需要授权
+	_, err = o.Subscribe(alice, CreateRequest{Platform: subscriptionDB.PlatformZsxq, TargetID: "42"}, zap.NewNop())
+	assert.ErrorIs(t, err, ErrForbidden)
+	_, err = o.Subscribe(alice, CreateRequest{Platform: subscriptionDB.PlatformXiaobot, TargetID: "p1"}, zap.NewNop())
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	require.NoError(t, o.Grant(subscriptionDB.PlatformXiaobot, "p1", "alice", "admin"))
+	_, err = o.Subscribe(alice, CreateRequest{Platform: subscriptionDB.PlatformXiaobot, TargetID: "p1"}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = o.Subscribe(alice, CreateRequest{Platform: subscriptionDB.PlatformZhihu, TargetID: "y"}, zap.NewNop())
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+	// 管理员不受限制
+	admin := User{Name: "root", Admin: true}
+	_, err = o.Subscribe(admin, CreateRequest{Platform: subscriptionDB.PlatformZsxq, TargetID: "42"}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Len(t, db.owners, 3)
+}
+
+func TestOwnershipList(t *testing.T) {
+	o, db := newTestOwnership(0, "secret")
+	bob := User{Name: "bob"}
+	require.NoError(t, db.AddOwner(subscriptionDB.PlatformZhihu, "z1", "bob"))
+	require.NoError(t, db.AddOwner(subscriptionDB.PlatformXiaobot, "p1", "bob"))
+	require.NoError(t, db.AddOwner(subscriptionDB.PlatformZhihu, "z1", "carol"))
+	require.NoError(t, o.Grant(subscriptionDB.PlatformXiaobot, "p1", "bob", "admin"))
+
+	subs, err := o.List(bob, Filter{})
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+	assert.Equal(t, "/rss/zhihu/answer/canglimo", subs[0].FeedPath)
+	u, err := url.Parse(subs[1].FeedPath)
+	require.NoError(t, err)
+	assert.Equal(t, "/rss/xiaobot/p1", u.Path)
+	assert.Equal(t, "bob", u.Query().Get("user"))
+
+	ok, err := o.CanReadFeed(subscriptionDB.PlatformXiaobot, "p1", u.Path, "bob", u.Query().Get("token"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 收回授权后订阅不再列出，令牌失效
+	require.NoError(t, o.Revoke(subscriptionDB.PlatformXiaobot, "p1", "bob"))
+	subs, err = o.List(bob, Filter{})
+	require.NoError(t, err)
+	assert.Len(t, subs, 1)
+	ok, err = o.CanReadFeed(subscriptionDB.PlatformXiaobot, "p1", u.Path, "bob", u.Query().Get("token"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, o.Unsubscribe(bob, subscriptionDB.PlatformZhihu, "z1"))
+	assert.ErrorIs(t, o.Unsubscribe(bob, subscriptionDB.PlatformZhihu, "z1"), ErrNotFound)
+	subs, err = o.List(User{Name: "carol"}, Filter{})
+	require.NoError(t, err)
+	assert.Len(t, subs, 1, "other owners keep the subscription")
+}
+
+func TestCanReadFeed(t *testing.T) {
+	o, _ := newTestOwnership(0, "secret")
+	adminToken := feedtoken.Sign("secret", "", "/rss/zsxq/random")
+
+	ok, err := o.CanReadFeed(subscriptionDB.PlatformZsxq, "", "/rss/zsxq/random", "", adminToken)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = o.CanReadFeed(subscriptionDB.PlatformZsxq, "42", "/rss/zsxq/42", "", adminToken)
+	require.NoError(t, err)
+	assert.False(t, ok, "token is bound to the feed path")
+
+	ok, err = o.CanReadFeed(subscriptionDB.PlatformZsxq, "", "/rss/zsxq/random", "bob", feedtoken.Sign("secret", "bob", "/rss/zsxq/random"))
+	require.NoError(t, err)
+	assert.False(t, ok, "feeds without a target only accept admin tokens")
+
+	open, _ := newTestOwnership(0, "")
+	ok, err = open.CanReadFeed(subscriptionDB.PlatformZsxq, "42", "/rss/zsxq/42", "", "")
+	require.NoError(t, err)
+	assert.True(t, ok, "paid feeds are open without a feed secret")
+}
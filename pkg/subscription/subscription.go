@@ -0,0 +1,240 @@
+// Package subscription 把各平台各自的订阅表统一成一套抽象：每个平台实现一个 Source，
+// Registry 汇总列表并附上抓取统计，/api/v1/subscriptions 与各平台旧的 /api/v1/sub 接口都经由它读写。
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+var (
+	ErrUnknownPlatform = errors.New("unknown platform")
+	ErrNotFound        = errors.New("subscription not found")
+	ErrInvalidTarget   = errors.New("invalid subscription target")
+	// ErrDeleteUnsupported 表示该平台的订阅记录同时是 feed 元数据（如小报童 paper、星球 group），
+	// 删除会让已归档内容失去标题，只能暂停。
+	ErrDeleteUnsupported = errors.New("delete is not supported for this platform, pause it instead")
+)
+
+// Subscription 是一条订阅的统一视图。
+type Subscription struct {
+	Platform string `json:"platform"`
+	// ID 是平台订阅表的主键，与 Platform 一起唯一确定一条订阅
+	ID string `json:"id"`
+	// TargetID 是被订阅对象：知乎用户 id 或问题 id、星球 group id、小报童 paper id、GitHub user/repo
+	TargetID string   `json:"target_id"`
+	Name     string   `json:"name"`
+	Kinds    []string `json:"kinds"`
+	Active   bool     `json:"active"`
+	// FeedPath 是本站 RSS 路径，如 /rss/zhihu/answer/canglimo
+	FeedPath string `json:"feed_path"`
+	// URL 是被订阅对象的原站地址
+	URL   string `json:"url"`
+	Stats *Stats `json:"stats"`
+}
+
+// Stats 是订阅的抓取统计，从未被 cron 抓取过时为空。
+type Stats struct {
+	CrawlCount          int        `json:"crawl_count"`
+	FailureCount        int        `json:"failure_count"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastAttemptAt       *time.Time `json:"last_attempt_at"`
+	LastSuccessAt       *time.Time `json:"last_success_at"`
+	LastFailureAt       *time.Time `json:"last_failure_at"`
+	LastError           string     `json:"last_error"`
+	ErrorClass          string     `json:"error_class"`
+	// ItemsFound / ItemsNew 是最近一次抓取解析到的条目数与新入库的条目数
+	ItemsFound int `json:"items_found"`
+	ItemsNew   int `json:"items_new"`
+}
+
+// CreateRequest 描述要新建的订阅。Kind 只对有多种内容的平台有意义：知乎为 answer/article/pin/question（TargetID 为问题 id），
+// GitHub 为 release/prerelease；Name 只在星球建订阅时作为 group 名称使用。
+type CreateRequest struct {
+	Platform string `json:"platform"`
+	TargetID string `json:"target_id"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+}
+
+// Source 由每个平台实现，List 需包含已暂停的订阅。
+type Source interface {
+	Platform() string
+	List() ([]Subscription, error)
+	// Create 建立订阅并返回它；订阅已存在（含已暂停）时保持原状直接返回。
+	Create(req CreateRequest, logger *zap.Logger) (Subscription, error)
+	Pause(id string) error
+	Resume(id string) error
+	Delete(id string) error
+	// RecentItemTimes 返回订阅最近 n 条已入库内容的发布时间，从新到旧。
+	RecentItemTimes(sub Subscription, n int) ([]time.Time, error)
+}
+
+type Registry struct {
+	sources []Source
+	stats   subscriptionDB.DB
+}
+
+func NewRegistry(stats subscriptionDB.DB, sources ...Source) *Registry {
+	return &Registry{sources: sources, stats: stats}
+}
+
+// Filter 为空字段表示不过滤。
+type Filter struct {
+	Platform string
+	Active   *bool
+}
+
+func (r *Registry) Platforms() []string {
+	platforms := make([]string, 0, len(r.sources))
+	for _, s := range r.sources {
+		platforms = append(platforms, s.Platform())
+	}
+	return platforms
+}
+
+func (r *Registry) source(platform string) (Source, error) {
+	for _, s := range r.sources {
+		if s.Platform() == platform {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownPlatform, platform)
+}
+
+// List 按注册顺序汇总各平台订阅并附上抓取统计。
+func (r *Registry) List(filter Filter) ([]Subscription, error) {
+	sources := r.sources
+	if filter.Platform != "" {
+		s, err := r.source(filter.Platform)
+		if err != nil {
+			return nil, err
+		}
+		sources = []Source{s}
+	}
+
+	result := make([]Subscription, 0)
+	for _, s := range sources {
+		subs, err := r.list(s)
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range subs {
+			if filter.Active != nil && sub.Active != *filter.Active {
+				continue
+			}
+			result = append(result, sub)
+		}
+	}
+	return result, nil
+}
+
+func (r *Registry) list(s Source) ([]Subscription, error) {
+	subs, err := s.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s subscriptions: %w", s.Platform(), err)
+	}
+	stats, err := r.stats.GetStats(s.Platform())
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		if stat, ok := stats[subs[i].ID]; ok {
+			subs[i].Stats = &Stats{
+				CrawlCount:          stat.CrawlCount,
+				FailureCount:        stat.FailureCount,
+				ConsecutiveFailures: stat.ConsecutiveFailures,
+				LastAttemptAt:       stat.LastAttemptAt,
+				LastSuccessAt:       stat.LastSuccessAt,
+				LastFailureAt:       stat.LastFailureAt,
+				LastError:           stat.LastError,
+				ErrorClass:          stat.ErrorClass,
+				ItemsFound:          stat.ItemsFound,
+				ItemsNew:            stat.ItemsNew,
+			}
+		}
+	}
+	return subs, nil
+}
+
+func (r *Registry) Get(platform, id string) (Subscription, error) {
+	s, err := r.source(platform)
+	if err != nil {
+		return Subscription{}, err
+	}
+	subs, err := r.list(s)
+	if err != nil {
+		return Subscription{}, err
+	}
+	for _, sub := range subs {
+		if sub.ID == id {
+			return sub, nil
+		}
+	}
+	return Subscription{}, fmt.Errorf("%w: %s/%s", ErrNotFound, platform, id)
+}
+
+func (r *Registry) Create(req CreateRequest, logger *zap.Logger) (Subscription, error) {
+	s, err := r.source(req.Platform)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if req.TargetID == "" {
+		return Subscription{}, fmt.Errorf("%w: empty target id", ErrInvalidTarget)
+	}
+	sub, err := s.Create(req, logger)
+	if err != nil {
+		return Subscription{}, err
+	}
+	return r.Get(sub.Platform, sub.ID)
+}
+
+func (r *Registry) Pause(platform, id string) error {
+	return r.mutate(platform, id, Source.Pause)
+}
+
+func (r *Registry) Resume(platform, id string) error {
+	return r.mutate(platform, id, Source.Resume)
+}
+
+// Delete 彻底删除订阅及其抓取统计，已抓取的内容保留。
+func (r *Registry) Delete(platform, id string) error {
+	if err := r.mutate(platform, id, Source.Delete); err != nil {
+		return err
+	}
+	if err := r.stats.DeleteStat(platform, id); err != nil {
+		return fmt.Errorf("failed to delete subscription stat: %w", err)
+	}
+	return nil
+}
+
+// mutate 先确认订阅存在，避免对不存在的 id 静默成功。
+func (r *Registry) mutate(platform, id string, fn func(Source, string) error) error {
+	if _, err := r.Get(platform, id); err != nil {
+		return err
+	}
+	s, err := r.source(platform)
+	if err != nil {
+		return err
+	}
+	return fn(s, id)
+}
+
+// find 在平台订阅中查找满足条件的一条，供各 Source 在 Create 后取回新建的订阅。
+func find(s Source, match func(Subscription) bool) (Subscription, error) {
+	subs, err := s.List()
+	if err != nil {
+		return Subscription{}, err
+	}
+	for _, sub := range subs {
+		if match(sub) {
+			return sub, nil
+		}
+	}
+	return Subscription{}, ErrNotFound
+}
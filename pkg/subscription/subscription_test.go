@@ -0,0 +1,154 @@
+package subscription
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+type fakeSource struct {
+	platform string
+	subs     []Subscription
+	deleted  []string
+	// itemTimes 按订阅 id 给出最近内容的发布时间
+	itemTimes map[string][]time.Time
+}
+
+func (f *fakeSource) Platform() string { return f.platform }
+func (f *fakeSource) List() ([]Subscription, error) {
+	return append([]Subscription(nil), f.subs...), nil
+}
+
+func (f *fakeSource) Create(req CreateRequest, _ *zap.Logger) (Subscription, error) {
+	sub := Subscription{Platform: f.platform, ID: "new-" + req.TargetID, TargetID: req.TargetID, Active: true}
+	f.subs = append(f.subs, sub)
+	return sub, nil
+}
+
+func (f *fakeSource) Pause(id string) error  { return f.setActive(id, false) }
+func (f *fakeSource) Resume(id string) error { return f.setActive(id, true) }
+func (f *fakeSource) Delete(id string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeSource) RecentItemTimes(sub Subscription, n int) ([]time.Time, error) {
+	times := f.itemTimes[sub.ID]
+	return times[:min(n, len(times))], nil
+}
+
+func (f *fakeSource) setActive(id string, active bool) error {
+	for i := range f.subs {
+		if f.subs[i].ID == id {
+			f.subs[i].Active = active
+		}
+	}
+	return nil
+}
+
+type fakeStatDB struct {
+	subscriptionDB.DB
+	stats   map[string]map[string]subscriptionDB.Stat
+	deleted []string
+}
+
+func (f *fakeStatDB) GetStats(platform string) (map[string]subscriptionDB.Stat, error) {
+	return f.stats[platform], nil
+}
+
+func (f *fakeStatDB) DeleteStat(platform, subID string) error {
+	f.deleted = append(f.deleted, platform+"/"+subID)
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	success := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	zhihu := &fakeSource{platform: "zhihu", subs: []Subscription{
+		{Platform: "zhihu", ID: "a", Active: true},
+		{Platform: "zhihu", ID: "b", Active: false},
+	}}
+	github := &fakeSource{platform: "github", subs: []Subscription{{Platform: "github", ID: "c", Active: true}}}
+	stats := &fakeStatDB{stats: map[string]map[string]subscriptionDB.Stat{
+		"zhihu": {"a": {CrawlCount: 3, FailureCount: 1, LastSuccessAt: &success, LastError: "timeout"}},
+	}}
+	r := NewRegistry(stats, zhihu, github)
+
+	assert.Equal(t, []string{"zhihu", "github"}, r.Platforms())
+
+	subs, err := r.List(Filter{})
+	require.NoError(t, err)
+	require.Len(t, subs, 3)
+	assert.Equal(t, &Stats{CrawlCount: 3, FailureCount: 1, LastSuccessAt: &success, LastError: "timeout"}, subs[0].Stats)
+	assert.Nil(t, subs[1].Stats)
+
+	active := true
+	subs, err = r.List(Filter{Active: &active})
+	require.NoError(t, err)
+	assert.Len(t, subs, 2)
+
+	subs, err = r.List(Filter{Platform: "github"})
+	require.NoError(t, err)
+	assert.Len(t, subs, 1)
+
+	_, err = r.List(Filter{Platform: "weibo"})
+	assert.ErrorIs(t, err, ErrUnknownPlatform)
+
+	require.NoError(t, r.Pause("zhihu", "a"))
+	sub, err := r.Get("zhihu", "a")
+	require.NoError(t, err)
+	assert.False(t, sub.Active)
+	require.NoError(t, r.Resume("zhihu", "a"))
+	sub, err = r.Get("zhihu", "a")
+	require.NoError(t, err)
+	assert.True(t, sub.Active)
+
+	// 不存在的订阅不能静默成功
+	assert.ErrorIs(t, r.Pause("zhihu", "missing"), ErrNotFound)
+	assert.ErrorIs(t, r.Delete("github", "a"), ErrNotFound)
+
+	require.NoError(t, r.Delete("github", "c"))
+	assert.Equal(t, []string{"c"}, github.deleted)
+	assert.Equal(t, []string{"github/c"}, stats.deleted)
+
+	sub, err = r.Create(CreateRequest{Platform: "github", TargetID: "golang/go"}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "new-golang/go", sub.ID)
+
+	_, err = r.Create(CreateRequest{Platform: "github"}, zap.NewNop())
+	assert.ErrorIs(t, err, ErrInvalidTarget)
+}
+
+func TestRegistryDeleteUnsupported(t *testing.T) {
+	s := NewXiaobotSource(nil, nil)
+	assert.ErrorIs(t, s.Delete("paper"), ErrDeleteUnsupported)
+	assert.ErrorIs(t, NewZsxqSource(nil).Delete("1"), ErrDeleteUnsupported)
+}
+
+func TestCreateValidation(t *testing.T) {
+	called := false
+	gh := NewGitHubSource(nil, func(string, string, bool) (string, error) {
+		called = true
+		return "sub", nil
+	})
+	for _, req := range []CreateRequest{
+		{TargetID: "golang"},
+		{TargetID: "golang/go/extra"},
+		{TargetID: "/go"},
+		{TargetID: "golang/go", Kind: "nightly"},
+	} {
+		_, err := gh.Create(req, zap.NewNop())
+		assert.ErrorIs(t, err, ErrInvalidTarget, req.TargetID)
+	}
+	assert.False(t, called)
+
+	_, err := NewZsxqSource(nil).Create(CreateRequest{TargetID: "abc"}, zap.NewNop())
+	assert.ErrorIs(t, err, ErrInvalidTarget)
+
+	_, err = NewZhihuSource(nil, nil).Create(CreateRequest{TargetID: "canglimo", Kind: "video"}, zap.NewNop())
+	assert.ErrorIs(t, err, ErrInvalidTarget)
+}
@@ -0,0 +1,71 @@
+package subscription
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	xiaobotDB "github.com/eli-yip/rss-zero/pkg/routers/xiaobot/db"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// XiaobotSubscribeFunc 是 xiaobot RSS 路由首次访问时的建订阅逻辑（会请求小报童拉取专栏信息）。
+type XiaobotSubscribeFunc func(paperID string, logger *zap.Logger) error
+
+type xiaobotSource struct {
+	db        xiaobotDB.DB
+	subscribe XiaobotSubscribeFunc
+}
+
+// NewXiaobotSource 每条小报童订阅即一个 paper。
+func NewXiaobotSource(db xiaobotDB.DB, subscribe XiaobotSubscribeFunc) Source {
+	return &xiaobotSource{db: db, subscribe: subscribe}
+}
+
+func (s *xiaobotSource) Platform() string { return subscriptionDB.PlatformXiaobot }
+
+func (s *xiaobotSource) List() ([]Subscription, error) {
+	papers, err := s.db.GetPapersIncludeDeleted()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Subscription, 0, len(papers))
+	for _, p := range papers {
+		result = append(result, Subscription{
+			Platform: subscriptionDB.PlatformXiaobot,
+			ID:       p.ID,
+			TargetID: p.ID,
+			Name:     p.Name,
+			Kinds:    []string{"post"},
+			Active:   !p.DeletedAt.Valid,
+			FeedPath: "/rss/xiaobot/" + p.ID,
+			URL:      "https://xiaobot.net/p/" + p.ID,
+		})
+	}
+	return result, nil
+}
+
+func (s *xiaobotSource) Create(req CreateRequest, logger *zap.Logger) (Subscription, error) {
+	if err := s.subscribe(req.TargetID, logger); err != nil {
+		return Subscription{}, err
+	}
+	return Subscription{Platform: subscriptionDB.PlatformXiaobot, ID: req.TargetID}, nil
+}
+
+func (s *xiaobotSource) Pause(id string) error  { return s.db.DeletePaper(id) }
+func (s *xiaobotSource) Resume(id string) error { return s.db.ActivatePaper(id) }
+
+// Delete paper 同时是已归档文章的专栏信息，不能删除。
+func (s *xiaobotSource) Delete(string) error { return ErrDeleteUnsupported }
+
+func (s *xiaobotSource) RecentItemTimes(sub Subscription, n int) ([]time.Time, error) {
+	posts, err := s.db.FetchNPostBefore(n, sub.TargetID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	times := make([]time.Time, 0, len(posts))
+	for _, p := range posts {
+		times = append(times, p.CreateAt)
+	}
+	return times, nil
+}
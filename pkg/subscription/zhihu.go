@@ -0,0 +1,185 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/eli-yip/rss-zero/pkg/common"
+	zhihuDB "github.com/eli-yip/rss-zero/pkg/routers/zhihu/db"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+// ZhihuSubscribeFunc 是 zhihu RSS 路由首次访问时的建订阅逻辑（需要请求知乎解析作者名）。
+type ZhihuSubscribeFunc func(t common.ZhihuContentType, authorID string, logger *zap.Logger) error
+
+type zhihuSource struct {
+	db        zhihuDB.DB
+	subscribe ZhihuSubscribeFunc
+}
+
+// NewZhihuSource 每条知乎订阅对应一个作者的一种内容，或一个问题下所有人的回答，或一个专栏、收藏夹里的内容。
+func NewZhihuSource(db zhihuDB.DB, subscribe ZhihuSubscribeFunc) Source {
+	return &zhihuSource{db: db, subscribe: subscribe}
+}
+
+func (s *zhihuSource) Platform() string { return subscriptionDB.PlatformZhihu }
+
+func (s *zhihuSource) List() ([]Subscription, error) {
+	subs, err := s.db.GetSubsIncludeDeleted()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	result := make([]Subscription, 0, len(subs))
+	for _, sub := range subs {
+		if sub.Type == common.ZhihuQuestion {
+			title, err := s.questionTitle(sub.AuthorID)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, s.subscription(sub, "知乎问题："+title))
+			continue
+		}
+		if sub.Type == common.ZhihuColumn || sub.Type == common.ZhihuCollection {
+			title, err := s.listTitle(sub.Type, sub.AuthorID)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, s.subscription(sub, fmt.Sprintf("知乎%s：%s", sub.Type.TitleZH(), title)))
+			continue
+		}
+
+		name, ok := names[sub.AuthorID]
+		if !ok {
+			if name, err = s.db.GetAuthorName(sub.AuthorID); err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, fmt.Errorf("failed to get author name of %s: %w", sub.AuthorID, err)
+				}
+				name = sub.AuthorID
+			}
+			names[sub.AuthorID] = name
+		}
+		result = append(result, s.subscription(sub, fmt.Sprintf("%s的知乎%s", name, sub.Type.TitleZH())))
+	}
+	return result, nil
+}
+
+func (s *zhihuSource) subscription(sub zhihuDB.Sub, name string) Subscription {
+	return Subscription{
+		Platform: subscriptionDB.PlatformZhihu,
+		ID:       sub.ID,
+		TargetID: sub.AuthorID,
+		Name:     name,
+		Kinds:    []string{sub.Type.Slug()},
+		Active:   !sub.DeletedAt.Valid,
+		FeedPath: fmt.Sprintf("/rss/zhihu/%s/%s", sub.Type.Slug(), sub.AuthorID),
+		URL:      sub.Type.TargetURL(sub.AuthorID),
+	}
+}
+
+// questionTitle 返回问题订阅的标题，问题不在库里时退回问题 id。
+func (s *zhihuSource) questionTitle(questionID string) (string, error) {
+	id, err := strconv.Atoi(questionID)
+	if err != nil {
+		return questionID, nil
+	}
+	question, err := s.db.GetQuestion(id)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("failed to get question %s: %w", questionID, err)
+		}
+		return questionID, nil
+	}
+	return question.Title, nil
+}
+
+// listTitle 返回专栏或收藏夹订阅的标题，不在库里时退回 id。
+func (s *zhihuSource) listTitle(t common.ZhihuContentType, id string) (string, error) {
+	list, err := s.db.GetList(t, id)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("failed to get %s %s: %w", t, id, err)
+		}
+		return id, nil
+	}
+	return list.Title, nil
+}
+
+func (s *zhihuSource) Create(req CreateRequest, logger *zap.Logger) (Subscription, error) {
+	t, err := common.ParseZhihuSlug(req.Kind)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("%w: %w", ErrInvalidTarget, err)
+	}
+	if err = s.subscribe(t, req.TargetID, logger); err != nil {
+		return Subscription{}, err
+	}
+	return find(s, func(sub Subscription) bool {
+		return sub.TargetID == req.TargetID && sub.Kinds[0] == t.Slug()
+	})
+}
+
+func (s *zhihuSource) Pause(id string) error  { return s.db.DeleteSub(id) }
+func (s *zhihuSource) Resume(id string) error { return s.db.ActivateSub(id) }
+func (s *zhihuSource) Delete(id string) error { return s.db.PurgeSub(id) }
+
+func (s *zhihuSource) RecentItemTimes(sub Subscription, n int) ([]time.Time, error) {
+	t, err := common.ParseZhihuSlug(sub.Kinds[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var times []time.Time
+	switch t {
+	case common.ZhihuAnswer:
+		answers, err := s.db.GetLatestNAnswer(n, sub.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range answers {
+			times = append(times, a.CreateAt)
+		}
+	case common.ZhihuArticle:
+		articles, err := s.db.GetLatestNArticle(n, sub.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range articles {
+			times = append(times, a.CreateAt)
+		}
+	case common.ZhihuPin:
+		pins, err := s.db.GetLatestNPin(n, sub.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range pins {
+			times = append(times, p.CreateAt)
+		}
+	case common.ZhihuQuestion:
+		id, err := strconv.Atoi(sub.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		answers, err := s.db.GetLatestNQuestionAnswer(n, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range answers {
+			times = append(times, a.CreateAt)
+		}
+	case common.ZhihuColumn, common.ZhihuCollection:
+		items, err := s.db.GetListItems(t, sub.TargetID, n, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			times = append(times, item.AddedAt)
+		}
+	}
+	return times, nil
+}
@@ -0,0 +1,94 @@
+package subscription
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	zsxqDB "github.com/eli-yip/rss-zero/pkg/routers/zsxq/db"
+	subscriptionDB "github.com/eli-yip/rss-zero/pkg/subscription/db"
+)
+
+type zsxqSource struct{ db zsxqDB.DB }
+
+// NewZsxqSource 每条星球订阅即一个 group。星球没有按需建订阅的 RSS 路由，建订阅只写入 group 记录，
+// 下次 cron 抓取时补齐内容。
+func NewZsxqSource(db zsxqDB.DB) Source { return &zsxqSource{db: db} }
+
+func (s *zsxqSource) Platform() string { return subscriptionDB.PlatformZsxq }
+
+func (s *zsxqSource) List() ([]Subscription, error) {
+	groups, err := s.db.GetGroupsIncludeDeleted()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Subscription, 0, len(groups))
+	for _, g := range groups {
+		id := strconv.Itoa(g.ID)
+		result = append(result, Subscription{
+			Platform: subscriptionDB.PlatformZsxq,
+			ID:       id,
+			TargetID: id,
+			Name:     g.Name,
+			Kinds:    []string{"topic"},
+			Active:   !g.DeletedAt.Valid,
+			FeedPath: "/rss/zsxq/" + id,
+			URL:      "https://wx.zsxq.com/group/" + id,
+		})
+	}
+	return result, nil
+}
+
+func (s *zsxqSource) Create(req CreateRequest, _ *zap.Logger) (Subscription, error) {
+	groupID, err := strconv.Atoi(req.TargetID)
+	if err != nil || groupID <= 0 {
+		return Subscription{}, fmt.Errorf("%w: zsxq group id must be a positive integer", ErrInvalidTarget)
+	}
+
+	exist, err := s.db.CheckGroupIncludeDeleted(groupID)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("failed to check zsxq group: %w", err)
+	}
+	if !exist {
+		name := req.Name
+		if name == "" {
+			name = req.TargetID
+		}
+		if err = s.db.SaveGroup(&zsxqDB.Group{ID: groupID, Name: name}); err != nil {
+			return Subscription{}, fmt.Errorf("failed to save zsxq group: %w", err)
+		}
+	}
+	return Subscription{Platform: subscriptionDB.PlatformZsxq, ID: req.TargetID}, nil
+}
+
+func (s *zsxqSource) Pause(id string) error  { return s.withGroupID(id, s.db.DeleteGroup) }
+func (s *zsxqSource) Resume(id string) error { return s.withGroupID(id, s.db.ActivateGroup) }
+
+// Delete group 同时是已归档 topic 的星球信息，不能删除。
+func (s *zsxqSource) Delete(string) error { return ErrDeleteUnsupported }
+
+func (s *zsxqSource) withGroupID(id string, fn func(int) error) error {
+	groupID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return fn(groupID)
+}
+
+func (s *zsxqSource) RecentItemTimes(sub Subscription, n int) ([]time.Time, error) {
+	groupID, err := strconv.Atoi(sub.TargetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, sub.TargetID)
+	}
+	topics, err := s.db.GetLatestNTopics(groupID, n)
+	if err != nil {
+		return nil, err
+	}
+	times := make([]time.Time, 0, len(topics))
+	for _, t := range topics {
+		times = append(times, t.Time)
+	}
+	return times, nil
+}